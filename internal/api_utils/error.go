@@ -56,3 +56,18 @@ var ErrConflict = &ErrResponse{
 	HTTPStatusCode: http.StatusConflict,
 	StatusText:     http.StatusText(http.StatusConflict),
 }
+
+var ErrRequestEntityTooLarge = &ErrResponse{
+	HTTPStatusCode: http.StatusRequestEntityTooLarge,
+	StatusText:     http.StatusText(http.StatusRequestEntityTooLarge),
+}
+
+var ErrPreconditionFailed = &ErrResponse{
+	HTTPStatusCode: http.StatusPreconditionFailed,
+	StatusText:     http.StatusText(http.StatusPreconditionFailed),
+}
+
+var ErrPreconditionRequired = &ErrResponse{
+	HTTPStatusCode: http.StatusPreconditionRequired,
+	StatusText:     http.StatusText(http.StatusPreconditionRequired),
+}
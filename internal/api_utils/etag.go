@@ -0,0 +1,34 @@
+package api_utils
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/render"
+)
+
+// ETag returns the entity tag of a version of a resource.
+func ETag(version int64) string {
+	return `"` + strconv.FormatInt(version, 10) + `"`
+}
+
+// CheckIfMatch checks the If-Match header of a request that modifies the
+// version of a resource. It returns ErrPreconditionRequired if the header is
+// missing and ErrPreconditionFailed if it lists neither the ETag of the
+// version nor "*". Weak tags never match. If the header matches nil is
+// returned.
+func CheckIfMatch(r *http.Request, version int64) render.Renderer {
+	header := strings.TrimSpace(r.Header.Get("If-Match"))
+	if header == "" {
+		return ErrPreconditionRequired
+	}
+	etag := ETag(version)
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" || tag == etag {
+			return nil
+		}
+	}
+	return ErrPreconditionFailed
+}
@@ -0,0 +1,39 @@
+package api_utils_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/chrishrb/blog-microservice/internal/api_utils"
+	"github.com/go-chi/render"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestETag(t *testing.T) {
+	assert.Equal(t, `"42"`, api_utils.ETag(42))
+}
+
+func TestCheckIfMatch(t *testing.T) {
+	tests := []struct {
+		ifMatch string
+		want    render.Renderer
+	}{
+		{"", api_utils.ErrPreconditionRequired},
+		{`"3"`, nil},
+		{`"2"`, api_utils.ErrPreconditionFailed},
+		{`"1", "3"`, nil},
+		{`*`, nil},
+		{`W/"3"`, api_utils.ErrPreconditionFailed},
+		{`3`, api_utils.ErrPreconditionFailed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.ifMatch, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPut, "/", nil)
+			if tt.ifMatch != "" {
+				req.Header.Set("If-Match", tt.ifMatch)
+			}
+			assert.Equal(t, tt.want, api_utils.CheckIfMatch(req, 3))
+		})
+	}
+}
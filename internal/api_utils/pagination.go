@@ -1,5 +1,16 @@
 package api_utils
 
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
 func GetPaginationWithDefaults(paramOffset, paramLimit *int) (int, int) {
 	offset := 0
 	limit := 20
@@ -16,3 +27,108 @@ func GetPaginationWithDefaults(paramOffset, paramLimit *int) (int, int) {
 
 	return offset, limit
 }
+
+// ErrInvalidCursor is returned if a pagination cursor cannot be decoded.
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Cursor is the position of an item in a listing that is ordered by creation
+// time and ID. Listings with other orderings can define their own cursor
+// type, which must be encodable as JSON.
+type Cursor struct {
+	CreatedAt time.Time `json:"t"`
+	ID        uuid.UUID `json:"id"`
+}
+
+// CompareCursor orders two listing positions by creation time and ID. It
+// returns -1 if a comes before b, +1 if a comes after b and 0 otherwise.
+func CompareCursor(a, b Cursor) int {
+	if c := a.CreatedAt.Compare(b.CreatedAt); c != 0 {
+		return c
+	}
+	return bytes.Compare(a.ID[:], b.ID[:])
+}
+
+// EncodeCursor returns the opaque token that is handed out to clients to
+// request the page following the cursor.
+func EncodeCursor[T any](cursor T) string {
+	b, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// DecodeCursor parses a token created by EncodeCursor.
+func DecodeCursor[T any](token string) (T, error) {
+	var cursor T
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return cursor, ErrInvalidCursor
+	}
+	if err := json.Unmarshal(b, &cursor); err != nil {
+		return cursor, ErrInvalidCursor
+	}
+	return cursor, nil
+}
+
+// Page selects a part of a listing whose items are ordered by listing
+// positions of type C. If After is set, the page starts with the first item
+// after the cursor and Offset is ignored.
+type Page[C any] struct {
+	After  *C
+	Offset int
+	Limit  int
+}
+
+// GetPage converts the pagination parameters of a listing request into a
+// Page. One more item than requested is fetched to find out whether there is
+// a next page, see NextPage.
+func GetPage[C any](paramCursor *string, paramOffset, paramLimit *int) (Page[C], error) {
+	offset, limit := GetPaginationWithDefaults(paramOffset, paramLimit)
+	page := Page[C]{
+		Offset: offset,
+		Limit:  max(limit, 1) + 1,
+	}
+	if paramCursor != nil {
+		cursor, err := DecodeCursor[C](*paramCursor)
+		if err != nil {
+			return page, err
+		}
+		page.After = &cursor
+	}
+	return page, nil
+}
+
+// NextPage trims the items fetched for page to the requested limit and
+// returns the cursor of the next page, or nil if this is the last page.
+func NextPage[T, C any](items []T, page Page[C], cursor func(T) C) ([]T, *string) {
+	limit := page.Limit - 1
+	if len(items) <= limit {
+		return items, nil
+	}
+
+	items = items[:limit]
+	next := EncodeCursor(cursor(items[limit-1]))
+	return items, &next
+}
+
+// Paginate sorts the items by the listing positions returned by cursor and
+// returns the part of the listing selected by page. Offsets past the end
+// yield an empty page. It is meant for stores that keep a listing in memory.
+func Paginate[T, C any](items []T, cursor func(T) C, compare func(a, b C) int, page Page[C]) []T {
+	slices.SortFunc(items, func(a, b T) int {
+		return compare(cursor(a), cursor(b))
+	})
+
+	start := max(page.Offset, 0)
+	if page.After != nil {
+		start = len(items)
+		for i, item := range items {
+			if compare(cursor(item), *page.After) > 0 {
+				start = i
+				break
+			}
+		}
+	}
+	start = min(start, len(items))
+
+	end := min(start+max(page.Limit, 0), len(items))
+	return items[start:end]
+}
@@ -2,10 +2,13 @@ package api_utils_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/chrishrb/blog-microservice/internal/api_utils"
 	"github.com/chrishrb/blog-microservice/internal/testutil"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestGetPaginationDefaults(t *testing.T) {
@@ -68,3 +71,82 @@ func TestGetPaginationDefaults(t *testing.T) {
 		})
 	}
 }
+
+func TestCursorRoundTrip(t *testing.T) {
+	cursor := api_utils.Cursor{
+		CreatedAt: time.Date(2023, 1, 1, 12, 0, 0, 123456789, time.UTC),
+		ID:        uuid.New(),
+	}
+
+	token := api_utils.EncodeCursor(cursor)
+	got, err := api_utils.DecodeCursor[api_utils.Cursor](token)
+	require.NoError(t, err)
+	assert.True(t, cursor.CreatedAt.Equal(got.CreatedAt))
+	assert.Equal(t, cursor.ID, got.ID)
+}
+
+func TestDecodeCursor_Invalid(t *testing.T) {
+	for _, token := range []string{"not base64!", "bm90IGpzb24", ""} {
+		_, err := api_utils.DecodeCursor[api_utils.Cursor](token)
+		assert.ErrorIs(t, err, api_utils.ErrInvalidCursor, token)
+	}
+}
+
+func TestGetPage(t *testing.T) {
+	page, err := api_utils.GetPage[api_utils.Cursor](nil, testutil.Ptr(5), testutil.Ptr(10))
+	require.NoError(t, err)
+	assert.Equal(t, api_utils.Page[api_utils.Cursor]{Offset: 5, Limit: 11}, page)
+
+	cursor := api_utils.Cursor{CreatedAt: time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC), ID: uuid.New()}
+	token := api_utils.EncodeCursor(cursor)
+	page, err = api_utils.GetPage[api_utils.Cursor](&token, nil, nil)
+	require.NoError(t, err)
+	require.NotNil(t, page.After)
+	assert.Equal(t, cursor.ID, page.After.ID)
+	assert.Equal(t, 21, page.Limit)
+
+	_, err = api_utils.GetPage[api_utils.Cursor](testutil.Ptr("not base64!"), nil, nil)
+	assert.ErrorIs(t, err, api_utils.ErrInvalidCursor)
+}
+
+func TestPaginate(t *testing.T) {
+	start := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	var items []api_utils.Cursor
+	for i := range 5 {
+		items = append(items, api_utils.Cursor{CreatedAt: start.Add(time.Duration(4-i) * time.Minute), ID: uuid.New()})
+	}
+	identity := func(c api_utils.Cursor) api_utils.Cursor { return c }
+
+	// Items are sorted and the first page is selected
+	first := api_utils.Paginate(items, identity, api_utils.CompareCursor, api_utils.Page[api_utils.Cursor]{Limit: 2})
+	require.Len(t, first, 2)
+	assert.Equal(t, start, first[0].CreatedAt)
+	assert.Equal(t, start.Add(time.Minute), first[1].CreatedAt)
+
+	// The next page starts after the cursor
+	second := api_utils.Paginate(items, identity, api_utils.CompareCursor, api_utils.Page[api_utils.Cursor]{After: &first[1], Limit: 2})
+	require.Len(t, second, 2)
+	assert.Equal(t, start.Add(2*time.Minute), second[0].CreatedAt)
+
+	// Offsets past the end yield an empty page
+	assert.Empty(t, api_utils.Paginate(items, identity, api_utils.CompareCursor, api_utils.Page[api_utils.Cursor]{Offset: 10, Limit: 2}))
+}
+
+func TestNextPage(t *testing.T) {
+	page := api_utils.Page[api_utils.Cursor]{Limit: 3}
+	identity := func(c api_utils.Cursor) api_utils.Cursor { return c }
+	items := []api_utils.Cursor{{ID: uuid.New()}, {ID: uuid.New()}, {ID: uuid.New()}}
+
+	// One more item than requested was fetched, so there is a next page
+	got, next := api_utils.NextPage(items, page, identity)
+	assert.Len(t, got, 2)
+	require.NotNil(t, next)
+	cursor, err := api_utils.DecodeCursor[api_utils.Cursor](*next)
+	require.NoError(t, err)
+	assert.Equal(t, items[1].ID, cursor.ID)
+
+	// The last page has no cursor
+	got, next = api_utils.NextPage(items[:2], page, identity)
+	assert.Len(t, got, 2)
+	assert.Nil(t, next)
+}
@@ -19,6 +19,10 @@ import (
 
 const UserIDContextKey = "userID"
 
+// authErrorContextKey holds the error of credentials that were sent with a
+// request but could not be validated.
+const authErrorContextKey = "authError"
+
 var (
 	ErrNoAuthHeader      = errors.New("authorization header is missing")
 	ErrInvalidAuthHeader = errors.New("authorization header is malformed")
@@ -33,22 +37,32 @@ func NewAuthenticator(v JWSVerifier) openapi3filter.AuthenticationFunc {
 
 // Authenticate uses the specified validator to ensure a JWT is valid, then makes
 // sure that the claims provided by the JWT match the scopes as required in the API.
+//
+// Operations with optional authentication list an empty security requirement
+// after BearerAuth. Requests without an Authorization header are anonymous for
+// these operations, while requests with invalid credentials are rejected by the
+// middleware returned from GetAuthMiddleware.
 func Authenticate(v JWSVerifier, ctx context.Context, input *openapi3filter.AuthenticationInput) error {
 	// Our security scheme is named BearerAuth, ensure this is the case
 	if input.SecuritySchemeName != "BearerAuth" {
 		return fmt.Errorf("security scheme %s != 'BearerAuth'", input.SecuritySchemeName)
 	}
+	reqstore := writeablecontext.FromContext(input.RequestValidationInput.Request.Context())
 
 	// Now, we need to get the JWS from the request, to match the request expectations
 	// against request contents.
 	jws, err := getJWSFromRequest(input.RequestValidationInput.Request)
 	if err != nil {
+		if !errors.Is(err, ErrNoAuthHeader) {
+			reqstore.Set(authErrorContextKey, err)
+		}
 		return fmt.Errorf("getting jws: %w", err)
 	}
 
 	// if the JWS is valid, we have a JWT, which will contain a bunch of claims.
 	token, err := v.ValidateToken(jws)
 	if err != nil {
+		reqstore.Set(authErrorContextKey, err)
 		return fmt.Errorf("validating JWS: %w", err)
 	}
 
@@ -66,8 +80,12 @@ func Authenticate(v JWSVerifier, ctx context.Context, input *openapi3filter.Auth
 	if err != nil {
 		return fmt.Errorf("userID not in token: %w", err)
 	}
-	reqstore := writeablecontext.FromContext(input.RequestValidationInput.Request.Context())
+	permissions, err := getClaimsFromToken(token)
+	if err != nil {
+		return fmt.Errorf("getting claims from token: %w", err)
+	}
 	reqstore.Set(UserIDContextKey, userID.String())
+	reqstore.Set(PermissionsContextKey, permissions)
 
 	return nil
 }
@@ -112,7 +130,7 @@ func GetUserIDFromToken(t jwt.Token) (uuid.UUID, error) {
 // GetAuthMiddleware returns a middleware for validating requests against the
 // OpenAPI spec.
 func GetAuthMiddleware(swagger *openapi3.T, v JWSVerifier) func(next http.Handler) http.Handler {
-	return oapimiddleware.OapiRequestValidatorWithOptions(swagger, &oapimiddleware.Options{
+	validator := oapimiddleware.OapiRequestValidatorWithOptions(swagger, &oapimiddleware.Options{
 		Options: openapi3filter.Options{
 			AuthenticationFunc: NewAuthenticator(v),
 		},
@@ -125,6 +143,28 @@ func GetAuthMiddleware(swagger *openapi3.T, v JWSVerifier) func(next http.Handle
 			})
 		},
 	})
+	return func(next http.Handler) http.Handler {
+		return validator(rejectInvalidCredentials(next))
+	}
+}
+
+// rejectInvalidCredentials responds with 401 if the request carried
+// credentials that could not be validated. Operations with optional
+// authentication would otherwise treat such requests as anonymous.
+func rejectInvalidCredentials(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if errAny, found := writeablecontext.FromContext(r.Context()).Get(authErrorContextKey); found {
+			err, _ := errAny.(error)
+			_ = render.Render(w, r, &api_utils.ErrResponse{
+				Err:            err,
+				HTTPStatusCode: http.StatusUnauthorized,
+				StatusText:     http.StatusText(http.StatusUnauthorized),
+				ErrorText:      fmt.Sprintf("invalid credentials: %v", err),
+			})
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
 }
 
 // getJWSFromRequest extracts a JWS string from an Authorization: Bearer <jws> header
@@ -0,0 +1,44 @@
+package auth
+
+import (
+	"context"
+	"slices"
+
+	"github.com/chrishrb/blog-microservice/internal/writeablecontext"
+)
+
+// Permissions that are granted in the "permissions" claim of an access token.
+const (
+	// PermissionAllUsersRead allows reading the accounts of all users.
+	PermissionAllUsersRead = "all-users:read"
+	// PermissionAllUsersWrite allows modifying the accounts of all users.
+	PermissionAllUsersWrite = "all-users:write"
+	// PermissionAllPostsRead allows reading unpublished posts of all users.
+	PermissionAllPostsRead = "all-posts:read"
+	// PermissionAllPostsWrite allows modifying posts and comments of all users.
+	PermissionAllPostsWrite = "all-posts:write"
+	// PermissionModerate allows moderating posts and comments of all users.
+	PermissionModerate = "posts:moderate"
+)
+
+const PermissionsContextKey = "permissions"
+
+// GetPermissionsFromContext returns the permissions of the authenticated
+// user, which are empty if the request is not authenticated.
+func GetPermissionsFromContext(ctx context.Context) []string {
+	permissionsAny, isValid := writeablecontext.FromContext(ctx).Get(PermissionsContextKey)
+	if !isValid {
+		return nil
+	}
+
+	permissions, isValid := permissionsAny.([]string)
+	if !isValid {
+		return nil
+	}
+	return permissions
+}
+
+// HasPermission reports whether the authenticated user has the permission.
+func HasPermission(ctx context.Context, permission string) bool {
+	return slices.Contains(GetPermissionsFromContext(ctx), permission)
+}
@@ -0,0 +1,139 @@
+package sqlitedb
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	_ "modernc.org/sqlite"
+)
+
+// Open opens (and creates if necessary) the SQLite database at the given path.
+// Foreign keys are enforced and the connection pool is limited to a single
+// connection as SQLite only supports one writer at a time.
+func Open(dbPath string) (*sql.DB, error) {
+	dsn := fmt.Sprintf("file:%s?_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)", dbPath)
+	db, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("opening sqlite database %s: %w", dbPath, err)
+	}
+	db.SetMaxOpenConns(1)
+
+	if err := db.Ping(); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("connecting to sqlite database %s: %w", dbPath, err)
+	}
+	return db, nil
+}
+
+type migration struct {
+	version int
+	name    string
+}
+
+// Migrate applies all migrations found in the root of fsys that have not been
+// applied yet. Migration files must be named <version>_<description>.sql, e.g.
+// 0001_init.sql, and are applied in ascending version order, each in its own
+// transaction. Applied versions are recorded in the schema_migrations table.
+func Migrate(ctx context.Context, db *sql.DB, fsys fs.FS) error {
+	_, err := db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (
+		version    INTEGER PRIMARY KEY,
+		applied_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+	)`)
+	if err != nil {
+		return fmt.Errorf("creating schema_migrations table: %w", err)
+	}
+
+	migrations, err := listMigrations(fsys)
+	if err != nil {
+		return err
+	}
+
+	current, err := Version(ctx, db)
+	if err != nil {
+		return err
+	}
+
+	for _, m := range migrations {
+		if m.version <= current {
+			continue
+		}
+		if err := apply(ctx, db, fsys, m); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Version returns the latest applied migration version or 0 if no migration
+// has been applied yet.
+func Version(ctx context.Context, db *sql.DB) (int, error) {
+	var version sql.NullInt64
+	err := db.QueryRowContext(ctx, `SELECT MAX(version) FROM schema_migrations`).Scan(&version)
+	if err != nil {
+		return 0, fmt.Errorf("reading schema version: %w", err)
+	}
+	return int(version.Int64), nil
+}
+
+func listMigrations(fsys fs.FS) ([]migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, fmt.Errorf("reading migrations: %w", err)
+	}
+
+	var migrations []migration
+	seen := make(map[int]string)
+	for _, entry := range entries {
+		if entry.IsDir() || path.Ext(entry.Name()) != ".sql" {
+			continue
+		}
+		prefix, _, found := strings.Cut(entry.Name(), "_")
+		if !found {
+			return nil, fmt.Errorf("migration %s: name must be <version>_<description>.sql", entry.Name())
+		}
+		version, err := strconv.Atoi(prefix)
+		if err != nil || version <= 0 {
+			return nil, fmt.Errorf("migration %s: invalid version %q", entry.Name(), prefix)
+		}
+		if other, ok := seen[version]; ok {
+			return nil, fmt.Errorf("migration %s: version %d already used by %s", entry.Name(), version, other)
+		}
+		seen[version] = entry.Name()
+		migrations = append(migrations, migration{version: version, name: entry.Name()})
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].version < migrations[j].version
+	})
+	return migrations, nil
+}
+
+func apply(ctx context.Context, db *sql.DB, fsys fs.FS, m migration) error {
+	script, err := fs.ReadFile(fsys, m.name)
+	if err != nil {
+		return fmt.Errorf("reading migration %s: %w", m.name, err)
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("starting migration %s: %w", m.name, err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := tx.ExecContext(ctx, string(script)); err != nil {
+		return fmt.Errorf("applying migration %s: %w", m.name, err)
+	}
+	if _, err := tx.ExecContext(ctx, `INSERT INTO schema_migrations (version) VALUES (?)`, m.version); err != nil {
+		return fmt.Errorf("recording migration %s: %w", m.name, err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("committing migration %s: %w", m.name, err)
+	}
+	return nil
+}
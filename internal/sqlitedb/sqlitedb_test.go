@@ -0,0 +1,74 @@
+package sqlitedb_test
+
+import (
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/chrishrb/blog-microservice/internal/sqlitedb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrate(t *testing.T) {
+	db, err := sqlitedb.Open(filepath.Join(t.TempDir(), "test.db"))
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	migrations := fstest.MapFS{
+		"0001_init.sql":       {Data: []byte(`CREATE TABLE items (id TEXT PRIMARY KEY);`)},
+		"0002_add_column.sql": {Data: []byte(`ALTER TABLE items ADD COLUMN name TEXT NOT NULL DEFAULT '';`)},
+		"README.md":           {Data: []byte(`not a migration`)},
+	}
+
+	err = sqlitedb.Migrate(t.Context(), db, migrations)
+	require.NoError(t, err)
+
+	version, err := sqlitedb.Version(t.Context(), db)
+	require.NoError(t, err)
+	assert.Equal(t, 2, version)
+
+	_, err = db.ExecContext(t.Context(), `INSERT INTO items (id, name) VALUES ('1', 'one')`)
+	require.NoError(t, err)
+
+	// Running the migrations again is a no-op
+	err = sqlitedb.Migrate(t.Context(), db, migrations)
+	require.NoError(t, err)
+
+	// New migrations are applied on top of the existing schema
+	migrations["0003_add_index.sql"] = &fstest.MapFile{Data: []byte(`CREATE INDEX idx_items_name ON items (name);`)}
+	err = sqlitedb.Migrate(t.Context(), db, migrations)
+	require.NoError(t, err)
+
+	version, err = sqlitedb.Version(t.Context(), db)
+	require.NoError(t, err)
+	assert.Equal(t, 3, version)
+}
+
+func TestMigrate_Failure(t *testing.T) {
+	db, err := sqlitedb.Open(filepath.Join(t.TempDir(), "test.db"))
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	err = sqlitedb.Migrate(t.Context(), db, fstest.MapFS{
+		"0001_init.sql":   {Data: []byte(`CREATE TABLE items (id TEXT PRIMARY KEY);`)},
+		"0002_broken.sql": {Data: []byte(`CREATE TABLE broken (`)},
+	})
+	assert.Error(t, err)
+
+	// The successful migration is kept, the broken one is rolled back
+	version, err := sqlitedb.Version(t.Context(), db)
+	require.NoError(t, err)
+	assert.Equal(t, 1, version)
+}
+
+func TestMigrate_InvalidName(t *testing.T) {
+	db, err := sqlitedb.Open(filepath.Join(t.TempDir(), "test.db"))
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	err = sqlitedb.Migrate(t.Context(), db, fstest.MapFS{
+		"init.sql": {Data: []byte(`CREATE TABLE items (id TEXT PRIMARY KEY);`)},
+	})
+	assert.Error(t, err)
+}
@@ -1,6 +1,11 @@
 package transport
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
 
 type Message struct {
 	ID   string          `json:"id"`
@@ -25,3 +30,130 @@ type VerifyAccountEvent struct {
 	LastName  string `json:"last_name"`
 	Token     string `json:"token"`
 }
+
+// Topics of the domain events of the post-service, which other services
+// consume to react to changes of posts and comments without polling the API.
+const (
+	PostCreatedTopic     = "post-created"
+	PostUpdatedTopic     = "post-updated"
+	PostPublishedTopic   = "post-published"
+	PostDeletedTopic     = "post-deleted"
+	CommentCreatedTopic  = "comment-created"
+	CommentApprovedTopic = "comment-approved"
+	CommentDeletedTopic  = "comment-deleted"
+)
+
+// PostCreatedEvent is published when a post is created, which may be a
+// draft.
+type PostCreatedEvent struct {
+	PostID    uuid.UUID `json:"post_id"`
+	AuthorID  uuid.UUID `json:"author_id"`
+	Title     string    `json:"title"`
+	Slug      string    `json:"slug"`
+	Tags      []string  `json:"tags,omitempty"`
+	Published bool      `json:"published"`
+	Version   int64     `json:"version"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// PostUpdatedEvent is published when a post is changed or restored from the
+// trash. It carries the current state of the post, so consumers can treat it
+// as an upsert.
+type PostUpdatedEvent struct {
+	PostID    uuid.UUID `json:"post_id"`
+	AuthorID  uuid.UUID `json:"author_id"`
+	Title     string    `json:"title"`
+	Slug      string    `json:"slug"`
+	Tags      []string  `json:"tags,omitempty"`
+	Published bool      `json:"published"`
+	Version   int64     `json:"version"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// PostPublishedEvent is published when a post becomes visible to everyone,
+// either when it is stored or when its schedule is applied.
+type PostPublishedEvent struct {
+	PostID      uuid.UUID `json:"post_id"`
+	AuthorID    uuid.UUID `json:"author_id"`
+	Title       string    `json:"title"`
+	Slug        string    `json:"slug"`
+	PublishedAt time.Time `json:"published_at"`
+}
+
+// PostDeletedEvent is published when a post is moved to the trash.
+type PostDeletedEvent struct {
+	PostID    uuid.UUID `json:"post_id"`
+	AuthorID  uuid.UUID `json:"author_id"`
+	DeletedAt time.Time `json:"deleted_at"`
+}
+
+// CommentCreatedEvent is published when a comment is created. Comments that
+// are held for moderation have the state pending.
+type CommentCreatedEvent struct {
+	CommentID    uuid.UUID  `json:"comment_id"`
+	PostID       uuid.UUID  `json:"post_id"`
+	PostAuthorID uuid.UUID  `json:"post_author_id"`
+	PostTitle    string     `json:"post_title"`
+	PostSlug     string     `json:"post_slug"`
+	AuthorID     uuid.UUID  `json:"author_id"`
+	ParentID     *uuid.UUID `json:"parent_id,omitempty"`
+	Content      string     `json:"content"`
+	State        string     `json:"state"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+// CommentApprovedEvent is published when a moderator approves a comment that
+// was not approved before, which makes it visible to everyone.
+type CommentApprovedEvent struct {
+	CommentID    uuid.UUID  `json:"comment_id"`
+	PostID       uuid.UUID  `json:"post_id"`
+	PostAuthorID uuid.UUID  `json:"post_author_id"`
+	PostTitle    string     `json:"post_title"`
+	PostSlug     string     `json:"post_slug"`
+	AuthorID     uuid.UUID  `json:"author_id"`
+	ParentID     *uuid.UUID `json:"parent_id,omitempty"`
+	Content      string     `json:"content"`
+	ApprovedAt   time.Time  `json:"approved_at"`
+}
+
+// CommentDeletedEvent is published when a comment is moved to the trash.
+type CommentDeletedEvent struct {
+	CommentID uuid.UUID `json:"comment_id"`
+	PostID    uuid.UUID `json:"post_id"`
+	AuthorID  uuid.UUID `json:"author_id"`
+	DeletedAt time.Time `json:"deleted_at"`
+}
+
+// Topics of the domain events of the user-service. Other services consume
+// them to keep a projection of the users, e.g. to resolve email addresses.
+const (
+	UserCreatedTopic = "user-created"
+	UserUpdatedTopic = "user-updated"
+	UserDeletedTopic = "user-deleted"
+)
+
+// UserCreatedEvent is published when a user registers or is created by an
+// admin.
+type UserCreatedEvent struct {
+	UserID    uuid.UUID `json:"user_id"`
+	Email     string    `json:"email"`
+	FirstName string    `json:"first_name"`
+	LastName  string    `json:"last_name"`
+	Version   int64     `json:"version"`
+}
+
+// UserUpdatedEvent is published when the profile of a user is changed. It
+// carries the current state of the user, so consumers can treat it as an
+// upsert and drop events with an older version.
+type UserUpdatedEvent struct {
+	UserID    uuid.UUID `json:"user_id"`
+	Email     string    `json:"email"`
+	FirstName string    `json:"first_name"`
+	LastName  string    `json:"last_name"`
+	Version   int64     `json:"version"`
+}
+
+// UserDeletedEvent is published when a user is deleted.
+type UserDeletedEvent struct {
+	UserID uuid.UUID `json:"user_id"`
+}
@@ -18,7 +18,18 @@ type VerifyAccountVariables struct {
 	AppName    string
 }
 
+type CommentCreatedVariables struct {
+	FirstName      string
+	LastName       string
+	CommenterName  string
+	PostTitle      string
+	PostLink       string
+	CommentExcerpt string
+	AppName        string
+}
+
 type Channel interface {
 	SendPasswordReset(ctx context.Context, recipient string, vars PasswordResetVariables) error
 	SendVerifyAccount(ctx context.Context, recipient string, vars VerifyAccountVariables) error
+	SendCommentCreated(ctx context.Context, recipient string, vars CommentCreatedVariables) error
 }
@@ -0,0 +1,69 @@
+package channels
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	"github.com/chrishrb/blog-microservice/internal/transport"
+	"github.com/chrishrb/blog-microservice/notification-service/ratelimit"
+	"github.com/chrishrb/blog-microservice/notification-service/store"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// CommentApprovedHandler notifies the author of a post about a comment that
+// was held for moderation and has been approved. It shares the limiter with
+// the CommentCreatedHandler, so both count towards the same notifications.
+type CommentApprovedHandler struct {
+	notifier commentNotifier
+}
+
+func NewCommentApprovedHandler(
+	orgName string,
+	websiteBaseURL string,
+	users store.UserStore,
+	limiter *ratelimit.Limiter,
+	emailChannel Channel,
+) CommentApprovedHandler {
+	return CommentApprovedHandler{
+		notifier: commentNotifier{
+			orgName:        orgName,
+			websiteBaseURL: websiteBaseURL,
+			users:          users,
+			limiter:        limiter,
+			emailChannel:   emailChannel,
+		},
+	}
+}
+
+func (r CommentApprovedHandler) Handle(ctx context.Context, msg *transport.Message) {
+	span := trace.SpanFromContext(ctx)
+
+	err := r.handle(ctx, msg)
+	if err != nil {
+		slog.Error("unable to handle message", slog.String("id", msg.ID), slog.String("event", "CommentApprovedEvent"), "err", err)
+		span.SetStatus(codes.Error, "handle CommentApprovedEvent failed")
+		span.RecordError(err)
+	} else {
+		span.SetStatus(codes.Ok, "ok")
+	}
+}
+
+func (r CommentApprovedHandler) handle(ctx context.Context, msg *transport.Message) error {
+	var req transport.CommentApprovedEvent
+	err := json.Unmarshal(msg.Data, &req)
+	if err != nil {
+		return fmt.Errorf("unmarshalling %s request payload: %w", msg.ID, err)
+	}
+
+	return r.notifier.notify(ctx, msg.ID, commentNotification{
+		PostID:       req.PostID,
+		PostAuthorID: req.PostAuthorID,
+		PostTitle:    req.PostTitle,
+		PostSlug:     req.PostSlug,
+		AuthorID:     req.AuthorID,
+		Content:      req.Content,
+	})
+}
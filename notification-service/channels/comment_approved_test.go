@@ -0,0 +1,70 @@
+package channels_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/chrishrb/blog-microservice/internal/transport"
+	"github.com/chrishrb/blog-microservice/notification-service/channels"
+	"github.com/chrishrb/blog-microservice/notification-service/ratelimit"
+	"github.com/chrishrb/blog-microservice/notification-service/store"
+	"github.com/chrishrb/blog-microservice/notification-service/store/inmemory"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	clock_testing "k8s.io/utils/clock/testing"
+)
+
+func TestCommentApprovedHandler(t *testing.T) {
+	fakeClock := clock_testing.NewFakeClock(time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC))
+	engine := inmemory.NewStore()
+	postAuthorID := uuid.New()
+	require.NoError(t, engine.SetUser(t.Context(), &store.User{
+		ID:        postAuthorID,
+		Email:     "john@example.com",
+		FirstName: "John",
+		LastName:  "Doe",
+		Version:   1,
+	}))
+
+	channel := &fakeChannel{}
+	limiter := ratelimit.New(fakeClock, 10*time.Minute)
+	approvedHandler := channels.NewCommentApprovedHandler("MyApp", "https://example.com", engine, limiter, channel)
+	createdHandler := channels.NewCommentCreatedHandler("MyApp", "https://example.com", engine, limiter, channel)
+
+	postID := uuid.New()
+	data, err := json.Marshal(transport.CommentApprovedEvent{
+		CommentID:    uuid.New(),
+		PostID:       postID,
+		PostAuthorID: postAuthorID,
+		PostTitle:    "Hello World",
+		PostSlug:     "hello-world",
+		AuthorID:     uuid.New(),
+		Content:      "Nice post!",
+	})
+	require.NoError(t, err)
+	approvedHandler.Handle(t.Context(), &transport.Message{ID: uuid.New().String(), Data: data})
+
+	require.Len(t, channel.comments, 1)
+	assert.Equal(t, "john@example.com", channel.comments[0].Recipient)
+	assert.Equal(t, channels.CommentCreatedVariables{
+		FirstName:      "John",
+		LastName:       "Doe",
+		CommenterName:  "Someone",
+		PostTitle:      "Hello World",
+		PostLink:       "https://example.com/posts/hello-world",
+		CommentExcerpt: "Nice post!",
+		AppName:        "MyApp",
+	}, channel.comments[0].Vars)
+
+	// New and approved comments count towards the same rate limit
+	createdHandler.Handle(t.Context(), commentCreatedMessage(t, transport.CommentCreatedEvent{
+		PostID:       postID,
+		PostAuthorID: postAuthorID,
+		AuthorID:     uuid.New(),
+		Content:      "Me too!",
+		State:        "approved",
+	}))
+	assert.Len(t, channel.comments, 1)
+}
@@ -0,0 +1,162 @@
+package channels
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"strings"
+
+	"github.com/chrishrb/blog-microservice/internal/transport"
+	"github.com/chrishrb/blog-microservice/notification-service/ratelimit"
+	"github.com/chrishrb/blog-microservice/notification-service/store"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// maxExcerptLength is the number of characters of a comment that are quoted
+// in a notification.
+const maxExcerptLength = 200
+
+// CommentCreatedHandler notifies the author of a post about a new comment.
+// Comments that are held for moderation are notified once they are
+// approved, see CommentApprovedHandler.
+type CommentCreatedHandler struct {
+	notifier commentNotifier
+}
+
+func NewCommentCreatedHandler(
+	orgName string,
+	websiteBaseURL string,
+	users store.UserStore,
+	limiter *ratelimit.Limiter,
+	emailChannel Channel,
+) CommentCreatedHandler {
+	return CommentCreatedHandler{
+		notifier: commentNotifier{
+			orgName:        orgName,
+			websiteBaseURL: websiteBaseURL,
+			users:          users,
+			limiter:        limiter,
+			emailChannel:   emailChannel,
+		},
+	}
+}
+
+func (r CommentCreatedHandler) Handle(ctx context.Context, msg *transport.Message) {
+	span := trace.SpanFromContext(ctx)
+
+	err := r.handle(ctx, msg)
+	if err != nil {
+		slog.Error("unable to handle message", slog.String("id", msg.ID), slog.String("event", "CommentCreatedEvent"), "err", err)
+		span.SetStatus(codes.Error, "handle CommentCreatedEvent failed")
+		span.RecordError(err)
+	} else {
+		span.SetStatus(codes.Ok, "ok")
+	}
+}
+
+func (r CommentCreatedHandler) handle(ctx context.Context, msg *transport.Message) error {
+	var req transport.CommentCreatedEvent
+	err := json.Unmarshal(msg.Data, &req)
+	if err != nil {
+		return fmt.Errorf("unmarshalling %s request payload: %w", msg.ID, err)
+	}
+
+	// Comments that are held for moderation may never be shown
+	if req.State != "approved" {
+		return nil
+	}
+
+	return r.notifier.notify(ctx, msg.ID, commentNotification{
+		PostID:       req.PostID,
+		PostAuthorID: req.PostAuthorID,
+		PostTitle:    req.PostTitle,
+		PostSlug:     req.PostSlug,
+		AuthorID:     req.AuthorID,
+		Content:      req.Content,
+	})
+}
+
+// commentNotification is a comment that became visible on a post.
+type commentNotification struct {
+	PostID       uuid.UUID
+	PostAuthorID uuid.UUID
+	PostTitle    string
+	PostSlug     string
+	AuthorID     uuid.UUID
+	Content      string
+}
+
+// commentNotifier sends the notifications about comments to the authors of
+// posts. The post-service does not know email addresses, so the recipient is
+// looked up in the projection of the users.
+type commentNotifier struct {
+	orgName        string
+	websiteBaseURL string
+	users          store.UserStore
+	limiter        *ratelimit.Limiter
+	emailChannel   Channel
+}
+
+func (n commentNotifier) notify(ctx context.Context, msgID string, comment commentNotification) error {
+	// Authors are not notified about their own comments
+	if comment.AuthorID == comment.PostAuthorID {
+		return nil
+	}
+
+	recipient, err := n.users.LookupUser(ctx, comment.PostAuthorID)
+	if err != nil {
+		return err
+	}
+	if recipient == nil {
+		// The projection only holds users whose events were consumed, older
+		// users are backfilled with the republish-users command of the
+		// user-service
+		return fmt.Errorf("unknown post author %s", comment.PostAuthorID)
+	}
+	commenterName := "Someone"
+	commenter, err := n.users.LookupUser(ctx, comment.AuthorID)
+	if err != nil {
+		return err
+	}
+	if commenter != nil {
+		commenterName = strings.TrimSpace(commenter.FirstName + " " + commenter.LastName)
+	}
+
+	// Several comments on the same post in quick succession result in a
+	// single notification. Only notifications that were sent count, so the
+	// reservation of a failed one is released and does not suppress the next.
+	key := comment.PostAuthorID.String() + "/" + comment.PostID.String()
+	if !n.limiter.Reserve(key) {
+		slog.Debug("notification rate limited", slog.String("id", msgID), slog.String("post_id", comment.PostID.String()))
+		return nil
+	}
+
+	vars := CommentCreatedVariables{
+		FirstName:      recipient.FirstName,
+		LastName:       recipient.LastName,
+		CommenterName:  commenterName,
+		PostTitle:      comment.PostTitle,
+		PostLink:       fmt.Sprintf("%s/posts/%s", n.websiteBaseURL, url.PathEscape(comment.PostSlug)),
+		CommentExcerpt: excerpt(comment.Content),
+		AppName:        n.orgName,
+	}
+	if err := n.emailChannel.SendCommentCreated(ctx, recipient.Email, vars); err != nil {
+		n.limiter.Release(key)
+		return err
+	}
+	return nil
+}
+
+// excerpt shortens the content of a comment to at most maxExcerptLength
+// characters.
+func excerpt(content string) string {
+	runes := []rune(strings.TrimSpace(content))
+	if len(runes) <= maxExcerptLength {
+		return string(runes)
+	}
+	return strings.TrimSpace(string(runes[:maxExcerptLength])) + "…"
+}
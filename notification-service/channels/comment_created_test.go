@@ -0,0 +1,251 @@
+package channels_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/chrishrb/blog-microservice/internal/transport"
+	"github.com/chrishrb/blog-microservice/notification-service/channels"
+	"github.com/chrishrb/blog-microservice/notification-service/ratelimit"
+	"github.com/chrishrb/blog-microservice/notification-service/store"
+	"github.com/chrishrb/blog-microservice/notification-service/store/inmemory"
+	"github.com/chrishrb/blog-microservice/notification-service/users"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	clock_testing "k8s.io/utils/clock/testing"
+)
+
+type sentComment struct {
+	Recipient string
+	Vars      channels.CommentCreatedVariables
+}
+
+// fakeChannel records the notifications about new comments. If err is set
+// sending fails.
+type fakeChannel struct {
+	comments []sentComment
+	err      error
+}
+
+func (c *fakeChannel) SendPasswordReset(ctx context.Context, recipient string, vars channels.PasswordResetVariables) error {
+	return nil
+}
+
+func (c *fakeChannel) SendVerifyAccount(ctx context.Context, recipient string, vars channels.VerifyAccountVariables) error {
+	return nil
+}
+
+func (c *fakeChannel) SendCommentCreated(ctx context.Context, recipient string, vars channels.CommentCreatedVariables) error {
+	if c.err != nil {
+		return c.err
+	}
+	c.comments = append(c.comments, sentComment{Recipient: recipient, Vars: vars})
+	return nil
+}
+
+func setupCommentCreatedHandler(t *testing.T) (channels.CommentCreatedHandler, *fakeChannel, *clock_testing.FakeClock, uuid.UUID, uuid.UUID) {
+	fakeClock := clock_testing.NewFakeClock(time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC))
+	engine := inmemory.NewStore()
+
+	postAuthorID, commenterID := uuid.New(), uuid.New()
+	require.NoError(t, engine.SetUser(t.Context(), &store.User{
+		ID:        postAuthorID,
+		Email:     "john@example.com",
+		FirstName: "John",
+		LastName:  "Doe",
+		Version:   1,
+	}))
+	require.NoError(t, engine.SetUser(t.Context(), &store.User{
+		ID:        commenterID,
+		Email:     "jane@example.com",
+		FirstName: "Jane",
+		LastName:  "Roe",
+		Version:   1,
+	}))
+
+	channel := &fakeChannel{}
+	handler := channels.NewCommentCreatedHandler(
+		"MyApp",
+		"https://example.com",
+		engine,
+		ratelimit.New(fakeClock, 10*time.Minute),
+		channel,
+	)
+	return handler, channel, fakeClock, postAuthorID, commenterID
+}
+
+func commentCreatedMessage(t *testing.T, event transport.CommentCreatedEvent) *transport.Message {
+	data, err := json.Marshal(event)
+	require.NoError(t, err)
+	return &transport.Message{ID: uuid.New().String(), Data: data}
+}
+
+func TestCommentCreatedHandler(t *testing.T) {
+	handler, channel, _, postAuthorID, commenterID := setupCommentCreatedHandler(t)
+
+	handler.Handle(t.Context(), commentCreatedMessage(t, transport.CommentCreatedEvent{
+		CommentID:    uuid.New(),
+		PostID:       uuid.New(),
+		PostAuthorID: postAuthorID,
+		PostTitle:    "Hello World",
+		PostSlug:     "hello-world",
+		AuthorID:     commenterID,
+		Content:      "Nice post!",
+		State:        "approved",
+	}))
+
+	require.Len(t, channel.comments, 1)
+	assert.Equal(t, "john@example.com", channel.comments[0].Recipient)
+	assert.Equal(t, channels.CommentCreatedVariables{
+		FirstName:      "John",
+		LastName:       "Doe",
+		CommenterName:  "Jane Roe",
+		PostTitle:      "Hello World",
+		PostLink:       "https://example.com/posts/hello-world",
+		CommentExcerpt: "Nice post!",
+		AppName:        "MyApp",
+	}, channel.comments[0].Vars)
+}
+
+func TestCommentCreatedHandler_Skipped(t *testing.T) {
+	handler, channel, _, postAuthorID, commenterID := setupCommentCreatedHandler(t)
+
+	// Comments of the post author
+	handler.Handle(t.Context(), commentCreatedMessage(t, transport.CommentCreatedEvent{
+		PostID:       uuid.New(),
+		PostAuthorID: postAuthorID,
+		AuthorID:     postAuthorID,
+		Content:      "Thanks for reading",
+		State:        "approved",
+	}))
+	// Comments that are held for moderation
+	handler.Handle(t.Context(), commentCreatedMessage(t, transport.CommentCreatedEvent{
+		PostID:       uuid.New(),
+		PostAuthorID: postAuthorID,
+		AuthorID:     commenterID,
+		Content:      "Buy now",
+		State:        "pending",
+	}))
+	// Post authors whose email address is not known
+	handler.Handle(t.Context(), commentCreatedMessage(t, transport.CommentCreatedEvent{
+		PostID:       uuid.New(),
+		PostAuthorID: uuid.New(),
+		AuthorID:     commenterID,
+		Content:      "Nice post!",
+		State:        "approved",
+	}))
+
+	assert.Empty(t, channel.comments)
+}
+
+func TestCommentCreatedHandler_MissingProjection(t *testing.T) {
+	fakeClock := clock_testing.NewFakeClock(time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC))
+	engine := inmemory.NewStore()
+	channel := &fakeChannel{}
+	handler := channels.NewCommentCreatedHandler(
+		"MyApp",
+		"https://example.com",
+		engine,
+		ratelimit.New(fakeClock, 10*time.Minute),
+		channel,
+	)
+
+	postAuthorID := uuid.New()
+	comment := commentCreatedMessage(t, transport.CommentCreatedEvent{
+		PostID:       uuid.New(),
+		PostAuthorID: postAuthorID,
+		AuthorID:     uuid.New(),
+		Content:      "Nice post!",
+		State:        "approved",
+	})
+
+	// Authors that were created before the projection are not known
+	handler.Handle(t.Context(), comment)
+	assert.Empty(t, channel.comments)
+
+	// Once the user-service republished them they are notified
+	data, err := json.Marshal(transport.UserUpdatedEvent{
+		UserID:    postAuthorID,
+		Email:     "john@example.com",
+		FirstName: "John",
+		LastName:  "Doe",
+		Version:   3,
+	})
+	require.NoError(t, err)
+	users.NewUserUpdatedHandler(engine).Handle(t.Context(), &transport.Message{ID: uuid.New().String(), Data: data})
+
+	handler.Handle(t.Context(), comment)
+	require.Len(t, channel.comments, 1)
+	assert.Equal(t, "john@example.com", channel.comments[0].Recipient)
+}
+
+func TestCommentCreatedHandler_RateLimit(t *testing.T) {
+	handler, channel, fakeClock, postAuthorID, commenterID := setupCommentCreatedHandler(t)
+
+	postID, otherPostID := uuid.New(), uuid.New()
+	comment := func(postID uuid.UUID) *transport.Message {
+		return commentCreatedMessage(t, transport.CommentCreatedEvent{
+			PostID:       postID,
+			PostAuthorID: postAuthorID,
+			AuthorID:     commenterID,
+			Content:      "Nice post!",
+			State:        "approved",
+		})
+	}
+
+	handler.Handle(t.Context(), comment(postID))
+	handler.Handle(t.Context(), comment(postID))
+	assert.Len(t, channel.comments, 1)
+
+	// Comments on other posts are notified separately
+	handler.Handle(t.Context(), comment(otherPostID))
+	assert.Len(t, channel.comments, 2)
+
+	// After the window the author is notified again
+	fakeClock.Step(10 * time.Minute)
+	handler.Handle(t.Context(), comment(postID))
+	assert.Len(t, channel.comments, 3)
+}
+
+func TestCommentCreatedHandler_RateLimitFailedSend(t *testing.T) {
+	handler, channel, _, postAuthorID, commenterID := setupCommentCreatedHandler(t)
+
+	comment := commentCreatedMessage(t, transport.CommentCreatedEvent{
+		PostID:       uuid.New(),
+		PostAuthorID: postAuthorID,
+		AuthorID:     commenterID,
+		Content:      "Nice post!",
+		State:        "approved",
+	})
+
+	// A notification that could not be sent does not count
+	channel.err = errors.New("connection refused")
+	handler.Handle(t.Context(), comment)
+	assert.Empty(t, channel.comments)
+
+	channel.err = nil
+	handler.Handle(t.Context(), comment)
+	assert.Len(t, channel.comments, 1)
+}
+
+func TestCommentCreatedHandler_Excerpt(t *testing.T) {
+	handler, channel, _, postAuthorID, _ := setupCommentCreatedHandler(t)
+
+	// Commenters that are not known are not named
+	handler.Handle(t.Context(), commentCreatedMessage(t, transport.CommentCreatedEvent{
+		PostID:       uuid.New(),
+		PostAuthorID: postAuthorID,
+		AuthorID:     uuid.New(),
+		Content:      strings.Repeat("ä", 300),
+		State:        "approved",
+	}))
+
+	require.Len(t, channel.comments, 1)
+	assert.Equal(t, "Someone", channel.comments[0].Vars.CommenterName)
+	assert.Equal(t, strings.Repeat("ä", 200)+"…", channel.comments[0].Vars.CommentExcerpt)
+}
@@ -4,6 +4,8 @@ import (
 	"bytes"
 	_ "embed"
 	"html/template"
+	"io"
+	texttemplate "text/template"
 
 	"github.com/chrishrb/blog-microservice/notification-service/channels"
 	"golang.org/x/net/context"
@@ -16,6 +18,9 @@ var passwordResetTemplate string
 //go:embed templates/verify-account.tmpl
 var verifyAccountTemplate string
 
+//go:embed templates/comment-created.tmpl
+var commentCreatedTemplate string
+
 type EmailChannel struct {
 	host     string
 	port     int
@@ -50,6 +55,20 @@ func (e *EmailChannel) SendVerifyAccount(ctx context.Context, recipient string,
 	return e.sendPlainTextEmail(recipient, subject, body)
 }
 
+func (e *EmailChannel) SendCommentCreated(ctx context.Context, recipient string, variables channels.CommentCreatedVariables) error {
+	// The excerpt of the comment is quoted as written, html/template would
+	// escape characters like quotes in the plain text body
+	t, err := texttemplate.New("email").Parse(commentCreatedTemplate)
+	if err != nil {
+		return err
+	}
+	subject, body, err := executeEmailTemplate(t, variables)
+	if err != nil {
+		return err
+	}
+	return e.sendPlainTextEmail(recipient, subject, body)
+}
+
 func (e *EmailChannel) sendPlainTextEmail(recipient, subject, body string) error {
 	m := gomail.NewMessage()
 	m.SetHeader("From", e.from)
@@ -65,7 +84,17 @@ func (e *EmailChannel) parseEmailTemplate(templateStr string, variables any) (st
 	if err != nil {
 		return "", "", err
 	}
+	return executeEmailTemplate(t, variables)
+}
+
+// emailTemplate is implemented by the templates of html/template and
+// text/template.
+type emailTemplate interface {
+	ExecuteTemplate(wr io.Writer, name string, data any) error
+}
 
+// executeEmailTemplate renders the subject and body of the template.
+func executeEmailTemplate(t emailTemplate, variables any) (string, string, error) {
 	// Parse subject
 	var subjectTpl bytes.Buffer
 	if err := t.ExecuteTemplate(&subjectTpl, "Subject", variables); err != nil {
@@ -51,6 +51,30 @@ func TestSendVerifyAccount(t *testing.T) {
 	assert.Equal(t, "MAIL FROM:<user@example.com>", messages[0].MailfromRequest())
 }
 
+func TestSendCommentCreated(t *testing.T) {
+	server, hostAddr, port := setupServer(t)
+
+	c, err := email.NewEmailChannel(hostAddr, port, "", "", "user@example.com")
+	require.NoError(t, err)
+
+	err = c.SendCommentCreated(t.Context(), "john@example.com", channels.CommentCreatedVariables{
+		FirstName:      "John",
+		LastName:       "Doe",
+		CommenterName:  "Jane Roe",
+		PostTitle:      "Hello World",
+		PostLink:       "https://example.com/posts/hello-world",
+		CommentExcerpt: "Nice post, I'm impressed!",
+		AppName:        "MyApp",
+	})
+	require.NoError(t, err)
+
+	messages, err := server.WaitForMessages(1, 10*time.Millisecond)
+	require.NoError(t, err)
+	assert.Len(t, messages, 1)
+	assert.Equal(t, "MAIL FROM:<user@example.com>", messages[0].MailfromRequest())
+	assert.Contains(t, messages[0].MsgRequest(), "Nice post, I'm impressed!")
+}
+
 func setupServer(t *testing.T) (*smtpmock.Server, string, int) {
 	server := smtpmock.New(smtpmock.ConfigurationAttr{
 		LogToStdout:       true,
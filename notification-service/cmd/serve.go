@@ -45,26 +45,32 @@ var serveCmd = &cobra.Command{
 		apiServer.Start(errCh)
 
 		// Start all consumers
-		// TODO: refactor this somehow
-		passwordResetConn, err := settings.MsgConsumer.Consume(context.Background(), transport.PasswordResetTopic, settings.PasswordResetHandler)
-		if err != nil {
-			errCh <- err
+		consumers := []struct {
+			topic   string
+			handler transport.MessageHandler
+		}{
+			{transport.PasswordResetTopic, settings.PasswordResetHandler},
+			{transport.VerifyAccountTopic, settings.VerifyAccountHandler},
+			{transport.CommentCreatedTopic, settings.CommentCreatedHandler},
+			{transport.CommentApprovedTopic, settings.CommentApprovedHandler},
+			{transport.UserCreatedTopic, settings.UserUpdatedHandler},
+			{transport.UserUpdatedTopic, settings.UserUpdatedHandler},
+			{transport.UserDeletedTopic, settings.UserDeletedHandler},
 		}
-		verifyAccountConn, err := settings.MsgConsumer.Consume(context.Background(), transport.VerifyAccountTopic, settings.VerifyAccountHandler)
-		if err != nil {
-			errCh <- err
+		var conns []transport.Connection
+		for _, c := range consumers {
+			conn, err := settings.MsgConsumer.Consume(context.Background(), c.topic, c.handler)
+			if err != nil {
+				errCh <- err
+				break
+			}
+			conns = append(conns, conn)
 		}
 
 		err = <-errCh
 
-		if passwordResetConn != nil {
-			err := passwordResetConn.Disconnect(context.Background())
-			if err != nil {
-				slog.Warn("disconnecting from consumer", "err", err)
-			}
-		}
-		if verifyAccountConn != nil {
-			err := verifyAccountConn.Disconnect(context.Background())
+		for _, conn := range conns {
+			err := conn.Disconnect(context.Background())
 			if err != nil {
 				slog.Warn("disconnecting from consumer", "err", err)
 			}
@@ -17,6 +17,8 @@ type BaseConfig struct {
 	Transport     TransportSettingsConfig     `mapstructure:"transport" json:"transport" validate:"required"`
 	Observability ObservabilitySettingsConfig `mapstructure:"observability" json:"observability" validate:"required"`
 	Channels      ChannelsSettingsConfig      `mapstructure:"channels" json:"channels"`
+	Storage       StorageConfig               `mapstructure:"storage" json:"storage" validate:"required"`
+	Notifications NotificationsConfig         `mapstructure:"notifications" json:"notifications" validate:"required"`
 }
 
 // DefaultConfig provides the default configuration. The configuration
@@ -49,6 +51,12 @@ var DefaultConfig = BaseConfig{
 			FromAddr: "myuser@example.com",
 		},
 	},
+	Storage: StorageConfig{
+		Type: "in_memory",
+	},
+	Notifications: NotificationsConfig{
+		CommentRateLimitWindow: "10m",
+	},
 }
 
 // Load reads YAML configuration from a reader.
@@ -44,6 +44,15 @@ func TestParseConfig(t *testing.T) {
 				FromAddr: "myuser@example.com",
 			},
 		},
+		Storage: config.StorageConfig{
+			Type: "sqlite",
+			SqliteStorage: &config.SqliteStorageConfig{
+				Path: "/data/notification-service.db",
+			},
+		},
+		Notifications: config.NotificationsConfig{
+			CommentRateLimitWindow: "15m",
+		},
 	}
 
 	assert.Equal(t, want, cfg)
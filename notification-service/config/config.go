@@ -12,6 +12,11 @@ import (
 	"github.com/chrishrb/blog-microservice/internal/transport/kafka"
 	"github.com/chrishrb/blog-microservice/notification-service/channels"
 	"github.com/chrishrb/blog-microservice/notification-service/channels/email"
+	"github.com/chrishrb/blog-microservice/notification-service/ratelimit"
+	"github.com/chrishrb/blog-microservice/notification-service/store"
+	"github.com/chrishrb/blog-microservice/notification-service/store/inmemory"
+	"github.com/chrishrb/blog-microservice/notification-service/store/sqlite"
+	"github.com/chrishrb/blog-microservice/notification-service/users"
 	"github.com/subnova/slog-exporter/slogtrace"
 	"go.opentelemetry.io/contrib/detectors/gcp"
 	"go.opentelemetry.io/otel"
@@ -24,15 +29,21 @@ import (
 	oteltrace "go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
+	"k8s.io/utils/clock"
 )
 
 type Config struct {
-	Tracer               oteltrace.Tracer
-	TracerProvider       *trace.TracerProvider
-	MsgProducer          transport.Producer
-	MsgConsumer          transport.Consumer
-	PasswordResetHandler transport.MessageHandler
-	VerifyAccountHandler transport.MessageHandler
+	Tracer                 oteltrace.Tracer
+	TracerProvider         *trace.TracerProvider
+	MsgProducer            transport.Producer
+	MsgConsumer            transport.Consumer
+	Storage                store.Engine
+	PasswordResetHandler   transport.MessageHandler
+	VerifyAccountHandler   transport.MessageHandler
+	CommentCreatedHandler  transport.MessageHandler
+	CommentApprovedHandler transport.MessageHandler
+	UserUpdatedHandler     transport.MessageHandler
+	UserDeletedHandler     transport.MessageHandler
 }
 
 func Configure(ctx context.Context, cfg *BaseConfig) (c *Config, err error) {
@@ -79,6 +90,19 @@ func Configure(ctx context.Context, cfg *BaseConfig) (c *Config, err error) {
 		return nil, err
 	}
 
+	c.Storage, err = getStorage(ctx, &cfg.Storage)
+	if err != nil {
+		return nil, err
+	}
+
+	c.CommentCreatedHandler, c.CommentApprovedHandler, err = getCommentHandlers(cfg, c.Storage)
+	if err != nil {
+		return nil, err
+	}
+
+	c.UserUpdatedHandler = users.NewUserUpdatedHandler(c.Storage)
+	c.UserDeletedHandler = users.NewUserDeletedHandler(c.Storage)
+
 	return
 }
 
@@ -213,6 +237,56 @@ func getVerifyAccountHandler(cfg *BaseConfig) (transport.MessageHandler, error)
 	), nil
 }
 
+// getCommentHandlers returns the handlers of created and approved comments,
+// which share the rate limit of the notifications.
+func getCommentHandlers(cfg *BaseConfig, engine store.Engine) (created, approved transport.MessageHandler, err error) {
+	window, err := time.ParseDuration(cfg.Notifications.CommentRateLimitWindow)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse comment rate limit window: %w", err)
+	}
+	if window < 0 {
+		return nil, nil, fmt.Errorf("comment rate limit window must not be negative: %s", cfg.Notifications.CommentRateLimitWindow)
+	}
+
+	emailChannel, err := getEmailChannel(cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	limiter := ratelimit.New(clock.RealClock{}, window)
+	created = channels.NewCommentCreatedHandler(
+		cfg.General.OrgName,
+		cfg.General.WebsiteBaseURL,
+		engine,
+		limiter,
+		emailChannel,
+	)
+	approved = channels.NewCommentApprovedHandler(
+		cfg.General.OrgName,
+		cfg.General.WebsiteBaseURL,
+		engine,
+		limiter,
+		emailChannel,
+	)
+	return created, approved, nil
+}
+
+func getStorage(ctx context.Context, cfg *StorageConfig) (engine store.Engine, err error) {
+	switch cfg.Type {
+	case "in_memory":
+		engine = inmemory.NewStore()
+	case "sqlite":
+		engine, err = sqlite.NewStore(ctx, cfg.SqliteStorage.Path)
+		if err != nil {
+			return nil, fmt.Errorf("create sqlite storage: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("unknown storage type: %s", cfg.Type)
+	}
+
+	return
+}
+
 func getEmailChannel(cfg *BaseConfig) (channels.Channel, error) {
 	emailChannel, err := email.NewEmailChannel(
 		cfg.Channels.Email.Host,
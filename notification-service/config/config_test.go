@@ -20,4 +20,19 @@ func TestConfigure(t *testing.T) {
 	assert.NotNil(t, settings.MsgProducer)
 	assert.NotNil(t, settings.MsgConsumer)
 	assert.NotNil(t, settings.PasswordResetHandler)
+	assert.NotNil(t, settings.Storage)
+	assert.NotNil(t, settings.CommentCreatedHandler)
+	assert.NotNil(t, settings.CommentApprovedHandler)
+	assert.NotNil(t, settings.UserUpdatedHandler)
+	assert.NotNil(t, settings.UserDeletedHandler)
+}
+
+func TestConfigureInvalidCommentRateLimitWindow(t *testing.T) {
+	for _, value := range []string{"soon", "-1m"} {
+		cfg := clone.Clone(&config.DefaultConfig)
+		cfg.Notifications.CommentRateLimitWindow = value
+
+		_, err := config.Configure(t.Context(), cfg)
+		assert.Error(t, err, value)
+	}
 }
@@ -14,3 +14,10 @@ type ObservabilitySettingsConfig struct {
 	OtelCollectorAddr string `mapstructure:"otel_collector_addr" json:"otel_collector_addr"`
 	TlsKeylogFile     string `mapstructure:"tls_keylog_file" json:"tls_keylog_file"`
 }
+
+type NotificationsConfig struct {
+	// CommentRateLimitWindow is the window in which a post author is
+	// notified at most once about new comments on the same post. A window of
+	// 0 disables the rate limit.
+	CommentRateLimitWindow string `mapstructure:"comment_rate_limit_window" json:"comment_rate_limit_window" validate:"required"`
+}
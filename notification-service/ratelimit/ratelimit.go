@@ -0,0 +1,62 @@
+// Package ratelimit suppresses repeated notifications that are sent within a
+// short window.
+package ratelimit
+
+import (
+	"sync"
+	"time"
+
+	"k8s.io/utils/clock"
+)
+
+// Limiter allows an action once per key within a window. The state is kept in
+// memory, so a restart or another instance may let a repeated action pass.
+type Limiter struct {
+	mu     sync.Mutex
+	clock  clock.PassiveClock
+	window time.Duration
+	last   map[string]time.Time
+}
+
+// New creates a limiter that allows an action for the same key at most once
+// per window.
+func New(clock clock.PassiveClock, window time.Duration) *Limiter {
+	return &Limiter{
+		clock:  clock,
+		window: window,
+		last:   make(map[string]time.Time),
+	}
+}
+
+// Reserve reports whether the action for the key may happen now and, if so,
+// counts it right away, so that concurrent callers cannot both pass. An
+// action that fails afterwards gives its reservation back with Release, so
+// that it can be retried within the window.
+func (l *Limiter) Reserve(key string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.clock.Now()
+	if last, ok := l.last[key]; ok && now.Sub(last) < l.window {
+		return false
+	}
+	l.last[key] = now
+
+	// Forget keys whose window has passed, so the map does not grow with
+	// every key that was ever seen
+	for k, t := range l.last {
+		if now.Sub(t) >= l.window {
+			delete(l.last, k)
+		}
+	}
+	return true
+}
+
+// Release gives back the reservation of the key, so that the action is
+// allowed again right away.
+func (l *Limiter) Release(key string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	delete(l.last, key)
+}
@@ -0,0 +1,65 @@
+package ratelimit_test
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/chrishrb/blog-microservice/notification-service/ratelimit"
+	"github.com/stretchr/testify/assert"
+	clock_testing "k8s.io/utils/clock/testing"
+)
+
+func TestLimiter(t *testing.T) {
+	fakeClock := clock_testing.NewFakeClock(time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC))
+	limiter := ratelimit.New(fakeClock, 10*time.Minute)
+
+	// The first action is reserved, repeated ones are not
+	assert.True(t, limiter.Reserve("a"))
+	assert.False(t, limiter.Reserve("a"))
+
+	// Keys are limited independently
+	assert.True(t, limiter.Reserve("b"))
+
+	// Still within the window of the first action
+	fakeClock.Step(9 * time.Minute)
+	assert.False(t, limiter.Reserve("a"))
+
+	// The window has passed
+	fakeClock.Step(time.Minute)
+	assert.True(t, limiter.Reserve("a"))
+	assert.False(t, limiter.Reserve("a"))
+}
+
+func TestLimiter_Release(t *testing.T) {
+	fakeClock := clock_testing.NewFakeClock(time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC))
+	limiter := ratelimit.New(fakeClock, 10*time.Minute)
+
+	// Released reservations allow the action again within the window
+	assert.True(t, limiter.Reserve("a"))
+	assert.True(t, limiter.Reserve("b"))
+	limiter.Release("a")
+	assert.True(t, limiter.Reserve("a"))
+	assert.False(t, limiter.Reserve("b"))
+}
+
+func TestLimiter_Concurrent(t *testing.T) {
+	fakeClock := clock_testing.NewFakeClock(time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC))
+	limiter := ratelimit.New(fakeClock, 10*time.Minute)
+
+	// Only one of the concurrent callers gets the reservation
+	var reserved atomic.Int32
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if limiter.Reserve("a") {
+				reserved.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(1), reserved.Load())
+}
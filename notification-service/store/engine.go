@@ -0,0 +1,5 @@
+package store
+
+type Engine interface {
+	UserStore
+}
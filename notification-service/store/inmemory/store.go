@@ -0,0 +1,23 @@
+package inmemory
+
+import (
+	"sync"
+
+	"github.com/chrishrb/blog-microservice/notification-service/store"
+	"github.com/google/uuid"
+)
+
+// Store is an in-memory implementation of the store.Engine interface. As
+// everything is stored in memory the projection is lost on restart and cannot
+// be shared if running >1 instances. It is primarily provided to support unit
+// testing.
+type Store struct {
+	sync.Mutex
+	users map[uuid.UUID]*store.User
+}
+
+func NewStore() *Store {
+	return &Store{
+		users: make(map[uuid.UUID]*store.User),
+	}
+}
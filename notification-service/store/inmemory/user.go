@@ -0,0 +1,40 @@
+package inmemory
+
+import (
+	"context"
+
+	"github.com/chrishrb/blog-microservice/notification-service/store"
+	"github.com/google/uuid"
+)
+
+func (s *Store) SetUser(ctx context.Context, user *store.User) error {
+	s.Lock()
+	defer s.Unlock()
+
+	if existing, ok := s.users[user.ID]; ok && existing.Version > user.Version {
+		return nil
+	}
+	u := *user
+	s.users[user.ID] = &u
+	return nil
+}
+
+func (s *Store) LookupUser(ctx context.Context, ID uuid.UUID) (*store.User, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	user, ok := s.users[ID]
+	if !ok {
+		return nil, nil
+	}
+	u := *user
+	return &u, nil
+}
+
+func (s *Store) DeleteUser(ctx context.Context, ID uuid.UUID) error {
+	s.Lock()
+	defer s.Unlock()
+
+	delete(s.users, ID)
+	return nil
+}
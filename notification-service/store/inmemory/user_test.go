@@ -0,0 +1,91 @@
+package inmemory_test
+
+import (
+	"testing"
+
+	"github.com/chrishrb/blog-microservice/notification-service/store"
+	"github.com/chrishrb/blog-microservice/notification-service/store/inmemory"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetUser(t *testing.T) {
+	engine := inmemory.NewStore()
+
+	userID := uuid.New()
+	err := engine.SetUser(t.Context(), &store.User{
+		ID:        userID,
+		Email:     "john@example.com",
+		FirstName: "John",
+		LastName:  "Doe",
+		Version:   1,
+	})
+	require.NoError(t, err)
+
+	user, err := engine.LookupUser(t.Context(), userID)
+	require.NoError(t, err)
+	require.NotNil(t, user)
+	assert.Equal(t, "john@example.com", user.Email)
+	assert.Equal(t, "John", user.FirstName)
+	assert.Equal(t, "Doe", user.LastName)
+	assert.Equal(t, int64(1), user.Version)
+
+	// A newer version replaces the user
+	err = engine.SetUser(t.Context(), &store.User{
+		ID:        userID,
+		Email:     "jane@example.com",
+		FirstName: "Jane",
+		LastName:  "Doe",
+		Version:   3,
+	})
+	require.NoError(t, err)
+
+	// An older version that is delivered late is ignored
+	err = engine.SetUser(t.Context(), &store.User{
+		ID:        userID,
+		Email:     "old@example.com",
+		FirstName: "Old",
+		LastName:  "Doe",
+		Version:   2,
+	})
+	require.NoError(t, err)
+
+	user, err = engine.LookupUser(t.Context(), userID)
+	require.NoError(t, err)
+	require.NotNil(t, user)
+	assert.Equal(t, "jane@example.com", user.Email)
+	assert.Equal(t, "Jane", user.FirstName)
+	assert.Equal(t, int64(3), user.Version)
+}
+
+func TestLookupUser_NotFound(t *testing.T) {
+	engine := inmemory.NewStore()
+
+	user, err := engine.LookupUser(t.Context(), uuid.New())
+	require.NoError(t, err)
+	assert.Nil(t, user)
+}
+
+func TestDeleteUser(t *testing.T) {
+	engine := inmemory.NewStore()
+
+	userID := uuid.New()
+	err := engine.SetUser(t.Context(), &store.User{
+		ID:      userID,
+		Email:   "john@example.com",
+		Version: 1,
+	})
+	require.NoError(t, err)
+
+	err = engine.DeleteUser(t.Context(), userID)
+	require.NoError(t, err)
+
+	user, err := engine.LookupUser(t.Context(), userID)
+	require.NoError(t, err)
+	assert.Nil(t, user)
+
+	// Deleting a missing user is not an error
+	err = engine.DeleteUser(t.Context(), userID)
+	assert.NoError(t, err)
+}
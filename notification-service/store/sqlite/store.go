@@ -0,0 +1,49 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"fmt"
+	"io/fs"
+
+	"github.com/chrishrb/blog-microservice/internal/sqlitedb"
+)
+
+//go:embed migrations/*.sql
+var migrations embed.FS
+
+// Store is a SQLite implementation of the store.Engine interface. Data is
+// persisted to a single database file, so the projection survives restarts
+// but cannot be shared between >1 instances.
+type Store struct {
+	db *sql.DB
+}
+
+// NewStore opens the database at the given path and applies all pending
+// schema migrations.
+func NewStore(ctx context.Context, path string) (*Store, error) {
+	db, err := sqlitedb.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	migrationsFS, err := fs.Sub(migrations, "migrations")
+	if err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+	if err := sqlitedb.Migrate(ctx, db, migrationsFS); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("migrating database: %w", err)
+	}
+
+	return &Store{
+		db: db,
+	}, nil
+}
+
+// Close closes the underlying database.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
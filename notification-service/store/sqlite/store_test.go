@@ -0,0 +1,50 @@
+package sqlite_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/chrishrb/blog-microservice/notification-service/store"
+	"github.com/chrishrb/blog-microservice/notification-service/store/sqlite"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newStore(t *testing.T) *sqlite.Store {
+	engine, err := sqlite.NewStore(t.Context(), filepath.Join(t.TempDir(), "notification-service.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = engine.Close()
+	})
+	return engine
+}
+
+func TestStorePersistsAcrossRestarts(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "notification-service.db")
+
+	engine, err := sqlite.NewStore(t.Context(), path)
+	require.NoError(t, err)
+
+	userID := uuid.New()
+	err = engine.SetUser(t.Context(), &store.User{
+		ID:        userID,
+		Email:     "john@example.com",
+		FirstName: "John",
+		LastName:  "Doe",
+		Version:   1,
+	})
+	require.NoError(t, err)
+	require.NoError(t, engine.Close())
+
+	engine, err = sqlite.NewStore(t.Context(), path)
+	require.NoError(t, err)
+	defer func() {
+		_ = engine.Close()
+	}()
+
+	user, err := engine.LookupUser(t.Context(), userID)
+	require.NoError(t, err)
+	require.NotNil(t, user)
+	assert.Equal(t, "john@example.com", user.Email)
+}
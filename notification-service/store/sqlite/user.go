@@ -0,0 +1,50 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/chrishrb/blog-microservice/notification-service/store"
+	"github.com/google/uuid"
+)
+
+func (s *Store) SetUser(ctx context.Context, user *store.User) error {
+	// An older version than the stored one does not update the row
+	_, err := s.db.ExecContext(ctx, `INSERT INTO users (id, email, first_name, last_name, version)
+		VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT (id) DO UPDATE SET
+			email = excluded.email,
+			first_name = excluded.first_name,
+			last_name = excluded.last_name,
+			version = excluded.version
+		WHERE excluded.version >= users.version`,
+		user.ID, user.Email, user.FirstName, user.LastName, user.Version,
+	)
+	if err != nil {
+		return fmt.Errorf("storing user %s: %w", user.ID, err)
+	}
+	return nil
+}
+
+func (s *Store) LookupUser(ctx context.Context, ID uuid.UUID) (*store.User, error) {
+	var user store.User
+	err := s.db.QueryRowContext(ctx, `SELECT id, email, first_name, last_name, version FROM users WHERE id = ?`, ID).
+		Scan(&user.ID, &user.Email, &user.FirstName, &user.LastName, &user.Version)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("looking up user %s: %w", ID, err)
+	}
+	return &user, nil
+}
+
+func (s *Store) DeleteUser(ctx context.Context, ID uuid.UUID) error {
+	_, err := s.db.ExecContext(ctx, `DELETE FROM users WHERE id = ?`, ID)
+	if err != nil {
+		return fmt.Errorf("deleting user %s: %w", ID, err)
+	}
+	return nil
+}
@@ -0,0 +1,28 @@
+package store
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// User is the projection of a user of the user-service. It holds what is
+// needed to address notifications and is kept up to date from the domain
+// events of the user-service.
+type User struct {
+	ID        uuid.UUID
+	Email     string
+	FirstName string
+	LastName  string
+	// Version is the version of the user in the user-service.
+	Version int64
+}
+
+type UserStore interface {
+	// SetUser stores the user unless a newer version of it is already
+	// stored. Events may be delivered more than once and out of order, so an
+	// older version is silently ignored.
+	SetUser(ctx context.Context, user *User) error
+	LookupUser(ctx context.Context, ID uuid.UUID) (*User, error)
+	DeleteUser(ctx context.Context, ID uuid.UUID) error
+}
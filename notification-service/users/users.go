@@ -0,0 +1,90 @@
+// Package users keeps the projection of the users of the user-service up to
+// date from its domain events.
+package users
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	"github.com/chrishrb/blog-microservice/internal/transport"
+	"github.com/chrishrb/blog-microservice/notification-service/store"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// UserUpdatedHandler stores the user of a UserCreatedEvent or
+// UserUpdatedEvent, which have the same payload.
+type UserUpdatedHandler struct {
+	engine store.UserStore
+}
+
+func NewUserUpdatedHandler(engine store.UserStore) UserUpdatedHandler {
+	return UserUpdatedHandler{
+		engine: engine,
+	}
+}
+
+func (r UserUpdatedHandler) Handle(ctx context.Context, msg *transport.Message) {
+	span := trace.SpanFromContext(ctx)
+
+	err := r.handle(ctx, msg)
+	if err != nil {
+		slog.Error("unable to handle message", slog.String("id", msg.ID), slog.String("event", "UserUpdatedEvent"), "err", err)
+		span.SetStatus(codes.Error, "handle UserUpdatedEvent failed")
+		span.RecordError(err)
+	} else {
+		span.SetStatus(codes.Ok, "ok")
+	}
+}
+
+func (r UserUpdatedHandler) handle(ctx context.Context, msg *transport.Message) error {
+	var req transport.UserUpdatedEvent
+	err := json.Unmarshal(msg.Data, &req)
+	if err != nil {
+		return fmt.Errorf("unmarshalling %s request payload: %w", msg.ID, err)
+	}
+
+	return r.engine.SetUser(ctx, &store.User{
+		ID:        req.UserID,
+		Email:     req.Email,
+		FirstName: req.FirstName,
+		LastName:  req.LastName,
+		Version:   req.Version,
+	})
+}
+
+// UserDeletedHandler removes the user of a UserDeletedEvent.
+type UserDeletedHandler struct {
+	engine store.UserStore
+}
+
+func NewUserDeletedHandler(engine store.UserStore) UserDeletedHandler {
+	return UserDeletedHandler{
+		engine: engine,
+	}
+}
+
+func (r UserDeletedHandler) Handle(ctx context.Context, msg *transport.Message) {
+	span := trace.SpanFromContext(ctx)
+
+	err := r.handle(ctx, msg)
+	if err != nil {
+		slog.Error("unable to handle message", slog.String("id", msg.ID), slog.String("event", "UserDeletedEvent"), "err", err)
+		span.SetStatus(codes.Error, "handle UserDeletedEvent failed")
+		span.RecordError(err)
+	} else {
+		span.SetStatus(codes.Ok, "ok")
+	}
+}
+
+func (r UserDeletedHandler) handle(ctx context.Context, msg *transport.Message) error {
+	var req transport.UserDeletedEvent
+	err := json.Unmarshal(msg.Data, &req)
+	if err != nil {
+		return fmt.Errorf("unmarshalling %s request payload: %w", msg.ID, err)
+	}
+
+	return r.engine.DeleteUser(ctx, req.UserID)
+}
@@ -0,0 +1,53 @@
+package users_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/chrishrb/blog-microservice/internal/transport"
+	"github.com/chrishrb/blog-microservice/notification-service/store/inmemory"
+	"github.com/chrishrb/blog-microservice/notification-service/users"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func message(t *testing.T, event any) *transport.Message {
+	data, err := json.Marshal(event)
+	require.NoError(t, err)
+	return &transport.Message{ID: uuid.New().String(), Data: data}
+}
+
+func TestUserHandlers(t *testing.T) {
+	engine := inmemory.NewStore()
+	updated := users.NewUserUpdatedHandler(engine)
+	deleted := users.NewUserDeletedHandler(engine)
+
+	userID := uuid.New()
+	updated.Handle(t.Context(), message(t, transport.UserCreatedEvent{
+		UserID:    userID,
+		Email:     "john@example.com",
+		FirstName: "John",
+		LastName:  "Doe",
+		Version:   1,
+	}))
+	updated.Handle(t.Context(), message(t, transport.UserUpdatedEvent{
+		UserID:    userID,
+		Email:     "johnny@example.com",
+		FirstName: "Johnny",
+		LastName:  "Doe",
+		Version:   2,
+	}))
+
+	user, err := engine.LookupUser(t.Context(), userID)
+	require.NoError(t, err)
+	require.NotNil(t, user)
+	assert.Equal(t, "johnny@example.com", user.Email)
+	assert.Equal(t, "Johnny", user.FirstName)
+
+	deleted.Handle(t.Context(), message(t, transport.UserDeletedEvent{UserID: userID}))
+
+	user, err = engine.LookupUser(t.Context(), userID)
+	require.NoError(t, err)
+	assert.Nil(t, user)
+}
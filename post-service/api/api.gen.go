@@ -6,12 +6,14 @@ package api
 import (
 	"bytes"
 	"compress/gzip"
+	"context"
 	"encoding/base64"
 	"fmt"
 	"net/http"
 	"net/url"
 	"path"
 	"strings"
+	"time"
 
 	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/go-chi/chi/v5"
@@ -19,22 +21,208 @@ import (
 	openapi_types "github.com/oapi-codegen/runtime/types"
 )
 
+const (
+	BearerAuthScopes = "BearerAuth.Scopes"
+)
+
+// Defines values for ContentFormat.
+const (
+	Markdown ContentFormat = "markdown"
+	Plain    ContentFormat = "plain"
+)
+
+// Defines values for DiffLineOp.
+const (
+	Delete DiffLineOp = "delete"
+	Equal  DiffLineOp = "equal"
+	Insert DiffLineOp = "insert"
+)
+
+// Defines values for FeedFile.
+const (
+	AtomXml  FeedFile = "atom.xml"
+	FeedJson FeedFile = "feed.json"
+	RssXml   FeedFile = "rss.xml"
+)
+
+// Defines values for MediaVariant.
+const (
+	Medium    MediaVariant = "medium"
+	Thumbnail MediaVariant = "thumbnail"
+)
+
+// Defines values for ModerationPolicy.
+const (
+	All     ModerationPolicy = "all"
+	None    ModerationPolicy = "none"
+	Trusted ModerationPolicy = "trusted"
+)
+
+// Defines values for ModerationState.
+const (
+	Approved ModerationState = "approved"
+	Pending  ModerationState = "pending"
+	Rejected ModerationState = "rejected"
+	Spam     ModerationState = "spam"
+)
+
+// Defines values for ListPostsParamsSort.
+const (
+	CreatedAt ListPostsParamsSort = "createdAt"
+	Title     ListPostsParamsSort = "title"
+	UpdatedAt ListPostsParamsSort = "updatedAt"
+)
+
+// Defines values for ListPostsParamsOrder.
+const (
+	Asc  ListPostsParamsOrder = "asc"
+	Desc ListPostsParamsOrder = "desc"
+)
+
+// Defines values for ListCommentsParamsThread.
+const (
+	Flat ListCommentsParamsThread = "flat"
+	Tree ListCommentsParamsThread = "tree"
+)
+
+// Bookmark defines model for Bookmark.
+type Bookmark struct {
+	CreatedAt time.Time `json:"createdAt"`
+
+	// ListId Reading list the bookmark is filed in, missing if it is not filed in a list
+	ListId *openapi_types.UUID `json:"listId,omitempty"`
+	Post   Post                `json:"post"`
+	PostId openapi_types.UUID  `json:"postId"`
+}
+
+// BookmarkList defines model for BookmarkList.
+type BookmarkList struct {
+	Items []Bookmark `json:"items"`
+
+	// NextCursor Cursor of the next page, missing on the last page
+	NextCursor *string `json:"nextCursor,omitempty"`
+}
+
+// BookmarkUpdate defines model for BookmarkUpdate.
+type BookmarkUpdate struct {
+	// ListId Reading list to file the bookmark in
+	ListId *openapi_types.UUID `json:"listId,omitempty"`
+}
+
+// Category defines model for Category.
+type Category struct {
+	// Children Subcategories sorted by name
+	Children    []Category         `json:"children"`
+	CreatedAt   time.Time          `json:"createdAt"`
+	Description string             `json:"description"`
+	Id          openapi_types.UUID `json:"id"`
+	Name        string             `json:"name"`
+
+	// ParentId Category this category is nested in, missing for top-level categories
+	ParentId  *openapi_types.UUID `json:"parentId,omitempty"`
+	UpdatedAt time.Time           `json:"updatedAt"`
+}
+
+// CategoryList defines model for CategoryList.
+type CategoryList struct {
+	// Items Top-level categories sorted by name
+	Items []Category `json:"items"`
+}
+
+// CategoryUpdate defines model for CategoryUpdate.
+type CategoryUpdate struct {
+	// Description Description of the category
+	Description *string `json:"description,omitempty"`
+
+	// Name Name of the category
+	Name string `json:"name"`
+
+	// ParentId Category to nest the category in, missing for a top-level category
+	ParentId *openapi_types.UUID `json:"parentId,omitempty"`
+}
+
 // Comment defines model for Comment.
 type Comment struct {
 	// AuthorId Unique identifier for the author
 	AuthorId openapi_types.UUID `json:"authorId"`
 
-	// Content Content of the comment
+	// Content Content of the comment in the Markdown subset supported for comments, without headings, images, tables and HTML
 	Content string `json:"content"`
 
+	// ContentHtml Sanitized HTML rendition of the content
+	ContentHtml string `json:"contentHtml"`
+
+	// Deleted Indicates a deleted comment that is kept without content because it has replies
+	Deleted bool `json:"deleted"`
+
+	// DeletedAt Time the comment was moved to the trash, missing for comments that are not trashed
+	DeletedAt *time.Time `json:"deletedAt,omitempty"`
+
+	// Depth Nesting level of the comment, 0 for top-level comments
+	Depth int `json:"depth"`
+
 	// Id Unique identifier for the comment
 	Id openapi_types.UUID `json:"id"`
+
+	// MyReaction Reaction of the caller, missing if they did not react
+	MyReaction *string `json:"myReaction,omitempty"`
+
+	// ParentId Comment this comment replies to, missing for top-level comments
+	ParentId *openapi_types.UUID `json:"parentId,omitempty"`
+
+	// PostId Unique identifier for the post
+	PostId openapi_types.UUID `json:"postId"`
+
+	// Reactions Number of users per reaction, reactions nobody gave are missing
+	Reactions ReactionCounts `json:"reactions"`
+
+	// Replies Direct replies, only set when listing threads as a tree
+	Replies *[]Comment `json:"replies,omitempty"`
+
+	// ReplyCount Number of approved direct replies
+	ReplyCount int `json:"replyCount"`
+
+	// State Moderation state of a comment
+	State ModerationState `json:"state"`
 }
 
 // CommentCreate defines model for CommentCreate.
 type CommentCreate struct {
 	// Content Content of the comment
 	Content string `json:"content"`
+
+	// ParentId Comment of the same post to reply to
+	ParentId *openapi_types.UUID `json:"parentId,omitempty"`
+}
+
+// CommentList defines model for CommentList.
+type CommentList struct {
+	Items []Comment `json:"items"`
+
+	// NextCursor Cursor of the next page, missing on the last page
+	NextCursor *string `json:"nextCursor,omitempty"`
+}
+
+// CommentRejection defines model for CommentRejection.
+type CommentRejection struct {
+	// Spam Mark the comment as spam instead of rejecting it
+	Spam *bool `json:"spam,omitempty"`
+}
+
+// CommentSearchResult defines model for CommentSearchResult.
+type CommentSearchResult struct {
+	Comment Comment `json:"comment"`
+
+	// Score Relevance of the comment, higher is better
+	Score float64 `json:"score"`
+
+	// Snippet HTML escaped excerpt of the comment, matching words are wrapped in <mark> elements
+	Snippet string `json:"snippet"`
+}
+
+// CommentSearchResultList defines model for CommentSearchResultList.
+type CommentSearchResultList struct {
+	Items []CommentSearchResult `json:"items"`
 }
 
 // CommentUpdate defines model for CommentUpdate.
@@ -43,6 +231,18 @@ type CommentUpdate struct {
 	Content *string `json:"content,omitempty"`
 }
 
+// ContentFormat Markup language of the content. Markdown is GitHub Flavored Markdown.
+type ContentFormat string
+
+// DiffLine defines model for DiffLine.
+type DiffLine struct {
+	Op   DiffLineOp `json:"op"`
+	Text string     `json:"text"`
+}
+
+// DiffLineOp defines model for DiffLine.Op.
+type DiffLineOp string
+
 // Error defines model for Error.
 type Error struct {
 	Error      *string `json:"error,omitempty"`
@@ -50,223 +250,3378 @@ type Error struct {
 	StatusCode int32   `json:"statusCode"`
 }
 
+// FeedFile defines model for FeedFile.
+type FeedFile string
+
+// Media defines model for Media.
+type Media struct {
+	// AltText Text describing the media for readers who cannot see it
+	AltText string `json:"altText"`
+
+	// ContentType Content type detected from the uploaded file
+	ContentType string    `json:"contentType"`
+	CreatedAt   time.Time `json:"createdAt"`
+
+	// Height Height of the image in pixels
+	Height int                `json:"height"`
+	Id     openapi_types.UUID `json:"id"`
+
+	// OwnerId User who uploaded the media
+	OwnerId openapi_types.UUID `json:"ownerId"`
+
+	// Size Size of the uploaded file in bytes
+	Size      int64     `json:"size"`
+	UpdatedAt time.Time `json:"updatedAt"`
+
+	// Variants Resized variants that were generated
+	Variants []MediaVariant `json:"variants"`
+
+	// Width Width of the image in pixels
+	Width int `json:"width"`
+}
+
+// MediaList defines model for MediaList.
+type MediaList struct {
+	Items []Media `json:"items"`
+
+	// NextCursor Cursor of the next page, missing on the last page
+	NextCursor *string `json:"nextCursor,omitempty"`
+}
+
+// MediaUpdate defines model for MediaUpdate.
+type MediaUpdate struct {
+	// AltText Text describing the media for readers who cannot see it
+	AltText string `json:"altText"`
+}
+
+// MediaUpload defines model for MediaUpload.
+type MediaUpload struct {
+	// AltText Text describing the media for readers who cannot see it
+	AltText *string `json:"altText,omitempty"`
+
+	// File Image to upload
+	File openapi_types.File `json:"file"`
+}
+
+// MediaVariant Resized variant of an image, thumbnail fits into 320 and medium into 1280 pixels
+type MediaVariant string
+
+// ModerationPolicy Moderation policy of new comments on the post, missing if the global policy applies. With none all comments are approved, with trusted the comments of users that are not trusted are held for moderation and with all every comment is held. Comments of moderators and of the author of the post are always approved.
+type ModerationPolicy string
+
+// ModerationState Moderation state of a comment
+type ModerationState string
+
 // Post defines model for Post.
 type Post struct {
 	// AuthorId Unique identifier for the author
 	AuthorId openapi_types.UUID `json:"authorId"`
 
+	// CategoryId Category the post is filed in
+	CategoryId *openapi_types.UUID `json:"categoryId,omitempty"`
+
+	// CommentModeration Moderation policy of new comments on the post, missing if the global policy applies. With none all comments are approved, with trusted the comments of users that are not trusted are held for moderation and with all every comment is held. Comments of moderators and of the author of the post are always approved.
+	CommentModeration *ModerationPolicy `json:"commentModeration,omitempty"`
+
 	// Content Content of the post
 	Content string `json:"content"`
 
+	// ContentFormat Markup language of the content. Markdown is GitHub Flavored Markdown.
+	ContentFormat ContentFormat `json:"contentFormat"`
+
+	// ContentHtml Sanitized HTML rendition of the content
+	ContentHtml string `json:"contentHtml"`
+
+	// CoverMediaId Media shown as the cover image of the post
+	CoverMediaId *openapi_types.UUID `json:"coverMediaId,omitempty"`
+
+	// DeletedAt Time the post was moved to the trash, only set for trashed posts
+	DeletedAt *time.Time `json:"deletedAt,omitempty"`
+
 	// Id Unique identifier for the post
 	Id openapi_types.UUID `json:"id"`
 
+	// MediaIds Media embedded in the post
+	MediaIds *[]openapi_types.UUID `json:"mediaIds,omitempty"`
+
+	// MyReaction Reaction of the caller, missing if they did not react
+	MyReaction *string `json:"myReaction,omitempty"`
+
+	// PublishAt Time at which the post will be published
+	PublishAt *time.Time `json:"publishAt,omitempty"`
+
 	// Published Indicates if the post is published
 	Published bool `json:"published"`
 
+	// PublishedAt Time at which the post was last published, missing if it never was
+	PublishedAt *time.Time `json:"publishedAt,omitempty"`
+
+	// Reactions Number of users per reaction, reactions nobody gave are missing
+	Reactions ReactionCounts `json:"reactions"`
+
+	// Series Series the post is part of. Previous and next are the neighbouring posts visible to the caller, missing at either end.
+	Series *PostSeries `json:"series,omitempty"`
+
+	// Slug Human-readable identifier of the post, derived from its title
+	Slug string `json:"slug"`
+
 	// Tags Tags associated with the post
 	Tags *[]string `json:"tags,omitempty"`
 
 	// Title Title of the post
 	Title string `json:"title"`
+
+	// UnpublishAt Time at which the post will be unpublished
+	UnpublishAt *time.Time `json:"unpublishAt,omitempty"`
+
+	// Version Version of the post, which is incremented by every change
+	Version int64 `json:"version"`
 }
 
 // PostCreate defines model for PostCreate.
 type PostCreate struct {
+	// CategoryId Category to file the post in
+	CategoryId *openapi_types.UUID `json:"categoryId,omitempty"`
+
+	// CommentModeration Moderation policy of new comments on the post, missing if the global policy applies. With none all comments are approved, with trusted the comments of users that are not trusted are held for moderation and with all every comment is held. Comments of moderators and of the author of the post are always approved.
+	CommentModeration *ModerationPolicy `json:"commentModeration,omitempty"`
+
 	// Content Content of the post
 	Content string `json:"content"`
 
+	// ContentFormat Markup language of the content. Markdown is GitHub Flavored Markdown.
+	ContentFormat *ContentFormat `json:"contentFormat,omitempty"`
+
+	// CoverMediaId Media shown as the cover image of the post
+	CoverMediaId *openapi_types.UUID `json:"coverMediaId,omitempty"`
+
+	// MediaIds Media embedded in the post
+	MediaIds *[]openapi_types.UUID `json:"mediaIds,omitempty"`
+
+	// PublishAt Publish the post at this time. A time in the past publishes the post immediately.
+	PublishAt *time.Time `json:"publishAt,omitempty"`
+
 	// Published Indicates if the post is published
 	Published *bool `json:"published,omitempty"`
 
-	// Tags Tags associated with the post
+	// Tags Tags associated with the post. Tags are normalized to lower case with runs of whitespace replaced by a hyphen, empty tags and duplicates are dropped.
 	Tags *[]string `json:"tags,omitempty"`
 
 	// Title Title of the post
 	Title string `json:"title"`
+
+	// UnpublishAt Unpublish the post at this time, which must be after publishAt
+	UnpublishAt *time.Time `json:"unpublishAt,omitempty"`
+}
+
+// PostLink defines model for PostLink.
+type PostLink struct {
+	Id    openapi_types.UUID `json:"id"`
+	Slug  string             `json:"slug"`
+	Title string             `json:"title"`
+}
+
+// PostList defines model for PostList.
+type PostList struct {
+	Items []Post `json:"items"`
+
+	// NextCursor Cursor of the next page, missing on the last page
+	NextCursor *string `json:"nextCursor,omitempty"`
+}
+
+// PostRevision defines model for PostRevision.
+type PostRevision struct {
+	Content   string    `json:"content"`
+	CreatedAt time.Time `json:"createdAt"`
+
+	// EditorId Unique identifier for the user who saved the revision
+	EditorId openapi_types.UUID `json:"editorId"`
+
+	// Number Number of the revision, starting at 1 for the first version of the post
+	Number int                `json:"number"`
+	PostId openapi_types.UUID `json:"postId"`
+
+	// RestoredFrom Number of the revision that was restored by this revision
+	RestoredFrom *int     `json:"restoredFrom,omitempty"`
+	Tags         []string `json:"tags"`
+	Title        string   `json:"title"`
+}
+
+// PostRevisionDiff defines model for PostRevisionDiff.
+type PostRevisionDiff struct {
+	AddedTags []string   `json:"addedTags"`
+	Content   []DiffLine `json:"content"`
+
+	// From Number of the old revision
+	From        int        `json:"from"`
+	RemovedTags []string   `json:"removedTags"`
+	Title       []DiffLine `json:"title"`
+
+	// To Number of the new revision
+	To int `json:"to"`
+}
+
+// PostRevisionList defines model for PostRevisionList.
+type PostRevisionList struct {
+	Items []PostRevision `json:"items"`
+
+	// NextCursor Cursor of the next page, missing on the last page
+	NextCursor *string `json:"nextCursor,omitempty"`
+}
+
+// PostSearchResult defines model for PostSearchResult.
+type PostSearchResult struct {
+	Post Post `json:"post"`
+
+	// Score Relevance of the post, higher is better
+	Score float64 `json:"score"`
+
+	// Snippet HTML escaped excerpt of the post, matching words are wrapped in <mark> elements
+	Snippet string `json:"snippet"`
+}
+
+// PostSearchResultList defines model for PostSearchResultList.
+type PostSearchResultList struct {
+	Items []PostSearchResult `json:"items"`
+}
+
+// PostSeries Series the post is part of. Previous and next are the neighbouring posts visible to the caller, missing at either end.
+type PostSeries struct {
+	Id   openapi_types.UUID `json:"id"`
+	Next *PostLink          `json:"next,omitempty"`
+
+	// Position Position of the post in the series, starting at 1
+	Position int       `json:"position"`
+	Previous *PostLink `json:"previous,omitempty"`
+	Title    string    `json:"title"`
+}
+
+// PostSlugRedirect defines model for PostSlugRedirect.
+type PostSlugRedirect struct {
+	// Slug Current slug of the post
+	Slug string `json:"slug"`
 }
 
 // PostUpdate defines model for PostUpdate.
 type PostUpdate struct {
+	// CategoryId Category to file the post in, the nil UUID removes the post from its category
+	CategoryId *openapi_types.UUID `json:"categoryId,omitempty"`
+
+	// CommentModeration Moderation policy of new comments on the post, missing if the global policy applies. With none all comments are approved, with trusted the comments of users that are not trusted are held for moderation and with all every comment is held. Comments of moderators and of the author of the post are always approved.
+	CommentModeration *ModerationPolicy `json:"commentModeration,omitempty"`
+
 	// Content Content of the post
 	Content *string `json:"content,omitempty"`
 
-	// Published Indicates if the post is published
+	// ContentFormat Markup language of the content. Markdown is GitHub Flavored Markdown.
+	ContentFormat *ContentFormat `json:"contentFormat,omitempty"`
+
+	// CoverMediaId Media shown as the cover image of the post, the nil UUID removes the cover image
+	CoverMediaId *openapi_types.UUID `json:"coverMediaId,omitempty"`
+
+	// MediaIds Media embedded in the post, replaces the current media
+	MediaIds *[]openapi_types.UUID `json:"mediaIds,omitempty"`
+
+	// PublishAt Publish the post at this time. A time in the past publishes the post immediately.
+	PublishAt *time.Time `json:"publishAt,omitempty"`
+
+	// Published Publish or unpublish the post immediately, this cancels its schedule
 	Published *bool `json:"published,omitempty"`
 
-	// Tags Tags associated with the post
+	// Tags Tags associated with the post. Tags are normalized to lower case with runs of whitespace replaced by a hyphen, empty tags and duplicates are dropped.
 	Tags *[]string `json:"tags,omitempty"`
 
 	// Title Title of the post
 	Title *string `json:"title,omitempty"`
+
+	// UnpublishAt Unpublish the post at this time, which must be after publishAt
+	UnpublishAt *time.Time `json:"unpublishAt,omitempty"`
 }
 
-// BadRequest defines model for BadRequest.
-type BadRequest = Error
+// ReactionCounts Number of users per reaction, reactions nobody gave are missing
+type ReactionCounts map[string]int
 
-// InternalServerError defines model for InternalServerError.
-type InternalServerError = Error
+// ReactionList defines model for ReactionList.
+type ReactionList struct {
+	Items []string `json:"items"`
+}
 
-// NotFound defines model for NotFound.
-type NotFound = Error
+// ReactionSummary defines model for ReactionSummary.
+type ReactionSummary struct {
+	// MyReaction Reaction of the caller, missing if they did not react
+	MyReaction *string `json:"myReaction,omitempty"`
 
-// ListPostsParams defines parameters for ListPosts.
-type ListPostsParams struct {
-	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
-	Limit  *int `form:"limit,omitempty" json:"limit,omitempty"`
+	// Reactions Number of users per reaction, reactions nobody gave are missing
+	Reactions ReactionCounts `json:"reactions"`
 }
 
-// ListCommentsParams defines parameters for ListComments.
-type ListCommentsParams struct {
-	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
-	Limit  *int `form:"limit,omitempty" json:"limit,omitempty"`
+// ReactionUpdate defines model for ReactionUpdate.
+type ReactionUpdate struct {
+	// Reaction Reaction to give, either like or one of the configured emojis
+	Reaction string `json:"reaction"`
 }
 
-// CreatePostJSONRequestBody defines body for CreatePost for application/json ContentType.
-type CreatePostJSONRequestBody = PostCreate
+// ReadingList defines model for ReadingList.
+type ReadingList struct {
+	CreatedAt time.Time          `json:"createdAt"`
+	Id        openapi_types.UUID `json:"id"`
+	Name      string             `json:"name"`
+	UpdatedAt time.Time          `json:"updatedAt"`
+}
 
-// UpdatePostJSONRequestBody defines body for UpdatePost for application/json ContentType.
-type UpdatePostJSONRequestBody = PostUpdate
+// ReadingListCreate defines model for ReadingListCreate.
+type ReadingListCreate struct {
+	// Name Name of the reading list
+	Name string `json:"name"`
+}
 
-// CreateCommentJSONRequestBody defines body for CreateComment for application/json ContentType.
-type CreateCommentJSONRequestBody = CommentCreate
+// ReadingListList defines model for ReadingListList.
+type ReadingListList struct {
+	Items []ReadingList `json:"items"`
 
-// UpdateCommentJSONRequestBody defines body for UpdateComment for application/json ContentType.
-type UpdateCommentJSONRequestBody = CommentUpdate
+	// NextCursor Cursor of the next page, missing on the last page
+	NextCursor *string `json:"nextCursor,omitempty"`
+}
 
-// ServerInterface represents all server handlers.
-type ServerInterface interface {
-	// List all posts
-	// (GET /posts)
-	ListPosts(w http.ResponseWriter, r *http.Request, params ListPostsParams)
-	// Create a new post
-	// (POST /posts)
-	CreatePost(w http.ResponseWriter, r *http.Request)
-	// Delete a post
-	// (DELETE /posts/{id})
-	DeletePost(w http.ResponseWriter, r *http.Request, id openapi_types.UUID)
-	// Get a post by ID
-	// (GET /posts/{id})
-	LookupPost(w http.ResponseWriter, r *http.Request, id openapi_types.UUID)
-	// Update a post
-	// (PUT /posts/{id})
-	UpdatePost(w http.ResponseWriter, r *http.Request, id openapi_types.UUID)
-	// List all comments for a post
-	// (GET /posts/{postId}/comments)
-	ListComments(w http.ResponseWriter, r *http.Request, postId openapi_types.UUID, params ListCommentsParams)
-	// Create a new comment
-	// (POST /posts/{postId}/comments)
-	CreateComment(w http.ResponseWriter, r *http.Request, postId openapi_types.UUID)
-	// Delete a comment
-	// (DELETE /posts/{postId}/comments/{id})
-	DeleteComment(w http.ResponseWriter, r *http.Request, postId openapi_types.UUID, id openapi_types.UUID)
-	// Get a comment by ID
-	// (GET /posts/{postId}/comments/{id})
-	LookupComment(w http.ResponseWriter, r *http.Request, postId openapi_types.UUID, id openapi_types.UUID)
-	// Update a comment
-	// (PUT /posts/{postId}/comments/{id})
-	UpdateComment(w http.ResponseWriter, r *http.Request, postId openapi_types.UUID, id openapi_types.UUID)
+// ReadingListUpdate defines model for ReadingListUpdate.
+type ReadingListUpdate struct {
+	// Name Name of the reading list
+	Name string `json:"name"`
 }
 
-// Unimplemented server implementation that returns http.StatusNotImplemented for each endpoint.
+// Series defines model for Series.
+type Series struct {
+	AuthorId    openapi_types.UUID `json:"authorId"`
+	CreatedAt   time.Time          `json:"createdAt"`
+	Description string             `json:"description"`
+	Id          openapi_types.UUID `json:"id"`
+	Title       string             `json:"title"`
+	UpdatedAt   time.Time          `json:"updatedAt"`
+}
 
-type Unimplemented struct{}
+// SeriesCreate defines model for SeriesCreate.
+type SeriesCreate struct {
+	// Description Description of the series
+	Description *string `json:"description,omitempty"`
 
-// List all posts
-// (GET /posts)
-func (_ Unimplemented) ListPosts(w http.ResponseWriter, r *http.Request, params ListPostsParams) {
-	w.WriteHeader(http.StatusNotImplemented)
+	// Title Title of the series
+	Title string `json:"title"`
 }
 
-// Create a new post
-// (POST /posts)
-func (_ Unimplemented) CreatePost(w http.ResponseWriter, r *http.Request) {
-	w.WriteHeader(http.StatusNotImplemented)
-}
+// SeriesList defines model for SeriesList.
+type SeriesList struct {
+	Items []Series `json:"items"`
 
-// Delete a post
-// (DELETE /posts/{id})
-func (_ Unimplemented) DeletePost(w http.ResponseWriter, r *http.Request, id openapi_types.UUID) {
-	w.WriteHeader(http.StatusNotImplemented)
+	// NextCursor Cursor of the next page, missing on the last page
+	NextCursor *string `json:"nextCursor,omitempty"`
 }
 
-// Get a post by ID
-// (GET /posts/{id})
-func (_ Unimplemented) LookupPost(w http.ResponseWriter, r *http.Request, id openapi_types.UUID) {
-	w.WriteHeader(http.StatusNotImplemented)
+// SeriesOrder defines model for SeriesOrder.
+type SeriesOrder struct {
+	// PostIds All posts of the series in their new order
+	PostIds []openapi_types.UUID `json:"postIds"`
 }
 
-// Update a post
-// (PUT /posts/{id})
-func (_ Unimplemented) UpdatePost(w http.ResponseWriter, r *http.Request, id openapi_types.UUID) {
-	w.WriteHeader(http.StatusNotImplemented)
+// SeriesPostUpdate defines model for SeriesPostUpdate.
+type SeriesPostUpdate struct {
+	// Position Position to insert the post at, positions after the last post append it
+	Position *int `json:"position,omitempty"`
 }
 
-// List all comments for a post
-// (GET /posts/{postId}/comments)
-func (_ Unimplemented) ListComments(w http.ResponseWriter, r *http.Request, postId openapi_types.UUID, params ListCommentsParams) {
-	w.WriteHeader(http.StatusNotImplemented)
+// SeriesUpdate defines model for SeriesUpdate.
+type SeriesUpdate struct {
+	// Description Description of the series
+	Description *string `json:"description,omitempty"`
+
+	// Title Title of the series
+	Title *string `json:"title,omitempty"`
 }
 
-// Create a new comment
-// (POST /posts/{postId}/comments)
-func (_ Unimplemented) CreateComment(w http.ResponseWriter, r *http.Request, postId openapi_types.UUID) {
-	w.WriteHeader(http.StatusNotImplemented)
+// Tag defines model for Tag.
+type Tag struct {
+	// Count Number of published posts with the tag
+	Count int    `json:"count"`
+	Name  string `json:"name"`
 }
 
-// Delete a comment
-// (DELETE /posts/{postId}/comments/{id})
-func (_ Unimplemented) DeleteComment(w http.ResponseWriter, r *http.Request, postId openapi_types.UUID, id openapi_types.UUID) {
-	w.WriteHeader(http.StatusNotImplemented)
+// TagList defines model for TagList.
+type TagList struct {
+	Items []Tag `json:"items"`
 }
 
-// Get a comment by ID
-// (GET /posts/{postId}/comments/{id})
-func (_ Unimplemented) LookupComment(w http.ResponseWriter, r *http.Request, postId openapi_types.UUID, id openapi_types.UUID) {
-	w.WriteHeader(http.StatusNotImplemented)
+// TagMerge defines model for TagMerge.
+type TagMerge struct {
+	// Into Tag to replace them with
+	Into string `json:"into"`
+
+	// Tags Tags to replace
+	Tags []string `json:"tags"`
 }
 
-// Update a comment
-// (PUT /posts/{postId}/comments/{id})
-func (_ Unimplemented) UpdateComment(w http.ResponseWriter, r *http.Request, postId openapi_types.UUID, id openapi_types.UUID) {
-	w.WriteHeader(http.StatusNotImplemented)
+// TagMergeResult defines model for TagMergeResult.
+type TagMergeResult struct {
+	// Name Normalized name of the resulting tag
+	Name string `json:"name"`
+
+	// UpdatedPosts Number of posts whose tags changed
+	UpdatedPosts int `json:"updatedPosts"`
 }
 
-// ServerInterfaceWrapper converts contexts to parameters.
-type ServerInterfaceWrapper struct {
-	Handler            ServerInterface
-	HandlerMiddlewares []MiddlewareFunc
-	ErrorHandlerFunc   func(w http.ResponseWriter, r *http.Request, err error)
+// TagRename defines model for TagRename.
+type TagRename struct {
+	// Name New name of the tag
+	Name string `json:"name"`
 }
 
-type MiddlewareFunc func(http.Handler) http.Handler
+// IfMatch defines model for IfMatch.
+type IfMatch = string
 
-// ListPosts operation middleware
+// BadRequest defines model for BadRequest.
+type BadRequest = Error
+
+// Forbidden defines model for Forbidden.
+type Forbidden = Error
+
+// InternalServerError defines model for InternalServerError.
+type InternalServerError = Error
+
+// NotFound defines model for NotFound.
+type NotFound = Error
+
+// PreconditionFailed defines model for PreconditionFailed.
+type PreconditionFailed = Error
+
+// PreconditionRequired defines model for PreconditionRequired.
+type PreconditionRequired = Error
+
+// RequestEntityTooLarge defines model for RequestEntityTooLarge.
+type RequestEntityTooLarge = Error
+
+// Unauthorized defines model for Unauthorized.
+type Unauthorized = Error
+
+// ListBookmarksParams defines parameters for ListBookmarks.
+type ListBookmarksParams struct {
+	// ListId Only return bookmarks filed in this reading list
+	ListId *openapi_types.UUID `form:"listId,omitempty" json:"listId,omitempty"`
+
+	// Cursor Opaque cursor returned as nextCursor by the previous page. If set, offset is ignored.
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Offset Number of items to skip, kept for compatibility. Prefer cursor.
+	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
+	Limit  *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// SearchCommentsParams defines parameters for SearchComments.
+type SearchCommentsParams struct {
+	// Q Search query
+	Q     string `form:"q" json:"q"`
+	Limit *int   `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// ListMediaParams defines parameters for ListMedia.
+type ListMediaParams struct {
+	// Cursor Opaque cursor returned as nextCursor by the previous page. If set, offset is ignored.
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Offset Number of items to skip, kept for compatibility. Prefer cursor.
+	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
+	Limit  *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// GetMediaContentParams defines parameters for GetMediaContent.
+type GetMediaContentParams struct {
+	// Variant Variant to retrieve, the original if missing
+	Variant *MediaVariant `form:"variant,omitempty" json:"variant,omitempty"`
+}
+
+// ListModerationCommentsParams defines parameters for ListModerationComments.
+type ListModerationCommentsParams struct {
+	// State Moderation state of the listed comments
+	State *ModerationState `form:"state,omitempty" json:"state,omitempty"`
+
+	// Cursor Opaque cursor returned as nextCursor by the previous page. If set, offset is ignored.
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Offset Number of items to skip, kept for compatibility. Prefer cursor.
+	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
+	Limit  *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// ListPostsParams defines parameters for ListPosts.
+type ListPostsParams struct {
+	// Mine Only return posts of the authenticated user, including drafts. Requires authentication.
+	Mine *bool `form:"mine,omitempty" json:"mine,omitempty"`
+
+	// Tag Only return posts with this tag, which is normalized like the tags of posts
+	Tag *string `form:"tag,omitempty" json:"tag,omitempty"`
+
+	// AuthorId Only return posts of this author
+	AuthorId *openapi_types.UUID `form:"authorId,omitempty" json:"authorId,omitempty"`
+
+	// CategoryId Only return posts filed in this category or one of its subcategories
+	CategoryId *openapi_types.UUID `form:"categoryId,omitempty" json:"categoryId,omitempty"`
+
+	// Published Only return published or unpublished posts
+	Published *bool `form:"published,omitempty" json:"published,omitempty"`
+
+	// CreatedFrom Only return posts created at or after this time
+	CreatedFrom *time.Time `form:"createdFrom,omitempty" json:"createdFrom,omitempty"`
+
+	// CreatedTo Only return posts created before this time
+	CreatedTo *time.Time `form:"createdTo,omitempty" json:"createdTo,omitempty"`
+
+	// Sort Key to sort the posts by, ties are broken by creation time
+	Sort *ListPostsParamsSort `form:"sort,omitempty" json:"sort,omitempty"`
+
+	// Order Sort direction
+	Order *ListPostsParamsOrder `form:"order,omitempty" json:"order,omitempty"`
+
+	// Cursor Opaque cursor returned as nextCursor by the previous page. If set, offset is ignored.
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Offset Number of items to skip, kept for compatibility. Prefer cursor.
+	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
+	Limit  *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// ListPostsParamsSort defines parameters for ListPosts.
+type ListPostsParamsSort string
+
+// ListPostsParamsOrder defines parameters for ListPosts.
+type ListPostsParamsOrder string
+
+// SearchPostsParams defines parameters for SearchPosts.
+type SearchPostsParams struct {
+	// Q Search query
+	Q     string `form:"q" json:"q"`
+	Limit *int   `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// UpdatePostParams defines parameters for UpdatePost.
+type UpdatePostParams struct {
+	// IfMatch ETag of the version of the resource the change is based on
+	IfMatch *IfMatch `json:"If-Match,omitempty"`
+}
+
+// ListPostRevisionsParams defines parameters for ListPostRevisions.
+type ListPostRevisionsParams struct {
+	// Cursor Opaque cursor returned as nextCursor by the previous page
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+	Limit  *int    `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// RestorePostRevisionParams defines parameters for RestorePostRevision.
+type RestorePostRevisionParams struct {
+	// IfMatch ETag of the version of the resource the change is based on
+	IfMatch *IfMatch `json:"If-Match,omitempty"`
+}
+
+// ListCommentsParams defines parameters for ListComments.
+type ListCommentsParams struct {
+	// Thread List threads either as a tree, where replies are nested in their parent, or flattened in depth-first order.
+	Thread *ListCommentsParamsThread `form:"thread,omitempty" json:"thread,omitempty"`
+
+	// Cursor Opaque cursor returned as nextCursor by the previous page. If set, offset is ignored.
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Offset Number of items to skip, kept for compatibility. Prefer cursor.
+	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
+	Limit  *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// ListCommentsParamsThread defines parameters for ListComments.
+type ListCommentsParamsThread string
+
+// ListReadingListsParams defines parameters for ListReadingLists.
+type ListReadingListsParams struct {
+	// Cursor Opaque cursor returned as nextCursor by the previous page. If set, offset is ignored.
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Offset Number of items to skip, kept for compatibility. Prefer cursor.
+	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
+	Limit  *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// ListSeriesParams defines parameters for ListSeries.
+type ListSeriesParams struct {
+	// AuthorId Only return series of this author
+	AuthorId *openapi_types.UUID `form:"authorId,omitempty" json:"authorId,omitempty"`
+
+	// Cursor Opaque cursor returned as nextCursor by the previous page. If set, offset is ignored.
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Offset Number of items to skip, kept for compatibility. Prefer cursor.
+	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
+	Limit  *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// ListTagsParams defines parameters for ListTags.
+type ListTagsParams struct {
+	// Prefix Only return tags starting with this prefix, which is normalized like the tags of posts
+	Prefix *string `form:"prefix,omitempty" json:"prefix,omitempty"`
+	Limit  *int    `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// ListTrashedCommentsParams defines parameters for ListTrashedComments.
+type ListTrashedCommentsParams struct {
+	// Cursor Opaque cursor returned as nextCursor by the previous page. If set, offset is ignored.
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Offset Number of items to skip, kept for compatibility. Prefer cursor.
+	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
+	Limit  *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// ListTrashedPostsParams defines parameters for ListTrashedPosts.
+type ListTrashedPostsParams struct {
+	// Cursor Opaque cursor returned as nextCursor by the previous page. If set, offset is ignored.
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Offset Number of items to skip, kept for compatibility. Prefer cursor.
+	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
+	Limit  *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// SetBookmarkJSONRequestBody defines body for SetBookmark for application/json ContentType.
+type SetBookmarkJSONRequestBody = BookmarkUpdate
+
+// CreateCategoryJSONRequestBody defines body for CreateCategory for application/json ContentType.
+type CreateCategoryJSONRequestBody = CategoryUpdate
+
+// UpdateCategoryJSONRequestBody defines body for UpdateCategory for application/json ContentType.
+type UpdateCategoryJSONRequestBody = CategoryUpdate
+
+// UploadMediaMultipartRequestBody defines body for UploadMedia for multipart/form-data ContentType.
+type UploadMediaMultipartRequestBody = MediaUpload
+
+// UpdateMediaJSONRequestBody defines body for UpdateMedia for application/json ContentType.
+type UpdateMediaJSONRequestBody = MediaUpdate
+
+// CreatePostJSONRequestBody defines body for CreatePost for application/json ContentType.
+type CreatePostJSONRequestBody = PostCreate
+
+// UpdatePostJSONRequestBody defines body for UpdatePost for application/json ContentType.
+type UpdatePostJSONRequestBody = PostUpdate
+
+// SetPostReactionJSONRequestBody defines body for SetPostReaction for application/json ContentType.
+type SetPostReactionJSONRequestBody = ReactionUpdate
+
+// CreateCommentJSONRequestBody defines body for CreateComment for application/json ContentType.
+type CreateCommentJSONRequestBody = CommentCreate
+
+// UpdateCommentJSONRequestBody defines body for UpdateComment for application/json ContentType.
+type UpdateCommentJSONRequestBody = CommentUpdate
+
+// SetCommentReactionJSONRequestBody defines body for SetCommentReaction for application/json ContentType.
+type SetCommentReactionJSONRequestBody = ReactionUpdate
+
+// RejectCommentJSONRequestBody defines body for RejectComment for application/json ContentType.
+type RejectCommentJSONRequestBody = CommentRejection
+
+// CreateReadingListJSONRequestBody defines body for CreateReadingList for application/json ContentType.
+type CreateReadingListJSONRequestBody = ReadingListCreate
+
+// UpdateReadingListJSONRequestBody defines body for UpdateReadingList for application/json ContentType.
+type UpdateReadingListJSONRequestBody = ReadingListUpdate
+
+// CreateSeriesJSONRequestBody defines body for CreateSeries for application/json ContentType.
+type CreateSeriesJSONRequestBody = SeriesCreate
+
+// UpdateSeriesJSONRequestBody defines body for UpdateSeries for application/json ContentType.
+type UpdateSeriesJSONRequestBody = SeriesUpdate
+
+// ReorderSeriesJSONRequestBody defines body for ReorderSeries for application/json ContentType.
+type ReorderSeriesJSONRequestBody = SeriesOrder
+
+// AddSeriesPostJSONRequestBody defines body for AddSeriesPost for application/json ContentType.
+type AddSeriesPostJSONRequestBody = SeriesPostUpdate
+
+// MergeTagsJSONRequestBody defines body for MergeTags for application/json ContentType.
+type MergeTagsJSONRequestBody = TagMerge
+
+// RenameTagJSONRequestBody defines body for RenameTag for application/json ContentType.
+type RenameTagJSONRequestBody = TagRename
+
+// ServerInterface represents all server handlers.
+type ServerInterface interface {
+	// List bookmarks
+	// (GET /bookmarks)
+	ListBookmarks(w http.ResponseWriter, r *http.Request, params ListBookmarksParams)
+	// Remove a bookmark
+	// (DELETE /bookmarks/{postId})
+	DeleteBookmark(w http.ResponseWriter, r *http.Request, postId openapi_types.UUID)
+	// Bookmark a post
+	// (PUT /bookmarks/{postId})
+	SetBookmark(w http.ResponseWriter, r *http.Request, postId openapi_types.UUID)
+	// List categories
+	// (GET /categories)
+	ListCategories(w http.ResponseWriter, r *http.Request)
+	// Create a category
+	// (POST /categories)
+	CreateCategory(w http.ResponseWriter, r *http.Request)
+	// Delete a category
+	// (DELETE /categories/{id})
+	DeleteCategory(w http.ResponseWriter, r *http.Request, id openapi_types.UUID)
+	// Get a category by ID
+	// (GET /categories/{id})
+	LookupCategory(w http.ResponseWriter, r *http.Request, id openapi_types.UUID)
+	// Update a category
+	// (PUT /categories/{id})
+	UpdateCategory(w http.ResponseWriter, r *http.Request, id openapi_types.UUID)
+	// Search comments
+	// (GET /comments/search)
+	SearchComments(w http.ResponseWriter, r *http.Request, params SearchCommentsParams)
+	// Get the feed of an author
+	// (GET /feeds/authors/{authorId}/{file})
+	GetAuthorFeed(w http.ResponseWriter, r *http.Request, authorId openapi_types.UUID, file FeedFile)
+	// Get the feed of a tag
+	// (GET /feeds/tags/{tag}/{file})
+	GetTagFeed(w http.ResponseWriter, r *http.Request, tag string, file FeedFile)
+	// Get the feed of all posts
+	// (GET /feeds/{file})
+	GetFeed(w http.ResponseWriter, r *http.Request, file FeedFile)
+	// List own media
+	// (GET /media)
+	ListMedia(w http.ResponseWriter, r *http.Request, params ListMediaParams)
+	// Upload media
+	// (POST /media)
+	UploadMedia(w http.ResponseWriter, r *http.Request)
+	// Delete media
+	// (DELETE /media/{id})
+	DeleteMedia(w http.ResponseWriter, r *http.Request, id openapi_types.UUID)
+	// Get media by ID
+	// (GET /media/{id})
+	LookupMedia(w http.ResponseWriter, r *http.Request, id openapi_types.UUID)
+	// Update media
+	// (PUT /media/{id})
+	UpdateMedia(w http.ResponseWriter, r *http.Request, id openapi_types.UUID)
+	// Get the content of media
+	// (GET /media/{id}/content)
+	GetMediaContent(w http.ResponseWriter, r *http.Request, id openapi_types.UUID, params GetMediaContentParams)
+	// List comments for moderation
+	// (GET /moderation/comments)
+	ListModerationComments(w http.ResponseWriter, r *http.Request, params ListModerationCommentsParams)
+	// List all posts
+	// (GET /posts)
+	ListPosts(w http.ResponseWriter, r *http.Request, params ListPostsParams)
+	// Create a new post
+	// (POST /posts)
+	CreatePost(w http.ResponseWriter, r *http.Request)
+	// Get a post by slug
+	// (GET /posts/by-slug/{slug})
+	LookupPostBySlug(w http.ResponseWriter, r *http.Request, slug string)
+	// Search posts
+	// (GET /posts/search)
+	SearchPosts(w http.ResponseWriter, r *http.Request, params SearchPostsParams)
+	// Delete a post
+	// (DELETE /posts/{id})
+	DeletePost(w http.ResponseWriter, r *http.Request, id openapi_types.UUID)
+	// Get a post by ID
+	// (GET /posts/{id})
+	LookupPost(w http.ResponseWriter, r *http.Request, id openapi_types.UUID)
+	// Update a post
+	// (PUT /posts/{id})
+	UpdatePost(w http.ResponseWriter, r *http.Request, id openapi_types.UUID, params UpdatePostParams)
+	// Remove the reaction to a post
+	// (DELETE /posts/{id}/reaction)
+	DeletePostReaction(w http.ResponseWriter, r *http.Request, id openapi_types.UUID)
+	// React to a post
+	// (PUT /posts/{id}/reaction)
+	SetPostReaction(w http.ResponseWriter, r *http.Request, id openapi_types.UUID)
+	// List the revisions of a post
+	// (GET /posts/{id}/revisions)
+	ListPostRevisions(w http.ResponseWriter, r *http.Request, id openapi_types.UUID, params ListPostRevisionsParams)
+	// Get a revision of a post
+	// (GET /posts/{id}/revisions/{revision})
+	LookupPostRevision(w http.ResponseWriter, r *http.Request, id openapi_types.UUID, revision int)
+	// Compare two revisions of a post
+	// (GET /posts/{id}/revisions/{revision}/diff/{other})
+	DiffPostRevisions(w http.ResponseWriter, r *http.Request, id openapi_types.UUID, revision int, other int)
+	// Restore a revision of a post
+	// (POST /posts/{id}/revisions/{revision}/restore)
+	RestorePostRevision(w http.ResponseWriter, r *http.Request, id openapi_types.UUID, revision int, params RestorePostRevisionParams)
+	// List all comments for a post
+	// (GET /posts/{postId}/comments)
+	ListComments(w http.ResponseWriter, r *http.Request, postId openapi_types.UUID, params ListCommentsParams)
+	// Create a new comment
+	// (POST /posts/{postId}/comments)
+	CreateComment(w http.ResponseWriter, r *http.Request, postId openapi_types.UUID)
+	// Delete a comment
+	// (DELETE /posts/{postId}/comments/{id})
+	DeleteComment(w http.ResponseWriter, r *http.Request, postId openapi_types.UUID, id openapi_types.UUID)
+	// Get a comment by ID
+	// (GET /posts/{postId}/comments/{id})
+	LookupComment(w http.ResponseWriter, r *http.Request, postId openapi_types.UUID, id openapi_types.UUID)
+	// Update a comment
+	// (PUT /posts/{postId}/comments/{id})
+	UpdateComment(w http.ResponseWriter, r *http.Request, postId openapi_types.UUID, id openapi_types.UUID)
+	// Approve a comment
+	// (POST /posts/{postId}/comments/{id}/approve)
+	ApproveComment(w http.ResponseWriter, r *http.Request, postId openapi_types.UUID, id openapi_types.UUID)
+	// Remove the reaction to a comment
+	// (DELETE /posts/{postId}/comments/{id}/reaction)
+	DeleteCommentReaction(w http.ResponseWriter, r *http.Request, postId openapi_types.UUID, id openapi_types.UUID)
+	// React to a comment
+	// (PUT /posts/{postId}/comments/{id}/reaction)
+	SetCommentReaction(w http.ResponseWriter, r *http.Request, postId openapi_types.UUID, id openapi_types.UUID)
+	// Reject a comment
+	// (POST /posts/{postId}/comments/{id}/reject)
+	RejectComment(w http.ResponseWriter, r *http.Request, postId openapi_types.UUID, id openapi_types.UUID)
+	// List the available reactions
+	// (GET /reactions)
+	ListReactions(w http.ResponseWriter, r *http.Request)
+	// List reading lists
+	// (GET /reading-lists)
+	ListReadingLists(w http.ResponseWriter, r *http.Request, params ListReadingListsParams)
+	// Create a reading list
+	// (POST /reading-lists)
+	CreateReadingList(w http.ResponseWriter, r *http.Request)
+	// Delete a reading list
+	// (DELETE /reading-lists/{id})
+	DeleteReadingList(w http.ResponseWriter, r *http.Request, id openapi_types.UUID)
+	// Get a reading list by ID
+	// (GET /reading-lists/{id})
+	LookupReadingList(w http.ResponseWriter, r *http.Request, id openapi_types.UUID)
+	// Rename a reading list
+	// (PUT /reading-lists/{id})
+	UpdateReadingList(w http.ResponseWriter, r *http.Request, id openapi_types.UUID)
+	// List series
+	// (GET /series)
+	ListSeries(w http.ResponseWriter, r *http.Request, params ListSeriesParams)
+	// Create a series
+	// (POST /series)
+	CreateSeries(w http.ResponseWriter, r *http.Request)
+	// Delete a series
+	// (DELETE /series/{id})
+	DeleteSeries(w http.ResponseWriter, r *http.Request, id openapi_types.UUID)
+	// Get a series by ID
+	// (GET /series/{id})
+	LookupSeries(w http.ResponseWriter, r *http.Request, id openapi_types.UUID)
+	// Update a series
+	// (PUT /series/{id})
+	UpdateSeries(w http.ResponseWriter, r *http.Request, id openapi_types.UUID)
+	// Reorder a series
+	// (PUT /series/{id}/order)
+	ReorderSeries(w http.ResponseWriter, r *http.Request, id openapi_types.UUID)
+	// List the posts of a series
+	// (GET /series/{id}/posts)
+	ListSeriesPosts(w http.ResponseWriter, r *http.Request, id openapi_types.UUID)
+	// Remove a post from a series
+	// (DELETE /series/{id}/posts/{postId})
+	RemoveSeriesPost(w http.ResponseWriter, r *http.Request, id openapi_types.UUID, postId openapi_types.UUID)
+	// Add a post to a series
+	// (PUT /series/{id}/posts/{postId})
+	AddSeriesPost(w http.ResponseWriter, r *http.Request, id openapi_types.UUID, postId openapi_types.UUID)
+	// Get the sitemap
+	// (GET /sitemap.xml)
+	GetSitemap(w http.ResponseWriter, r *http.Request)
+	// Get a sitemap of the sitemap index
+	// (GET /sitemaps/{page})
+	GetSitemapPage(w http.ResponseWriter, r *http.Request, page int)
+	// List tags
+	// (GET /tags)
+	ListTags(w http.ResponseWriter, r *http.Request, params ListTagsParams)
+	// Merge tags
+	// (POST /tags/merge)
+	MergeTags(w http.ResponseWriter, r *http.Request)
+	// Rename a tag
+	// (PUT /tags/{tag})
+	RenameTag(w http.ResponseWriter, r *http.Request, tag string)
+	// List trashed comments
+	// (GET /trash/comments)
+	ListTrashedComments(w http.ResponseWriter, r *http.Request, params ListTrashedCommentsParams)
+	// List trashed posts
+	// (GET /trash/posts)
+	ListTrashedPosts(w http.ResponseWriter, r *http.Request, params ListTrashedPostsParams)
+	// Restore a trashed post
+	// (POST /trash/posts/{id}/restore)
+	RestorePost(w http.ResponseWriter, r *http.Request, id openapi_types.UUID)
+	// Restore a trashed comment
+	// (POST /trash/posts/{postId}/comments/{id}/restore)
+	RestoreComment(w http.ResponseWriter, r *http.Request, postId openapi_types.UUID, id openapi_types.UUID)
+}
+
+// Unimplemented server implementation that returns http.StatusNotImplemented for each endpoint.
+
+type Unimplemented struct{}
+
+// List bookmarks
+// (GET /bookmarks)
+func (_ Unimplemented) ListBookmarks(w http.ResponseWriter, r *http.Request, params ListBookmarksParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Remove a bookmark
+// (DELETE /bookmarks/{postId})
+func (_ Unimplemented) DeleteBookmark(w http.ResponseWriter, r *http.Request, postId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Bookmark a post
+// (PUT /bookmarks/{postId})
+func (_ Unimplemented) SetBookmark(w http.ResponseWriter, r *http.Request, postId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List categories
+// (GET /categories)
+func (_ Unimplemented) ListCategories(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Create a category
+// (POST /categories)
+func (_ Unimplemented) CreateCategory(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Delete a category
+// (DELETE /categories/{id})
+func (_ Unimplemented) DeleteCategory(w http.ResponseWriter, r *http.Request, id openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get a category by ID
+// (GET /categories/{id})
+func (_ Unimplemented) LookupCategory(w http.ResponseWriter, r *http.Request, id openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Update a category
+// (PUT /categories/{id})
+func (_ Unimplemented) UpdateCategory(w http.ResponseWriter, r *http.Request, id openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Search comments
+// (GET /comments/search)
+func (_ Unimplemented) SearchComments(w http.ResponseWriter, r *http.Request, params SearchCommentsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get the feed of an author
+// (GET /feeds/authors/{authorId}/{file})
+func (_ Unimplemented) GetAuthorFeed(w http.ResponseWriter, r *http.Request, authorId openapi_types.UUID, file FeedFile) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get the feed of a tag
+// (GET /feeds/tags/{tag}/{file})
+func (_ Unimplemented) GetTagFeed(w http.ResponseWriter, r *http.Request, tag string, file FeedFile) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get the feed of all posts
+// (GET /feeds/{file})
+func (_ Unimplemented) GetFeed(w http.ResponseWriter, r *http.Request, file FeedFile) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List own media
+// (GET /media)
+func (_ Unimplemented) ListMedia(w http.ResponseWriter, r *http.Request, params ListMediaParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Upload media
+// (POST /media)
+func (_ Unimplemented) UploadMedia(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Delete media
+// (DELETE /media/{id})
+func (_ Unimplemented) DeleteMedia(w http.ResponseWriter, r *http.Request, id openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get media by ID
+// (GET /media/{id})
+func (_ Unimplemented) LookupMedia(w http.ResponseWriter, r *http.Request, id openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Update media
+// (PUT /media/{id})
+func (_ Unimplemented) UpdateMedia(w http.ResponseWriter, r *http.Request, id openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get the content of media
+// (GET /media/{id}/content)
+func (_ Unimplemented) GetMediaContent(w http.ResponseWriter, r *http.Request, id openapi_types.UUID, params GetMediaContentParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List comments for moderation
+// (GET /moderation/comments)
+func (_ Unimplemented) ListModerationComments(w http.ResponseWriter, r *http.Request, params ListModerationCommentsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List all posts
+// (GET /posts)
+func (_ Unimplemented) ListPosts(w http.ResponseWriter, r *http.Request, params ListPostsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Create a new post
+// (POST /posts)
+func (_ Unimplemented) CreatePost(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get a post by slug
+// (GET /posts/by-slug/{slug})
+func (_ Unimplemented) LookupPostBySlug(w http.ResponseWriter, r *http.Request, slug string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Search posts
+// (GET /posts/search)
+func (_ Unimplemented) SearchPosts(w http.ResponseWriter, r *http.Request, params SearchPostsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Delete a post
+// (DELETE /posts/{id})
+func (_ Unimplemented) DeletePost(w http.ResponseWriter, r *http.Request, id openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get a post by ID
+// (GET /posts/{id})
+func (_ Unimplemented) LookupPost(w http.ResponseWriter, r *http.Request, id openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Update a post
+// (PUT /posts/{id})
+func (_ Unimplemented) UpdatePost(w http.ResponseWriter, r *http.Request, id openapi_types.UUID, params UpdatePostParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Remove the reaction to a post
+// (DELETE /posts/{id}/reaction)
+func (_ Unimplemented) DeletePostReaction(w http.ResponseWriter, r *http.Request, id openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// React to a post
+// (PUT /posts/{id}/reaction)
+func (_ Unimplemented) SetPostReaction(w http.ResponseWriter, r *http.Request, id openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List the revisions of a post
+// (GET /posts/{id}/revisions)
+func (_ Unimplemented) ListPostRevisions(w http.ResponseWriter, r *http.Request, id openapi_types.UUID, params ListPostRevisionsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get a revision of a post
+// (GET /posts/{id}/revisions/{revision})
+func (_ Unimplemented) LookupPostRevision(w http.ResponseWriter, r *http.Request, id openapi_types.UUID, revision int) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Compare two revisions of a post
+// (GET /posts/{id}/revisions/{revision}/diff/{other})
+func (_ Unimplemented) DiffPostRevisions(w http.ResponseWriter, r *http.Request, id openapi_types.UUID, revision int, other int) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Restore a revision of a post
+// (POST /posts/{id}/revisions/{revision}/restore)
+func (_ Unimplemented) RestorePostRevision(w http.ResponseWriter, r *http.Request, id openapi_types.UUID, revision int, params RestorePostRevisionParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List all comments for a post
+// (GET /posts/{postId}/comments)
+func (_ Unimplemented) ListComments(w http.ResponseWriter, r *http.Request, postId openapi_types.UUID, params ListCommentsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Create a new comment
+// (POST /posts/{postId}/comments)
+func (_ Unimplemented) CreateComment(w http.ResponseWriter, r *http.Request, postId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Delete a comment
+// (DELETE /posts/{postId}/comments/{id})
+func (_ Unimplemented) DeleteComment(w http.ResponseWriter, r *http.Request, postId openapi_types.UUID, id openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get a comment by ID
+// (GET /posts/{postId}/comments/{id})
+func (_ Unimplemented) LookupComment(w http.ResponseWriter, r *http.Request, postId openapi_types.UUID, id openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Update a comment
+// (PUT /posts/{postId}/comments/{id})
+func (_ Unimplemented) UpdateComment(w http.ResponseWriter, r *http.Request, postId openapi_types.UUID, id openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Approve a comment
+// (POST /posts/{postId}/comments/{id}/approve)
+func (_ Unimplemented) ApproveComment(w http.ResponseWriter, r *http.Request, postId openapi_types.UUID, id openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Remove the reaction to a comment
+// (DELETE /posts/{postId}/comments/{id}/reaction)
+func (_ Unimplemented) DeleteCommentReaction(w http.ResponseWriter, r *http.Request, postId openapi_types.UUID, id openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// React to a comment
+// (PUT /posts/{postId}/comments/{id}/reaction)
+func (_ Unimplemented) SetCommentReaction(w http.ResponseWriter, r *http.Request, postId openapi_types.UUID, id openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Reject a comment
+// (POST /posts/{postId}/comments/{id}/reject)
+func (_ Unimplemented) RejectComment(w http.ResponseWriter, r *http.Request, postId openapi_types.UUID, id openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List the available reactions
+// (GET /reactions)
+func (_ Unimplemented) ListReactions(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List reading lists
+// (GET /reading-lists)
+func (_ Unimplemented) ListReadingLists(w http.ResponseWriter, r *http.Request, params ListReadingListsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Create a reading list
+// (POST /reading-lists)
+func (_ Unimplemented) CreateReadingList(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Delete a reading list
+// (DELETE /reading-lists/{id})
+func (_ Unimplemented) DeleteReadingList(w http.ResponseWriter, r *http.Request, id openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get a reading list by ID
+// (GET /reading-lists/{id})
+func (_ Unimplemented) LookupReadingList(w http.ResponseWriter, r *http.Request, id openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Rename a reading list
+// (PUT /reading-lists/{id})
+func (_ Unimplemented) UpdateReadingList(w http.ResponseWriter, r *http.Request, id openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List series
+// (GET /series)
+func (_ Unimplemented) ListSeries(w http.ResponseWriter, r *http.Request, params ListSeriesParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Create a series
+// (POST /series)
+func (_ Unimplemented) CreateSeries(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Delete a series
+// (DELETE /series/{id})
+func (_ Unimplemented) DeleteSeries(w http.ResponseWriter, r *http.Request, id openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get a series by ID
+// (GET /series/{id})
+func (_ Unimplemented) LookupSeries(w http.ResponseWriter, r *http.Request, id openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Update a series
+// (PUT /series/{id})
+func (_ Unimplemented) UpdateSeries(w http.ResponseWriter, r *http.Request, id openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Reorder a series
+// (PUT /series/{id}/order)
+func (_ Unimplemented) ReorderSeries(w http.ResponseWriter, r *http.Request, id openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List the posts of a series
+// (GET /series/{id}/posts)
+func (_ Unimplemented) ListSeriesPosts(w http.ResponseWriter, r *http.Request, id openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Remove a post from a series
+// (DELETE /series/{id}/posts/{postId})
+func (_ Unimplemented) RemoveSeriesPost(w http.ResponseWriter, r *http.Request, id openapi_types.UUID, postId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Add a post to a series
+// (PUT /series/{id}/posts/{postId})
+func (_ Unimplemented) AddSeriesPost(w http.ResponseWriter, r *http.Request, id openapi_types.UUID, postId openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get the sitemap
+// (GET /sitemap.xml)
+func (_ Unimplemented) GetSitemap(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get a sitemap of the sitemap index
+// (GET /sitemaps/{page})
+func (_ Unimplemented) GetSitemapPage(w http.ResponseWriter, r *http.Request, page int) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List tags
+// (GET /tags)
+func (_ Unimplemented) ListTags(w http.ResponseWriter, r *http.Request, params ListTagsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Merge tags
+// (POST /tags/merge)
+func (_ Unimplemented) MergeTags(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Rename a tag
+// (PUT /tags/{tag})
+func (_ Unimplemented) RenameTag(w http.ResponseWriter, r *http.Request, tag string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List trashed comments
+// (GET /trash/comments)
+func (_ Unimplemented) ListTrashedComments(w http.ResponseWriter, r *http.Request, params ListTrashedCommentsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List trashed posts
+// (GET /trash/posts)
+func (_ Unimplemented) ListTrashedPosts(w http.ResponseWriter, r *http.Request, params ListTrashedPostsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Restore a trashed post
+// (POST /trash/posts/{id}/restore)
+func (_ Unimplemented) RestorePost(w http.ResponseWriter, r *http.Request, id openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Restore a trashed comment
+// (POST /trash/posts/{postId}/comments/{id}/restore)
+func (_ Unimplemented) RestoreComment(w http.ResponseWriter, r *http.Request, postId openapi_types.UUID, id openapi_types.UUID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// ServerInterfaceWrapper converts contexts to parameters.
+type ServerInterfaceWrapper struct {
+	Handler            ServerInterface
+	HandlerMiddlewares []MiddlewareFunc
+	ErrorHandlerFunc   func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+type MiddlewareFunc func(http.Handler) http.Handler
+
+// ListBookmarks operation middleware
+func (siw *ServerInterfaceWrapper) ListBookmarks(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListBookmarksParams
+
+	// ------------- Optional query parameter "listId" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "listId", r.URL.Query(), &params.ListId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "listId", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", r.URL.Query(), &params.Offset)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "offset", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListBookmarks(w, r, params)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteBookmark operation middleware
+func (siw *ServerInterfaceWrapper) DeleteBookmark(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "postId" -------------
+	var postId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "postId", chi.URLParam(r, "postId"), &postId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "postId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteBookmark(w, r, postId)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// SetBookmark operation middleware
+func (siw *ServerInterfaceWrapper) SetBookmark(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "postId" -------------
+	var postId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "postId", chi.URLParam(r, "postId"), &postId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "postId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SetBookmark(w, r, postId)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListCategories operation middleware
+func (siw *ServerInterfaceWrapper) ListCategories(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListCategories(w, r)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreateCategory operation middleware
+func (siw *ServerInterfaceWrapper) CreateCategory(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateCategory(w, r)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteCategory operation middleware
+func (siw *ServerInterfaceWrapper) DeleteCategory(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteCategory(w, r, id)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// LookupCategory operation middleware
+func (siw *ServerInterfaceWrapper) LookupCategory(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.LookupCategory(w, r, id)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// UpdateCategory operation middleware
+func (siw *ServerInterfaceWrapper) UpdateCategory(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UpdateCategory(w, r, id)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// SearchComments operation middleware
+func (siw *ServerInterfaceWrapper) SearchComments(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params SearchCommentsParams
+
+	// ------------- Required query parameter "q" -------------
+
+	if paramValue := r.URL.Query().Get("q"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "q"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "q", r.URL.Query(), &params.Q)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "q", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SearchComments(w, r, params)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetAuthorFeed operation middleware
+func (siw *ServerInterfaceWrapper) GetAuthorFeed(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "authorId" -------------
+	var authorId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "authorId", chi.URLParam(r, "authorId"), &authorId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "authorId", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "file" -------------
+	var file FeedFile
+
+	err = runtime.BindStyledParameterWithOptions("simple", "file", chi.URLParam(r, "file"), &file, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "file", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetAuthorFeed(w, r, authorId, file)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetTagFeed operation middleware
+func (siw *ServerInterfaceWrapper) GetTagFeed(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "tag" -------------
+	var tag string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "tag", chi.URLParam(r, "tag"), &tag, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "tag", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "file" -------------
+	var file FeedFile
+
+	err = runtime.BindStyledParameterWithOptions("simple", "file", chi.URLParam(r, "file"), &file, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "file", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetTagFeed(w, r, tag, file)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetFeed operation middleware
+func (siw *ServerInterfaceWrapper) GetFeed(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "file" -------------
+	var file FeedFile
+
+	err = runtime.BindStyledParameterWithOptions("simple", "file", chi.URLParam(r, "file"), &file, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "file", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetFeed(w, r, file)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListMedia operation middleware
+func (siw *ServerInterfaceWrapper) ListMedia(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListMediaParams
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", r.URL.Query(), &params.Offset)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "offset", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListMedia(w, r, params)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// UploadMedia operation middleware
+func (siw *ServerInterfaceWrapper) UploadMedia(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UploadMedia(w, r)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteMedia operation middleware
+func (siw *ServerInterfaceWrapper) DeleteMedia(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteMedia(w, r, id)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// LookupMedia operation middleware
+func (siw *ServerInterfaceWrapper) LookupMedia(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.LookupMedia(w, r, id)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// UpdateMedia operation middleware
+func (siw *ServerInterfaceWrapper) UpdateMedia(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UpdateMedia(w, r, id)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetMediaContent operation middleware
+func (siw *ServerInterfaceWrapper) GetMediaContent(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetMediaContentParams
+
+	// ------------- Optional query parameter "variant" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "variant", r.URL.Query(), &params.Variant)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "variant", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetMediaContent(w, r, id, params)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListModerationComments operation middleware
+func (siw *ServerInterfaceWrapper) ListModerationComments(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListModerationCommentsParams
+
+	// ------------- Optional query parameter "state" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "state", r.URL.Query(), &params.State)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "state", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", r.URL.Query(), &params.Offset)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "offset", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListModerationComments(w, r, params)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListPosts operation middleware
 func (siw *ServerInterfaceWrapper) ListPosts(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListPostsParams
+
+	// ------------- Optional query parameter "mine" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "mine", r.URL.Query(), &params.Mine)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "mine", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "tag" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "tag", r.URL.Query(), &params.Tag)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "tag", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "authorId" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "authorId", r.URL.Query(), &params.AuthorId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "authorId", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "categoryId" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "categoryId", r.URL.Query(), &params.CategoryId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "categoryId", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "published" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "published", r.URL.Query(), &params.Published)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "published", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "createdFrom" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "createdFrom", r.URL.Query(), &params.CreatedFrom)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "createdFrom", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "createdTo" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "createdTo", r.URL.Query(), &params.CreatedTo)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "createdTo", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "sort" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sort", r.URL.Query(), &params.Sort)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sort", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "order" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "order", r.URL.Query(), &params.Order)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "order", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", r.URL.Query(), &params.Offset)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "offset", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListPosts(w, r, params)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreatePost operation middleware
+func (siw *ServerInterfaceWrapper) CreatePost(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreatePost(w, r)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// LookupPostBySlug operation middleware
+func (siw *ServerInterfaceWrapper) LookupPostBySlug(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "slug" -------------
+	var slug string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "slug", chi.URLParam(r, "slug"), &slug, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "slug", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.LookupPostBySlug(w, r, slug)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// SearchPosts operation middleware
+func (siw *ServerInterfaceWrapper) SearchPosts(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params SearchPostsParams
+
+	// ------------- Required query parameter "q" -------------
+
+	if paramValue := r.URL.Query().Get("q"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "q"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "q", r.URL.Query(), &params.Q)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "q", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SearchPosts(w, r, params)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeletePost operation middleware
+func (siw *ServerInterfaceWrapper) DeletePost(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeletePost(w, r, id)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// LookupPost operation middleware
+func (siw *ServerInterfaceWrapper) LookupPost(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.LookupPost(w, r, id)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// UpdatePost operation middleware
+func (siw *ServerInterfaceWrapper) UpdatePost(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params UpdatePostParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch IfMatch
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-Match", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-Match", Err: err})
+			return
+		}
+
+		params.IfMatch = &IfMatch
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UpdatePost(w, r, id, params)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeletePostReaction operation middleware
+func (siw *ServerInterfaceWrapper) DeletePostReaction(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeletePostReaction(w, r, id)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// SetPostReaction operation middleware
+func (siw *ServerInterfaceWrapper) SetPostReaction(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SetPostReaction(w, r, id)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListPostRevisions operation middleware
+func (siw *ServerInterfaceWrapper) ListPostRevisions(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListPostRevisionsParams
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListPostRevisions(w, r, id, params)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// LookupPostRevision operation middleware
+func (siw *ServerInterfaceWrapper) LookupPostRevision(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "revision" -------------
+	var revision int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "revision", chi.URLParam(r, "revision"), &revision, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "revision", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.LookupPostRevision(w, r, id, revision)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DiffPostRevisions operation middleware
+func (siw *ServerInterfaceWrapper) DiffPostRevisions(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "revision" -------------
+	var revision int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "revision", chi.URLParam(r, "revision"), &revision, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "revision", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "other" -------------
+	var other int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "other", chi.URLParam(r, "other"), &other, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "other", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DiffPostRevisions(w, r, id, revision, other)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// RestorePostRevision operation middleware
+func (siw *ServerInterfaceWrapper) RestorePostRevision(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "revision" -------------
+	var revision int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "revision", chi.URLParam(r, "revision"), &revision, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "revision", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params RestorePostRevisionParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch IfMatch
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-Match", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-Match", Err: err})
+			return
+		}
+
+		params.IfMatch = &IfMatch
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RestorePostRevision(w, r, id, revision, params)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListComments operation middleware
+func (siw *ServerInterfaceWrapper) ListComments(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "postId" -------------
+	var postId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "postId", chi.URLParam(r, "postId"), &postId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "postId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListCommentsParams
+
+	// ------------- Optional query parameter "thread" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "thread", r.URL.Query(), &params.Thread)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "thread", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", r.URL.Query(), &params.Offset)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "offset", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListComments(w, r, postId, params)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreateComment operation middleware
+func (siw *ServerInterfaceWrapper) CreateComment(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "postId" -------------
+	var postId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "postId", chi.URLParam(r, "postId"), &postId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "postId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateComment(w, r, postId)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteComment operation middleware
+func (siw *ServerInterfaceWrapper) DeleteComment(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "postId" -------------
+	var postId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "postId", chi.URLParam(r, "postId"), &postId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "postId", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteComment(w, r, postId, id)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// LookupComment operation middleware
+func (siw *ServerInterfaceWrapper) LookupComment(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "postId" -------------
+	var postId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "postId", chi.URLParam(r, "postId"), &postId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "postId", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.LookupComment(w, r, postId, id)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// UpdateComment operation middleware
+func (siw *ServerInterfaceWrapper) UpdateComment(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "postId" -------------
+	var postId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "postId", chi.URLParam(r, "postId"), &postId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "postId", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UpdateComment(w, r, postId, id)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ApproveComment operation middleware
+func (siw *ServerInterfaceWrapper) ApproveComment(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "postId" -------------
+	var postId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "postId", chi.URLParam(r, "postId"), &postId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "postId", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ApproveComment(w, r, postId, id)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteCommentReaction operation middleware
+func (siw *ServerInterfaceWrapper) DeleteCommentReaction(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "postId" -------------
+	var postId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "postId", chi.URLParam(r, "postId"), &postId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "postId", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteCommentReaction(w, r, postId, id)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// SetCommentReaction operation middleware
+func (siw *ServerInterfaceWrapper) SetCommentReaction(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "postId" -------------
+	var postId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "postId", chi.URLParam(r, "postId"), &postId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "postId", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SetCommentReaction(w, r, postId, id)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// RejectComment operation middleware
+func (siw *ServerInterfaceWrapper) RejectComment(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "postId" -------------
+	var postId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "postId", chi.URLParam(r, "postId"), &postId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "postId", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RejectComment(w, r, postId, id)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListReactions operation middleware
+func (siw *ServerInterfaceWrapper) ListReactions(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListReactions(w, r)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListReadingLists operation middleware
+func (siw *ServerInterfaceWrapper) ListReadingLists(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListReadingListsParams
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", r.URL.Query(), &params.Offset)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "offset", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListReadingLists(w, r, params)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreateReadingList operation middleware
+func (siw *ServerInterfaceWrapper) CreateReadingList(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateReadingList(w, r)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteReadingList operation middleware
+func (siw *ServerInterfaceWrapper) DeleteReadingList(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteReadingList(w, r, id)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// LookupReadingList operation middleware
+func (siw *ServerInterfaceWrapper) LookupReadingList(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.LookupReadingList(w, r, id)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// UpdateReadingList operation middleware
+func (siw *ServerInterfaceWrapper) UpdateReadingList(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UpdateReadingList(w, r, id)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListSeries operation middleware
+func (siw *ServerInterfaceWrapper) ListSeries(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
 	// Parameter object where we will unmarshal all parameters from the context
-	var params ListPostsParams
+	var params ListSeriesParams
+
+	// ------------- Optional query parameter "authorId" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "authorId", r.URL.Query(), &params.AuthorId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "authorId", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", r.URL.Query(), &params.Offset)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "offset", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListSeries(w, r, params)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreateSeries operation middleware
+func (siw *ServerInterfaceWrapper) CreateSeries(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateSeries(w, r)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteSeries operation middleware
+func (siw *ServerInterfaceWrapper) DeleteSeries(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteSeries(w, r, id)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// LookupSeries operation middleware
+func (siw *ServerInterfaceWrapper) LookupSeries(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.LookupSeries(w, r, id)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// UpdateSeries operation middleware
+func (siw *ServerInterfaceWrapper) UpdateSeries(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UpdateSeries(w, r, id)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ReorderSeries operation middleware
+func (siw *ServerInterfaceWrapper) ReorderSeries(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ReorderSeries(w, r, id)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListSeriesPosts operation middleware
+func (siw *ServerInterfaceWrapper) ListSeriesPosts(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListSeriesPosts(w, r, id)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// RemoveSeriesPost operation middleware
+func (siw *ServerInterfaceWrapper) RemoveSeriesPost(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "postId" -------------
+	var postId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "postId", chi.URLParam(r, "postId"), &postId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "postId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RemoveSeriesPost(w, r, id, postId)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// AddSeriesPost operation middleware
+func (siw *ServerInterfaceWrapper) AddSeriesPost(w http.ResponseWriter, r *http.Request) {
 
-	// ------------- Optional query parameter "offset" -------------
+	var err error
 
-	err = runtime.BindQueryParameter("form", true, false, "offset", r.URL.Query(), &params.Offset)
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "offset", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
 		return
 	}
 
-	// ------------- Optional query parameter "limit" -------------
+	// ------------- Path parameter "postId" -------------
+	var postId openapi_types.UUID
 
-	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	err = runtime.BindStyledParameterWithOptions("simple", "postId", chi.URLParam(r, "postId"), &postId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "postId", Err: err})
 		return
 	}
 
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.ListPosts(w, r, params)
+		siw.Handler.AddSeriesPost(w, r, id, postId)
 	}))
 
 	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
@@ -276,11 +3631,11 @@ func (siw *ServerInterfaceWrapper) ListPosts(w http.ResponseWriter, r *http.Requ
 	handler.ServeHTTP(w, r)
 }
 
-// CreatePost operation middleware
-func (siw *ServerInterfaceWrapper) CreatePost(w http.ResponseWriter, r *http.Request) {
+// GetSitemap operation middleware
+func (siw *ServerInterfaceWrapper) GetSitemap(w http.ResponseWriter, r *http.Request) {
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.CreatePost(w, r)
+		siw.Handler.GetSitemap(w, r)
 	}))
 
 	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
@@ -290,22 +3645,22 @@ func (siw *ServerInterfaceWrapper) CreatePost(w http.ResponseWriter, r *http.Req
 	handler.ServeHTTP(w, r)
 }
 
-// DeletePost operation middleware
-func (siw *ServerInterfaceWrapper) DeletePost(w http.ResponseWriter, r *http.Request) {
+// GetSitemapPage operation middleware
+func (siw *ServerInterfaceWrapper) GetSitemapPage(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
-	// ------------- Path parameter "id" -------------
-	var id openapi_types.UUID
+	// ------------- Path parameter "page" -------------
+	var page int
 
-	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "page", chi.URLParam(r, "page"), &page, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "page", Err: err})
 		return
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.DeletePost(w, r, id)
+		siw.Handler.GetSitemapPage(w, r, page)
 	}))
 
 	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
@@ -315,22 +3670,32 @@ func (siw *ServerInterfaceWrapper) DeletePost(w http.ResponseWriter, r *http.Req
 	handler.ServeHTTP(w, r)
 }
 
-// LookupPost operation middleware
-func (siw *ServerInterfaceWrapper) LookupPost(w http.ResponseWriter, r *http.Request) {
+// ListTags operation middleware
+func (siw *ServerInterfaceWrapper) ListTags(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
-	// ------------- Path parameter "id" -------------
-	var id openapi_types.UUID
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListTagsParams
 
-	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	// ------------- Optional query parameter "prefix" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "prefix", r.URL.Query(), &params.Prefix)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "prefix", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
 		return
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.LookupPost(w, r, id)
+		siw.Handler.ListTags(w, r, params)
 	}))
 
 	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
@@ -340,22 +3705,48 @@ func (siw *ServerInterfaceWrapper) LookupPost(w http.ResponseWriter, r *http.Req
 	handler.ServeHTTP(w, r)
 }
 
-// UpdatePost operation middleware
-func (siw *ServerInterfaceWrapper) UpdatePost(w http.ResponseWriter, r *http.Request) {
+// MergeTags operation middleware
+func (siw *ServerInterfaceWrapper) MergeTags(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.MergeTags(w, r)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// RenameTag operation middleware
+func (siw *ServerInterfaceWrapper) RenameTag(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
-	// ------------- Path parameter "id" -------------
-	var id openapi_types.UUID
+	// ------------- Path parameter "tag" -------------
+	var tag string
 
-	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "tag", chi.URLParam(r, "tag"), &tag, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "tag", Err: err})
 		return
 	}
 
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.UpdatePost(w, r, id)
+		siw.Handler.RenameTag(w, r, tag)
 	}))
 
 	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
@@ -365,23 +3756,28 @@ func (siw *ServerInterfaceWrapper) UpdatePost(w http.ResponseWriter, r *http.Req
 	handler.ServeHTTP(w, r)
 }
 
-// ListComments operation middleware
-func (siw *ServerInterfaceWrapper) ListComments(w http.ResponseWriter, r *http.Request) {
+// ListTrashedComments operation middleware
+func (siw *ServerInterfaceWrapper) ListTrashedComments(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
-	// ------------- Path parameter "postId" -------------
-	var postId openapi_types.UUID
+	ctx := r.Context()
 
-	err = runtime.BindStyledParameterWithOptions("simple", "postId", chi.URLParam(r, "postId"), &postId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListTrashedCommentsParams
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "postId", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
 		return
 	}
 
-	// Parameter object where we will unmarshal all parameters from the context
-	var params ListCommentsParams
-
 	// ------------- Optional query parameter "offset" -------------
 
 	err = runtime.BindQueryParameter("form", true, false, "offset", r.URL.Query(), &params.Offset)
@@ -399,7 +3795,7 @@ func (siw *ServerInterfaceWrapper) ListComments(w http.ResponseWriter, r *http.R
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.ListComments(w, r, postId, params)
+		siw.Handler.ListTrashedComments(w, r, params)
 	}))
 
 	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
@@ -409,56 +3805,46 @@ func (siw *ServerInterfaceWrapper) ListComments(w http.ResponseWriter, r *http.R
 	handler.ServeHTTP(w, r)
 }
 
-// CreateComment operation middleware
-func (siw *ServerInterfaceWrapper) CreateComment(w http.ResponseWriter, r *http.Request) {
+// ListTrashedPosts operation middleware
+func (siw *ServerInterfaceWrapper) ListTrashedPosts(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
-	// ------------- Path parameter "postId" -------------
-	var postId openapi_types.UUID
-
-	err = runtime.BindStyledParameterWithOptions("simple", "postId", chi.URLParam(r, "postId"), &postId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "postId", Err: err})
-		return
-	}
+	ctx := r.Context()
 
-	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.CreateComment(w, r, postId)
-	}))
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
 
-	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
-		handler = siw.HandlerMiddlewares[i](handler)
-	}
+	r = r.WithContext(ctx)
 
-	handler.ServeHTTP(w, r)
-}
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListTrashedPostsParams
 
-// DeleteComment operation middleware
-func (siw *ServerInterfaceWrapper) DeleteComment(w http.ResponseWriter, r *http.Request) {
+	// ------------- Optional query parameter "cursor" -------------
 
-	var err error
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
 
-	// ------------- Path parameter "postId" -------------
-	var postId openapi_types.UUID
+	// ------------- Optional query parameter "offset" -------------
 
-	err = runtime.BindStyledParameterWithOptions("simple", "postId", chi.URLParam(r, "postId"), &postId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	err = runtime.BindQueryParameter("form", true, false, "offset", r.URL.Query(), &params.Offset)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "postId", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "offset", Err: err})
 		return
 	}
 
-	// ------------- Path parameter "id" -------------
-	var id openapi_types.UUID
+	// ------------- Optional query parameter "limit" -------------
 
-	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
 		return
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.DeleteComment(w, r, postId, id)
+		siw.Handler.ListTrashedPosts(w, r, params)
 	}))
 
 	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
@@ -468,20 +3854,11 @@ func (siw *ServerInterfaceWrapper) DeleteComment(w http.ResponseWriter, r *http.
 	handler.ServeHTTP(w, r)
 }
 
-// LookupComment operation middleware
-func (siw *ServerInterfaceWrapper) LookupComment(w http.ResponseWriter, r *http.Request) {
+// RestorePost operation middleware
+func (siw *ServerInterfaceWrapper) RestorePost(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
-	// ------------- Path parameter "postId" -------------
-	var postId openapi_types.UUID
-
-	err = runtime.BindStyledParameterWithOptions("simple", "postId", chi.URLParam(r, "postId"), &postId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "postId", Err: err})
-		return
-	}
-
 	// ------------- Path parameter "id" -------------
 	var id openapi_types.UUID
 
@@ -491,8 +3868,14 @@ func (siw *ServerInterfaceWrapper) LookupComment(w http.ResponseWriter, r *http.
 		return
 	}
 
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.LookupComment(w, r, postId, id)
+		siw.Handler.RestorePost(w, r, id)
 	}))
 
 	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
@@ -502,8 +3885,8 @@ func (siw *ServerInterfaceWrapper) LookupComment(w http.ResponseWriter, r *http.
 	handler.ServeHTTP(w, r)
 }
 
-// UpdateComment operation middleware
-func (siw *ServerInterfaceWrapper) UpdateComment(w http.ResponseWriter, r *http.Request) {
+// RestoreComment operation middleware
+func (siw *ServerInterfaceWrapper) RestoreComment(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
@@ -525,8 +3908,14 @@ func (siw *ServerInterfaceWrapper) UpdateComment(w http.ResponseWriter, r *http.
 		return
 	}
 
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.UpdateComment(w, r, postId, id)
+		siw.Handler.RestoreComment(w, r, postId, id)
 	}))
 
 	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
@@ -649,12 +4038,75 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 		ErrorHandlerFunc:   options.ErrorHandlerFunc,
 	}
 
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/bookmarks", wrapper.ListBookmarks)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/bookmarks/{postId}", wrapper.DeleteBookmark)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/bookmarks/{postId}", wrapper.SetBookmark)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/categories", wrapper.ListCategories)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/categories", wrapper.CreateCategory)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/categories/{id}", wrapper.DeleteCategory)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/categories/{id}", wrapper.LookupCategory)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/categories/{id}", wrapper.UpdateCategory)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/comments/search", wrapper.SearchComments)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/feeds/authors/{authorId}/{file}", wrapper.GetAuthorFeed)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/feeds/tags/{tag}/{file}", wrapper.GetTagFeed)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/feeds/{file}", wrapper.GetFeed)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/media", wrapper.ListMedia)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/media", wrapper.UploadMedia)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/media/{id}", wrapper.DeleteMedia)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/media/{id}", wrapper.LookupMedia)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/media/{id}", wrapper.UpdateMedia)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/media/{id}/content", wrapper.GetMediaContent)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/moderation/comments", wrapper.ListModerationComments)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/posts", wrapper.ListPosts)
 	})
 	r.Group(func(r chi.Router) {
 		r.Post(options.BaseURL+"/posts", wrapper.CreatePost)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/posts/by-slug/{slug}", wrapper.LookupPostBySlug)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/posts/search", wrapper.SearchPosts)
+	})
 	r.Group(func(r chi.Router) {
 		r.Delete(options.BaseURL+"/posts/{id}", wrapper.DeletePost)
 	})
@@ -664,6 +4116,24 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 	r.Group(func(r chi.Router) {
 		r.Put(options.BaseURL+"/posts/{id}", wrapper.UpdatePost)
 	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/posts/{id}/reaction", wrapper.DeletePostReaction)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/posts/{id}/reaction", wrapper.SetPostReaction)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/posts/{id}/revisions", wrapper.ListPostRevisions)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/posts/{id}/revisions/{revision}", wrapper.LookupPostRevision)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/posts/{id}/revisions/{revision}/diff/{other}", wrapper.DiffPostRevisions)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/posts/{id}/revisions/{revision}/restore", wrapper.RestorePostRevision)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/posts/{postId}/comments", wrapper.ListComments)
 	})
@@ -679,6 +4149,90 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 	r.Group(func(r chi.Router) {
 		r.Put(options.BaseURL+"/posts/{postId}/comments/{id}", wrapper.UpdateComment)
 	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/posts/{postId}/comments/{id}/approve", wrapper.ApproveComment)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/posts/{postId}/comments/{id}/reaction", wrapper.DeleteCommentReaction)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/posts/{postId}/comments/{id}/reaction", wrapper.SetCommentReaction)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/posts/{postId}/comments/{id}/reject", wrapper.RejectComment)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/reactions", wrapper.ListReactions)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/reading-lists", wrapper.ListReadingLists)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/reading-lists", wrapper.CreateReadingList)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/reading-lists/{id}", wrapper.DeleteReadingList)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/reading-lists/{id}", wrapper.LookupReadingList)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/reading-lists/{id}", wrapper.UpdateReadingList)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/series", wrapper.ListSeries)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/series", wrapper.CreateSeries)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/series/{id}", wrapper.DeleteSeries)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/series/{id}", wrapper.LookupSeries)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/series/{id}", wrapper.UpdateSeries)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/series/{id}/order", wrapper.ReorderSeries)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/series/{id}/posts", wrapper.ListSeriesPosts)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/series/{id}/posts/{postId}", wrapper.RemoveSeriesPost)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/series/{id}/posts/{postId}", wrapper.AddSeriesPost)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/sitemap.xml", wrapper.GetSitemap)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/sitemaps/{page}", wrapper.GetSitemapPage)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/tags", wrapper.ListTags)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/tags/merge", wrapper.MergeTags)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/tags/{tag}", wrapper.RenameTag)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/trash/comments", wrapper.ListTrashedComments)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/trash/posts", wrapper.ListTrashedPosts)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/trash/posts/{id}/restore", wrapper.RestorePost)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/trash/posts/{postId}/comments/{id}/restore", wrapper.RestoreComment)
+	})
 
 	return r
 }
@@ -686,28 +4240,162 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/+xYTW/jNhD9KwTboxrZ2fRQnbrrbQsDQRFku6cgB0Ya2dxSJENS2RiB/nvBD1OSJX+k",
-	"SZxgkZNliZwZvXl8fNQDzkUlBQduNM4esAItBdfg/nwixSXc1qCN/ZcLboC7SyIlozkxVPD0mxbc3tP5",
-	"Eipir35WUOIM/5S2oVP/VKd/KCUUbpomwQXoXFFpg+DM5kIqJGsSPOcGFCfsC6g7UH7Wi9ewToq0y4rA",
-	"D0zw38L8KWpevHwJl6BFrXJAXBhUupx2UJhnw85EVYX8UgkJylDfLVKbpVBzV2Q/6FdOb2tAtABuaElB",
-	"oVIoZJaA/Byc4FKoihic4bqmBU6wWUnAGdZGUb6wEHReux985h8gUbqIeShvJAR9VGltoD21NQm2xKEK",
-	"CpxdYTckYtEWfh0niptvkDuWBSxnCoiBIaJPfueN0g4o5assXqyUQc64rPq5YH170EFtiKn1jkczUbji",
-	"Y8soNx9O22ooN7AANUCmMztmGUPpQug3y3xpa3sy7UOUvVXJ+oZRvYQQvCQ1MzgrCdMwlLXCqhRoRNtK",
-	"EdWojRET3AjBgHCbwZCFHlb+D1loRLQWOSUGCvSdmmW3cGqgGmdIuEGUIiv3nxoGIwns7d2Y7lnxPnDb",
-	"uC5Y20j1VAnY1vz3NsU2bbZlWyueKoHvrThE/e0tykuxRpbkDlmoCGV2Yi2lUOZ3uCeVZHCSiwonmJPK",
-	"xvh4MUdf/AA8cDD2oRWzinCyoHyBbphYuLI0IrxY71A6LtTMdR1Zp0dzQB8v5jjBd6C0jzc9mZxMbBoh",
-	"gRNJcYY/uFsJlsQsHXKpC2+vFjBCkEswisIdIIIY1Y4ohDFfE3aBlTNxdgfB51Sbi/BEEkUqMKA0zq4e",
-	"MLXBbmtQqxYLUZYaLOKt8YvcmiS4opxWdeWuh3vgeEhGK7ol4qkNSe59yOmkm2A6kuA66Rv608nkURY2",
-	"UnOXl3V78oCtQ2t7HqD3VFChJwXSdZ6D1mXNmGP5r77IsZTxZdKx84EzynVVEbVap+u22a/OK+ybe20V",
-	"IbiJPgH8VnDh11E4knwSxerZzH9nv2n6ImlUDc2gZ9NnzTzWGrcAc1fSsB9nh/Sjc1R8vhZ6kBBBHL5H",
-	"YdvoYpOE1Z8+0KLxi4WBGZHJz+4+IkhLyGlJc6/qNytEjUbzzwMl8BMiEXo9ORvGdyD65GMgnu1HJB4z",
-	"nw/C+NJb4Ev2KuahaJ0L8W8tx9GaHIfB2zXlteD/C0zA3iLnUBuRoZFdxm5t7Y7gHG5fJrrbw74D8rU1",
-	"PyNd9k4LEY7gnmpjN+tAk35r/bgXVsRg+w5SxCPxqXYlPYsivhYB1y0+QD7tz7xo0mjQHmmn1vOc+9vQ",
-	"jVGXNWud4LvROsBorb/8PcJrxZ4cz25t0GCDd7Hnhwmf5+STxS/4vI1jY9ddhKoP4q6fOItf2l5CEftf",
-	"Jo9sEyPRhsQKj966Wex8Bh0Sb4fm/Q8TuWbOPh/Z5cs+K7lG+c26yZ34PsJT7gfP28qt4E2OSfq36i87",
-	"KPYs5vHFNnll+9rycszBHkWyX8fHHsDeH8nN7tZ3O8fF8FTf+Fq6BMREThgq4A6YkA4cPx4nuFYMZ3hp",
-	"jMzS1I1bCm2y386mU7dp/KL9d8r0bootM0P+h/HzKHOIAy+koN7phiXh/bddL6PN0rvmxldtrpv/AgAA",
-	"//8ws9epwB8AAA==",
+	"H4sIAAAAAAAC/+x93XfbtvLgv4Kj3af9MbbT9rfnnjxtmjS97iZtju3cPtzNAySOJDQkwQKgHV0f/+97",
+	"ZgCQoAh+yJFlJ9FLG4v4GAAzg/nCzO1sIfNSFlAYPXtxOyu54jkYUPTX+fIdN4s1/jMFvVCiNEIWsxez",
+	"X674isklM2tg16C0kIX/U4GWlVoA/bFY82IFTGg25xpSJotZMhM4whp4CmqWzAqew+zF7Hz5zM6VzPRi",
+	"DTnHSc2mxG/aKFGsZnd3d8lMgS5loYHg+5mnF/B3BdrgXwtZGCjon7wsM7HgCO3pXxpBvg2G/Z8KlrMX",
+	"s/9x2qz91H7Vp78oJZWdqr3kn3nKlJvsLpm9kWou0hSKh5/5d2kYzzJ5AykzkvHFArRu7TUCdF4YUAXP",
+	"LkFdg7KDPThoflKmaVYGtmGCML+RVZE+PAgXHt8KadiS5rxLZu8VLGSRCmz0hosMDgDJVYj9N1w77E+Z",
+	"FsUCWrQiClYj/Ba0iNBCHQpeDwWz9IiUmgutidySmSOuXwojzOZKyrdcreAQR0rTsrlMN8xIyTKa9y6Z",
+	"fSh4ZdZSif8cYoNeVmYNhXGjMuVPBlu6zsSFpPyUc/UJ/10qWYIywvKnhQJuIH1JAC6lyrmZvZil3MAz",
+	"I3KYJdscLpllQpvztMtxL4Cnolgx/E6oNHez4pEtEcGZKBJ/eEwsmTD4iajCfWacus+SBpaqEmkMjFJq",
+	"M7Zv76U2vq0FeWRYYt8eu//t+yXBNrmJP9Zd5fwvWNAsfpffCm26Oy0M5O1/DEFen9hdPRFXim/w7wI+",
+	"m1eV0lJ1D8H+7m86bMlKvoJm22VBXzKu7ZfRPbDgDq33Q4n40l3xNESRdPhbCFOMY8BdBKJX3MBKqk0E",
+	"z9ciSxUUXWguq/nCdhOgmZbKQMrmG0a3fjLttOp5I6d1DwprAXjb/S6moLKXWyL9S66giB6NXwgza6HZ",
+	"wv+FVArabFHwUipmZPksg2vIWLOJU6i3KtPddmUbK1MvmLW3K2lOuk21zYQfBxBnhHS3bqfI2veOQJOp",
+	"0Q/RR41bSNX6c/a6+cvzDn/4Q5i1JQXyHKb0noJ+khCuNVQH93gX+zaT+Ea4obSU6H7KPHcXd3sj7f0e",
+	"A/9DIf6ugIkUr+SlAGVJZA3M9plCGIG8sLU39kO9wRY8vDPxz3dcfUrlTcF0NddgmK7K0mIiguAa64Td",
+	"CLOWlSFhShQrnTCR8xXohBk+z0AzXqTsn1fv3g7A9k+TZxE+ygthUOqh7kyBkxYbgO3CovwuAwORDT0v",
+	"UhRtECzmGtULN2tO4sMnKE29LDcJm8OCVxpQwlhzzRSUmWVMbu65lBnwIpj8ZWTLr0QOrc1GkTmX11bL",
+	"wQ9Gcb1uY6XfawsgV1bsp4aQhhgwcgWUJqLV/g7a0NVJSN9GhYSdbbNkB0ozgSgMrEA1l8hUBHZDTcHg",
+	"fHMBfBHnM/5LwyayDFRLKDRr2LBUpLRtCtvvyENq/MAbzP3hEIAZ2Xt9NXs1SfTcjf6xx5SRlduf0QvD",
+	"b+QrWSHQ1NXieJe3CwWLegsSJotsw5BH3KyhICEMd8OsFfBUM460ZhRMv7ocZkREH5xyQxBGMLnK50CC",
+	"Ki9LRTSVtgCNIq027m4bguidTEGRQnRJzaOyQ83FG5abBPJ+wOk8MfrZG37VWmF4eAP3ySsFbglbAupu",
+	"fP9+NOHG0HhR41qRjdESmJE735we4oHF7kMTGsCvp6IIORAvAH9wfK+9ZF3y3MK45FVmZi+WPNOwrc3j",
+	"Jd66b7hm2JOJQhvgKa5G2UmQV5rIfXbXD94lcLVYX4AmALr4V4s7E09DL6SCGIvP4JqjOWn7elqL1doa",
+	"cOZgDLTEoVRW8yw4g4LYA01TiLKECGGQmAF6wUtIGXxegCpNZ84cTUe4WzdSIXdTwG4UL0trbfh/1dnZ",
+	"jwtUOelfwCCDrRuzF/k9GdptaOD8OO0E9kgarYP9AgXCjtanP3wxg4qjJvV949AgIJBZ7iTaWYxKqpJl",
+	"vFhVfAVb8uVJIwoLzX4V5p/VnL3J+LVUkNbfTmbJDIoqxx0JJiozLorZxw7oyey1WC7fiiKyL7LE//rB",
+	"4O+KZ+RB0KBMfVdExzTw2cR8CO0Dk+XMNY0dWm1FbwMF/ufOpHiHVXrg0yuZQkspF4X58YfIbbwFZ9C7",
+	"niUG8RuA9I3IINw1pfXJZ7pquZG5++cSID0hO2ls895BKnh34TwzV25bt2R5vAHsT3Mr7wDLcQyS0RTZ",
+	"ljW7WUu24AWKnhqgxWI7atAV/d5HDdiLpWBgQUqYkjlNWZWZ5Cn+IrKo4H8Pc9EaxGodY5D0u6cP0vOQ",
+	"6ZXiM2RDOsGolCpvCogrwBoU7WG9zHqbp0i/WvwnsqOX4j81kbe2Dxcz35i2wUkU5n//FF3czianZHbN",
+	"leDO79jx6ZCu61tYbe8GFLAVFKC4lQ8nsXFC5X/ZgWJizo1IY7rgn/jz9NONCcH+INs47Q4iqYnJg1Cj",
+	"WrAzu1jZaKH7uPhooKcsERKAfTfpw/OoLTj9hAOQIlk9Djddurtgy/JD+Gw8JwkpfC4Krjaji6Zxe1fs",
+	"yW2MsElBLSx5Jcysq3xecJGxpTCaicJI9uMPZ2Q0w+VXuf3t+Q//OGso0d9yde9ZMrOt4zdbrb6+l5lY",
+	"bLowNi1YSU0QygJuGtuTQ+VSarNtXGGrTM555nuSUxL0CftTmDUrZAHoyW9GQonZa+jWfMiMqsgXEEh7",
+	"GiGoNCjHCBuzl22Kf68hs9bIvAEfN47GxDnhGtSmMWxq6nHCXgVTuK5SWUOlo2Wry/u/SLElsLMbvtE1",
+	"9KHMh+ucJTMHHrG6bOQwLr3poRFRSyjQiNqVUJsFksmAkCgQjD0UTX8PI1kR/iKZYZZYrTEG1XupD2+Y",
+	"dhb2EbeR2//A5TtpcLs3zcZNt/E4ItnBdO6McX1yXaOMDOteYeMHNI4v5DUo4lmxnacPTK9R3+HajYVx",
+	"LlYgaK949Bim2MLpePsM4bVtkfDL2ryph55s+RYPYljN7Qbqvh2EfA5paq0Cwbi1NDI6wbYkchgreDXP",
+	"hF73nheKpGuxWAcHJ7KMzYG5njt4JJoeo3asxm0jli2WEM7a9cTUX3dZD9dOYPOdtyNMCrxWsN3klX6B",
+	"EV6DEjDaD9n3pW2JfbJqFVHeqpwXz1CSQr9ciPoBTScsBSWuvYIpjGZGmLhiafgq5sPmK8241nIhUHB3",
+	"F3yEAEYR3k4cOTeTwRjnrYr7Y3JVhFg1Ua+zQW7duf7VjhS1e2wnFijvLRTZB62D38krFEI3RQUdcUP4",
+	"kyN8CL0S7Xtp2zkRrr7B3GaNMREYEbDXETHpng+idixxfz/X/OGu44NfWgNU+N5+CiRs52JFGjthL+n/",
+	"NSAhR9ZNH5HTkgxkm5MndPHcgzWeMPuZ9ByV84yEOyMZBkErtuAabAdVFaS53KyFAV3yBZDHjS8sD+Fs",
+	"vSnXUCQM8tJsmKFRi5SlVZn52AcFLFUSnRYnT4Mlf/Af4+jgWWZeYYgsML40oFgzXnKfiC/PHofcjsjX",
+	"3ooiEuc60bLp7+LOh3orJ4SlOQZuu/SD+eVmMB/e+lStYAjfBVwLHfWJBnx8H1ZwSIXZVfutvL1a82tn",
+	"0FAe3gn82TkpB2IbwhETpg1X5Lrlhj2vgVgKpc32G5U2WQZW7MmhzHhI2kgF6Rsl86lAOjs2xUzZ3sil",
+	"iLKDnemC5VnoPZjTxBBst9nBQScdnuDgCPFnDC/RsRcxpeC1erXzmgKUnkTAtVcxMtZywqnJLB0+FgWk",
+	"pV/d/3S+eB1Gjq0CLZcDq9i26+K+0LCx828Orr34MTTYFzv24z11tjwcDLLL24qpYSBWlzpgDIid8KEC",
+	"QByDnhL9sb3f+8K1PcV9BNaIrt2Sfm+L0lzhFp+w90izsrLiKmEsbq5FX7Faz2WFG0f9NEOqQDuGkVGL",
+	"FzcMhEHMgIKE3HtJb4XzVY1tHImI9i4VcQvde/el5VNwyo218Wzd5vG72u3QLjDtImh69levo/d4s2p1",
+	"ATa+sot5cQPUq0op1Kbx67CysAUaDdcHSW900T2tDgn9UYiMffhw/ppZlh8gbG0Xm/5A4GimaDHQ3v0N",
+	"ejyUMSPxurKb0aGkDyj5fg0dHkipGvtndPbEv+QqFpBpogTEmbTK4GgK+ZpMIR1uuuV8sEoLXQM8e9/i",
+	"rd2LqU8atx78EhTzluSk/pdmhaTnzit+DXQQ7vKeDQA3VdQZOb/Jsoyf97LKcx57B3oQt9y9HUhb6xx+",
+	"UOA7912panyhRrKVuIbEC1+Z+ATIU2QRhtUuxapSKF/n8i8xLhnX8/YAjSEPcby4h9XnS5/C7vMd6vRA",
+	"uGAX+tww408sVfCM+v7PHANQ9qGXhOf7hFXgAMw+8jnUATSqV380z7jE+miPzPv0lT2RVsQ/uvXcezLR",
+	"2Y3uo7ddn0c7T//QjgyIGn29oz6P/sXsg2KbQISnSqwWwj9UCipuqopqFS+zzNkeWnvuJHehyOwoadD7",
+	"axJRY/XQKoZ04AnmCCOZfVkSSpsJ8z21ky+bnacWZQlFasNvc1GIvMpnL55HDa09UO8rp8CDEU0H7iu+",
+	"inmcRp6i1hqYw5xazzF8FbXw9MgVMd6fuOk/xqHdBynjou8vO1/x1Ttw2ZO2oChiRnxMsObej3KbPCqn",
+	"Ddsx+KgZYlChy0Vxbj8+H1mhcwER0EPr7DN+91z8jWpbtGQAHIRC4EMU6dyCSPd6EPUswq2lBqv5utRc",
+	"4w4Rh12tiXrWfQF+cZOWDDettUZXOE3ModC4RaWE2VwisrrkeMAVKMxhhX/N6S9v75r99udVJ7D6tz+v",
+	"mE+vZeOrXVKwSvtXCHZMMnLQtrh/vHDDNwtYG1PalFqiWErvkObWQAo5Fxmu0qbR+D/wmedlBicL8j3Z",
+	"7Zq9fH/OLm2DrlKNHynYnRd8hcDNM+mN4WjUCHMzWN5H9lGGyfHEAtjL9+dBENeL2fOTs5MznEaWUPBS",
+	"zF7MfqSfklnJzZr289QnUKK/VhB93mCUgOt2viXd0XgREgULKIx1T5+wn8O2PhcHLiSIv/PLc8H/KBdb",
+	"Ow3iGp0XirMzZHX1cLOkldHx39sA/4ERxQpMpYoA3jpfmPNMtwRxgf3+rkBtmrNyuajC1I2jr907oJQc",
+	"4wgWVvSxMOEmaNZIStZbDsyb/kn2OWHnS6bBJEwulxrIjSJWhVR2d2Lw2kkGU00m/cyE2ChyVv1JlIlN",
+	"keJyk5TciLnIhNmQB2cJyi2oDxILcguS2jZ5FggUZzFGddtzGLnoGfEHHJJ/djLK2dmIxPJxK93mD2dn",
+	"e0u610rnFsm9h7/jZjdYqRxxpUxXlAJzWWUZidI/nZ31TVfDfxrkCqUuz8e7tLINUqefxjvVmS/vktl/",
+	"TwEslrgzZOlEtiEz//dHPBntjXB2q+YByVt54N+zhg18xAEbBnZ6a2XpO4sdyG5izAy9Ee3UcS1O5t2N",
+	"1kxNzclh1zSncJdUgk1DCJ+FthmDCslguYSF6bKv1wSNh3zWwcCfuoD6xs5/EsOPexz2Yc7ObXKzZz3H",
+	"1+HjRPl4NzWEXwfzNCKDURXswpU/op/EDGxx4/qTKkCFhMnSGsezTXN9NIZOoVrXiG5uPIsxNCRfcVGw",
+	"xgXWoJHFMzSm2pQ6CZlRK/d+cEO/oQGZHtgJbVt2cesSTAuxiBv8LNPN3rmaU+nuuimLH4KHRtMV+82z",
+	"+cqODDMgunpveO2V6uGYQdrFUZmPHnbW7ZtUTycsmsoQpTvSu1sZMuWSAV+sm7R8KO61sx7GRb5XDaQP",
+	"iHGtTI4RrGuCCRTAwI39ZefcvvlaqTH9QQbb8TFIZ7sFLVkrGbevex3oSZOME/GDsj41xyGWjrkwEp15",
+	"motCs5xvrCoCATTdc7LzvWpiJR6CBW1lqry7u9u+ELos6fneZx9EDmdVfkS29ON4pybB+8H4Uo2PYX7P",
+	"GEK3WdPprRiW5KxMFYx7ws6N3uI8VtiTFMTgsJ6TXVO3FE+WyWJFgQe2538gvRctWJhatDAm59X44xXk",
+	"vch5uyPDE73VOqfczw5HLrKG3Rm5AvJdk61WbCNN9yaS8lNV9p/q2WEZzZDKeNAzrA/pVzDh/s437Pz1",
+	"wLU1KvOLh5H3LxqzM8kcCQu+E19wLMLmXqjZystmaS41yRyC+1QYDdkyiIHoYNS9eIm96p7gvXpgdHdG",
+	"6q/oXn2irNSe8dSL2NmaTzVFjPcqCm+qLHuGqeaYbcgowjPIT4EE4QcLErOsub2YsRUqyKhn2HB7Z40h",
+	"+x8RJV7R5HS12oLyrwQcVQXJk2sbDpKYouyXhbvnYx9xYKqtkrSs0z4HRT0wpQOIKd644FeNUX7QKG1b",
+	"M2/WjNk4/x7kerko3kKxMuvQrBladr9es2lfrssIW3jn32bUp/P4FtQHI9/ktk3BDodCP5CnX/+Tpd4l",
+	"QKpPLZT69NZHxNyd3qIZ626aqyf06Gz7a1pZlFC5vAFd+32cg0uzuvwPz3yVKecgP18++10W4Mr0INVh",
+	"zR6ZiqWA9NmlQPLuENyvYF7ShJgPcjcxjBuZ/9dnm2ZnwD3S6oOb+F9dRB7upLQen6eD1bigPkROXFUx",
+	"WuUvLkhhCJy3XJt6L0cAuUtmP8a0EgIIJZ3cjROUe0IYXK2n4Ayd5dRHuneO894E+XgiLa4HkcAldKvz",
+	"Xnmiw02aKtIGQWn3F2w77jvr+fbUiLAmzCVGJVv2xeUl++HkLGE+Ryr9+tLInD0/sTno6pSp9Om3yz9+",
+	"Z3T4z0+ez5LYSlwW0v5VDHH6OpPr3d3HhlHhhp7eGr7aH4MKw3AOyJ+u+OrInI7M6dDMycfZjDGmbjRW",
+	"8I5FB2mLglc5FODvKEnXYUdx3mDB6GcN3wtD2xMXOyDjOnKtI9c6NNfy0da7c66vnk3kPhX9BP6ATZss",
+	"5vNNYMtImMzSmkFEXajv3MPX4Yi5Y5ja9xSm1iRVH4hRs3j3DVtXuuFm+LbdPxT3HMnST7+73eZBZ5z9",
+	"9v6XXxP2/vdfkW//ev7GPnM/YViQGE8XSaNb3GHRftmPTMa+n09hkXEFad3XkxW7qjOKBznE66ICPCwn",
+	"QKRBcGhbbhhvFE6vWfKYxwGX4hlGv7shx/D1kitzigrrs5QbviPu2ZkO7ca3K4sZFdss9tE8Dc8neBri",
+	"1asP6EQgdO+jkvpum+q+p8a1T95j8QmjNwmBZ574OaC8JIwz/GMHqkZB3QPnmp0SG/Y46BsUH/POW8w4",
+	"uuYHXPP9DNPJNjFnes8JnB2K1p+mD90Sw7b7PLiBHstz7lyHZPLPDCN/nxcRxqjR+m+j1GiHHb9wvhAN",
+	"Hse5PXrbHN3a+3RrT7uRToOjHVe9KHNULQrbt8tBjEdzW/2rW9IJNf1GDvPFhYO6S5rp3Dqkl6gJz/mi",
+	"jgmXSqxEwTMrPAYiogXEpqe3j/10wrRkwhCpzVEvXKwpLCWFpSgEZROK242QTBUOUVuQooYh2sdXTbnT",
+	"ITXSbYN9n2n3MWktCCNNmxQwMQXK7eks2YXK6kJYE7Qm2v3T/9Wm4/FCQd34FHcqU0xMr/BQnmEPJUft",
+	"ZeP2qF4Tk4dpFyvTV2pE2iaKR7ovibvUmdzqqJlp7CUshFQbw2yEdr5VDqht6HFXblDZKOfuvUg9Zrtq",
+	"Ujy8vslBNzWKJVamCJdi322GkQkx4vbFkCeSdrcm89Fu9V3brcIS0QOWqycUGvRUg/LfDvCKkJU2P1pJ",
+	"qpR6CnfjlhmFfO2EfYi++pbNY+26NJRQvj4bxeNJp07Emdh7qcf5VvgkvBPBBIVBfISUktklTBSLrKJn",
+	"faniSwT9wt4QOmzuuGqMmnJRQJyYXGbGbgnucYBdRIPQNqRhd1ftFpDWV7sDM+vZQ6GbyJjYPEHky5c8",
+	"pO9M3n7PX0doD4Rh9zH2Jo/s/kCsMT1MtxkUNosBElYc6ZzLTrji3yJxg/P7BD8uv2TfNtg+b2yu9Mg+",
+	"DKbhmg7THJZSwVRwruQegPm/QImAtQySIGk23yTMCJchdK7kJ8DawBZOSpzUDxyOFCfwVnIxX7QxnnCs",
+	"W+6jfwWXCLpNxmw5dAwqn5kqBhbXiwAg+xdOMWn2o5j1XYlZda2ZARnLktB3FHtNC4+FLLjsSYGDMPY+",
+	"Fls9kI0zqMx2YIcaLSqCJPh7/D1sNPJmQj09I9G4pen9pqZIGIqQuVlDYS2plGzXDLKNu2/Oad166r31",
+	"6N+jZS21n843zzDP/ukt/vduihCvS1iIpVjQ2MjgSaTKqtUJwwgcUPSHtV+0Cx8atub1VV/XmPQJyhKm",
+	"XGUB/zZ3EVQOGNQU2kUhpisK5HXCPfl5c2lrbT0o++ylil0i0g5IFz/umSu0qkdE9gLt2njWKBBQAXiP",
+	"TFuJ1YNdeSsXPJ418sPF27Abq4oUVAetJvCGrzoZyPaFZR/lesp1OxC5tEZtpNrTy9So3o8Nz7n360Hi",
+	"F0lt5iXyDpVaHyGwj3eEPS/7JhkWjs/6phccGn3T990JlQ57+kTKho7Gomneyf4L+/z1VsX1bv6D5oHu",
+	"VasCqlqhmlfnB1aASIGXUAlKSEzkmIHWeN2LpvpfX9RNLQKPBd1gw2PMzXA6jB5pL7mfTHf++mGFrqO4",
+	"9R2JGq3gpV0EjYcNXuKFzdXoLxrL6+oztAhh+ZiFwIbXVtrUwkXtRHdI4cXL6xiuaGmjQRay8O1cyEad",
+	"7RalnRsljInl9bNgO+rZ2rzYkTZNTs+XzrX/8eEsDo8TVDVI7NGQqu/F4nCAK+6n5z+Md3ivoI4yesPR",
+	"R0Ndf/jHbl0vPEodPj3KqCGFAsnapZJGk82qaKGo3mSzdfN7J5u1lWW59xeMS12+8X4Tzj5ROSp2MijJ",
+	"bB/+RVNO6zGvsEswbVh7sciXP3S57YVqHDK+8wm7BGNcA6Z5Hoxrs9WOINglmA527f+S2apVduCLZrsq",
+	"XOTOqQnmmIw2Ql98YUYpqsNSbSHsieFzdfPG/hzad2wp6BP2yzUagLBaf9DOOie0M537kfoDTC5q0B7q",
+	"JeUXuUO/bhtRq6D6gM+xOfCvMbDrKefZ7yGnR9Lj+tjC6a3/Z+i96rM5eKyaHQh741eE/TaIsN8z9lnL",
+	"gT/Wx8a8JDqoChFpwIw/wEAnYPRpKpbL01tp1qDupkVYFvBszjWkDLvWFZfI0WrLBQW+k3XtfLV+lDmY",
+	"G4CCmRvZUH5XlRDL5fb1dxBiwoljBIW/H4mpLxxA5iVX0D7TJ0BTfcFaiJQyS1lAYA9CfiMghBJgHAQi",
+	"ygcmf+c9wcG+Xj7XlyTB668DXt3ae+/fwBVQ/17L5+yKYn2jwrt9abYWuI0bJjQF/z20kZcirpdSOdtj",
+	"xAlyYc91SyL5EnvugU2rgQxDK/k2XClHY+kX6veEC1Nlt4ABuhpdOzyVaz8maT1a2XJnJqH+34rjPmF/",
+	"1gkh15T8uSYv+2NTzMaPjzepe9bWdpj7yk9lZmsDawM8TVz+eHzmSrMSIYKuXe/dCdC16jPDl6Uim2tk",
+	"9oTB54WPYyb6gSJtZUBuGQNP2LvmWaCG4JWhKBhYW4jhpq/gzsR3gE5rxJ3UDARtTl0bCKMAQUG9RVyF",
+	"6fKdVZIy7eOBsWXGjYHCfk2hNOtn9MrRHmZfdLadvMUefHQ7wjBLZjjuMbz9GN7+LbwifKqe/zoqfost",
+	"b90DQXryA9b8m1Aey8IVu0tO2GvwnNYVpg8eZZcyE4tNW25tXnRTVKtn6Uqs1obxG05v09aQpaNvs11J",
+	"LTvYQ1X+sKM/zsMBv7RoXgW7hY9fTutp6vgR9O2vA9Ajc+0cTujxOh5R6NOU1Lh/z8BBej2QgftVFM0M",
+	"7ie9xgx9XNfBgWJpnxpoL2v0luQKaGm0IpdbyDECcbgg1xDy7RCH2MGtoEoMRT/4aKmao0afjTfJMJwo",
+	"vi0ld4IXNbuBLOsLW+zFmLNDcsG9mzqfdghhgAvtCmIHlx+SR45QdDtxwl52VMObtdRNApwwptDRAKQu",
+	"joOsU8FF0V9l7BCixiPVGBsnsmMqtr1XGPsSweTUMfpppvCnTOtRBcQRdLNL/tFkzj8BiURBuD0ZbGQB",
+	"8YxPbqMGyNtN9jQus/oCP0pUIdV0EGI0AU8f4ew1JrW+gfYalupw4RiZunNkahc5dg1O/drEomlRrzUX",
+	"PUjgawx/j7GvRxNNPPZ1mGYn8PO/YGG+UTnoghbXFYPWIrViENVI8AIQm1cmEH/i8pDdrwFxyM55EG3H",
+	"TuXiAu8e24rwly07cdRwvpi220g7JKqpmtQnhrO75kzZgAq24AVbiWtSBNyL3KKxA3h6sZ7iTzbmDwEr",
+	"lmJVKY4aBHJnuWSQy79ET/rEhiEd4EYZj/Bu9qDP8LWnRMJ13DO/5iKj3VLBVvTxazwbUayeZUKb6QeL",
+	"Xcidv+Wsn1C/6cL2fiumZLk8OrK/J0d2gBoTCCvAwe+qqFNr6QFl/yzlp5yrT3qguFPL24a4kbaGq0Ny",
+	"LDX3+HGDY3o4dcHP8Dj+3HCJcW2h2bPHduwe2E8b4ksP9nXulanFk9qjb8eBnWOOUz8NiQnEZn0qpKa2",
+	"Up1J10ba9ZlutvF4gtmmgW3PztSn7RqdcOY7OEj7z7jxmfY4MQeP7OxRyP+b9WhG3zUFC9/2aW7dP0+g",
+	"tBNOZMN4+1Gux4F40Dvu0axik5H88T2KT1R7JhSbfClqUAJ2jhC3vSaoVpd2+B1KB9ihD5r3/qjSfU8q",
+	"nUXJMW3OoeFe1bh9mlW0JyxP2I7SJipaDZW5MhM3xXbh6x5Vq6boh7iB7OCPo2C5hUVwwn753rSqfgxr",
+	"7o3pWpRtbzWmJvVhj7ZUcmWvmrpbXf8ySIu4SznaAGvHdCp32sfQ1EH9a4j/DGSN6DuHs8OR8dOsS+t3",
+	"tKPDhHz9CSgwTe6DoMlOtDparPYAN8zjqDejqHmMktx3lOTkS+zUVvZ5cft4VOZjcQiSdsFKZx9wLIIb",
+	"JjGp+hilKbBDxUjtwn47AK39QRs7idT6b2O3lCNt7MNCYLFiF+KYWJOwKfwVXAjNg2T72PihihRa4H1F",
+	"gSdeuelJPzaNHuNjiSRRVKyjuSaE4bp0hBTq1DDRJFilfUQnDKP2VYkyGAWEXzVuAMpfMgfkyfKmfg4l",
+	"lhs2l2YdDFzngqIXrhG+i5M0uDo9W/+eI3O/GW7WOeRHxtjkId9bx+SG80KDqrPDi8IF7TbCAn2hLCYt",
+	"rHeadwv5qUS+R3/M5yGrsH/d3T2/hiKF9IS99M+zOX2o1feCsjrV94CmOdIHoauXabpFVA8lz7Qzs99N",
+	"pl+epkfxZQ9POdLUozrF/44IMcJAzsuTz3k2UXzhK2cXbcsoic2ihUjo9dxLYcDVDM5tuVVesP8+S87O",
+	"ztwwK6vYWxiYKFL4TG6UOj7efqkz3HTR+lcwl7bRbjKNW+9AXqeOCuqg3KWcR//wyewt1+bZOyRlAekI",
+	"LFQdLSb8O5gKaSxXEAiWKBbQpBATBWa2+l0W4NJbSV/dKgf30UPx7BK77tlwE5xjiITulxYWotjCVzAh",
+	"9aQrr9zCEZcoab4Jf7ZINYA2723e4yPq7At17sWwH9Wu6HZCLqOI08XYSe+cPFrdP1ujnXgKT66r0m2p",
+	"jUGqsKL2kXb4dk51SzSk1k1NL5RYqWApPtMNUhmJ+0t2bZwprl1eIbQ7uLEJZm24ImbfFJa3E++htrwd",
+	"6NvNWn7FV2NqNm3Tk/XQGosxnr4Igex1gD+d5qBW9v11z9MdfGkHTMM1KJ65jMIboudilRGOML5QUuvA",
+	"Tti2DAamQJqtB73f4TeH3w8hNl/xFU1xaHO7n9dWRoyWSMVNpa35mgTzw8jZ72qMGUDiW8NtmePxG8Pw",
+	"HSuc9mi7dYDTMAFQM/uiGhsaSTKV11BFgTeCPXpNmmaXZJSdKE4zFoorvno4mrFTPEmicXtzJJre6DuL",
+	"7TGyUVyvd8jMWufnCtPTtCOUZeGQv5XKyXVqx+idsJcWwTX0D43UZLe7TxaynaYmMj3G1x1zfz7p3J+H",
+	"rAKzRW8hk8BPLS6xg9fNj9tyF8ceQPZygLrnVPKfVLv7SPvfFe3vxzn6zVL9dhHyHpL3mSkOV62iN4PE",
+	"u8C5hq4gx1kI2MEi56OhKT65fVMn2PuVCumdJeSNMpLGcVb3oeIPj1gCO1a24eibrQ85JIAp+N+Xq2UH",
+	"ivjqkrW8ayUo61DbDiQVZHuR2tEV+VmRsub1ld9LS08lL+uRqCYRVTdPSk1XNDBOFJPNiHUhHGIB7OX7",
+	"81kyq1Q2e2EzJj3T9svp9XMqiuPGvo3zv4ziVqFISymsWOuoxUqJXcHrVSP+9/b1bSLdm0wwQwM0rSJD",
+	"XASJUHpHqBtFBvi5efhdpJ30D71D1t0iQ17x1WBf/B7pVgdJ9nb0ocfdc+AGVnKse9MqtpGXlwl7aWRO",
+	"+/Db5R+/syVAGnPZNCO+wRaxpXh3Z7czieMauFqsGRQrUUC4QNsvMuKHMpMcpQmRk1PexZFQfRlNfpdr",
+	"rgRvIw6kgkfGeu0ehXRTBVnjC6YTmkPDuqrCiAxn25BdxiZtD46T6PTu493/HwBYXTdJTikBAA==",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
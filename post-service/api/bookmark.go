@@ -0,0 +1,309 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/chrishrb/blog-microservice/internal/api_utils"
+	"github.com/chrishrb/blog-microservice/internal/auth"
+	"github.com/chrishrb/blog-microservice/post-service/authz"
+	"github.com/chrishrb/blog-microservice/post-service/store"
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+)
+
+// errInvalidReadingListName is returned for reading lists without a name.
+var errInvalidReadingListName = errors.New("name must not be empty")
+
+// errUnknownReadingList is returned for bookmarks filed in a reading list
+// that does not exist or belongs to another user.
+var errUnknownReadingList = errors.New("listId must be a reading list of the caller")
+
+func (s *Server) ListBookmarks(w http.ResponseWriter, r *http.Request, params ListBookmarksParams) {
+	userID, err := auth.GetUserIDFromContext(r.Context())
+	if err != nil {
+		_ = render.Render(w, r, api_utils.ErrInternalError(err))
+		return
+	}
+	page, err := api_utils.GetPage[store.Cursor](params.Cursor, params.Offset, params.Limit)
+	if err != nil {
+		_ = render.Render(w, r, api_utils.ErrInvalidRequest(err))
+		return
+	}
+	if params.ListId != nil {
+		if _, ok := s.ownReadingList(w, r, userID, *params.ListId); !ok {
+			return
+		}
+	}
+
+	bookmarks, err := s.engine.ListBookmarks(r.Context(), store.BookmarkQuery{
+		UserID: userID,
+		ListID: params.ListId,
+		Page:   page,
+	})
+	if err != nil {
+		_ = render.Render(w, r, api_utils.ErrInternalError(err))
+		return
+	}
+	bookmarks, nextCursor := api_utils.NextPage(bookmarks, page, (*store.Bookmark).Cursor)
+
+	res := &BookmarkList{
+		Items:      make([]Bookmark, len(bookmarks)),
+		NextCursor: nextCursor,
+	}
+	posts := make([]*Post, len(bookmarks))
+	for i, b := range bookmarks {
+		res.Items[i] = *toBookmark(b)
+		posts[i] = &res.Items[i].Post
+	}
+	if err := s.enrichPosts(r.Context(), posts...); err != nil {
+		_ = render.Render(w, r, api_utils.ErrInternalError(err))
+		return
+	}
+
+	_ = render.Render(w, r, res)
+}
+
+func (s *Server) SetBookmark(w http.ResponseWriter, r *http.Request, postId uuid.UUID) {
+	userID, err := auth.GetUserIDFromContext(r.Context())
+	if err != nil {
+		_ = render.Render(w, r, api_utils.ErrInternalError(err))
+		return
+	}
+
+	// The request body is optional
+	req := new(BookmarkUpdate)
+	if r.ContentLength != 0 {
+		if err := render.Bind(r, req); err != nil {
+			_ = render.Render(w, r, api_utils.ErrInvalidRequest(err))
+			return
+		}
+	}
+
+	post, err := s.engine.LookupPost(r.Context(), postId)
+	if err != nil {
+		_ = render.Render(w, r, api_utils.ErrInternalError(err))
+		return
+	}
+	if post == nil || authz.CanRead(authz.CallerFromContext(r.Context()), post) != nil {
+		_ = render.Render(w, r, api_utils.ErrNotFound)
+		return
+	}
+
+	if req.ListId != nil {
+		list, err := s.engine.LookupReadingList(r.Context(), *req.ListId)
+		if err != nil {
+			_ = render.Render(w, r, api_utils.ErrInternalError(err))
+			return
+		}
+		if list == nil || list.UserID != userID {
+			_ = render.Render(w, r, api_utils.ErrInvalidRequest(errUnknownReadingList))
+			return
+		}
+	}
+
+	bookmark := &store.Bookmark{
+		UserID: userID,
+		PostID: post.ID,
+		ListID: req.ListId,
+	}
+	err = s.engine.SetBookmark(r.Context(), bookmark)
+	if err != nil {
+		_ = render.Render(w, r, api_utils.ErrInternalError(err))
+		return
+	}
+
+	bookmark.Post = post
+	res := toBookmark(bookmark)
+	if err := s.enrichPosts(r.Context(), &res.Post); err != nil {
+		_ = render.Render(w, r, api_utils.ErrInternalError(err))
+		return
+	}
+	_ = render.Render(w, r, res)
+}
+
+func (s *Server) DeleteBookmark(w http.ResponseWriter, r *http.Request, postId uuid.UUID) {
+	userID, err := auth.GetUserIDFromContext(r.Context())
+	if err != nil {
+		_ = render.Render(w, r, api_utils.ErrInternalError(err))
+		return
+	}
+
+	err = s.engine.DeleteBookmark(r.Context(), userID, postId)
+	if err != nil {
+		_ = render.Render(w, r, api_utils.ErrInternalError(err))
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) ListReadingLists(w http.ResponseWriter, r *http.Request, params ListReadingListsParams) {
+	userID, err := auth.GetUserIDFromContext(r.Context())
+	if err != nil {
+		_ = render.Render(w, r, api_utils.ErrInternalError(err))
+		return
+	}
+	page, err := api_utils.GetPage[store.Cursor](params.Cursor, params.Offset, params.Limit)
+	if err != nil {
+		_ = render.Render(w, r, api_utils.ErrInvalidRequest(err))
+		return
+	}
+
+	lists, err := s.engine.ListReadingLists(r.Context(), userID, page)
+	if err != nil {
+		_ = render.Render(w, r, api_utils.ErrInternalError(err))
+		return
+	}
+	lists, nextCursor := api_utils.NextPage(lists, page, (*store.ReadingList).Cursor)
+
+	res := &ReadingListList{
+		Items:      make([]ReadingList, len(lists)),
+		NextCursor: nextCursor,
+	}
+	for i, l := range lists {
+		res.Items[i] = *toReadingList(l)
+	}
+
+	_ = render.Render(w, r, res)
+}
+
+func (s *Server) CreateReadingList(w http.ResponseWriter, r *http.Request) {
+	userID, err := auth.GetUserIDFromContext(r.Context())
+	if err != nil {
+		_ = render.Render(w, r, api_utils.ErrInternalError(err))
+		return
+	}
+
+	req := new(ReadingListCreate)
+	if err := render.Bind(r, req); err != nil {
+		_ = render.Render(w, r, api_utils.ErrInvalidRequest(err))
+		return
+	}
+	name, err := readingListName(req.Name)
+	if err != nil {
+		_ = render.Render(w, r, api_utils.ErrInvalidRequest(err))
+		return
+	}
+
+	list := &store.ReadingList{
+		ID:     uuid.New(),
+		UserID: userID,
+		Name:   name,
+	}
+	err = s.engine.SetReadingList(r.Context(), list)
+	if err != nil {
+		_ = render.Render(w, r, api_utils.ErrInternalError(err))
+		return
+	}
+
+	render.Status(r, http.StatusCreated)
+	_ = render.Render(w, r, toReadingList(list))
+}
+
+func (s *Server) LookupReadingList(w http.ResponseWriter, r *http.Request, id uuid.UUID) {
+	userID, err := auth.GetUserIDFromContext(r.Context())
+	if err != nil {
+		_ = render.Render(w, r, api_utils.ErrInternalError(err))
+		return
+	}
+
+	list, ok := s.ownReadingList(w, r, userID, id)
+	if !ok {
+		return
+	}
+	_ = render.Render(w, r, toReadingList(list))
+}
+
+func (s *Server) UpdateReadingList(w http.ResponseWriter, r *http.Request, id uuid.UUID) {
+	userID, err := auth.GetUserIDFromContext(r.Context())
+	if err != nil {
+		_ = render.Render(w, r, api_utils.ErrInternalError(err))
+		return
+	}
+
+	req := new(ReadingListUpdate)
+	if err := render.Bind(r, req); err != nil {
+		_ = render.Render(w, r, api_utils.ErrInvalidRequest(err))
+		return
+	}
+	name, err := readingListName(req.Name)
+	if err != nil {
+		_ = render.Render(w, r, api_utils.ErrInvalidRequest(err))
+		return
+	}
+
+	list, ok := s.ownReadingList(w, r, userID, id)
+	if !ok {
+		return
+	}
+	list.Name = name
+	err = s.engine.SetReadingList(r.Context(), list)
+	if err != nil {
+		_ = render.Render(w, r, api_utils.ErrInternalError(err))
+		return
+	}
+
+	_ = render.Render(w, r, toReadingList(list))
+}
+
+func (s *Server) DeleteReadingList(w http.ResponseWriter, r *http.Request, id uuid.UUID) {
+	userID, err := auth.GetUserIDFromContext(r.Context())
+	if err != nil {
+		_ = render.Render(w, r, api_utils.ErrInternalError(err))
+		return
+	}
+
+	if _, ok := s.ownReadingList(w, r, userID, id); !ok {
+		return
+	}
+	err = s.engine.DeleteReadingList(r.Context(), id)
+	if err != nil {
+		_ = render.Render(w, r, api_utils.ErrInternalError(err))
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ownReadingList looks up a reading list of the user. Reading lists of other
+// users are reported as missing to not leak them. It reports whether the
+// request may proceed.
+func (s *Server) ownReadingList(w http.ResponseWriter, r *http.Request, userID, ID uuid.UUID) (*store.ReadingList, bool) {
+	list, err := s.engine.LookupReadingList(r.Context(), ID)
+	if err != nil {
+		_ = render.Render(w, r, api_utils.ErrInternalError(err))
+		return nil, false
+	}
+	if list == nil || list.UserID != userID {
+		_ = render.Render(w, r, api_utils.ErrNotFound)
+		return nil, false
+	}
+	return list, true
+}
+
+// readingListName returns the trimmed name of a reading list.
+func readingListName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", errInvalidReadingListName
+	}
+	return name, nil
+}
+
+func toBookmark(bookmark *store.Bookmark) *Bookmark {
+	return &Bookmark{
+		PostId:    bookmark.PostID,
+		ListId:    bookmark.ListID,
+		CreatedAt: bookmark.CreatedAt,
+		Post:      *toPost(bookmark.Post),
+	}
+}
+
+func toReadingList(list *store.ReadingList) *ReadingList {
+	return &ReadingList{
+		Id:        list.ID,
+		Name:      list.Name,
+		CreatedAt: list.CreatedAt,
+		UpdatedAt: list.UpdatedAt,
+	}
+}
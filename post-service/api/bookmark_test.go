@@ -0,0 +1,293 @@
+package api_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/chrishrb/blog-microservice/post-service/api"
+	"github.com/chrishrb/blog-microservice/post-service/store"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// bookmark bookmarks the post for the user, filed in the list if it is not
+// nil.
+func bookmark(t *testing.T, r http.Handler, userID, postID uuid.UUID, listID *uuid.UUID) *httptest.ResponseRecorder {
+	t.Helper()
+	var body bytes.Buffer
+	if listID != nil {
+		require.NoError(t, json.NewEncoder(&body).Encode(api.BookmarkUpdate{ListId: listID}))
+	}
+
+	req := httptest.NewRequest(http.MethodPut, fmt.Sprintf("/bookmarks/%s", postID), &body)
+	req.Header.Set("content-type", "application/json")
+	req = userIDContext(req, userID)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	return rr
+}
+
+// listBookmarks lists the bookmarks of the user with the query parameters.
+func listBookmarks(t *testing.T, r http.Handler, userID uuid.UUID, params url.Values) (int, api.BookmarkList) {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, "/bookmarks?"+params.Encode(), nil)
+	req = userIDContext(req, userID)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	var res api.BookmarkList
+	if rr.Result().StatusCode == http.StatusOK {
+		require.NoError(t, json.NewDecoder(rr.Body).Decode(&res))
+	}
+	return rr.Result().StatusCode, res
+}
+
+// createReadingList creates a reading list of the user.
+func createReadingList(t *testing.T, r http.Handler, userID uuid.UUID, name string) api.ReadingList {
+	t.Helper()
+	jsonData, err := json.Marshal(api.ReadingListCreate{Name: name})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, "/reading-lists", bytes.NewBuffer(jsonData))
+	req.Header.Set("content-type", "application/json")
+	req = userIDContext(req, userID)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusCreated, rr.Result().StatusCode)
+	var res api.ReadingList
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&res))
+	return res
+}
+
+func TestSetBookmark(t *testing.T) {
+	server, r, engine, _ := setupServer(t)
+	defer server.Close()
+
+	userID := uuid.New()
+	post := &store.Post{ID: uuid.New(), AuthorID: uuid.New(), Title: "Test Post", Published: true}
+	require.NoError(t, engine.SetPost(t.Context(), post))
+	list := createReadingList(t, r, userID, "Later")
+
+	rr := bookmark(t, r, userID, post.ID, nil)
+	assert.Equal(t, http.StatusOK, rr.Result().StatusCode)
+	var res api.Bookmark
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&res))
+	assert.Equal(t, post.ID, res.PostId)
+	assert.Nil(t, res.ListId)
+	assert.Equal(t, post.Title, res.Post.Title)
+
+	// Bookmarking again moves the bookmark to the list
+	rr = bookmark(t, r, userID, post.ID, &list.Id)
+	assert.Equal(t, http.StatusOK, rr.Result().StatusCode)
+	var moved api.Bookmark
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&moved))
+	assert.Equal(t, &list.Id, moved.ListId)
+	assert.Equal(t, res.CreatedAt, moved.CreatedAt)
+
+	status, bookmarks := listBookmarks(t, r, userID, url.Values{"listId": {list.Id.String()}})
+	assert.Equal(t, http.StatusOK, status)
+	require.Len(t, bookmarks.Items, 1)
+	assert.Equal(t, post.ID, bookmarks.Items[0].PostId)
+	assert.Equal(t, api.ReactionCounts{}, bookmarks.Items[0].Post.Reactions)
+
+	// Bookmarks are scoped to the user
+	status, bookmarks = listBookmarks(t, r, uuid.New(), nil)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Empty(t, bookmarks.Items)
+}
+
+func TestSetBookmark_Invalid(t *testing.T) {
+	server, r, engine, _ := setupServer(t)
+	defer server.Close()
+
+	userID := uuid.New()
+	post := &store.Post{ID: uuid.New(), AuthorID: uuid.New(), Title: "Test Post", Published: true}
+	draft := &store.Post{ID: uuid.New(), AuthorID: uuid.New(), Title: "Test Draft"}
+	require.NoError(t, engine.SetPost(t.Context(), post))
+	require.NoError(t, engine.SetPost(t.Context(), draft))
+	otherList := createReadingList(t, r, uuid.New(), "Other")
+	missingList := uuid.New()
+
+	tests := []struct {
+		name   string
+		postID uuid.UUID
+		listID *uuid.UUID
+		want   int
+	}{
+		{"missing post", uuid.New(), nil, http.StatusNotFound},
+		{"draft of other author", draft.ID, nil, http.StatusNotFound},
+		{"missing list", post.ID, &missingList, http.StatusBadRequest},
+		{"list of other user", post.ID, &otherList.Id, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := bookmark(t, r, userID, tt.postID, tt.listID)
+			assert.Equal(t, tt.want, rr.Result().StatusCode)
+		})
+	}
+}
+
+func TestDeleteBookmark(t *testing.T) {
+	server, r, engine, _ := setupServer(t)
+	defer server.Close()
+
+	userID := uuid.New()
+	post := &store.Post{ID: uuid.New(), AuthorID: uuid.New(), Title: "Test Post", Published: true}
+	require.NoError(t, engine.SetPost(t.Context(), post))
+	require.Equal(t, http.StatusOK, bookmark(t, r, userID, post.ID, nil).Result().StatusCode)
+
+	// Removing a bookmark twice has no effect
+	for range 2 {
+		req := httptest.NewRequest(http.MethodDelete, fmt.Sprintf("/bookmarks/%s", post.ID), nil)
+		req = userIDContext(req, userID)
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusNoContent, rr.Result().StatusCode)
+	}
+
+	status, bookmarks := listBookmarks(t, r, userID, nil)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Empty(t, bookmarks.Items)
+}
+
+func TestListBookmarks(t *testing.T) {
+	server, r, engine, _ := setupServer(t)
+	defer server.Close()
+
+	userID := uuid.New()
+	posts := make([]*store.Post, 5)
+	for i := range posts {
+		posts[i] = &store.Post{ID: uuid.New(), AuthorID: uuid.New(), Title: "Test Post", Published: true}
+		require.NoError(t, engine.SetPost(t.Context(), posts[i]))
+		require.Equal(t, http.StatusOK, bookmark(t, r, userID, posts[i].ID, nil).Result().StatusCode)
+	}
+
+	t.Run("pagination", func(t *testing.T) {
+		var listed []uuid.UUID
+		params := url.Values{"limit": {"2"}}
+		for {
+			status, page := listBookmarks(t, r, userID, params)
+			require.Equal(t, http.StatusOK, status)
+			assert.LessOrEqual(t, len(page.Items), 2)
+			for _, item := range page.Items {
+				listed = append(listed, item.PostId)
+			}
+			if page.NextCursor == nil {
+				break
+			}
+			params.Set("cursor", *page.NextCursor)
+		}
+		assert.ElementsMatch(t, []uuid.UUID{posts[0].ID, posts[1].ID, posts[2].ID, posts[3].ID, posts[4].ID}, listed)
+	})
+
+	t.Run("unpublished and deleted posts", func(t *testing.T) {
+		posts[0].Published = false
+		require.NoError(t, engine.SetPost(t.Context(), posts[0]))
+		require.NoError(t, engine.DeletePost(t.Context(), posts[1].ID))
+
+		status, bookmarks := listBookmarks(t, r, userID, nil)
+		require.Equal(t, http.StatusOK, status)
+		listed := make([]uuid.UUID, len(bookmarks.Items))
+		for i, item := range bookmarks.Items {
+			listed[i] = item.PostId
+		}
+		assert.ElementsMatch(t, []uuid.UUID{posts[2].ID, posts[3].ID, posts[4].ID}, listed)
+	})
+
+	t.Run("list of other user", func(t *testing.T) {
+		list := createReadingList(t, r, uuid.New(), "Other")
+		status, _ := listBookmarks(t, r, userID, url.Values{"listId": {list.Id.String()}})
+		assert.Equal(t, http.StatusNotFound, status)
+	})
+}
+
+func TestReadingLists(t *testing.T) {
+	server, r, engine, _ := setupServer(t)
+	defer server.Close()
+
+	userID := uuid.New()
+	list := createReadingList(t, r, userID, "  Later ")
+	assert.Equal(t, "Later", list.Name)
+
+	t.Run("list", func(t *testing.T) {
+		createReadingList(t, r, uuid.New(), "Other")
+
+		req := httptest.NewRequest(http.MethodGet, "/reading-lists", nil)
+		req = userIDContext(req, userID)
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Result().StatusCode)
+		var res api.ReadingListList
+		require.NoError(t, json.NewDecoder(rr.Body).Decode(&res))
+		require.Len(t, res.Items, 1)
+		assert.Equal(t, list.Id, res.Items[0].Id)
+	})
+
+	t.Run("rename", func(t *testing.T) {
+		jsonData, err := json.Marshal(api.ReadingListUpdate{Name: "Weekend"})
+		require.NoError(t, err)
+		req := httptest.NewRequest(http.MethodPut, fmt.Sprintf("/reading-lists/%s", list.Id), bytes.NewBuffer(jsonData))
+		req.Header.Set("content-type", "application/json")
+		req = userIDContext(req, userID)
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Result().StatusCode)
+		var res api.ReadingList
+		require.NoError(t, json.NewDecoder(rr.Body).Decode(&res))
+		assert.Equal(t, "Weekend", res.Name)
+	})
+
+	t.Run("empty name", func(t *testing.T) {
+		jsonData, err := json.Marshal(api.ReadingListCreate{Name: " "})
+		require.NoError(t, err)
+		req := httptest.NewRequest(http.MethodPost, "/reading-lists", bytes.NewBuffer(jsonData))
+		req.Header.Set("content-type", "application/json")
+		req = userIDContext(req, userID)
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Result().StatusCode)
+	})
+
+	t.Run("other user", func(t *testing.T) {
+		for _, method := range []string{http.MethodGet, http.MethodDelete} {
+			req := httptest.NewRequest(method, fmt.Sprintf("/reading-lists/%s", list.Id), nil)
+			req = userIDContext(req, uuid.New())
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+			assert.Equal(t, http.StatusNotFound, rr.Result().StatusCode, method)
+		}
+	})
+
+	t.Run("delete keeps bookmarks", func(t *testing.T) {
+		post := &store.Post{ID: uuid.New(), AuthorID: uuid.New(), Title: "Test Post", Published: true}
+		require.NoError(t, engine.SetPost(t.Context(), post))
+		require.Equal(t, http.StatusOK, bookmark(t, r, userID, post.ID, &list.Id).Result().StatusCode)
+
+		req := httptest.NewRequest(http.MethodDelete, fmt.Sprintf("/reading-lists/%s", list.Id), nil)
+		req = userIDContext(req, userID)
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusNoContent, rr.Result().StatusCode)
+
+		req = httptest.NewRequest(http.MethodGet, fmt.Sprintf("/reading-lists/%s", list.Id), nil)
+		req = userIDContext(req, userID)
+		rr = httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusNotFound, rr.Result().StatusCode)
+
+		status, bookmarks := listBookmarks(t, r, userID, nil)
+		require.Equal(t, http.StatusOK, status)
+		require.Len(t, bookmarks.Items, 1)
+		assert.Nil(t, bookmarks.Items[0].ListId)
+	})
+}
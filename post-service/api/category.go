@@ -0,0 +1,244 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/chrishrb/blog-microservice/internal/api_utils"
+	"github.com/chrishrb/blog-microservice/post-service/authz"
+	"github.com/chrishrb/blog-microservice/post-service/store"
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+)
+
+// errInvalidCategoryName is returned for categories without a name.
+var errInvalidCategoryName = errors.New("name must not be empty")
+
+// errUnknownCategory is returned if a post or category refers to a category
+// that does not exist.
+var errUnknownCategory = errors.New("category does not exist")
+
+// errCategoryCycle is returned if a category would be nested in itself or
+// one of its subcategories.
+var errCategoryCycle = errors.New("category cannot be nested in itself or one of its subcategories")
+
+func (s *Server) ListCategories(w http.ResponseWriter, r *http.Request) {
+	categories, err := s.engine.ListCategories(r.Context())
+	if err != nil {
+		_ = render.Render(w, r, api_utils.ErrInternalError(err))
+		return
+	}
+
+	children := childCategories(categories)
+	res := &CategoryList{Items: []Category{}}
+	for _, category := range children[uuid.Nil] {
+		res.Items = append(res.Items, *toCategory(category, children))
+	}
+	_ = render.Render(w, r, res)
+}
+
+func (s *Server) CreateCategory(w http.ResponseWriter, r *http.Request) {
+	if err := authz.CanManageTaxonomy(authz.CallerFromContext(r.Context())); err != nil {
+		_ = render.Render(w, r, api_utils.ErrForbidden)
+		return
+	}
+
+	req := new(CategoryUpdate)
+	if err := render.Bind(r, req); err != nil {
+		_ = render.Render(w, r, api_utils.ErrInvalidRequest(err))
+		return
+	}
+	category := &store.Category{ID: uuid.New()}
+	if ok := s.setCategory(w, r, category, req); !ok {
+		return
+	}
+
+	render.Status(r, http.StatusCreated)
+	_ = render.Render(w, r, toCategory(category, nil))
+}
+
+func (s *Server) LookupCategory(w http.ResponseWriter, r *http.Request, id uuid.UUID) {
+	categories, err := s.engine.ListCategories(r.Context())
+	if err != nil {
+		_ = render.Render(w, r, api_utils.ErrInternalError(err))
+		return
+	}
+
+	for _, category := range categories {
+		if category.ID == id {
+			_ = render.Render(w, r, toCategory(category, childCategories(categories)))
+			return
+		}
+	}
+	_ = render.Render(w, r, api_utils.ErrNotFound)
+}
+
+func (s *Server) UpdateCategory(w http.ResponseWriter, r *http.Request, id uuid.UUID) {
+	if err := authz.CanManageTaxonomy(authz.CallerFromContext(r.Context())); err != nil {
+		_ = render.Render(w, r, api_utils.ErrForbidden)
+		return
+	}
+
+	category, err := s.engine.LookupCategory(r.Context(), id)
+	if err != nil {
+		_ = render.Render(w, r, api_utils.ErrInternalError(err))
+		return
+	}
+	if category == nil {
+		_ = render.Render(w, r, api_utils.ErrNotFound)
+		return
+	}
+
+	req := new(CategoryUpdate)
+	if err := render.Bind(r, req); err != nil {
+		_ = render.Render(w, r, api_utils.ErrInvalidRequest(err))
+		return
+	}
+	if ok := s.setCategory(w, r, category, req); !ok {
+		return
+	}
+
+	categories, err := s.engine.ListCategories(r.Context())
+	if err != nil {
+		_ = render.Render(w, r, api_utils.ErrInternalError(err))
+		return
+	}
+	_ = render.Render(w, r, toCategory(category, childCategories(categories)))
+}
+
+func (s *Server) DeleteCategory(w http.ResponseWriter, r *http.Request, id uuid.UUID) {
+	if err := authz.CanManageTaxonomy(authz.CallerFromContext(r.Context())); err != nil {
+		_ = render.Render(w, r, api_utils.ErrForbidden)
+		return
+	}
+
+	category, err := s.engine.LookupCategory(r.Context(), id)
+	if err != nil {
+		_ = render.Render(w, r, api_utils.ErrInternalError(err))
+		return
+	}
+	if category == nil {
+		_ = render.Render(w, r, api_utils.ErrNotFound)
+		return
+	}
+
+	err = s.engine.DeleteCategory(r.Context(), id)
+	if err != nil {
+		_ = render.Render(w, r, api_utils.ErrInternalError(err))
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// setCategory replaces the category by the request and stores it. The parent
+// must exist and must not be the category or one of its subcategories. It
+// renders the error response and returns false if the request may not
+// proceed.
+func (s *Server) setCategory(w http.ResponseWriter, r *http.Request, category *store.Category, req *CategoryUpdate) bool {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		_ = render.Render(w, r, api_utils.ErrInvalidRequest(errInvalidCategoryName))
+		return false
+	}
+
+	if req.ParentId != nil {
+		categories, err := s.engine.ListCategories(r.Context())
+		if err != nil {
+			_ = render.Render(w, r, api_utils.ErrInternalError(err))
+			return false
+		}
+		parents := make(map[uuid.UUID]*uuid.UUID, len(categories))
+		for _, c := range categories {
+			parents[c.ID] = c.ParentID
+		}
+		if _, ok := parents[*req.ParentId]; !ok {
+			_ = render.Render(w, r, api_utils.ErrInvalidRequest(errUnknownCategory))
+			return false
+		}
+		// Walk up from the new parent, reaching the category means it would
+		// be nested in itself
+		for ID := req.ParentId; ID != nil; ID = parents[*ID] {
+			if *ID == category.ID {
+				_ = render.Render(w, r, api_utils.ErrInvalidRequest(errCategoryCycle))
+				return false
+			}
+		}
+	}
+
+	category.Name = name
+	category.ParentID = req.ParentId
+	category.Description = ""
+	if req.Description != nil {
+		category.Description = *req.Description
+	}
+	err := s.engine.SetCategory(r.Context(), category)
+	if err != nil {
+		_ = render.Render(w, r, api_utils.ErrInternalError(err))
+		return false
+	}
+	return true
+}
+
+// lookupCategoryIDs returns the category together with all its
+// subcategories, so that filtering by a category includes the posts filed
+// further down the hierarchy.
+func (s *Server) lookupCategoryIDs(ctx context.Context, ID uuid.UUID) ([]uuid.UUID, error) {
+	categories, err := s.engine.ListCategories(ctx)
+	if err != nil {
+		return nil, err
+	}
+	children := childCategories(categories)
+
+	IDs := []uuid.UUID{ID}
+	for i := 0; i < len(IDs); i++ {
+		for _, child := range children[IDs[i]] {
+			IDs = append(IDs, child.ID)
+		}
+	}
+	return IDs, nil
+}
+
+// checkCategory returns errUnknownCategory if the category does not exist.
+func (s *Server) checkCategory(ctx context.Context, ID uuid.UUID) error {
+	category, err := s.engine.LookupCategory(ctx, ID)
+	if err != nil {
+		return err
+	}
+	if category == nil {
+		return errUnknownCategory
+	}
+	return nil
+}
+
+// childCategories groups the categories by their parent, top-level
+// categories are grouped under the nil UUID. The order of the categories is
+// kept.
+func childCategories(categories []*store.Category) map[uuid.UUID][]*store.Category {
+	children := make(map[uuid.UUID][]*store.Category)
+	for _, category := range categories {
+		parentID := uuid.Nil
+		if category.ParentID != nil {
+			parentID = *category.ParentID
+		}
+		children[parentID] = append(children[parentID], category)
+	}
+	return children
+}
+
+func toCategory(category *store.Category, children map[uuid.UUID][]*store.Category) *Category {
+	res := &Category{
+		Id:          category.ID,
+		ParentId:    category.ParentID,
+		Name:        category.Name,
+		Description: category.Description,
+		Children:    []Category{},
+		CreatedAt:   category.CreatedAt,
+		UpdatedAt:   category.UpdatedAt,
+	}
+	for _, child := range children[category.ID] {
+		res.Children = append(res.Children, *toCategory(child, children))
+	}
+	return res
+}
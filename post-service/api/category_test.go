@@ -0,0 +1,199 @@
+package api_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/chrishrb/blog-microservice/internal/api_utils"
+	"github.com/chrishrb/blog-microservice/internal/auth"
+	"github.com/chrishrb/blog-microservice/internal/testutil"
+	"github.com/chrishrb/blog-microservice/post-service/api"
+	"github.com/chrishrb/blog-microservice/post-service/store"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListCategories(t *testing.T) {
+	server, r, engine, _ := setupServer(t)
+	defer server.Close()
+
+	programming := &store.Category{ID: uuid.New(), Name: "Programming"}
+	golang := &store.Category{ID: uuid.New(), ParentID: &programming.ID, Name: "Go"}
+	cooking := &store.Category{ID: uuid.New(), Name: "Cooking"}
+	for _, category := range []*store.Category{programming, golang, cooking} {
+		require.NoError(t, engine.SetCategory(t.Context(), category))
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/categories", nil)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Result().StatusCode)
+	var res api.CategoryList
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&res))
+	require.Len(t, res.Items, 2)
+	assert.Equal(t, "Cooking", res.Items[0].Name)
+	assert.Empty(t, res.Items[0].Children)
+	assert.Equal(t, "Programming", res.Items[1].Name)
+	require.Len(t, res.Items[1].Children, 1)
+	assert.Equal(t, golang.ID, res.Items[1].Children[0].Id)
+	assert.Equal(t, &programming.ID, res.Items[1].Children[0].ParentId)
+
+	req = httptest.NewRequest(http.MethodGet, "/categories/"+programming.ID.String(), nil)
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Result().StatusCode)
+	var category api.Category
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&category))
+	assert.Equal(t, "Programming", category.Name)
+	require.Len(t, category.Children, 1)
+
+	req = httptest.NewRequest(http.MethodGet, "/categories/"+uuid.NewString(), nil)
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusNotFound, rr.Result().StatusCode)
+}
+
+func TestManageCategories(t *testing.T) {
+	server, r, engine, _ := setupServer(t)
+	defer server.Close()
+
+	send := func(method, url string, body any, permissions ...string) *httptest.ResponseRecorder {
+		t.Helper()
+		var data []byte
+		if body != nil {
+			var err error
+			data, err = json.Marshal(body)
+			require.NoError(t, err)
+		}
+		req := httptest.NewRequest(method, url, bytes.NewBuffer(data))
+		req.Header.Set("content-type", "application/json")
+		req = userIDContext(req, uuid.New(), permissions...)
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		return rr
+	}
+	admin := auth.PermissionAllPostsWrite
+
+	rr := send(http.MethodPost, "/categories", api.CategoryUpdate{Name: "Programming"}, admin)
+	require.Equal(t, http.StatusCreated, rr.Result().StatusCode)
+	var parent api.Category
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&parent))
+
+	rr = send(http.MethodPost, "/categories", api.CategoryUpdate{Name: " Go ", ParentId: &parent.Id}, admin)
+	require.Equal(t, http.StatusCreated, rr.Result().StatusCode)
+	var child api.Category
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&child))
+	assert.Equal(t, "Go", child.Name)
+	assert.Equal(t, &parent.Id, child.ParentId)
+
+	// Moving the child to the top level
+	rr = send(http.MethodPut, "/categories/"+child.Id.String(), api.CategoryUpdate{Name: "Golang"}, admin)
+	assert.Equal(t, http.StatusOK, rr.Result().StatusCode)
+	dbCategory, err := engine.LookupCategory(t.Context(), child.Id)
+	require.NoError(t, err)
+	assert.Equal(t, "Golang", dbCategory.Name)
+	assert.Nil(t, dbCategory.ParentID)
+
+	// Nesting it in the parent again
+	rr = send(http.MethodPut, "/categories/"+child.Id.String(), api.CategoryUpdate{Name: "Golang", ParentId: &parent.Id}, admin)
+	assert.Equal(t, http.StatusOK, rr.Result().StatusCode)
+
+	tests := []struct {
+		name        string
+		method      string
+		url         string
+		body        any
+		permissions []string
+		want        int
+	}{
+		{"create by moderator", http.MethodPost, "/categories", api.CategoryUpdate{Name: "Cooking"}, []string{auth.PermissionModerate}, http.StatusForbidden},
+		{"delete by user", http.MethodDelete, "/categories/" + parent.Id.String(), nil, nil, http.StatusForbidden},
+		{"empty name", http.MethodPost, "/categories", api.CategoryUpdate{Name: " "}, []string{admin}, http.StatusBadRequest},
+		{"unknown parent", http.MethodPost, "/categories", api.CategoryUpdate{Name: "Cooking", ParentId: testutil.Ptr(uuid.New())}, []string{admin}, http.StatusBadRequest},
+		{"nested in itself", http.MethodPut, "/categories/" + parent.Id.String(), api.CategoryUpdate{Name: "Programming", ParentId: &parent.Id}, []string{admin}, http.StatusBadRequest},
+		{"nested in subcategory", http.MethodPut, "/categories/" + parent.Id.String(), api.CategoryUpdate{Name: "Programming", ParentId: &child.Id}, []string{admin}, http.StatusBadRequest},
+		{"update unknown", http.MethodPut, "/categories/" + uuid.NewString(), api.CategoryUpdate{Name: "Cooking"}, []string{admin}, http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := send(tt.method, tt.url, tt.body, tt.permissions...)
+			assert.Equal(t, tt.want, rr.Result().StatusCode)
+		})
+	}
+
+	rr = send(http.MethodDelete, "/categories/"+parent.Id.String(), nil, admin)
+	assert.Equal(t, http.StatusNoContent, rr.Result().StatusCode)
+	dbCategory, err = engine.LookupCategory(t.Context(), child.Id)
+	require.NoError(t, err)
+	assert.Nil(t, dbCategory.ParentID)
+}
+
+func TestPostCategories(t *testing.T) {
+	server, r, engine, _ := setupServer(t)
+	defer server.Close()
+
+	programming := &store.Category{ID: uuid.New(), Name: "Programming"}
+	golang := &store.Category{ID: uuid.New(), ParentID: &programming.ID, Name: "Go"}
+	for _, category := range []*store.Category{programming, golang} {
+		require.NoError(t, engine.SetCategory(t.Context(), category))
+	}
+	userID := uuid.New()
+
+	jsonData, err := json.Marshal(api.PostCreate{Title: "Post", Content: "Content", Published: testutil.Ptr(true), CategoryId: &golang.ID})
+	require.NoError(t, err)
+	req := httptest.NewRequest(http.MethodPost, "/posts", bytes.NewBuffer(jsonData))
+	req.Header.Set("content-type", "application/json")
+	req = userIDContext(req, userID)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusCreated, rr.Result().StatusCode)
+	var post api.Post
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&post))
+	assert.Equal(t, &golang.ID, post.CategoryId)
+
+	// Filtering by a category includes its subcategories
+	req = httptest.NewRequest(http.MethodGet, "/posts?categoryId="+programming.ID.String(), nil)
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Result().StatusCode)
+	var list api.PostList
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&list))
+	require.Len(t, list.Items, 1)
+	assert.Equal(t, post.Id, list.Items[0].Id)
+
+	// Unknown categories are rejected
+	jsonData, err = json.Marshal(api.PostUpdate{CategoryId: testutil.Ptr(uuid.New())})
+	require.NoError(t, err)
+	req = httptest.NewRequest(http.MethodPut, "/posts/"+post.Id.String(), bytes.NewBuffer(jsonData))
+	req.Header.Set("content-type", "application/json")
+	req.Header.Set("If-Match", api_utils.ETag(post.Version))
+	req = userIDContext(req, userID)
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Result().StatusCode)
+
+	// The nil UUID removes the post from its category
+	jsonData, err = json.Marshal(api.PostUpdate{CategoryId: testutil.Ptr(uuid.Nil)})
+	require.NoError(t, err)
+	req = httptest.NewRequest(http.MethodPut, "/posts/"+post.Id.String(), bytes.NewBuffer(jsonData))
+	req.Header.Set("content-type", "application/json")
+	req.Header.Set("If-Match", api_utils.ETag(post.Version))
+	req = userIDContext(req, userID)
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Result().StatusCode)
+	dbPost, err := engine.LookupPost(t.Context(), post.Id)
+	require.NoError(t, err)
+	assert.Nil(t, dbPost.CategoryID)
+}
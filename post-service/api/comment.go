@@ -1,10 +1,12 @@
 package api
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/chrishrb/blog-microservice/internal/api_utils"
 	"github.com/chrishrb/blog-microservice/internal/auth"
+	"github.com/chrishrb/blog-microservice/post-service/authz"
 	"github.com/chrishrb/blog-microservice/post-service/store"
 	"github.com/go-chi/render"
 	"github.com/google/uuid"
@@ -16,6 +18,10 @@ func (s *Server) CreateComment(w http.ResponseWriter, r *http.Request, postId uu
 		_ = render.Render(w, r, api_utils.ErrInternalError(err))
 		return
 	}
+	post, ok := s.readablePost(w, r, postId)
+	if !ok {
+		return
+	}
 
 	req := new(CommentCreate)
 	if err := render.Bind(r, req); err != nil {
@@ -23,6 +29,7 @@ func (s *Server) CreateComment(w http.ResponseWriter, r *http.Request, postId uu
 		return
 	}
 
+	caller := authz.CallerFromContext(r.Context())
 	ID := uuid.New()
 	comment := &store.Comment{
 		ID:       ID,
@@ -30,57 +37,108 @@ func (s *Server) CreateComment(w http.ResponseWriter, r *http.Request, postId uu
 		PostID:   postId,
 		Content:  req.Content,
 	}
+	if req.ParentId != nil {
+		parent, err := s.engine.LookupComment(r.Context(), postId, *req.ParentId)
+		if err != nil {
+			_ = render.Render(w, r, api_utils.ErrInternalError(err))
+			return
+		}
+		if parent == nil || parent.Deleted || authz.CanReadComment(caller, parent) != nil {
+			_ = render.Render(w, r, api_utils.ErrInvalidRequest(errParentNotFound))
+			return
+		}
+		if parent.Depth >= s.maxCommentDepth {
+			_ = render.Render(w, r, api_utils.ErrInvalidRequest(errMaxCommentDepth))
+			return
+		}
+		comment.ParentID = &parent.ID
+		comment.Depth = parent.Depth + 1
+	}
+	comment.State, err = s.moderationState(r.Context(), caller, post)
+	if err != nil {
+		_ = render.Render(w, r, api_utils.ErrInternalError(err))
+		return
+	}
+	if err := renderCommentContent(comment); err != nil {
+		_ = render.Render(w, r, api_utils.ErrInternalError(err))
+		return
+	}
 	err = s.engine.SetComment(r.Context(), comment)
+	// The post may have been deleted in the meantime
+	if errors.Is(err, store.ErrPostNotFound) {
+		_ = render.Render(w, r, api_utils.ErrNotFound)
+		return
+	}
 	if err != nil {
 		_ = render.Render(w, r, api_utils.ErrInternalError(err))
 		return
 	}
+	s.events.CommentCreated(r.Context(), post, comment)
 
 	render.Status(r, http.StatusCreated)
-	_ = render.Render(w, r, &Comment{
-		Id:       ID,
-		AuthorId: comment.AuthorID,
-		Content:  comment.Content,
-	})
+	_ = render.Render(w, r, toComment(comment))
 }
 
 func (s *Server) ListComments(w http.ResponseWriter, r *http.Request, postId uuid.UUID, params ListCommentsParams) {
-	offset, limit := api_utils.GetPaginationWithDefaults(params.Offset, params.Limit)
+	page, err := api_utils.GetPage[store.Cursor](params.Cursor, params.Offset, params.Limit)
+	if err != nil {
+		_ = render.Render(w, r, api_utils.ErrInvalidRequest(err))
+		return
+	}
+	if _, ok := s.readablePost(w, r, postId); !ok {
+		return
+	}
+	reader := authz.CommentListingReader(authz.CallerFromContext(r.Context()))
+	if params.Thread != nil {
+		s.listCommentThreads(w, r, postId, reader, page, *params.Thread)
+		return
+	}
 
-	comment, err := s.engine.ListCommentsByPostID(r.Context(), postId, offset, limit)
+	comments, err := s.engine.ListCommentsByPostID(r.Context(), postId, reader, page)
 	if err != nil {
 		_ = render.Render(w, r, api_utils.ErrInternalError(err))
 		return
 	}
+	comments, nextCursor := api_utils.NextPage(comments, page, (*store.Comment).Cursor)
 
-	comments := make([]render.Renderer, len(comment))
-	for i, c := range comment {
-		comments[i] = &Comment{
-			Id:       c.ID,
-			AuthorId: c.AuthorID,
-			Content:  c.Content,
-		}
+	res := &CommentList{
+		Items:      make([]Comment, len(comments)),
+		NextCursor: nextCursor,
+	}
+	items := make([]*Comment, len(comments))
+	for i, c := range comments {
+		res.Items[i] = *toComment(c)
+		items[i] = &res.Items[i]
+	}
+	if err := s.setCommentReactions(r.Context(), items...); err != nil {
+		_ = render.Render(w, r, api_utils.ErrInternalError(err))
+		return
 	}
 
-	_ = render.RenderList(w, r, comments)
+	_ = render.Render(w, r, res)
 }
 
 func (s *Server) LookupComment(w http.ResponseWriter, r *http.Request, postId, id uuid.UUID) {
+	if _, ok := s.readablePost(w, r, postId); !ok {
+		return
+	}
+
 	comment, err := s.engine.LookupComment(r.Context(), postId, id)
 	if err != nil {
 		_ = render.Render(w, r, api_utils.ErrInternalError(err))
 		return
 	}
-	if comment == nil {
+	if comment == nil || authz.CanReadComment(authz.CallerFromContext(r.Context()), comment) != nil {
 		_ = render.Render(w, r, api_utils.ErrNotFound)
 		return
 	}
 
-	_ = render.Render(w, r, &Comment{
-		Id:       comment.ID,
-		AuthorId: comment.AuthorID,
-		Content:  comment.Content,
-	})
+	res := toComment(comment)
+	if err := s.setCommentReactions(r.Context(), res); err != nil {
+		_ = render.Render(w, r, api_utils.ErrInternalError(err))
+		return
+	}
+	_ = render.Render(w, r, res)
 }
 
 func (s *Server) UpdateComment(w http.ResponseWriter, r *http.Request, postId, id uuid.UUID) {
@@ -90,10 +148,15 @@ func (s *Server) UpdateComment(w http.ResponseWriter, r *http.Request, postId, i
 		_ = render.Render(w, r, api_utils.ErrInternalError(err))
 		return
 	}
-	if comment == nil {
+	if comment == nil || comment.Deleted {
 		_ = render.Render(w, r, api_utils.ErrNotFound)
 		return
 	}
+	caller := authz.CallerFromContext(r.Context())
+	if err := authz.CanModify(caller, comment.AuthorID); err != nil {
+		_ = render.Render(w, r, api_utils.ErrForbidden)
+		return
+	}
 
 	// Afterwards update the comment
 	req := new(CommentUpdate)
@@ -102,28 +165,173 @@ func (s *Server) UpdateComment(w http.ResponseWriter, r *http.Request, postId, i
 		return
 	}
 
+	// Approved comments are moderated again when their content changes, so
+	// that approval cannot be used to sneak in other content. Comments that
+	// are pending or were turned down keep their state.
+	approved := comment.State == store.ModerationStateApproved || comment.State == ""
+	if req.Content != nil && *req.Content != comment.Content && approved {
+		post, err := s.engine.LookupPost(r.Context(), postId)
+		if err != nil {
+			_ = render.Render(w, r, api_utils.ErrInternalError(err))
+			return
+		}
+		comment.State, err = s.moderationState(r.Context(), caller, post)
+		if err != nil {
+			_ = render.Render(w, r, api_utils.ErrInternalError(err))
+			return
+		}
+	}
 	if req.Content != nil {
 		comment.Content = *req.Content
 	}
+	if err := renderCommentContent(comment); err != nil {
+		_ = render.Render(w, r, api_utils.ErrInternalError(err))
+		return
+	}
 
 	err = s.engine.SetComment(r.Context(), comment)
+	// The post may have been deleted in the meantime
+	if errors.Is(err, store.ErrPostNotFound) {
+		_ = render.Render(w, r, api_utils.ErrNotFound)
+		return
+	}
 	if err != nil {
 		_ = render.Render(w, r, api_utils.ErrInternalError(err))
 		return
 	}
 
-	_ = render.Render(w, r, &Comment{
-		Id:       comment.ID,
-		AuthorId: comment.AuthorID,
-		Content:  comment.Content,
-	})
+	res := toComment(comment)
+	if err := s.setCommentReactions(r.Context(), res); err != nil {
+		_ = render.Render(w, r, api_utils.ErrInternalError(err))
+		return
+	}
+	_ = render.Render(w, r, res)
 }
 
 func (s *Server) DeleteComment(w http.ResponseWriter, r *http.Request, postId, id uuid.UUID) {
-	err := s.engine.DeleteComment(r.Context(), postId, id)
+	// Check if the comment exists
+	comment, err := s.engine.LookupComment(r.Context(), postId, id)
+	if err != nil {
+		_ = render.Render(w, r, api_utils.ErrInternalError(err))
+		return
+	}
+	if comment == nil || comment.Deleted {
+		_ = render.Render(w, r, api_utils.ErrNotFound)
+		return
+	}
+	if err := authz.CanModify(authz.CallerFromContext(r.Context()), comment.AuthorID); err != nil {
+		_ = render.Render(w, r, api_utils.ErrForbidden)
+		return
+	}
+
+	// Afterwards move the comment to the trash
+	err = s.engine.TrashComment(r.Context(), postId, id)
 	if err != nil {
 		_ = render.Render(w, r, api_utils.ErrInternalError(err))
 		return
 	}
+	s.events.CommentDeleted(r.Context(), comment)
 	w.WriteHeader(http.StatusNoContent)
 }
+
+// listCommentThreads renders a page of top-level comments together with
+// their replies, either nested or flattened in depth-first order.
+func (s *Server) listCommentThreads(w http.ResponseWriter, r *http.Request, postID uuid.UUID, reader *uuid.UUID, page store.Page, thread ListCommentsParamsThread) {
+	comments, err := s.engine.ListCommentThreads(r.Context(), postID, reader, page)
+	if err != nil {
+		_ = render.Render(w, r, api_utils.ErrInternalError(err))
+		return
+	}
+
+	// Comments are ordered by creation time, so replies are as well
+	var roots []*store.Comment
+	replies := make(map[uuid.UUID][]*store.Comment)
+	for _, c := range comments {
+		if c.ParentID == nil {
+			roots = append(roots, c)
+		} else {
+			replies[*c.ParentID] = append(replies[*c.ParentID], c)
+		}
+	}
+	roots, nextCursor := api_utils.NextPage(roots, page, (*store.Comment).Cursor)
+
+	res := &CommentList{
+		Items:      []Comment{},
+		NextCursor: nextCursor,
+	}
+	var flatten func(c *store.Comment)
+	flatten = func(c *store.Comment) {
+		res.Items = append(res.Items, *toComment(c))
+		for _, reply := range replies[c.ID] {
+			flatten(reply)
+		}
+	}
+	var nest func(c *store.Comment) Comment
+	nest = func(c *store.Comment) Comment {
+		comment := toComment(c)
+		nested := make([]Comment, len(replies[c.ID]))
+		for i, reply := range replies[c.ID] {
+			nested[i] = nest(reply)
+		}
+		comment.Replies = &nested
+		return *comment
+	}
+	for _, root := range roots {
+		if thread == Tree {
+			res.Items = append(res.Items, nest(root))
+		} else {
+			flatten(root)
+		}
+	}
+	items := make([]*Comment, len(res.Items))
+	for i := range res.Items {
+		items[i] = &res.Items[i]
+	}
+	if err := s.setCommentReactions(r.Context(), items...); err != nil {
+		_ = render.Render(w, r, api_utils.ErrInternalError(err))
+		return
+	}
+
+	_ = render.Render(w, r, res)
+}
+
+// errParentNotFound is returned if a reply references a comment that does
+// not exist on the same post.
+var errParentNotFound = errors.New("parentId must reference a comment of the same post")
+
+// errMaxCommentDepth is returned if a reply would be nested too deeply.
+var errMaxCommentDepth = errors.New("replies are nested too deeply")
+
+func toComment(comment *store.Comment) *Comment {
+	return &Comment{
+		Id:          comment.ID,
+		AuthorId:    comment.AuthorID,
+		PostId:      comment.PostID,
+		Content:     comment.Content,
+		ContentHtml: commentContentHTML(comment),
+		ParentId:    comment.ParentID,
+		Depth:       comment.Depth,
+		State:       ModerationState(comment.State),
+		Deleted:     comment.Deleted,
+		ReplyCount:  comment.ReplyCount,
+		Reactions:   ReactionCounts{},
+		DeletedAt:   comment.DeletedAt,
+	}
+}
+
+// readablePost looks up the post of a comment or reaction request. It
+// renders a 404 response if the post does not exist or is a draft that the
+// caller may not see, so that its comments and reactions are hidden as well.
+// It reports whether the request may proceed.
+func (s *Server) readablePost(w http.ResponseWriter, r *http.Request, postID uuid.UUID) (*store.Post, bool) {
+	post, err := s.engine.LookupPost(r.Context(), postID)
+	if err != nil {
+		_ = render.Render(w, r, api_utils.ErrInternalError(err))
+		return nil, false
+	}
+	if post == nil || authz.CanRead(authz.CallerFromContext(r.Context()), post) != nil {
+		_ = render.Render(w, r, api_utils.ErrNotFound)
+		return nil, false
+	}
+	return post, true
+}
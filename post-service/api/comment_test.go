@@ -71,6 +71,70 @@ func TestCreateComment(t *testing.T) {
 	assert.Equal(t, "Test comment content", dbComment.Content)
 }
 
+func TestCreateComment_PostNotFound(t *testing.T) {
+	server, r, engine, _ := setupServer(t)
+	defer server.Close()
+
+	postID := uuid.New()
+	jsonData, err := json.Marshal(api.CommentCreate{Content: "Test comment content"})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(
+		http.MethodPost,
+		fmt.Sprintf("/posts/%s/comments", postID),
+		bytes.NewBuffer(jsonData),
+	)
+	req.Header.Set("content-type", "application/json")
+	req = userIDContext(req, uuid.New())
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusNotFound, rr.Result().StatusCode)
+
+	// Nothing is stored for the missing post
+	comments, err := engine.ListCommentsByState(t.Context(), store.ModerationStateApproved, store.Page{Limit: 10})
+	require.NoError(t, err)
+	assert.Empty(t, comments)
+}
+
+func TestListComments_PostNotFound(t *testing.T) {
+	server, r, _, _ := setupServer(t)
+	defer server.Close()
+
+	req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/posts/%s/comments", uuid.New()), nil)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusNotFound, rr.Result().StatusCode)
+}
+
+func TestDeletePost_Comments(t *testing.T) {
+	server, r, engine, _ := setupServer(t)
+	defer server.Close()
+
+	userID := uuid.New()
+	post := &store.Post{ID: uuid.New(), AuthorID: userID, Title: "Test Post", Published: true}
+	require.NoError(t, engine.SetPost(t.Context(), post))
+	comment := &store.Comment{ID: uuid.New(), AuthorID: uuid.New(), PostID: post.ID, Content: "Test comment content"}
+	require.NoError(t, engine.SetComment(t.Context(), comment))
+
+	req := httptest.NewRequest(http.MethodDelete, fmt.Sprintf("/posts/%s", post.ID), nil)
+	req = userIDContext(req, userID)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusNoContent, rr.Result().StatusCode)
+
+	// The comments of the deleted post are gone as well
+	req = httptest.NewRequest(http.MethodGet, fmt.Sprintf("/posts/%s/comments/%s", post.ID, comment.ID), nil)
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusNotFound, rr.Result().StatusCode)
+
+	comments, err := engine.ListCommentsByState(t.Context(), store.ModerationStateApproved, store.Page{Limit: 10})
+	require.NoError(t, err)
+	assert.Empty(t, comments)
+}
+
 func TestDeleteComment(t *testing.T) {
 	server, r, engine, _ := setupServer(t)
 	defer server.Close()
@@ -119,6 +183,63 @@ func TestDeleteComment(t *testing.T) {
 	assert.Nil(t, dbComment)
 }
 
+func TestDeleteComment_Authorization(t *testing.T) {
+	authorID := uuid.New()
+	for _, tt := range authorizationTests(authorID, http.StatusNoContent) {
+		t.Run(tt.name, func(t *testing.T) {
+			server, r, engine, _ := setupServer(t)
+			defer server.Close()
+
+			post := &store.Post{
+				ID:       uuid.New(),
+				AuthorID: uuid.New(),
+				Title:    "Test Post",
+				Content:  "Test Content",
+			}
+			require.NoError(t, engine.SetPost(t.Context(), post))
+			comment := &store.Comment{
+				ID:       uuid.New(),
+				AuthorID: authorID,
+				PostID:   post.ID,
+				Content:  "Test comment content",
+			}
+			require.NoError(t, engine.SetComment(t.Context(), comment))
+
+			req := httptest.NewRequest(
+				http.MethodDelete,
+				fmt.Sprintf("/posts/%s/comments/%s", post.ID, comment.ID),
+				nil,
+			)
+			req = userIDContext(req, tt.userID, tt.permissions...)
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.want, rr.Result().StatusCode)
+
+			// Forbidden requests leave the comment untouched
+			dbComment, err := engine.LookupComment(t.Context(), post.ID, comment.ID)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want == http.StatusForbidden, dbComment != nil)
+		})
+	}
+}
+
+func TestDeleteComment_NotFound(t *testing.T) {
+	server, r, _, _ := setupServer(t)
+	defer server.Close()
+
+	req := httptest.NewRequest(
+		http.MethodDelete,
+		fmt.Sprintf("/posts/%s/comments/%s", uuid.New(), uuid.New()),
+		nil,
+	)
+	req = userIDContext(req, uuid.New())
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusNotFound, rr.Result().StatusCode)
+}
+
 func TestLookupComment(t *testing.T) {
 	server, r, engine, _ := setupServer(t)
 	defer server.Close()
@@ -254,6 +375,56 @@ func TestUpdateComment(t *testing.T) {
 	assert.Equal(t, "Updated comment content", dbComment.Content)
 }
 
+func TestUpdateComment_Authorization(t *testing.T) {
+	authorID := uuid.New()
+	for _, tt := range authorizationTests(authorID, http.StatusOK) {
+		t.Run(tt.name, func(t *testing.T) {
+			server, r, engine, _ := setupServer(t)
+			defer server.Close()
+
+			post := &store.Post{
+				ID:       uuid.New(),
+				AuthorID: uuid.New(),
+				Title:    "Test Post",
+				Content:  "Test Content",
+			}
+			require.NoError(t, engine.SetPost(t.Context(), post))
+			comment := &store.Comment{
+				ID:       uuid.New(),
+				AuthorID: authorID,
+				PostID:   post.ID,
+				Content:  "Original content",
+			}
+			require.NoError(t, engine.SetComment(t.Context(), comment))
+
+			jsonData, err := json.Marshal(api.CommentUpdate{
+				Content: testutil.Ptr("Updated content"),
+			})
+			require.NoError(t, err)
+			req := httptest.NewRequest(
+				http.MethodPut,
+				fmt.Sprintf("/posts/%s/comments/%s", post.ID, comment.ID),
+				bytes.NewBuffer(jsonData),
+			)
+			req.Header.Set("content-type", "application/json")
+			req = userIDContext(req, tt.userID, tt.permissions...)
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.want, rr.Result().StatusCode)
+
+			dbComment, err := engine.LookupComment(t.Context(), post.ID, comment.ID)
+			require.NoError(t, err)
+			if tt.want == http.StatusForbidden {
+				assert.Equal(t, "Original content", dbComment.Content)
+			} else {
+				assert.Equal(t, "Updated content", dbComment.Content)
+			}
+			assert.Equal(t, authorID, dbComment.AuthorID)
+		})
+	}
+}
+
 func TestUpdateComment_NotFound(t *testing.T) {
 	server, r, _, _ := setupServer(t)
 	defer server.Close()
@@ -331,9 +502,11 @@ func TestListComments(t *testing.T) {
 	r.ServeHTTP(rr, req)
 
 	assert.Equal(t, http.StatusOK, rr.Result().StatusCode)
-	var resList []api.Comment
-	err = json.NewDecoder(rr.Body).Decode(&resList)
+	var res api.CommentList
+	err = json.NewDecoder(rr.Body).Decode(&res)
 	require.NoError(t, err)
+	assert.Nil(t, res.NextCursor)
+	resList := res.Items
 	require.Equal(t, 2, len(resList))
 
 	// Check that our created comments exist in the response
@@ -344,3 +517,249 @@ func TestListComments(t *testing.T) {
 	assert.Contains(t, ids, commentID1)
 	assert.Contains(t, ids, commentID2)
 }
+
+func TestListComments_Draft(t *testing.T) {
+	server, r, engine, _ := setupServer(t)
+	defer server.Close()
+
+	authorID := uuid.New()
+	draft := &store.Post{ID: uuid.New(), AuthorID: authorID, Title: "Draft"}
+	require.NoError(t, engine.SetPost(t.Context(), draft))
+	comment := &store.Comment{ID: uuid.New(), AuthorID: authorID, PostID: draft.ID, Content: "Note"}
+	require.NoError(t, engine.SetComment(t.Context(), comment))
+
+	for _, tt := range visibilityTests(authorID) {
+		t.Run(tt.name, func(t *testing.T) {
+			want := http.StatusNotFound
+			if tt.visible {
+				want = http.StatusOK
+			}
+
+			req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/posts/%s/comments", draft.ID), nil)
+			req = tt.context(req)
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+			assert.Equal(t, want, rr.Result().StatusCode)
+
+			req = httptest.NewRequest(http.MethodGet, fmt.Sprintf("/posts/%s/comments/%s", draft.ID, comment.ID), nil)
+			req = tt.context(req)
+			rr = httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+			assert.Equal(t, want, rr.Result().StatusCode)
+		})
+	}
+}
+
+func TestCreateComment_Draft(t *testing.T) {
+	server, r, engine, _ := setupServer(t)
+	defer server.Close()
+
+	draft := &store.Post{ID: uuid.New(), AuthorID: uuid.New(), Title: "Draft"}
+	require.NoError(t, engine.SetPost(t.Context(), draft))
+
+	req := httptest.NewRequest(
+		http.MethodPost,
+		fmt.Sprintf("/posts/%s/comments", draft.ID),
+		bytes.NewBufferString(`{"content": "Test comment content"}`),
+	)
+	req.Header.Set("content-type", "application/json")
+	req = userIDContext(req, uuid.New())
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusNotFound, rr.Result().StatusCode)
+}
+
+// createReply creates a comment on the post via the API, replying to parentID
+// if it is set, and returns the response.
+func createReply(t *testing.T, r http.Handler, postID uuid.UUID, parentID *uuid.UUID) *httptest.ResponseRecorder {
+	t.Helper()
+	jsonData, err := json.Marshal(api.CommentCreate{Content: "Some reply", ParentId: parentID})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/posts/%s/comments", postID), bytes.NewBuffer(jsonData))
+	req.Header.Set("content-type", "application/json")
+	req = userIDContext(req, uuid.New())
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	return rr
+}
+
+func TestCreateComment_Reply(t *testing.T) {
+	server, r, engine, _ := setupServer(t)
+	defer server.Close()
+
+	post := &store.Post{ID: uuid.New(), AuthorID: uuid.New(), Title: "Test Post", Published: true}
+	require.NoError(t, engine.SetPost(t.Context(), post))
+	parent := &store.Comment{ID: uuid.New(), AuthorID: uuid.New(), PostID: post.ID, Content: "Parent"}
+	require.NoError(t, engine.SetComment(t.Context(), parent))
+
+	rr := createReply(t, r, post.ID, &parent.ID)
+
+	assert.Equal(t, http.StatusCreated, rr.Result().StatusCode)
+	var res api.Comment
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&res))
+	assert.Equal(t, &parent.ID, res.ParentId)
+	assert.Equal(t, 1, res.Depth)
+
+	dbParent, err := engine.LookupComment(t.Context(), post.ID, parent.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, dbParent.ReplyCount)
+}
+
+func TestCreateComment_InvalidParent(t *testing.T) {
+	server, r, engine, _ := setupServer(t)
+	defer server.Close()
+
+	post := &store.Post{ID: uuid.New(), AuthorID: uuid.New(), Title: "Test Post", Published: true}
+	other := &store.Post{ID: uuid.New(), AuthorID: uuid.New(), Title: "Other Post", Published: true}
+	require.NoError(t, engine.SetPost(t.Context(), post))
+	require.NoError(t, engine.SetPost(t.Context(), other))
+	otherComment := &store.Comment{ID: uuid.New(), AuthorID: uuid.New(), PostID: other.ID, Content: "Other"}
+	require.NoError(t, engine.SetComment(t.Context(), otherComment))
+	tooDeep := &store.Comment{
+		ID:       uuid.New(),
+		AuthorID: uuid.New(),
+		PostID:   post.ID,
+		ParentID: testutil.Ptr(uuid.New()),
+		Depth:    api.DefaultMaxCommentDepth,
+		Content:  "Deep",
+	}
+	require.NoError(t, engine.SetComment(t.Context(), tooDeep))
+
+	tests := []struct {
+		name     string
+		parentID uuid.UUID
+	}{
+		{"missing parent", uuid.New()},
+		{"parent of other post", otherComment.ID},
+		{"too deep", tooDeep.ID},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := createReply(t, r, post.ID, &tt.parentID)
+			assert.Equal(t, http.StatusBadRequest, rr.Result().StatusCode)
+		})
+	}
+}
+
+func TestListComments_Thread(t *testing.T) {
+	server, r, engine, _ := setupServer(t)
+	defer server.Close()
+
+	post := &store.Post{ID: uuid.New(), AuthorID: uuid.New(), Title: "Test Post", Published: true}
+	require.NoError(t, engine.SetPost(t.Context(), post))
+
+	// first
+	// ├── firstReply
+	// │   └── nestedReply
+	// second
+	var first, firstReply, nestedReply, second api.Comment
+	for _, c := range []struct {
+		res    *api.Comment
+		parent *api.Comment
+	}{{&first, nil}, {&second, nil}, {&firstReply, &first}, {&nestedReply, &firstReply}} {
+		var parentID *uuid.UUID
+		if c.parent != nil {
+			parentID = &c.parent.Id
+		}
+		rr := createReply(t, r, post.ID, parentID)
+		require.Equal(t, http.StatusCreated, rr.Result().StatusCode)
+		require.NoError(t, json.NewDecoder(rr.Body).Decode(c.res))
+	}
+
+	list := func(query string) api.CommentList {
+		req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/posts/%s/comments?%s", post.ID, query), nil)
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		require.Equal(t, http.StatusOK, rr.Result().StatusCode)
+		var res api.CommentList
+		require.NoError(t, json.NewDecoder(rr.Body).Decode(&res))
+		return res
+	}
+	ids := func(comments []api.Comment) []uuid.UUID {
+		var res []uuid.UUID
+		for _, c := range comments {
+			res = append(res, c.Id)
+		}
+		return res
+	}
+
+	t.Run("flat", func(t *testing.T) {
+		res := list("thread=flat")
+		assert.Equal(t, []uuid.UUID{first.Id, firstReply.Id, nestedReply.Id, second.Id}, ids(res.Items))
+		assert.Equal(t, []int{1, 1, 0, 0}, []int{
+			res.Items[0].ReplyCount, res.Items[1].ReplyCount, res.Items[2].ReplyCount, res.Items[3].ReplyCount,
+		})
+		assert.Equal(t, 2, res.Items[2].Depth)
+		assert.Nil(t, res.NextCursor)
+	})
+
+	t.Run("tree", func(t *testing.T) {
+		res := list("thread=tree")
+		require.Equal(t, []uuid.UUID{first.Id, second.Id}, ids(res.Items))
+		require.NotNil(t, res.Items[0].Replies)
+		replies := *res.Items[0].Replies
+		require.Equal(t, []uuid.UUID{firstReply.Id}, ids(replies))
+		assert.Equal(t, []uuid.UUID{nestedReply.Id}, ids(*replies[0].Replies))
+		assert.Empty(t, *res.Items[1].Replies)
+	})
+
+	t.Run("paginated by top-level comments", func(t *testing.T) {
+		res := list("thread=flat&limit=1")
+		assert.Equal(t, []uuid.UUID{first.Id, firstReply.Id, nestedReply.Id}, ids(res.Items))
+		require.NotNil(t, res.NextCursor)
+
+		res = list("thread=flat&limit=1&cursor=" + *res.NextCursor)
+		assert.Equal(t, []uuid.UUID{second.Id}, ids(res.Items))
+		assert.Nil(t, res.NextCursor)
+	})
+
+	t.Run("creation order", func(t *testing.T) {
+		res := list("")
+		assert.Equal(t, []uuid.UUID{first.Id, second.Id, firstReply.Id, nestedReply.Id}, ids(res.Items))
+		assert.Nil(t, res.Items[0].Replies)
+	})
+}
+
+func TestDeleteComment_Tombstone(t *testing.T) {
+	server, r, engine, _ := setupServer(t)
+	defer server.Close()
+
+	authorID := uuid.New()
+	post := &store.Post{ID: uuid.New(), AuthorID: uuid.New(), Title: "Test Post", Published: true}
+	require.NoError(t, engine.SetPost(t.Context(), post))
+	parent := &store.Comment{ID: uuid.New(), AuthorID: authorID, PostID: post.ID, Content: "Parent"}
+	require.NoError(t, engine.SetComment(t.Context(), parent))
+	require.Equal(t, http.StatusCreated, createReply(t, r, post.ID, &parent.ID).Result().StatusCode)
+
+	req := httptest.NewRequest(http.MethodDelete, fmt.Sprintf("/posts/%s/comments/%s", post.ID, parent.ID), nil)
+	req = userIDContext(req, authorID)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusNoContent, rr.Result().StatusCode)
+
+	// The comment stays in the thread without its content
+	req = httptest.NewRequest(http.MethodGet, fmt.Sprintf("/posts/%s/comments/%s", post.ID, parent.ID), nil)
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusOK, rr.Result().StatusCode)
+	var res api.Comment
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&res))
+	assert.True(t, res.Deleted)
+	assert.Empty(t, res.Content)
+	assert.Empty(t, res.ContentHtml)
+	assert.Equal(t, 1, res.ReplyCount)
+
+	// Tombstones can neither be edited nor replied to
+	jsonData, err := json.Marshal(api.CommentUpdate{Content: testutil.Ptr("Edited")})
+	require.NoError(t, err)
+	req = httptest.NewRequest(http.MethodPut, fmt.Sprintf("/posts/%s/comments/%s", post.ID, parent.ID), bytes.NewBuffer(jsonData))
+	req.Header.Set("content-type", "application/json")
+	req = userIDContext(req, authorID)
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusNotFound, rr.Result().StatusCode)
+
+	assert.Equal(t, http.StatusBadRequest, createReply(t, r, post.ID, &parent.ID).Result().StatusCode)
+}
@@ -0,0 +1,79 @@
+package api
+
+import (
+	"errors"
+	"log/slog"
+
+	"github.com/chrishrb/blog-microservice/post-service/markdown"
+	"github.com/chrishrb/blog-microservice/post-service/store"
+)
+
+// errInvalidContentFormat is returned for content formats that are not
+// supported.
+var errInvalidContentFormat = errors.New("contentFormat must be markdown or plain")
+
+// toContentFormat converts the content format of a request.
+func toContentFormat(format ContentFormat) (store.ContentFormat, error) {
+	switch format {
+	case Markdown:
+		return store.ContentFormatMarkdown, nil
+	case Plain:
+		return store.ContentFormatPlain, nil
+	}
+	return "", errInvalidContentFormat
+}
+
+// renderPostContent stores the sanitized rendition of the content of the
+// post. Posts without a format are written in Markdown.
+func renderPostContent(post *store.Post) error {
+	if post.ContentFormat == "" {
+		post.ContentFormat = store.ContentFormatMarkdown
+	}
+	if post.ContentFormat == store.ContentFormatPlain {
+		post.ContentHTML = markdown.Plain(post.Content)
+		return nil
+	}
+	html, err := markdown.Post(post.Content)
+	if err != nil {
+		return err
+	}
+	post.ContentHTML = html
+	return nil
+}
+
+// renderCommentContent stores the sanitized rendition of the content of the
+// comment.
+func renderCommentContent(comment *store.Comment) error {
+	html, err := markdown.Comment(comment.Content)
+	if err != nil {
+		return err
+	}
+	comment.ContentHTML = html
+	return nil
+}
+
+// postContentHTML returns the rendition of the content of the post. Posts
+// stored before renditions were introduced are rendered on the fly.
+func postContentHTML(post *store.Post) string {
+	if post.ContentHTML != "" || post.Content == "" {
+		return post.ContentHTML
+	}
+	rendered := *post
+	if err := renderPostContent(&rendered); err != nil {
+		slog.Error("unable to render post content", slog.String("id", post.ID.String()), "err", err)
+	}
+	return rendered.ContentHTML
+}
+
+// commentContentHTML returns the rendition of the content of the comment,
+// see postContentHTML.
+func commentContentHTML(comment *store.Comment) string {
+	if comment.ContentHTML != "" || comment.Content == "" {
+		return comment.ContentHTML
+	}
+	rendered := *comment
+	if err := renderCommentContent(&rendered); err != nil {
+		slog.Error("unable to render comment content", slog.String("id", comment.ID.String()), "err", err)
+	}
+	return rendered.ContentHTML
+}
@@ -0,0 +1,163 @@
+package api_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/chrishrb/blog-microservice/internal/api_utils"
+	"github.com/chrishrb/blog-microservice/internal/testutil"
+	"github.com/chrishrb/blog-microservice/post-service/api"
+	"github.com/chrishrb/blog-microservice/post-service/store"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreatePost_Markdown(t *testing.T) {
+	server, r, engine, _ := setupServer(t)
+	defer server.Close()
+
+	jsonData, err := json.Marshal(api.PostCreate{
+		Title:   "someTitle",
+		Content: "# Intro\n\nSome **bold** text <script>alert(1)</script>\n\n```go\nfunc main() {}\n```",
+	})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, "/posts", bytes.NewBuffer(jsonData))
+	req.Header.Set("content-type", "application/json")
+	req = userIDContext(req, uuid.New())
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusCreated, rr.Result().StatusCode)
+	var res api.Post
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&res))
+	assert.Equal(t, api.Markdown, res.ContentFormat)
+	assert.Contains(t, res.ContentHtml, `<h1 id="intro">Intro</h1>`)
+	assert.Contains(t, res.ContentHtml, "<strong>bold</strong>")
+	assert.Contains(t, res.ContentHtml, `<pre class="chroma">`)
+	assert.NotContains(t, res.ContentHtml, "<script")
+
+	dbPost, err := engine.LookupPost(t.Context(), res.Id)
+	require.NoError(t, err)
+	assert.Equal(t, store.ContentFormatMarkdown, dbPost.ContentFormat)
+	assert.Equal(t, res.ContentHtml, dbPost.ContentHTML)
+}
+
+func TestCreatePost_Plain(t *testing.T) {
+	server, r, _, _ := setupServer(t)
+	defer server.Close()
+
+	jsonData, err := json.Marshal(api.PostCreate{
+		Title:         "someTitle",
+		Content:       "Some **text** <b>",
+		ContentFormat: testutil.Ptr(api.Plain),
+	})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, "/posts", bytes.NewBuffer(jsonData))
+	req.Header.Set("content-type", "application/json")
+	req = userIDContext(req, uuid.New())
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusCreated, rr.Result().StatusCode)
+	var res api.Post
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&res))
+	assert.Equal(t, api.Plain, res.ContentFormat)
+	assert.Equal(t, "<p>Some **text** &lt;b&gt;</p>\n", res.ContentHtml)
+}
+
+func TestCreatePost_InvalidContentFormat(t *testing.T) {
+	server, r, _, _ := setupServer(t)
+	defer server.Close()
+
+	req := httptest.NewRequest(http.MethodPost, "/posts",
+		bytes.NewBufferString(`{"title": "someTitle", "content": "someContent", "contentFormat": "html"}`))
+	req.Header.Set("content-type", "application/json")
+	req = userIDContext(req, uuid.New())
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Result().StatusCode)
+}
+
+func TestUpdatePost_ContentFormat(t *testing.T) {
+	server, r, engine, _ := setupServer(t)
+	defer server.Close()
+
+	authorID := uuid.New()
+	post := &store.Post{ID: uuid.New(), AuthorID: authorID, Title: "someTitle", Content: "**text**"}
+	require.NoError(t, engine.SetPost(t.Context(), post))
+
+	jsonData, err := json.Marshal(api.PostUpdate{ContentFormat: testutil.Ptr(api.Plain)})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPut, fmt.Sprintf("/posts/%s", post.ID), bytes.NewBuffer(jsonData))
+	req.Header.Set("content-type", "application/json")
+	req.Header.Set("If-Match", api_utils.ETag(post.Version))
+	req = userIDContext(req, authorID)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Result().StatusCode)
+	var res api.Post
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&res))
+	assert.Equal(t, api.Plain, res.ContentFormat)
+	assert.Equal(t, "<p>**text**</p>\n", res.ContentHtml)
+}
+
+func TestLookupPost_UnrenderedContent(t *testing.T) {
+	server, r, engine, _ := setupServer(t)
+	defer server.Close()
+
+	// Posts stored before renditions existed have no HTML
+	post := &store.Post{ID: uuid.New(), AuthorID: uuid.New(), Title: "someTitle", Content: "*text*", Published: true}
+	require.NoError(t, engine.SetPost(t.Context(), post))
+
+	req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/posts/%s", post.ID), nil)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Result().StatusCode)
+	var res api.Post
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&res))
+	assert.Equal(t, api.Markdown, res.ContentFormat)
+	assert.Equal(t, "<p><em>text</em></p>\n", res.ContentHtml)
+}
+
+func TestCreateComment_Markdown(t *testing.T) {
+	server, r, engine, _ := setupServer(t)
+	defer server.Close()
+
+	post := &store.Post{ID: uuid.New(), AuthorID: uuid.New(), Title: "someTitle", Published: true}
+	require.NoError(t, engine.SetPost(t.Context(), post))
+
+	jsonData, err := json.Marshal(api.CommentCreate{
+		Content: "# Heading\n\n*nice* [link](https://example.com) ![image](https://example.com/a.png) <script>alert(1)</script>",
+	})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/posts/%s/comments", post.ID), bytes.NewBuffer(jsonData))
+	req.Header.Set("content-type", "application/json")
+	req = userIDContext(req, uuid.New())
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusCreated, rr.Result().StatusCode)
+	var res api.Comment
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&res))
+	assert.Contains(t, res.ContentHtml, "<p># Heading</p>")
+	assert.Contains(t, res.ContentHtml, "<em>nice</em>")
+	assert.Contains(t, res.ContentHtml, `<a href="https://example.com" rel="nofollow">link</a>`)
+	assert.NotContains(t, res.ContentHtml, "<img")
+	assert.NotContains(t, res.ContentHtml, "<script")
+
+	dbComment, err := engine.LookupComment(t.Context(), post.ID, res.Id)
+	require.NoError(t, err)
+	assert.Equal(t, res.ContentHtml, dbComment.ContentHTML)
+}
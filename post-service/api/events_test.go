@@ -0,0 +1,178 @@
+package api_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/chrishrb/blog-microservice/internal/api_utils"
+	"github.com/chrishrb/blog-microservice/internal/auth"
+	"github.com/chrishrb/blog-microservice/internal/testutil"
+	"github.com/chrishrb/blog-microservice/internal/transport"
+	"github.com/chrishrb/blog-microservice/post-service/api"
+	"github.com/chrishrb/blog-microservice/post-service/store"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostEvents(t *testing.T) {
+	producer := &MockProducer{}
+	server, r, engine, _ := setupServer(t, api.WithProducer(producer))
+	defer server.Close()
+
+	userID := uuid.New()
+
+	// Creating a draft
+	jsonData, err := json.Marshal(api.PostCreate{Title: "someTitle", Content: "someContent"})
+	require.NoError(t, err)
+	req := httptest.NewRequest(http.MethodPost, "/posts", bytes.NewBuffer(jsonData))
+	req.Header.Set("content-type", "application/json")
+	req = userIDContext(req, userID)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusCreated, rr.Result().StatusCode)
+	var res api.Post
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&res))
+	require.Equal(t, []string{transport.PostCreatedTopic}, producer.Topics())
+	var created transport.PostCreatedEvent
+	require.NoError(t, json.Unmarshal(producer.ProducedMessages[0].Message.Data, &created))
+	assert.Equal(t, res.Id, created.PostID)
+	assert.Equal(t, userID, created.AuthorID)
+	assert.False(t, created.Published)
+
+	// Publishing it
+	producer.ProducedMessages = nil
+	jsonData, err = json.Marshal(api.PostUpdate{Published: testutil.Ptr(true)})
+	require.NoError(t, err)
+	req = httptest.NewRequest(http.MethodPut, fmt.Sprintf("/posts/%s", res.Id), bytes.NewBuffer(jsonData))
+	req.Header.Set("content-type", "application/json")
+	req.Header.Set("If-Match", api_utils.ETag(res.Version))
+	req = userIDContext(req, userID)
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusOK, rr.Result().StatusCode)
+	assert.Equal(t, []string{transport.PostUpdatedTopic, transport.PostPublishedTopic}, producer.Topics())
+
+	// Deleting it
+	producer.ProducedMessages = nil
+	req = httptest.NewRequest(http.MethodDelete, fmt.Sprintf("/posts/%s", res.Id), nil)
+	req = userIDContext(req, userID)
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusNoContent, rr.Result().StatusCode)
+	require.Equal(t, []string{transport.PostDeletedTopic}, producer.Topics())
+	var deleted transport.PostDeletedEvent
+	require.NoError(t, json.Unmarshal(producer.ProducedMessages[0].Message.Data, &deleted))
+	assert.Equal(t, res.Id, deleted.PostID)
+
+	// Failed requests do not publish events
+	producer.ProducedMessages = nil
+	req = httptest.NewRequest(http.MethodDelete, fmt.Sprintf("/posts/%s", res.Id), nil)
+	req = userIDContext(req, userID)
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusNotFound, rr.Result().StatusCode)
+	assert.Empty(t, producer.ProducedMessages)
+
+	// Restoring it from the trash
+	req = httptest.NewRequest(http.MethodPost, fmt.Sprintf("/trash/posts/%s/restore", res.Id), nil)
+	req = userIDContext(req, userID)
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusOK, rr.Result().StatusCode)
+	assert.Equal(t, []string{transport.PostUpdatedTopic}, producer.Topics())
+	dbPost, err := engine.LookupPost(t.Context(), res.Id)
+	require.NoError(t, err)
+	assert.NotNil(t, dbPost)
+}
+
+func TestCommentEvents(t *testing.T) {
+	producer := &MockProducer{}
+	server, r, engine, _ := setupServer(t, api.WithProducer(producer))
+	defer server.Close()
+
+	userID := uuid.New()
+	post := &store.Post{ID: uuid.New(), AuthorID: uuid.New(), Title: "someTitle", Published: true}
+	require.NoError(t, engine.SetPost(t.Context(), post))
+
+	jsonData, err := json.Marshal(api.CommentCreate{Content: "Some comment"})
+	require.NoError(t, err)
+	req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/posts/%s/comments", post.ID), bytes.NewBuffer(jsonData))
+	req.Header.Set("content-type", "application/json")
+	req = userIDContext(req, userID)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusCreated, rr.Result().StatusCode)
+	var res api.Comment
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&res))
+
+	require.Equal(t, []string{transport.CommentCreatedTopic}, producer.Topics())
+	var created transport.CommentCreatedEvent
+	require.NoError(t, json.Unmarshal(producer.ProducedMessages[0].Message.Data, &created))
+	assert.Equal(t, res.Id, created.CommentID)
+	assert.Equal(t, post.ID, created.PostID)
+	assert.Equal(t, post.AuthorID, created.PostAuthorID)
+	assert.Equal(t, "someTitle", created.PostTitle)
+	assert.Equal(t, userID, created.AuthorID)
+	assert.Equal(t, "Some comment", created.Content)
+	assert.Equal(t, "approved", created.State)
+
+	producer.ProducedMessages = nil
+	req = httptest.NewRequest(http.MethodDelete, fmt.Sprintf("/posts/%s/comments/%s", post.ID, res.Id), nil)
+	req = userIDContext(req, userID)
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusNoContent, rr.Result().StatusCode)
+
+	require.Equal(t, []string{transport.CommentDeletedTopic}, producer.Topics())
+	var deleted transport.CommentDeletedEvent
+	require.NoError(t, json.Unmarshal(producer.ProducedMessages[0].Message.Data, &deleted))
+	assert.Equal(t, res.Id, deleted.CommentID)
+	assert.Equal(t, post.ID, deleted.PostID)
+	assert.Equal(t, userID, deleted.AuthorID)
+}
+
+func TestCommentApprovedEvent(t *testing.T) {
+	producer := &MockProducer{}
+	server, r, engine, _ := setupServer(t, api.WithProducer(producer))
+	defer server.Close()
+
+	post := &store.Post{ID: uuid.New(), AuthorID: uuid.New(), Title: "someTitle", Slug: "some-title", Published: true}
+	require.NoError(t, engine.SetPost(t.Context(), post))
+	comment := &store.Comment{
+		ID:       uuid.New(),
+		AuthorID: uuid.New(),
+		PostID:   post.ID,
+		Content:  "Some comment",
+		State:    store.ModerationStatePending,
+	}
+	require.NoError(t, engine.SetComment(t.Context(), comment))
+
+	approve := func() {
+		req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/posts/%s/comments/%s/approve", post.ID, comment.ID), nil)
+		req = userIDContext(req, uuid.New(), auth.PermissionModerate)
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		require.Equal(t, http.StatusOK, rr.Result().StatusCode)
+	}
+
+	approve()
+	require.Equal(t, []string{transport.CommentApprovedTopic}, producer.Topics())
+	var approved transport.CommentApprovedEvent
+	require.NoError(t, json.Unmarshal(producer.ProducedMessages[0].Message.Data, &approved))
+	assert.Equal(t, comment.ID, approved.CommentID)
+	assert.Equal(t, post.ID, approved.PostID)
+	assert.Equal(t, post.AuthorID, approved.PostAuthorID)
+	assert.Equal(t, "some-title", approved.PostSlug)
+	assert.Equal(t, comment.AuthorID, approved.AuthorID)
+	assert.Equal(t, "Some comment", approved.Content)
+
+	// Approving a comment again changes nothing
+	producer.ProducedMessages = nil
+	approve()
+	assert.Empty(t, producer.ProducedMessages)
+}
@@ -0,0 +1,103 @@
+package api
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/chrishrb/blog-microservice/internal/api_utils"
+	"github.com/chrishrb/blog-microservice/post-service/feed"
+	"github.com/chrishrb/blog-microservice/post-service/store"
+	"github.com/chrishrb/blog-microservice/post-service/tag"
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+)
+
+func (s *Server) GetFeed(w http.ResponseWriter, r *http.Request, file FeedFile) {
+	s.serveFeed(w, r, file, s.siteTitle, store.PostQuery{})
+}
+
+func (s *Server) GetTagFeed(w http.ResponseWriter, r *http.Request, name string, file FeedFile) {
+	name = tag.Normalize(name)
+	s.serveFeed(w, r, file, s.siteTitle+": #"+name, store.PostQuery{Tag: name})
+}
+
+func (s *Server) GetAuthorFeed(w http.ResponseWriter, r *http.Request, authorId uuid.UUID, file FeedFile) {
+	s.serveFeed(w, r, file, s.siteTitle+": posts by "+authorId.String(), store.PostQuery{AuthorID: &authorId})
+}
+
+// serveFeed responds with the most recently published posts matching the
+// query in the format of the file. The ETag is derived from the encoded feed, so
+// conditional requests are answered with 304 until a post of the feed
+// changes.
+func (s *Server) serveFeed(w http.ResponseWriter, r *http.Request, file FeedFile, title string, query store.PostQuery) {
+	published := true
+	query.Published = &published
+	query.Sort = store.PostSortPublishedAt
+	query.Descending = true
+	query.Page = store.Page{Limit: s.feedLimit}
+	posts, err := s.engine.ListPosts(r.Context(), query)
+	if err != nil {
+		_ = render.Render(w, r, api_utils.ErrInternalError(err))
+		return
+	}
+
+	f := &feed.Feed{
+		Title:  title,
+		Link:   s.siteURL,
+		Self:   s.siteURL + r.URL.Path,
+		Author: s.siteTitle,
+		Items:  make([]feed.Item, len(posts)),
+	}
+	for i, post := range posts {
+		publishedAt := post.CreatedAt
+		if post.PublishedAt != nil {
+			publishedAt = *post.PublishedAt
+		}
+		f.Items[i] = feed.Item{
+			ID:          "urn:uuid:" + post.ID.String(),
+			Title:       post.Title,
+			Link:        s.siteURL + "/posts/" + url.PathEscape(post.Slug),
+			ContentHTML: postContentHTML(post),
+			Tags:        post.Tags,
+			Published:   publishedAt,
+			Updated:     post.UpdatedAt,
+		}
+	}
+
+	var body []byte
+	var contentType string
+	switch file {
+	case RssXml:
+		body, err = f.RSS()
+		contentType = feed.ContentTypeRSS
+	case AtomXml:
+		body, err = f.Atom()
+		contentType = feed.ContentTypeAtom
+	case FeedJson:
+		body, err = f.JSON()
+		contentType = feed.ContentTypeJSON
+	default:
+		_ = render.Render(w, r, api_utils.ErrNotFound)
+		return
+	}
+	if err != nil {
+		_ = render.Render(w, r, api_utils.ErrInternalError(err))
+		return
+	}
+
+	sum := sha256.Sum256(body)
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("ETag", `"`+hex.EncodeToString(sum[:16])+`"`)
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	// ServeContent answers conditional requests based on the ETag and the
+	// last modification
+	var modified time.Time
+	if len(posts) > 0 {
+		modified = f.Updated()
+	}
+	http.ServeContent(w, r, "", modified, bytes.NewReader(body))
+}
@@ -0,0 +1,229 @@
+package api_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/chrishrb/blog-microservice/internal/api_utils"
+	"github.com/chrishrb/blog-microservice/internal/testutil"
+	"github.com/chrishrb/blog-microservice/post-service/api"
+	"github.com/chrishrb/blog-microservice/post-service/store"
+	"github.com/chrishrb/blog-microservice/post-service/store/inmemory"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	clockTest "k8s.io/utils/clock/testing"
+)
+
+// rssTitles returns the titles of the items of an RSS feed.
+func rssTitles(t *testing.T, body []byte) []string {
+	t.Helper()
+	var doc struct {
+		Items []struct {
+			Title string `xml:"title"`
+		} `xml:"channel>item"`
+	}
+	require.NoError(t, xml.Unmarshal(body, &doc))
+	titles := []string{}
+	for _, item := range doc.Items {
+		titles = append(titles, item.Title)
+	}
+	return titles
+}
+
+func TestGetFeed(t *testing.T) {
+	server, r, engine, _ := setupServer(t, api.WithFeeds("Example", "example.com", 2))
+	defer server.Close()
+
+	authorID := uuid.New()
+	for _, post := range []*store.Post{
+		{ID: uuid.New(), AuthorID: authorID, Title: "First", Slug: "first", Tags: []string{"go"}, Published: true},
+		{ID: uuid.New(), AuthorID: uuid.New(), Title: "Second", Slug: "second", Published: true},
+		{ID: uuid.New(), AuthorID: authorID, Title: "Draft", Slug: "draft", Tags: []string{"go"}},
+		{ID: uuid.New(), AuthorID: authorID, Title: "Third", Slug: "third", Tags: []string{"go"}, Published: true},
+	} {
+		require.NoError(t, engine.SetPost(t.Context(), post))
+		time.Sleep(time.Millisecond)
+	}
+
+	tests := []struct {
+		name string
+		url  string
+		want []string
+	}{
+		{"all posts up to the limit", "/feeds/rss.xml", []string{"Third", "Second"}},
+		{"tag", "/feeds/tags/Go/rss.xml", []string{"Third", "First"}},
+		{"author", "/feeds/authors/" + authorID.String() + "/rss.xml", []string{"Third", "First"}},
+		{"unknown author", "/feeds/authors/" + uuid.NewString() + "/rss.xml", []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.url, nil)
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+
+			assert.Equal(t, http.StatusOK, rr.Result().StatusCode)
+			assert.Equal(t, "application/rss+xml; charset=utf-8", rr.Header().Get("Content-Type"))
+			assert.Equal(t, tt.want, rssTitles(t, rr.Body.Bytes()))
+		})
+	}
+}
+
+func TestGetFeed_Formats(t *testing.T) {
+	server, r, engine, _ := setupServer(t, api.WithFeeds("Example", "https://blog.example.com/", 10))
+	defer server.Close()
+
+	post := &store.Post{ID: uuid.New(), Title: "Hello", Slug: "hello", Content: "Hello *world*", Published: true}
+	require.NoError(t, engine.SetPost(t.Context(), post))
+
+	req := httptest.NewRequest(http.MethodGet, "/feeds/atom.xml", nil)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Result().StatusCode)
+	assert.Equal(t, "application/atom+xml; charset=utf-8", rr.Header().Get("Content-Type"))
+	var atom struct {
+		Title   string `xml:"title"`
+		Updated string `xml:"updated"`
+		Entries []struct {
+			ID      string `xml:"id"`
+			Updated string `xml:"updated"`
+			Link    struct {
+				Href string `xml:"href,attr"`
+			} `xml:"link"`
+			Content string `xml:"content"`
+		} `xml:"entry"`
+	}
+	require.NoError(t, xml.Unmarshal(rr.Body.Bytes(), &atom))
+	assert.Equal(t, "Example", atom.Title)
+	assert.Equal(t, post.UpdatedAt.UTC().Format(time.RFC3339), atom.Updated)
+	require.Len(t, atom.Entries, 1)
+	assert.Equal(t, "urn:uuid:"+post.ID.String(), atom.Entries[0].ID)
+	assert.Equal(t, post.UpdatedAt.UTC().Format(time.RFC3339), atom.Entries[0].Updated)
+	assert.Equal(t, "https://blog.example.com/posts/hello", atom.Entries[0].Link.Href)
+	assert.Contains(t, atom.Entries[0].Content, "<em>world</em>")
+
+	req = httptest.NewRequest(http.MethodGet, "/feeds/feed.json", nil)
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Result().StatusCode)
+	assert.Equal(t, "application/feed+json; charset=utf-8", rr.Header().Get("Content-Type"))
+	var jsonFeed struct {
+		Title   string `json:"title"`
+		FeedURL string `json:"feed_url"`
+		Items   []struct {
+			ID  string `json:"id"`
+			URL string `json:"url"`
+		} `json:"items"`
+	}
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&jsonFeed))
+	assert.Equal(t, "https://blog.example.com/feeds/feed.json", jsonFeed.FeedURL)
+	require.Len(t, jsonFeed.Items, 1)
+	assert.Equal(t, "https://blog.example.com/posts/hello", jsonFeed.Items[0].URL)
+
+	req = httptest.NewRequest(http.MethodGet, "/feeds/feed.html", nil)
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusNotFound, rr.Result().StatusCode)
+}
+
+func TestGetFeed_ConditionalRequests(t *testing.T) {
+	server, r, engine, _ := setupServer(t)
+	defer server.Close()
+
+	post := &store.Post{ID: uuid.New(), Title: "Hello", Slug: "hello", Published: true}
+	require.NoError(t, engine.SetPost(t.Context(), post))
+
+	req := httptest.NewRequest(http.MethodGet, "/feeds/rss.xml", nil)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Result().StatusCode)
+	etag := rr.Header().Get("ETag")
+	require.NotEmpty(t, etag)
+	lastModified := rr.Header().Get("Last-Modified")
+	require.NotEmpty(t, lastModified)
+
+	req = httptest.NewRequest(http.MethodGet, "/feeds/rss.xml", nil)
+	req.Header.Set("If-None-Match", etag)
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusNotModified, rr.Result().StatusCode)
+	assert.Empty(t, rr.Body.Bytes())
+
+	req = httptest.NewRequest(http.MethodGet, "/feeds/rss.xml", nil)
+	req.Header.Set("If-Modified-Since", lastModified)
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusNotModified, rr.Result().StatusCode)
+
+	// Changing a post of the feed changes the ETag
+	post.Title = "Hello again"
+	require.NoError(t, engine.SetPost(t.Context(), post))
+
+	req = httptest.NewRequest(http.MethodGet, "/feeds/rss.xml", nil)
+	req.Header.Set("If-None-Match", etag)
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Result().StatusCode)
+	assert.NotEqual(t, etag, rr.Header().Get("ETag"))
+	assert.Equal(t, []string{"Hello again"}, rssTitles(t, rr.Body.Bytes()))
+}
+
+func TestGetFeed_PublicationOrder(t *testing.T) {
+	fakeClock := clockTest.NewFakeClock(time.Now().UTC().Truncate(time.Second))
+	engine := inmemory.NewStore(fakeClock)
+	srv, err := api.NewServer(engine, fakeClock, api.WithFeeds("Example", "example.com", 10))
+	require.NoError(t, err)
+	r := chi.NewRouter()
+	r.Mount("/", api.Handler(srv))
+
+	authorID := uuid.New()
+	draft := &store.Post{ID: uuid.New(), AuthorID: authorID, Title: "Draft", Slug: "draft"}
+	require.NoError(t, engine.SetPost(t.Context(), draft))
+	fakeClock.Step(time.Minute)
+	published := &store.Post{ID: uuid.New(), AuthorID: authorID, Title: "Published", Slug: "published", Published: true}
+	require.NoError(t, engine.SetPost(t.Context(), published))
+
+	// Publishing the draft later puts it on top of the feed although it was
+	// created first
+	fakeClock.Step(time.Hour)
+	jsonData, err := json.Marshal(api.PostUpdate{Published: testutil.Ptr(true)})
+	require.NoError(t, err)
+	req := httptest.NewRequest(http.MethodPut, "/posts/"+draft.ID.String(), bytes.NewBuffer(jsonData))
+	req.Header.Set("content-type", "application/json")
+	req.Header.Set("If-Match", api_utils.ETag(draft.Version))
+	req = userIDContext(req, authorID)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusOK, rr.Result().StatusCode)
+
+	req = httptest.NewRequest(http.MethodGet, "/feeds/feed.json", nil)
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Result().StatusCode)
+	var jsonFeed struct {
+		Items []struct {
+			Title         string    `json:"title"`
+			DatePublished time.Time `json:"date_published"`
+		} `json:"items"`
+	}
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&jsonFeed))
+	require.Len(t, jsonFeed.Items, 2)
+	assert.Equal(t, "Draft", jsonFeed.Items[0].Title)
+	assert.True(t, fakeClock.Now().Equal(jsonFeed.Items[0].DatePublished))
+	assert.Equal(t, "Published", jsonFeed.Items[1].Title)
+	assert.True(t, published.CreatedAt.Equal(jsonFeed.Items[1].DatePublished))
+}
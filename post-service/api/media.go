@@ -0,0 +1,382 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"slices"
+
+	"github.com/chrishrb/blog-microservice/internal/api_utils"
+	"github.com/chrishrb/blog-microservice/internal/auth"
+	"github.com/chrishrb/blog-microservice/post-service/authz"
+	"github.com/chrishrb/blog-microservice/post-service/blob"
+	"github.com/chrishrb/blog-microservice/post-service/media"
+	"github.com/chrishrb/blog-microservice/post-service/store"
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+)
+
+// maxAltTextLength is the maximum length of the alt text of media in bytes.
+const maxAltTextLength = 1000
+
+// multipartOverhead is the size of an upload in addition to the file that is
+// accepted for the boundaries, headers and the alt text.
+const multipartOverhead = 64 << 10
+
+// errNoBlobStore is returned by the media endpoints if no blob store is
+// configured.
+var errNoBlobStore = errors.New("media storage is not configured")
+
+// errMediaTooLarge is returned for files larger than the maximum size.
+var errMediaTooLarge = errors.New("file is too large")
+
+// errMissingMediaFile is returned for uploads without a file.
+var errMissingMediaFile = errors.New("file is required")
+
+// errAltTextTooLong is returned for alt texts longer than maxAltTextLength.
+var errAltTextTooLong = fmt.Errorf("altText must not be longer than %d bytes", maxAltTextLength)
+
+// errUnknownMedia is returned if a post references media that does not
+// exist.
+var errUnknownMedia = errors.New("unknown media")
+
+func (s *Server) ListMedia(w http.ResponseWriter, r *http.Request, params ListMediaParams) {
+	userID, err := auth.GetUserIDFromContext(r.Context())
+	if err != nil {
+		_ = render.Render(w, r, api_utils.ErrInternalError(err))
+		return
+	}
+	page, err := api_utils.GetPage[store.Cursor](params.Cursor, params.Offset, params.Limit)
+	if err != nil {
+		_ = render.Render(w, r, api_utils.ErrInvalidRequest(err))
+		return
+	}
+
+	list, err := s.engine.ListMedia(r.Context(), userID, page)
+	if err != nil {
+		_ = render.Render(w, r, api_utils.ErrInternalError(err))
+		return
+	}
+	list, nextCursor := api_utils.NextPage(list, page, (*store.Media).Cursor)
+
+	res := &MediaList{
+		Items:      make([]Media, len(list)),
+		NextCursor: nextCursor,
+	}
+	for i, m := range list {
+		res.Items[i] = *toMedia(m)
+	}
+
+	_ = render.Render(w, r, res)
+}
+
+func (s *Server) UploadMedia(w http.ResponseWriter, r *http.Request) {
+	userID, err := auth.GetUserIDFromContext(r.Context())
+	if err != nil {
+		_ = render.Render(w, r, api_utils.ErrInternalError(err))
+		return
+	}
+	if s.blobs == nil {
+		_ = render.Render(w, r, api_utils.ErrInternalError(errNoBlobStore))
+		return
+	}
+
+	data, altText, err := readMediaUpload(w, r, s.maxMediaSize)
+	var maxBytesErr *http.MaxBytesError
+	if errors.Is(err, errMediaTooLarge) || errors.As(err, &maxBytesErr) {
+		_ = render.Render(w, r, api_utils.ErrRequestEntityTooLarge)
+		return
+	}
+	if err != nil {
+		_ = render.Render(w, r, api_utils.ErrInvalidRequest(err))
+		return
+	}
+	img, err := media.Process(data)
+	if err != nil {
+		_ = render.Render(w, r, api_utils.ErrInvalidRequest(err))
+		return
+	}
+
+	m := &store.Media{
+		ID:          uuid.New(),
+		OwnerID:     userID,
+		ContentType: img.ContentType,
+		Size:        int64(len(data)),
+		AltText:     altText,
+		Width:       img.Width,
+		Height:      img.Height,
+		Variants:    []string{},
+	}
+	err = s.blobs.Put(r.Context(), mediaKey(m.ID, ""), bytes.NewReader(data))
+	for _, rendition := range img.Renditions {
+		if err != nil {
+			break
+		}
+		m.Variants = append(m.Variants, rendition.Variant)
+		err = s.blobs.Put(r.Context(), mediaKey(m.ID, rendition.Variant), bytes.NewReader(rendition.Data))
+	}
+	if err == nil {
+		err = s.engine.SetMedia(r.Context(), m)
+	}
+	if err != nil {
+		s.deleteMediaBlobs(r.Context(), m)
+		_ = render.Render(w, r, api_utils.ErrInternalError(err))
+		return
+	}
+
+	render.Status(r, http.StatusCreated)
+	_ = render.Render(w, r, toMedia(m))
+}
+
+func (s *Server) LookupMedia(w http.ResponseWriter, r *http.Request, id uuid.UUID) {
+	m, err := s.engine.LookupMedia(r.Context(), id)
+	if err != nil {
+		_ = render.Render(w, r, api_utils.ErrInternalError(err))
+		return
+	}
+	if m == nil {
+		_ = render.Render(w, r, api_utils.ErrNotFound)
+		return
+	}
+	_ = render.Render(w, r, toMedia(m))
+}
+
+func (s *Server) UpdateMedia(w http.ResponseWriter, r *http.Request, id uuid.UUID) {
+	m, ok := s.modifiableMedia(w, r, id)
+	if !ok {
+		return
+	}
+
+	req := new(MediaUpdate)
+	if err := render.Bind(r, req); err != nil {
+		_ = render.Render(w, r, api_utils.ErrInvalidRequest(err))
+		return
+	}
+	if len(req.AltText) > maxAltTextLength {
+		_ = render.Render(w, r, api_utils.ErrInvalidRequest(errAltTextTooLong))
+		return
+	}
+	m.AltText = req.AltText
+
+	err := s.engine.SetMedia(r.Context(), m)
+	if err != nil {
+		_ = render.Render(w, r, api_utils.ErrInternalError(err))
+		return
+	}
+	_ = render.Render(w, r, toMedia(m))
+}
+
+func (s *Server) DeleteMedia(w http.ResponseWriter, r *http.Request, id uuid.UUID) {
+	m, ok := s.modifiableMedia(w, r, id)
+	if !ok {
+		return
+	}
+
+	err := s.engine.DeleteMedia(r.Context(), id)
+	if err != nil {
+		_ = render.Render(w, r, api_utils.ErrInternalError(err))
+		return
+	}
+	s.deleteMediaBlobs(r.Context(), m)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) GetMediaContent(w http.ResponseWriter, r *http.Request, id uuid.UUID, params GetMediaContentParams) {
+	if s.blobs == nil {
+		_ = render.Render(w, r, api_utils.ErrInternalError(errNoBlobStore))
+		return
+	}
+	m, err := s.engine.LookupMedia(r.Context(), id)
+	if err != nil {
+		_ = render.Render(w, r, api_utils.ErrInternalError(err))
+		return
+	}
+	if m == nil {
+		_ = render.Render(w, r, api_utils.ErrNotFound)
+		return
+	}
+
+	// Variants that were not generated fall back to the original
+	key, contentType := mediaKey(m.ID, ""), m.ContentType
+	if params.Variant != nil && slices.Contains(m.Variants, string(*params.Variant)) {
+		key, contentType = mediaKey(m.ID, string(*params.Variant)), media.VariantContentType(m.ContentType)
+	}
+	f, err := s.blobs.Get(r.Context(), key)
+	if errors.Is(err, blob.ErrNotFound) {
+		_ = render.Render(w, r, api_utils.ErrNotFound)
+		return
+	}
+	if err != nil {
+		_ = render.Render(w, r, api_utils.ErrInternalError(err))
+		return
+	}
+	defer func() { _ = f.Close() }()
+
+	// The content stored under a key never changes
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+	w.Header().Set("ETag", `"`+key+`"`)
+	http.ServeContent(w, r, "", m.CreatedAt, f)
+}
+
+// readMediaUpload returns the file and the alt text of a multipart upload.
+func readMediaUpload(w http.ResponseWriter, r *http.Request, maxSize int64) ([]byte, string, error) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxSize+multipartOverhead)
+	reader, err := r.MultipartReader()
+	if err != nil {
+		return nil, "", err
+	}
+
+	var data []byte
+	var altText string
+	for {
+		part, err := reader.NextPart()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, "", err
+		}
+		switch part.FormName() {
+		case "file":
+			data, err = io.ReadAll(io.LimitReader(part, maxSize+1))
+			if err != nil {
+				return nil, "", err
+			}
+			if int64(len(data)) > maxSize {
+				return nil, "", errMediaTooLarge
+			}
+		case "altText":
+			b, err := io.ReadAll(io.LimitReader(part, maxAltTextLength+1))
+			if err != nil {
+				return nil, "", err
+			}
+			if len(b) > maxAltTextLength {
+				return nil, "", errAltTextTooLong
+			}
+			altText = string(b)
+		}
+	}
+	if data == nil {
+		return nil, "", errMissingMediaFile
+	}
+	return data, altText, nil
+}
+
+// modifiableMedia returns the media if the caller may modify it. Otherwise
+// it renders the error and returns false.
+func (s *Server) modifiableMedia(w http.ResponseWriter, r *http.Request, ID uuid.UUID) (*store.Media, bool) {
+	m, err := s.engine.LookupMedia(r.Context(), ID)
+	if err != nil {
+		_ = render.Render(w, r, api_utils.ErrInternalError(err))
+		return nil, false
+	}
+	if m == nil {
+		_ = render.Render(w, r, api_utils.ErrNotFound)
+		return nil, false
+	}
+	if err := authz.CanModify(authz.CallerFromContext(r.Context()), m.OwnerID); err != nil {
+		_ = render.Render(w, r, api_utils.ErrForbidden)
+		return nil, false
+	}
+	return m, true
+}
+
+// deleteMediaBlobs removes the files of the media and its variants. Files
+// that cannot be removed are left behind and logged, the media is gone
+// either way.
+func (s *Server) deleteMediaBlobs(ctx context.Context, m *store.Media) {
+	if s.blobs == nil {
+		return
+	}
+	for _, variant := range append([]string{""}, m.Variants...) {
+		if err := s.blobs.Delete(ctx, mediaKey(m.ID, variant)); err != nil {
+			slog.Warn("unable to delete media file", slog.String("id", m.ID.String()), "err", err)
+		}
+	}
+}
+
+// checkMedia returns errUnknownMedia unless all media exist.
+func (s *Server) checkMedia(ctx context.Context, IDs ...uuid.UUID) error {
+	for _, ID := range IDs {
+		m, err := s.engine.LookupMedia(ctx, ID)
+		if err != nil {
+			return err
+		}
+		if m == nil {
+			return fmt.Errorf("%w: %s", errUnknownMedia, ID)
+		}
+	}
+	return nil
+}
+
+// setPostMedia sets the media embedded in the post and its cover image,
+// which must exist. A nil cover keeps the current one and the nil UUID
+// removes it. Otherwise it renders the error and returns false.
+func (s *Server) setPostMedia(w http.ResponseWriter, r *http.Request, post *store.Post, mediaIDs *[]uuid.UUID, coverID *uuid.UUID) bool {
+	var IDs []uuid.UUID
+	if mediaIDs != nil {
+		IDs = append(IDs, *mediaIDs...)
+	}
+	if coverID != nil && *coverID != uuid.Nil {
+		IDs = append(IDs, *coverID)
+	}
+	err := s.checkMedia(r.Context(), IDs...)
+	if errors.Is(err, errUnknownMedia) {
+		_ = render.Render(w, r, api_utils.ErrInvalidRequest(err))
+		return false
+	}
+	if err != nil {
+		_ = render.Render(w, r, api_utils.ErrInternalError(err))
+		return false
+	}
+
+	if mediaIDs != nil {
+		post.MediaIDs = slices.Clone(*mediaIDs)
+		if len(post.MediaIDs) == 0 {
+			post.MediaIDs = nil
+		}
+	}
+	if coverID != nil {
+		if *coverID == uuid.Nil {
+			post.CoverMediaID = nil
+		} else {
+			ID := *coverID
+			post.CoverMediaID = &ID
+		}
+	}
+	return true
+}
+
+// mediaKey returns the key of the file of the variant of the media in the
+// blob store, the empty variant is the original.
+func mediaKey(ID uuid.UUID, variant string) string {
+	if variant == "" {
+		return ID.String()
+	}
+	return ID.String() + "-" + variant
+}
+
+func toMedia(m *store.Media) *Media {
+	res := &Media{
+		Id:          m.ID,
+		OwnerId:     m.OwnerID,
+		ContentType: m.ContentType,
+		Size:        m.Size,
+		AltText:     m.AltText,
+		Width:       m.Width,
+		Height:      m.Height,
+		Variants:    make([]MediaVariant, len(m.Variants)),
+		CreatedAt:   m.CreatedAt,
+		UpdatedAt:   m.UpdatedAt,
+	}
+	for i, variant := range m.Variants {
+		res.Variants[i] = MediaVariant(variant)
+	}
+	return res
+}
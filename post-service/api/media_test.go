@@ -0,0 +1,321 @@
+package api_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/chrishrb/blog-microservice/internal/api_utils"
+	"github.com/chrishrb/blog-microservice/internal/testutil"
+	"github.com/chrishrb/blog-microservice/post-service/api"
+	"github.com/chrishrb/blog-microservice/post-service/blob"
+	"github.com/chrishrb/blog-microservice/post-service/store"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func encodePNG(t *testing.T, width, height int) []byte {
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := range height {
+		for x := range width {
+			img.Set(x, y, color.NRGBA{R: 10, G: 20, B: 30, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+// uploadRequest returns a multipart upload of the file with the alt text.
+func uploadRequest(t *testing.T, userID uuid.UUID, file []byte, altText string) *http.Request {
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	fw, err := mw.CreateFormFile("file", "upload.png")
+	require.NoError(t, err)
+	_, err = fw.Write(file)
+	require.NoError(t, err)
+	if altText != "" {
+		require.NoError(t, mw.WriteField("altText", altText))
+	}
+	require.NoError(t, mw.Close())
+
+	req := httptest.NewRequest(http.MethodPost, "/media", &body)
+	req.Header.Set("content-type", mw.FormDataContentType())
+	return userIDContext(req, userID)
+}
+
+func TestUploadMedia(t *testing.T) {
+	blobs := blob.NewLocalStore(t.TempDir())
+	server, r, engine, _ := setupServer(t, api.WithBlobStore(blobs))
+	defer server.Close()
+
+	userID := uuid.New()
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, uploadRequest(t, userID, encodePNG(t, 640, 480), "A dark blue square"))
+
+	assert.Equal(t, http.StatusCreated, rr.Result().StatusCode)
+	var res api.Media
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&res))
+	assert.Equal(t, userID, res.OwnerId)
+	assert.Equal(t, "image/png", res.ContentType)
+	assert.Equal(t, "A dark blue square", res.AltText)
+	assert.Equal(t, 640, res.Width)
+	assert.Equal(t, 480, res.Height)
+	assert.Equal(t, []api.MediaVariant{api.Thumbnail}, res.Variants)
+
+	dbMedia, err := engine.LookupMedia(t.Context(), res.Id)
+	require.NoError(t, err)
+	require.NotNil(t, dbMedia)
+	assert.Equal(t, res.Size, dbMedia.Size)
+
+	f, err := blobs.Get(t.Context(), res.Id.String()+"-thumbnail")
+	require.NoError(t, err)
+	cfg, _, err := image.DecodeConfig(f)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+	assert.Equal(t, 320, cfg.Width)
+	assert.Equal(t, 240, cfg.Height)
+}
+
+func TestUploadMedia_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    []byte
+		altText string
+		want    int
+	}{
+		{"unsupported type", []byte("<svg onload=alert(1)></svg>"), "", http.StatusBadRequest},
+		{"corrupt image", []byte("\x89PNG\x0D\x0A\x1A\x0Abroken"), "", http.StatusBadRequest},
+		{"too large", bytes.Repeat([]byte{0}, 2048), "", http.StatusRequestEntityTooLarge},
+		{"alt text too long", encodePNG(t, 1, 1), string(bytes.Repeat([]byte("a"), 1001)), http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, r, _, _ := setupServer(t, api.WithBlobStore(blob.NewLocalStore(t.TempDir())), api.WithMaxMediaSize(1024))
+			defer server.Close()
+
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, uploadRequest(t, uuid.New(), tt.file, tt.altText))
+
+			assert.Equal(t, tt.want, rr.Result().StatusCode)
+		})
+	}
+}
+
+func TestGetMediaContent(t *testing.T) {
+	blobs := blob.NewLocalStore(t.TempDir())
+	server, r, _, _ := setupServer(t, api.WithBlobStore(blobs))
+	defer server.Close()
+
+	original := encodePNG(t, 400, 200)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, uploadRequest(t, uuid.New(), original, ""))
+	require.Equal(t, http.StatusCreated, rr.Result().StatusCode)
+	var m api.Media
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&m))
+
+	// The original
+	req := httptest.NewRequest(http.MethodGet, "/media/"+m.Id.String()+"/content", nil)
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Result().StatusCode)
+	assert.Equal(t, "image/png", rr.Result().Header.Get("Content-Type"))
+	assert.Equal(t, "public, max-age=31536000, immutable", rr.Result().Header.Get("Cache-Control"))
+	assert.Equal(t, original, rr.Body.Bytes())
+	etag := rr.Result().Header.Get("ETag")
+	require.NotEmpty(t, etag)
+
+	// A conditional request
+	req = httptest.NewRequest(http.MethodGet, "/media/"+m.Id.String()+"/content", nil)
+	req.Header.Set("If-None-Match", etag)
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusNotModified, rr.Result().StatusCode)
+
+	// A variant
+	req = httptest.NewRequest(http.MethodGet, "/media/"+m.Id.String()+"/content?variant=thumbnail", nil)
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Result().StatusCode)
+	assert.NotEqual(t, etag, rr.Result().Header.Get("ETag"))
+	cfg, _, err := image.DecodeConfig(rr.Body)
+	require.NoError(t, err)
+	assert.Equal(t, 320, cfg.Width)
+	assert.Equal(t, 160, cfg.Height)
+
+	// Variants that are larger than the image fall back to the original
+	req = httptest.NewRequest(http.MethodGet, "/media/"+m.Id.String()+"/content?variant=medium", nil)
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Result().StatusCode)
+	assert.Equal(t, etag, rr.Result().Header.Get("ETag"))
+	assert.Equal(t, original, rr.Body.Bytes())
+
+	// Missing media
+	req = httptest.NewRequest(http.MethodGet, "/media/"+uuid.NewString()+"/content", nil)
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusNotFound, rr.Result().StatusCode)
+}
+
+func TestUpdateMedia_Authorization(t *testing.T) {
+	ownerID := uuid.New()
+	for _, tt := range authorizationTests(ownerID, http.StatusOK) {
+		t.Run(tt.name, func(t *testing.T) {
+			server, r, engine, _ := setupServer(t)
+			defer server.Close()
+
+			m := &store.Media{ID: uuid.New(), OwnerID: ownerID, ContentType: "image/png"}
+			require.NoError(t, engine.SetMedia(t.Context(), m))
+
+			jsonData, err := json.Marshal(api.MediaUpdate{AltText: "Updated"})
+			require.NoError(t, err)
+			req := httptest.NewRequest(http.MethodPut, "/media/"+m.ID.String(), bytes.NewBuffer(jsonData))
+			req.Header.Set("content-type", "application/json")
+			req = userIDContext(req, tt.userID, tt.permissions...)
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.want, rr.Result().StatusCode)
+			dbMedia, err := engine.LookupMedia(t.Context(), m.ID)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want == http.StatusOK, dbMedia.AltText == "Updated")
+		})
+	}
+}
+
+func TestDeleteMedia_Authorization(t *testing.T) {
+	ownerID := uuid.New()
+	for _, tt := range authorizationTests(ownerID, http.StatusNoContent) {
+		t.Run(tt.name, func(t *testing.T) {
+			blobs := blob.NewLocalStore(t.TempDir())
+			server, r, engine, _ := setupServer(t, api.WithBlobStore(blobs))
+			defer server.Close()
+
+			m := &store.Media{ID: uuid.New(), OwnerID: ownerID, ContentType: "image/png", Variants: []string{"thumbnail"}}
+			require.NoError(t, engine.SetMedia(t.Context(), m))
+			for _, key := range []string{m.ID.String(), m.ID.String() + "-thumbnail"} {
+				require.NoError(t, blobs.Put(t.Context(), key, bytes.NewReader([]byte("image"))))
+			}
+
+			req := httptest.NewRequest(http.MethodDelete, "/media/"+m.ID.String(), nil)
+			req = userIDContext(req, tt.userID, tt.permissions...)
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.want, rr.Result().StatusCode)
+			dbMedia, err := engine.LookupMedia(t.Context(), m.ID)
+			require.NoError(t, err)
+			for _, key := range []string{m.ID.String(), m.ID.String() + "-thumbnail"} {
+				f, err := blobs.Get(t.Context(), key)
+				if tt.want == http.StatusNoContent {
+					assert.ErrorIs(t, err, blob.ErrNotFound)
+				} else {
+					require.NoError(t, err)
+					_, _ = io.Copy(io.Discard, f)
+					require.NoError(t, f.Close())
+				}
+			}
+			if tt.want == http.StatusNoContent {
+				assert.Nil(t, dbMedia)
+			} else {
+				assert.NotNil(t, dbMedia)
+			}
+		})
+	}
+}
+
+func TestListMedia(t *testing.T) {
+	server, r, engine, _ := setupServer(t)
+	defer server.Close()
+
+	userID := uuid.New()
+	own := &store.Media{ID: uuid.New(), OwnerID: userID, ContentType: "image/png"}
+	other := &store.Media{ID: uuid.New(), OwnerID: uuid.New(), ContentType: "image/png"}
+	require.NoError(t, engine.SetMedia(t.Context(), own))
+	require.NoError(t, engine.SetMedia(t.Context(), other))
+
+	req := httptest.NewRequest(http.MethodGet, "/media", nil)
+	req = userIDContext(req, userID)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Result().StatusCode)
+	var res api.MediaList
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&res))
+	require.Len(t, res.Items, 1)
+	assert.Equal(t, own.ID, res.Items[0].Id)
+}
+
+func TestPostMedia(t *testing.T) {
+	server, r, engine, _ := setupServer(t)
+	defer server.Close()
+
+	userID := uuid.New()
+	cover := &store.Media{ID: uuid.New(), OwnerID: userID, ContentType: "image/jpeg"}
+	embedded := &store.Media{ID: uuid.New(), OwnerID: userID, ContentType: "image/png"}
+	require.NoError(t, engine.SetMedia(t.Context(), cover))
+	require.NoError(t, engine.SetMedia(t.Context(), embedded))
+
+	// Create a post with media
+	jsonData, err := json.Marshal(api.PostCreate{
+		Title:        "With images",
+		Content:      "![image](/media/" + embedded.ID.String() + "/content)",
+		MediaIds:     &[]uuid.UUID{embedded.ID},
+		CoverMediaId: &cover.ID,
+	})
+	require.NoError(t, err)
+	req := httptest.NewRequest(http.MethodPost, "/posts", bytes.NewBuffer(jsonData))
+	req.Header.Set("content-type", "application/json")
+	req = userIDContext(req, userID)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusCreated, rr.Result().StatusCode)
+	var res api.Post
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&res))
+	require.NotNil(t, res.MediaIds)
+	assert.Equal(t, []uuid.UUID{embedded.ID}, *res.MediaIds)
+	assert.Equal(t, &cover.ID, res.CoverMediaId)
+
+	// Unknown media are rejected
+	jsonData, err = json.Marshal(api.PostUpdate{CoverMediaId: testutil.Ptr(uuid.New())})
+	require.NoError(t, err)
+	req = httptest.NewRequest(http.MethodPut, "/posts/"+res.Id.String(), bytes.NewBuffer(jsonData))
+	req.Header.Set("content-type", "application/json")
+	req.Header.Set("If-Match", api_utils.ETag(res.Version))
+	req = userIDContext(req, userID)
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Result().StatusCode)
+
+	// The nil UUID removes the cover image
+	jsonData, err = json.Marshal(api.PostUpdate{CoverMediaId: &uuid.Nil, MediaIds: &[]uuid.UUID{}})
+	require.NoError(t, err)
+	req = httptest.NewRequest(http.MethodPut, "/posts/"+res.Id.String(), bytes.NewBuffer(jsonData))
+	req.Header.Set("content-type", "application/json")
+	req.Header.Set("If-Match", api_utils.ETag(res.Version))
+	req = userIDContext(req, userID)
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Result().StatusCode)
+	dbPost, err := engine.LookupPost(t.Context(), res.Id)
+	require.NoError(t, err)
+	assert.Nil(t, dbPost.CoverMediaID)
+	assert.Empty(t, dbPost.MediaIDs)
+}
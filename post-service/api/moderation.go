@@ -0,0 +1,198 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/chrishrb/blog-microservice/internal/api_utils"
+	"github.com/chrishrb/blog-microservice/post-service/authz"
+	"github.com/chrishrb/blog-microservice/post-service/store"
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+)
+
+// errInvalidModerationPolicy is returned for moderation policies that are
+// not supported.
+var errInvalidModerationPolicy = errors.New("commentModeration must be none, trusted or all")
+
+// errInvalidModerationState is returned for moderation states that are not
+// supported.
+var errInvalidModerationState = errors.New("state must be pending, approved, rejected or spam")
+
+// toModerationPolicy converts the moderation policy of a request.
+func toModerationPolicy(policy ModerationPolicy) (store.ModerationPolicy, error) {
+	switch policy {
+	case None:
+		return store.ModerationPolicyNone, nil
+	case Trusted:
+		return store.ModerationPolicyTrusted, nil
+	case All:
+		return store.ModerationPolicyAll, nil
+	}
+	return "", errInvalidModerationPolicy
+}
+
+// toModerationState converts the moderation state of a request.
+func toModerationState(state ModerationState) (store.ModerationState, error) {
+	switch state {
+	case Pending:
+		return store.ModerationStatePending, nil
+	case Approved:
+		return store.ModerationStateApproved, nil
+	case Rejected:
+		return store.ModerationStateRejected, nil
+	case Spam:
+		return store.ModerationStateSpam, nil
+	}
+	return "", errInvalidModerationState
+}
+
+// moderationState decides whether a new comment of the caller on the post is
+// approved right away or held for moderation. The policy of the post takes
+// precedence over the one of the server. Comments of moderators and of the
+// author of the post are always approved.
+func (s *Server) moderationState(ctx context.Context, caller *authz.Caller, post *store.Post) (store.ModerationState, error) {
+	if authz.CanModerate(caller) == nil || (post != nil && caller.UserID == post.AuthorID) {
+		return store.ModerationStateApproved, nil
+	}
+
+	policy := s.commentModeration
+	if post != nil && post.CommentModeration != "" {
+		policy = post.CommentModeration
+	}
+	switch policy {
+	case store.ModerationPolicyAll:
+		return store.ModerationStatePending, nil
+	case store.ModerationPolicyTrusted:
+		if s.trustedAfter <= 0 {
+			return store.ModerationStatePending, nil
+		}
+		approved, err := s.engine.CountApprovedComments(ctx, caller.UserID)
+		if err != nil {
+			return "", err
+		}
+		if approved < s.trustedAfter {
+			return store.ModerationStatePending, nil
+		}
+	}
+	return store.ModerationStateApproved, nil
+}
+
+func (s *Server) ListModerationComments(w http.ResponseWriter, r *http.Request, params ListModerationCommentsParams) {
+	if err := authz.CanModerate(authz.CallerFromContext(r.Context())); err != nil {
+		_ = render.Render(w, r, api_utils.ErrForbidden)
+		return
+	}
+	page, err := api_utils.GetPage[store.Cursor](params.Cursor, params.Offset, params.Limit)
+	if err != nil {
+		_ = render.Render(w, r, api_utils.ErrInvalidRequest(err))
+		return
+	}
+	state := store.ModerationStatePending
+	if params.State != nil {
+		state, err = toModerationState(*params.State)
+		if err != nil {
+			_ = render.Render(w, r, api_utils.ErrInvalidRequest(err))
+			return
+		}
+	}
+
+	comments, err := s.engine.ListCommentsByState(r.Context(), state, page)
+	if err != nil {
+		_ = render.Render(w, r, api_utils.ErrInternalError(err))
+		return
+	}
+	comments, nextCursor := api_utils.NextPage(comments, page, (*store.Comment).Cursor)
+
+	res := &CommentList{
+		Items:      make([]Comment, len(comments)),
+		NextCursor: nextCursor,
+	}
+	items := make([]*Comment, len(comments))
+	for i, c := range comments {
+		res.Items[i] = *toComment(c)
+		items[i] = &res.Items[i]
+	}
+	if err := s.setCommentReactions(r.Context(), items...); err != nil {
+		_ = render.Render(w, r, api_utils.ErrInternalError(err))
+		return
+	}
+
+	_ = render.Render(w, r, res)
+}
+
+func (s *Server) ApproveComment(w http.ResponseWriter, r *http.Request, postId, id uuid.UUID) {
+	s.moderateComment(w, r, postId, id, store.ModerationStateApproved)
+}
+
+func (s *Server) RejectComment(w http.ResponseWriter, r *http.Request, postId, id uuid.UUID) {
+	// The request body is optional
+	req := new(CommentRejection)
+	if r.ContentLength != 0 {
+		if err := render.Bind(r, req); err != nil {
+			_ = render.Render(w, r, api_utils.ErrInvalidRequest(err))
+			return
+		}
+	}
+
+	state := store.ModerationStateRejected
+	if req.Spam != nil && *req.Spam {
+		state = store.ModerationStateSpam
+	}
+	s.moderateComment(w, r, postId, id, state)
+}
+
+// moderateComment moves a comment to the moderation state.
+func (s *Server) moderateComment(w http.ResponseWriter, r *http.Request, postID, ID uuid.UUID, state store.ModerationState) {
+	if err := authz.CanModerate(authz.CallerFromContext(r.Context())); err != nil {
+		_ = render.Render(w, r, api_utils.ErrForbidden)
+		return
+	}
+
+	comment, err := s.engine.LookupComment(r.Context(), postID, ID)
+	if err != nil {
+		_ = render.Render(w, r, api_utils.ErrInternalError(err))
+		return
+	}
+	if comment == nil || comment.Deleted {
+		_ = render.Render(w, r, api_utils.ErrNotFound)
+		return
+	}
+
+	// The post is looked up first, so that no error can occur after the
+	// state is stored
+	post, err := s.engine.LookupPost(r.Context(), postID)
+	if err != nil {
+		_ = render.Render(w, r, api_utils.ErrInternalError(err))
+		return
+	}
+	if post == nil {
+		_ = render.Render(w, r, api_utils.ErrNotFound)
+		return
+	}
+
+	wasApproved := comment.State == store.ModerationStateApproved
+	comment.State = state
+	err = s.engine.SetComment(r.Context(), comment)
+	if errors.Is(err, store.ErrPostNotFound) {
+		_ = render.Render(w, r, api_utils.ErrNotFound)
+		return
+	}
+	if err != nil {
+		_ = render.Render(w, r, api_utils.ErrInternalError(err))
+		return
+	}
+	// Comments that are approved now become visible, which consumers learn
+	// about from an event as they did not from the one of the creation
+	if state == store.ModerationStateApproved && !wasApproved {
+		s.events.CommentApproved(r.Context(), post, comment)
+	}
+
+	res := toComment(comment)
+	if err := s.setCommentReactions(r.Context(), res); err != nil {
+		_ = render.Render(w, r, api_utils.ErrInternalError(err))
+		return
+	}
+	_ = render.Render(w, r, res)
+}
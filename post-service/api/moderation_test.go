@@ -0,0 +1,395 @@
+package api_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/chrishrb/blog-microservice/internal/api_utils"
+	"github.com/chrishrb/blog-microservice/internal/auth"
+	"github.com/chrishrb/blog-microservice/internal/testutil"
+	"github.com/chrishrb/blog-microservice/post-service/api"
+	"github.com/chrishrb/blog-microservice/post-service/store"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateComment_Moderation(t *testing.T) {
+	authorID := uuid.New()
+	trustedID := uuid.New()
+
+	tests := []struct {
+		name        string
+		policy      store.ModerationPolicy
+		postPolicy  store.ModerationPolicy
+		userID      uuid.UUID
+		permissions []string
+		want        api.ModerationState
+	}{
+		{"no moderation", store.ModerationPolicyNone, "", uuid.New(), nil, api.Approved},
+		{"untrusted user", store.ModerationPolicyTrusted, "", uuid.New(), nil, api.Pending},
+		{"trusted user", store.ModerationPolicyTrusted, "", trustedID, nil, api.Approved},
+		{"trusted user on moderated post", store.ModerationPolicyTrusted, store.ModerationPolicyAll, trustedID, nil, api.Pending},
+		{"untrusted user on open post", store.ModerationPolicyAll, store.ModerationPolicyNone, uuid.New(), nil, api.Approved},
+		{"author of the post", store.ModerationPolicyAll, "", authorID, nil, api.Approved},
+		{"moderator", store.ModerationPolicyAll, "", uuid.New(), []string{auth.PermissionModerate}, api.Approved},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, r, engine, _ := setupServer(t, api.WithCommentModeration(tt.policy, 2))
+			defer server.Close()
+
+			post := &store.Post{
+				ID:                uuid.New(),
+				AuthorID:          authorID,
+				Title:             "Test Post",
+				Published:         true,
+				CommentModeration: tt.postPolicy,
+			}
+			require.NoError(t, engine.SetPost(t.Context(), post))
+			// Users are trusted after two approved comments
+			for range 2 {
+				comment := &store.Comment{ID: uuid.New(), AuthorID: trustedID, PostID: post.ID, Content: "Approved"}
+				require.NoError(t, engine.SetComment(t.Context(), comment))
+			}
+
+			jsonData, err := json.Marshal(api.CommentCreate{Content: "Some comment"})
+			require.NoError(t, err)
+			req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/posts/%s/comments", post.ID), bytes.NewBuffer(jsonData))
+			req.Header.Set("content-type", "application/json")
+			req = userIDContext(req, tt.userID, tt.permissions...)
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+
+			assert.Equal(t, http.StatusCreated, rr.Result().StatusCode)
+			var res api.Comment
+			require.NoError(t, json.NewDecoder(rr.Body).Decode(&res))
+			assert.Equal(t, tt.want, res.State)
+
+			dbComment, err := engine.LookupComment(t.Context(), post.ID, res.Id)
+			require.NoError(t, err)
+			assert.Equal(t, store.ModerationState(tt.want), dbComment.State)
+		})
+	}
+}
+
+func TestListComments_Moderation(t *testing.T) {
+	server, r, engine, _ := setupServer(t)
+	defer server.Close()
+
+	authorID := uuid.New()
+	post := &store.Post{ID: uuid.New(), AuthorID: uuid.New(), Title: "Test Post", Published: true}
+	require.NoError(t, engine.SetPost(t.Context(), post))
+	approved := &store.Comment{ID: uuid.New(), AuthorID: uuid.New(), PostID: post.ID, Content: "Approved"}
+	require.NoError(t, engine.SetComment(t.Context(), approved))
+	pending := &store.Comment{
+		ID:       uuid.New(),
+		AuthorID: authorID,
+		PostID:   post.ID,
+		Content:  "Pending",
+		State:    store.ModerationStatePending,
+	}
+	require.NoError(t, engine.SetComment(t.Context(), pending))
+	spam := &store.Comment{ID: uuid.New(), AuthorID: authorID, PostID: post.ID, Content: "Spam", State: store.ModerationStateSpam}
+	require.NoError(t, engine.SetComment(t.Context(), spam))
+
+	tests := []struct {
+		name        string
+		userID      uuid.UUID
+		permissions []string
+		want        []uuid.UUID
+	}{
+		{"anonymous", uuid.Nil, nil, []uuid.UUID{approved.ID}},
+		{"other user", uuid.New(), nil, []uuid.UUID{approved.ID}},
+		{"author of the comments", authorID, nil, []uuid.UUID{approved.ID, pending.ID}},
+		{"moderator", uuid.New(), []string{auth.PermissionModerate}, []uuid.UUID{approved.ID, pending.ID, spam.ID}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, query := range []string{"", "?thread=flat"} {
+				req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/posts/%s/comments%s", post.ID, query), nil)
+				if tt.userID != uuid.Nil {
+					req = userIDContext(req, tt.userID, tt.permissions...)
+				}
+				rr := httptest.NewRecorder()
+				r.ServeHTTP(rr, req)
+
+				assert.Equal(t, http.StatusOK, rr.Result().StatusCode)
+				var res api.CommentList
+				require.NoError(t, json.NewDecoder(rr.Body).Decode(&res))
+				var ids []uuid.UUID
+				for _, c := range res.Items {
+					ids = append(ids, c.Id)
+				}
+				assert.ElementsMatch(t, tt.want, ids)
+			}
+
+			// Comments that are not listed can not be looked up either
+			for _, comment := range []*store.Comment{approved, pending, spam} {
+				req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/posts/%s/comments/%s", post.ID, comment.ID), nil)
+				if tt.userID != uuid.Nil {
+					req = userIDContext(req, tt.userID, tt.permissions...)
+				}
+				rr := httptest.NewRecorder()
+				r.ServeHTTP(rr, req)
+
+				want := http.StatusNotFound
+				for _, ID := range tt.want {
+					if ID == comment.ID {
+						want = http.StatusOK
+					}
+				}
+				assert.Equal(t, want, rr.Result().StatusCode, comment.Content)
+			}
+		})
+	}
+}
+
+func TestListModerationComments(t *testing.T) {
+	server, r, engine, _ := setupServer(t)
+	defer server.Close()
+
+	post := &store.Post{ID: uuid.New(), AuthorID: uuid.New(), Title: "Test Post", Published: true}
+	require.NoError(t, engine.SetPost(t.Context(), post))
+	var pending []uuid.UUID
+	for _, state := range []store.ModerationState{
+		store.ModerationStatePending, store.ModerationStateApproved, store.ModerationStateSpam, store.ModerationStatePending,
+	} {
+		comment := &store.Comment{ID: uuid.New(), AuthorID: uuid.New(), PostID: post.ID, Content: "Some comment", State: state}
+		require.NoError(t, engine.SetComment(t.Context(), comment))
+		if state == store.ModerationStatePending {
+			pending = append(pending, comment.ID)
+		}
+	}
+
+	list := func(query string, permissions ...string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/moderation/comments"+query, nil)
+		req = userIDContext(req, uuid.New(), permissions...)
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("pending by default", func(t *testing.T) {
+		rr := list("", auth.PermissionModerate)
+		assert.Equal(t, http.StatusOK, rr.Result().StatusCode)
+		var res api.CommentList
+		require.NoError(t, json.NewDecoder(rr.Body).Decode(&res))
+		var ids []uuid.UUID
+		for _, c := range res.Items {
+			assert.Equal(t, post.ID, c.PostId)
+			assert.Equal(t, api.Pending, c.State)
+			ids = append(ids, c.Id)
+		}
+		assert.ElementsMatch(t, pending, ids)
+	})
+
+	t.Run("state", func(t *testing.T) {
+		rr := list("?state=spam&limit=1", auth.PermissionAllPostsWrite)
+		assert.Equal(t, http.StatusOK, rr.Result().StatusCode)
+		var res api.CommentList
+		require.NoError(t, json.NewDecoder(rr.Body).Decode(&res))
+		require.Len(t, res.Items, 1)
+		assert.Equal(t, api.Spam, res.Items[0].State)
+		assert.Nil(t, res.NextCursor)
+	})
+
+	t.Run("invalid state", func(t *testing.T) {
+		rr := list("?state=deleted", auth.PermissionModerate)
+		assert.Equal(t, http.StatusBadRequest, rr.Result().StatusCode)
+	})
+
+	t.Run("not a moderator", func(t *testing.T) {
+		rr := list("", auth.PermissionAllPostsRead)
+		assert.Equal(t, http.StatusForbidden, rr.Result().StatusCode)
+	})
+}
+
+func TestModerateComment(t *testing.T) {
+	tests := []struct {
+		name   string
+		action string
+		body   string
+		want   api.ModerationState
+	}{
+		{"approve", "approve", "", api.Approved},
+		{"reject", "reject", "", api.Rejected},
+		{"reject as spam", "reject", `{"spam": true}`, api.Spam},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, r, engine, _ := setupServer(t)
+			defer server.Close()
+
+			post := &store.Post{ID: uuid.New(), AuthorID: uuid.New(), Title: "Test Post", Published: true}
+			require.NoError(t, engine.SetPost(t.Context(), post))
+			comment := &store.Comment{
+				ID:       uuid.New(),
+				AuthorID: uuid.New(),
+				PostID:   post.ID,
+				Content:  "Some comment",
+				State:    store.ModerationStatePending,
+			}
+			require.NoError(t, engine.SetComment(t.Context(), comment))
+
+			req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/posts/%s/comments/%s/%s", post.ID, comment.ID, tt.action),
+				bytes.NewBufferString(tt.body))
+			if tt.body != "" {
+				req.Header.Set("content-type", "application/json")
+			}
+			req = userIDContext(req, uuid.New(), auth.PermissionModerate)
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+
+			assert.Equal(t, http.StatusOK, rr.Result().StatusCode)
+			var res api.Comment
+			require.NoError(t, json.NewDecoder(rr.Body).Decode(&res))
+			assert.Equal(t, tt.want, res.State)
+
+			dbComment, err := engine.LookupComment(t.Context(), post.ID, comment.ID)
+			require.NoError(t, err)
+			assert.Equal(t, store.ModerationState(tt.want), dbComment.State)
+		})
+	}
+}
+
+func TestModerateComment_Authorization(t *testing.T) {
+	server, r, engine, _ := setupServer(t)
+	defer server.Close()
+
+	authorID := uuid.New()
+	post := &store.Post{ID: uuid.New(), AuthorID: uuid.New(), Title: "Test Post", Published: true}
+	require.NoError(t, engine.SetPost(t.Context(), post))
+	comment := &store.Comment{
+		ID:       uuid.New(),
+		AuthorID: authorID,
+		PostID:   post.ID,
+		Content:  "Some comment",
+		State:    store.ModerationStatePending,
+	}
+	require.NoError(t, engine.SetComment(t.Context(), comment))
+
+	tests := []struct {
+		name        string
+		userID      uuid.UUID
+		permissions []string
+		want        int
+	}{
+		{"author of the comment", authorID, nil, http.StatusForbidden},
+		{"author of the post", post.AuthorID, nil, http.StatusForbidden},
+		{"admin", uuid.New(), []string{auth.PermissionAllPostsWrite}, http.StatusOK},
+		{"moderator", uuid.New(), []string{auth.PermissionModerate}, http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/posts/%s/comments/%s/approve", post.ID, comment.ID), nil)
+			req = userIDContext(req, tt.userID, tt.permissions...)
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+			assert.Equal(t, tt.want, rr.Result().StatusCode)
+		})
+	}
+}
+
+func TestModerateComment_NotFound(t *testing.T) {
+	server, r, engine, _ := setupServer(t)
+	defer server.Close()
+
+	post := &store.Post{ID: uuid.New(), AuthorID: uuid.New(), Title: "Test Post", Published: true}
+	require.NoError(t, engine.SetPost(t.Context(), post))
+
+	req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/posts/%s/comments/%s/reject", post.ID, uuid.New()), nil)
+	req = userIDContext(req, uuid.New(), auth.PermissionModerate)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusNotFound, rr.Result().StatusCode)
+}
+
+func TestCreatePost_CommentModeration(t *testing.T) {
+	server, r, engine, _ := setupServer(t)
+	defer server.Close()
+
+	jsonData, err := json.Marshal(api.PostCreate{
+		Title:             "someTitle",
+		Content:           "someContent",
+		CommentModeration: testutil.Ptr(api.All),
+	})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, "/posts", bytes.NewBuffer(jsonData))
+	req.Header.Set("content-type", "application/json")
+	req = userIDContext(req, uuid.New())
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusCreated, rr.Result().StatusCode)
+	var res api.Post
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&res))
+	assert.Equal(t, testutil.Ptr(api.All), res.CommentModeration)
+
+	dbPost, err := engine.LookupPost(t.Context(), res.Id)
+	require.NoError(t, err)
+	assert.Equal(t, store.ModerationPolicyAll, dbPost.CommentModeration)
+
+	// Invalid policies are rejected
+	req = httptest.NewRequest(http.MethodPut, fmt.Sprintf("/posts/%s", res.Id),
+		bytes.NewBufferString(`{"commentModeration": "sometimes"}`))
+	req.Header.Set("content-type", "application/json")
+	req.Header.Set("If-Match", api_utils.ETag(res.Version))
+	req = userIDContext(req, res.AuthorId)
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusBadRequest, rr.Result().StatusCode)
+}
+
+func TestUpdateComment_Moderation(t *testing.T) {
+	authorID := uuid.New()
+
+	tests := []struct {
+		name        string
+		state       store.ModerationState
+		content     string
+		userID      uuid.UUID
+		permissions []string
+		want        store.ModerationState
+	}{
+		{"changed content", store.ModerationStateApproved, "Changed", authorID, nil, store.ModerationStatePending},
+		{"comment without state", "", "Changed", authorID, nil, store.ModerationStatePending},
+		{"same content", store.ModerationStateApproved, "Original", authorID, nil, store.ModerationStateApproved},
+		{"moderator", store.ModerationStateApproved, "Changed", uuid.New(), []string{auth.PermissionModerate}, store.ModerationStateApproved},
+		{"rejected comment", store.ModerationStateRejected, "Changed", authorID, nil, store.ModerationStateRejected},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, r, engine, _ := setupServer(t, api.WithCommentModeration(store.ModerationPolicyAll, 0))
+			defer server.Close()
+
+			post := &store.Post{ID: uuid.New(), AuthorID: uuid.New(), Title: "Test Post", Published: true}
+			require.NoError(t, engine.SetPost(t.Context(), post))
+			comment := &store.Comment{ID: uuid.New(), AuthorID: authorID, PostID: post.ID, Content: "Original", State: tt.state}
+			require.NoError(t, engine.SetComment(t.Context(), comment))
+
+			jsonData, err := json.Marshal(api.CommentUpdate{Content: testutil.Ptr(tt.content)})
+			require.NoError(t, err)
+			req := httptest.NewRequest(
+				http.MethodPut,
+				fmt.Sprintf("/posts/%s/comments/%s", post.ID, comment.ID),
+				bytes.NewBuffer(jsonData),
+			)
+			req.Header.Set("content-type", "application/json")
+			req = userIDContext(req, tt.userID, tt.permissions...)
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+
+			assert.Equal(t, http.StatusOK, rr.Result().StatusCode)
+			dbComment, err := engine.LookupComment(t.Context(), post.ID, comment.ID)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, dbComment.State)
+			assert.Equal(t, tt.content, dbComment.Content)
+		})
+	}
+}
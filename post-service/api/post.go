@@ -1,11 +1,18 @@
 package api
 
 import (
+	"context"
+	"errors"
 	"net/http"
+	"net/url"
+	"path"
 
 	"github.com/chrishrb/blog-microservice/internal/api_utils"
 	"github.com/chrishrb/blog-microservice/internal/auth"
+	"github.com/chrishrb/blog-microservice/post-service/authz"
+	"github.com/chrishrb/blog-microservice/post-service/slug"
 	"github.com/chrishrb/blog-microservice/post-service/store"
+	"github.com/chrishrb/blog-microservice/post-service/tag"
 	"github.com/go-chi/render"
 	"github.com/google/uuid"
 )
@@ -31,36 +38,74 @@ func (s *Server) CreatePost(w http.ResponseWriter, r *http.Request) {
 		Content:  req.Content,
 	}
 	if req.Tags != nil {
-		post.Tags = *req.Tags
+		post.Tags = tag.NormalizeAll(*req.Tags)
 	}
 	if req.Published != nil {
 		post.Published = *req.Published
 	} else {
 		post.Published = false
 	}
-	err = s.engine.SetPost(r.Context(), post)
+	if req.ContentFormat != nil {
+		post.ContentFormat, err = toContentFormat(*req.ContentFormat)
+		if err != nil {
+			_ = render.Render(w, r, api_utils.ErrInvalidRequest(err))
+			return
+		}
+	}
+	if req.CommentModeration != nil {
+		post.CommentModeration, err = toModerationPolicy(*req.CommentModeration)
+		if err != nil {
+			_ = render.Render(w, r, api_utils.ErrInvalidRequest(err))
+			return
+		}
+	}
+	if req.CategoryId != nil {
+		if ok := s.setPostCategory(w, r, post, *req.CategoryId); !ok {
+			return
+		}
+	}
+	if ok := s.setPostMedia(w, r, post, req.MediaIds, req.CoverMediaId); !ok {
+		return
+	}
+	post.PublishAt = req.PublishAt
+	post.UnpublishAt = req.UnpublishAt
+	if err := validateSchedule(post); err != nil {
+		_ = render.Render(w, r, api_utils.ErrInvalidRequest(err))
+		return
+	}
+	post.ApplySchedule(s.clock.Now())
+	if err := renderPostContent(post); err != nil {
+		_ = render.Render(w, r, api_utils.ErrInternalError(err))
+		return
+	}
+
+	err = s.setPostWithRevision(r.Context(), post, userID, nil)
 	if err != nil {
 		_ = render.Render(w, r, api_utils.ErrInternalError(err))
 		return
 	}
+	s.sitemap.Invalidate()
+	s.events.PostCreated(r.Context(), post)
 
+	w.Header().Set("ETag", api_utils.ETag(post.Version))
 	render.Status(r, http.StatusCreated)
-	_ = render.Render(w, r, &Post{
-		Id:        ID,
-		AuthorId:  post.AuthorID,
-		Title:     post.Title,
-		Content:   post.Content,
-		Tags:      &post.Tags,
-		Published: post.Published,
-	})
+	_ = render.Render(w, r, toPost(post))
 }
 
 func (s *Server) DeletePost(w http.ResponseWriter, r *http.Request, id uuid.UUID) {
-	err := s.engine.DeletePost(r.Context(), id)
-	if err != nil {
+	// Check if the post exists
+	post, ok := s.lookupEditablePost(w, r, id)
+	if !ok {
+		return
+	}
+
+	// Afterwards move the post to the trash
+	if err := s.engine.TrashPost(r.Context(), id); err != nil {
 		_ = render.Render(w, r, api_utils.ErrInternalError(err))
 		return
 	}
+	s.sitemap.Invalidate()
+	s.events.PostDeleted(r.Context(), post)
 	w.WriteHeader(http.StatusNoContent)
 }
 
@@ -70,34 +115,65 @@ func (s *Server) LookupPost(w http.ResponseWriter, r *http.Request, id uuid.UUID
 		_ = render.Render(w, r, api_utils.ErrInternalError(err))
 		return
 	}
-	if post == nil {
+	// Drafts of other authors are reported as missing to not leak them
+	if post == nil || authz.CanRead(authz.CallerFromContext(r.Context()), post) != nil {
 		_ = render.Render(w, r, api_utils.ErrNotFound)
 		return
 	}
 
-	_ = render.Render(w, r, &Post{
-		Id:        post.ID,
-		AuthorId:  post.AuthorID,
-		Title:     post.Title,
-		Content:   post.Content,
-		Tags:      &post.Tags,
-		Published: post.Published,
-	})
+	res := toPost(post)
+	if err := s.enrichPosts(r.Context(), res); err != nil {
+		_ = render.Render(w, r, api_utils.ErrInternalError(err))
+		return
+	}
+	w.Header().Set("ETag", api_utils.ETag(post.Version))
+	_ = render.Render(w, r, res)
 }
 
-func (s *Server) UpdatePost(w http.ResponseWriter, r *http.Request, id uuid.UUID) {
-	// Check if the post exists
-	post, err := s.engine.LookupPost(r.Context(), id)
+func (s *Server) LookupPostBySlug(w http.ResponseWriter, r *http.Request, slug string) {
+	post, err := s.engine.LookupPostBySlug(r.Context(), slug)
 	if err != nil {
 		_ = render.Render(w, r, api_utils.ErrInternalError(err))
 		return
 	}
-	if post == nil {
+	// Drafts of other authors are reported as missing to not leak them
+	if post == nil || authz.CanRead(authz.CallerFromContext(r.Context()), post) != nil {
 		_ = render.Render(w, r, api_utils.ErrNotFound)
 		return
 	}
 
+	// Former slugs redirect to the current one
+	if post.Slug != slug {
+		w.Header().Set("Location", path.Join(path.Dir(r.URL.Path), url.PathEscape(post.Slug)))
+		render.Status(r, http.StatusMovedPermanently)
+		_ = render.Render(w, r, &PostSlugRedirect{Slug: post.Slug})
+		return
+	}
+
+	res := toPost(post)
+	if err := s.enrichPosts(r.Context(), res); err != nil {
+		_ = render.Render(w, r, api_utils.ErrInternalError(err))
+		return
+	}
+	w.Header().Set("ETag", api_utils.ETag(post.Version))
+	_ = render.Render(w, r, res)
+}
+
+func (s *Server) UpdatePost(w http.ResponseWriter, r *http.Request, id uuid.UUID, params UpdatePostParams) {
+	// Check if the post exists
+	post, ok := s.lookupEditablePost(w, r, id)
+	if !ok {
+		return
+	}
+	if errResponse := api_utils.CheckIfMatch(r, post.Version); errResponse != nil {
+		_ = render.Render(w, r, errResponse)
+		return
+	}
+
 	// Afterwards update the post
+	var err error
+	caller := authz.CallerFromContext(r.Context())
+	wasPublished := post.Published
 	req := new(PostUpdate)
 	if err := render.Bind(r, req); err != nil {
 		_ = render.Render(w, r, api_utils.ErrInvalidRequest(err))
@@ -105,53 +181,259 @@ func (s *Server) UpdatePost(w http.ResponseWriter, r *http.Request, id uuid.UUID
 	}
 
 	if req.Title != nil {
-		post.Title = *req.Title
+		setTitle(post, *req.Title)
 	}
 	if req.Content != nil {
 		post.Content = *req.Content
 	}
+	if req.ContentFormat != nil {
+		post.ContentFormat, err = toContentFormat(*req.ContentFormat)
+		if err != nil {
+			_ = render.Render(w, r, api_utils.ErrInvalidRequest(err))
+			return
+		}
+	}
+	if req.CommentModeration != nil {
+		post.CommentModeration, err = toModerationPolicy(*req.CommentModeration)
+		if err != nil {
+			_ = render.Render(w, r, api_utils.ErrInvalidRequest(err))
+			return
+		}
+	}
 	if req.Tags != nil {
-		post.Tags = *req.Tags
+		post.Tags = tag.NormalizeAll(*req.Tags)
+	}
+	// The nil UUID removes the post from its category
+	if req.CategoryId != nil {
+		if *req.CategoryId == uuid.Nil {
+			post.CategoryID = nil
+		} else if ok := s.setPostCategory(w, r, post, *req.CategoryId); !ok {
+			return
+		}
 	}
+	if ok := s.setPostMedia(w, r, post, req.MediaIds, req.CoverMediaId); !ok {
+		return
+	}
+	// Publishing or unpublishing by hand overrides the schedule
 	if req.Published != nil {
 		post.Published = *req.Published
+		post.PublishAt = nil
+		post.UnpublishAt = nil
+	}
+	if req.PublishAt != nil {
+		post.PublishAt = req.PublishAt
+	}
+	if req.UnpublishAt != nil {
+		post.UnpublishAt = req.UnpublishAt
+	}
+	if err := validateSchedule(post); err != nil {
+		_ = render.Render(w, r, api_utils.ErrInvalidRequest(err))
+		return
+	}
+	post.ApplySchedule(s.clock.Now())
+	if err := renderPostContent(post); err != nil {
+		_ = render.Render(w, r, api_utils.ErrInternalError(err))
+		return
 	}
 
-	err = s.engine.SetPost(r.Context(), post)
+	err = s.setPostWithRevision(r.Context(), post, caller.UserID, nil)
+	if errors.Is(err, store.ErrVersionConflict) {
+		_ = render.Render(w, r, api_utils.ErrPreconditionFailed)
+		return
+	}
 	if err != nil {
 		_ = render.Render(w, r, api_utils.ErrInternalError(err))
 		return
 	}
+	s.sitemap.Invalidate()
+	s.events.PostUpdated(r.Context(), post, wasPublished)
 
-	_ = render.Render(w, r, &Post{
-		Id:        post.ID,
-		AuthorId:  post.AuthorID,
-		Title:     post.Title,
-		Content:   post.Content,
-		Tags:      &post.Tags,
-		Published: post.Published,
-	})
+	res := toPost(post)
+	if err := s.enrichPosts(r.Context(), res); err != nil {
+		_ = render.Render(w, r, api_utils.ErrInternalError(err))
+		return
+	}
+	w.Header().Set("ETag", api_utils.ETag(post.Version))
+	_ = render.Render(w, r, res)
 }
 
 func (s *Server) ListPosts(w http.ResponseWriter, r *http.Request, params ListPostsParams) {
-	offset, limit := api_utils.GetPaginationWithDefaults(params.Offset, params.Limit)
+	page, err := api_utils.GetPage[store.Cursor](params.Cursor, params.Offset, params.Limit)
+	if err != nil {
+		_ = render.Render(w, r, api_utils.ErrInvalidRequest(err))
+		return
+	}
 
-	posts, err := s.engine.ListPosts(r.Context(), offset, limit)
+	caller := authz.CallerFromContext(r.Context())
+	query := store.PostQuery{
+		AuthorID:    params.AuthorId,
+		Published:   params.Published,
+		Reader:      authz.ListingReader(caller),
+		CreatedFrom: params.CreatedFrom,
+		CreatedTo:   params.CreatedTo,
+		Page:        page,
+	}
+	if params.Mine != nil && *params.Mine {
+		if caller == nil {
+			_ = render.Render(w, r, api_utils.ErrUnauthorized)
+			return
+		}
+		query.AuthorID = &caller.UserID
+	}
+	if params.Tag != nil {
+		query.Tag = tag.Normalize(*params.Tag)
+	}
+	if params.CategoryId != nil {
+		query.CategoryIDs, err = s.lookupCategoryIDs(r.Context(), *params.CategoryId)
+		if err != nil {
+			_ = render.Render(w, r, api_utils.ErrInternalError(err))
+			return
+		}
+	}
+	if params.Sort != nil {
+		switch *params.Sort {
+		case UpdatedAt:
+			query.Sort = store.PostSortUpdatedAt
+		case Title:
+			query.Sort = store.PostSortTitle
+		}
+	}
+	if params.Order != nil && *params.Order == Desc {
+		query.Descending = true
+	}
+
+	posts, err := s.engine.ListPosts(r.Context(), query)
 	if err != nil {
 		_ = render.Render(w, r, api_utils.ErrInternalError(err))
 		return
 	}
+	posts, nextCursor := api_utils.NextPage(posts, page, query.Cursor)
 
-	res := make([]render.Renderer, len(posts))
+	res := &PostList{
+		Items:      make([]Post, len(posts)),
+		NextCursor: nextCursor,
+	}
+	items := make([]*Post, len(posts))
 	for i, p := range posts {
-		res[i] = &Post{
-			Id:        p.ID,
-			AuthorId:  p.AuthorID,
-			Title:     p.Title,
-			Content:   p.Content,
-			Published: p.Published,
+		res.Items[i] = *toPost(p)
+		items[i] = &res.Items[i]
+	}
+	if err := s.enrichPosts(r.Context(), items...); err != nil {
+		_ = render.Render(w, r, api_utils.ErrInternalError(err))
+		return
+	}
+
+	_ = render.Render(w, r, res)
+}
+
+// lookupEditablePost looks up a post the caller is about to change or whose
+// editing history they request, both of which require that they may modify
+// it. Drafts the caller may not read are reported as missing with 404,
+// other posts they may not modify with 403. It renders the error response
+// and returns false if the request may not proceed.
+func (s *Server) lookupEditablePost(w http.ResponseWriter, r *http.Request, id uuid.UUID) (*store.Post, bool) {
+	post, err := s.engine.LookupPost(r.Context(), id)
+	if err != nil {
+		_ = render.Render(w, r, api_utils.ErrInternalError(err))
+		return nil, false
+	}
+	if post == nil {
+		_ = render.Render(w, r, api_utils.ErrNotFound)
+		return nil, false
+	}
+	caller := authz.CallerFromContext(r.Context())
+	if err := authz.CanModify(caller, post.AuthorID); err != nil {
+		// Drafts of other authors are reported as missing to not leak them
+		if authz.CanRead(caller, post) != nil {
+			_ = render.Render(w, r, api_utils.ErrNotFound)
+		} else {
+			_ = render.Render(w, r, api_utils.ErrForbidden)
 		}
+		return nil, false
+	}
+	return post, true
+}
+
+// enrichPosts sets the reactions and the series navigation of the posts as
+// seen by the caller.
+func (s *Server) enrichPosts(ctx context.Context, posts ...*Post) error {
+	if err := s.setPostReactions(ctx, posts...); err != nil {
+		return err
+	}
+	return s.setPostSeries(ctx, posts...)
+}
+
+// setPostCategory files the post in the category, which must exist. It
+// renders the error response and returns false if the request may not
+// proceed.
+func (s *Server) setPostCategory(w http.ResponseWriter, r *http.Request, post *store.Post, categoryID uuid.UUID) bool {
+	err := s.checkCategory(r.Context(), categoryID)
+	if errors.Is(err, errUnknownCategory) {
+		_ = render.Render(w, r, api_utils.ErrInvalidRequest(err))
+		return false
+	}
+	if err != nil {
+		_ = render.Render(w, r, api_utils.ErrInternalError(err))
+		return false
 	}
+	post.CategoryID = &categoryID
+	return true
+}
+
+// errInvalidSchedule is returned if a post would be unpublished before it is
+// published.
+var errInvalidSchedule = errors.New("unpublishAt must be after publishAt")
+
+func validateSchedule(post *store.Post) error {
+	if post.PublishAt != nil && post.UnpublishAt != nil && !post.UnpublishAt.After(*post.PublishAt) {
+		return errInvalidSchedule
+	}
+	return nil
+}
+
+// setTitle changes the title of the post. A new title moves the post to a
+// new slug, the store keeps the former one as a redirect.
+func setTitle(post *store.Post, title string) {
+	if title == post.Title {
+		return
+	}
+	post.Title = title
+	post.Slug = slug.Make(title)
+}
 
-	_ = render.RenderList(w, r, res)
+func toPost(post *store.Post) *Post {
+	format := ContentFormat(post.ContentFormat)
+	if format == "" {
+		format = Markdown
+	}
+	res := &Post{
+		Id:            post.ID,
+		AuthorId:      post.AuthorID,
+		Title:         post.Title,
+		Slug:          post.Slug,
+		Content:       post.Content,
+		ContentFormat: format,
+		ContentHtml:   postContentHTML(post),
+		Tags:          &post.Tags,
+		Published:     post.Published,
+		PublishAt:     post.PublishAt,
+		UnpublishAt:   post.UnpublishAt,
+		PublishedAt:   post.PublishedAt,
+		Reactions:     ReactionCounts{},
+		Version:       post.Version,
+	}
+	if post.CommentModeration != "" {
+		res.CommentModeration = (*ModerationPolicy)(&post.CommentModeration)
+	}
+	// The title and navigation of the series are set by enrichPosts
+	if post.SeriesID != nil {
+		res.Series = &PostSeries{Id: *post.SeriesID, Position: post.SeriesPosition}
+	}
+	res.CategoryId = post.CategoryID
+	if post.MediaIDs != nil {
+		res.MediaIds = &post.MediaIDs
+	}
+	res.CoverMediaId = post.CoverMediaID
+	res.DeletedAt = post.DeletedAt
+	return res
 }
@@ -7,7 +7,9 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
+	"github.com/chrishrb/blog-microservice/internal/api_utils"
 	"github.com/chrishrb/blog-microservice/internal/testutil"
 	"github.com/chrishrb/blog-microservice/post-service/api"
 	"github.com/chrishrb/blog-microservice/post-service/store"
@@ -50,6 +52,7 @@ func TestCreatePost(t *testing.T) {
 	assert.NotEmpty(t, res.Id)
 	assert.Equal(t, userID, res.AuthorId)
 	assert.Equal(t, "someTitle", res.Title)
+	assert.Equal(t, "sometitle", res.Slug)
 	assert.Equal(t, "someContent", res.Content)
 	assert.Equal(t, testutil.Ptr([]string{"tag1", "tag2"}), res.Tags)
 	assert.True(t, res.Published)
@@ -102,6 +105,80 @@ func TestDeletePost(t *testing.T) {
 	assert.Nil(t, dbPost)
 }
 
+func TestDeletePost_Authorization(t *testing.T) {
+	authorID := uuid.New()
+	for _, tt := range authorizationTests(authorID, http.StatusNoContent) {
+		t.Run(tt.name, func(t *testing.T) {
+			server, r, engine, _ := setupServer(t)
+			defer server.Close()
+
+			post := &store.Post{
+				ID:        uuid.New(),
+				AuthorID:  authorID,
+				Title:     "someTitle",
+				Content:   "someContent",
+				Published: true,
+			}
+			require.NoError(t, engine.SetPost(t.Context(), post))
+
+			req := httptest.NewRequest(
+				http.MethodDelete,
+				fmt.Sprintf("/posts/%s", post.ID),
+				nil,
+			)
+			req = userIDContext(req, tt.userID, tt.permissions...)
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.want, rr.Result().StatusCode)
+
+			// Forbidden requests leave the post untouched
+			dbPost, err := engine.LookupPost(t.Context(), post.ID)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want == http.StatusForbidden, dbPost != nil)
+		})
+	}
+}
+
+func TestDeletePost_NotFound(t *testing.T) {
+	server, r, _, _ := setupServer(t)
+	defer server.Close()
+
+	req := httptest.NewRequest(
+		http.MethodDelete,
+		fmt.Sprintf("/posts/%s", uuid.New()),
+		nil,
+	)
+	req = userIDContext(req, uuid.New())
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusNotFound, rr.Result().StatusCode)
+}
+
+func TestDeletePost_DraftOfOtherAuthor(t *testing.T) {
+	server, r, engine, _ := setupServer(t)
+	defer server.Close()
+
+	// Drafts of other authors are reported as missing to not leak them
+	draft := &store.Post{ID: uuid.New(), AuthorID: uuid.New(), Title: "Draft"}
+	require.NoError(t, engine.SetPost(t.Context(), draft))
+
+	req := httptest.NewRequest(
+		http.MethodDelete,
+		fmt.Sprintf("/posts/%s", draft.ID),
+		nil,
+	)
+	req = userIDContext(req, uuid.New())
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusNotFound, rr.Result().StatusCode)
+	dbPost, err := engine.LookupPost(t.Context(), draft.ID)
+	require.NoError(t, err)
+	assert.NotNil(t, dbPost)
+}
+
 func TestLookupPost(t *testing.T) {
 	server, r, engine, _ := setupServer(t)
 	defer server.Close()
@@ -161,6 +238,95 @@ func TestLookupPost_NotFound(t *testing.T) {
 	assert.Equal(t, http.StatusNotFound, rr.Result().StatusCode)
 }
 
+func TestLookupPostBySlug(t *testing.T) {
+	server, r, engine, _ := setupServer(t)
+	defer server.Close()
+
+	post := &store.Post{
+		ID:        uuid.New(),
+		AuthorID:  uuid.New(),
+		Title:     "Crème Brûlée",
+		Content:   "someContent",
+		Published: true,
+	}
+	require.NoError(t, engine.SetPost(t.Context(), post))
+
+	req := httptest.NewRequest(http.MethodGet, "/posts/by-slug/creme-brulee", nil)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Result().StatusCode)
+	var res api.Post
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&res))
+	assert.Equal(t, post.ID, res.Id)
+	assert.Equal(t, "creme-brulee", res.Slug)
+}
+
+func TestLookupPostBySlug_Redirect(t *testing.T) {
+	server, r, engine, _ := setupServer(t)
+	defer server.Close()
+
+	authorID := uuid.New()
+	post := &store.Post{ID: uuid.New(), AuthorID: authorID, Title: "Old Title", Published: true}
+	require.NoError(t, engine.SetPost(t.Context(), post))
+
+	// Changing the title moves the post to a new slug
+	jsonData, err := json.Marshal(api.PostUpdate{Title: testutil.Ptr("New Title")})
+	require.NoError(t, err)
+	req := httptest.NewRequest(http.MethodPut, fmt.Sprintf("/posts/%s", post.ID), bytes.NewBuffer(jsonData))
+	req.Header.Set("content-type", "application/json")
+	req.Header.Set("If-Match", api_utils.ETag(post.Version))
+	req = userIDContext(req, authorID)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusOK, rr.Result().StatusCode)
+
+	req = httptest.NewRequest(http.MethodGet, "/posts/by-slug/old-title", nil)
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusMovedPermanently, rr.Result().StatusCode)
+	assert.Equal(t, "/posts/by-slug/new-title", rr.Result().Header.Get("Location"))
+	var res api.PostSlugRedirect
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&res))
+	assert.Equal(t, "new-title", res.Slug)
+}
+
+func TestLookupPostBySlug_NotFound(t *testing.T) {
+	server, r, _, _ := setupServer(t)
+	defer server.Close()
+
+	req := httptest.NewRequest(http.MethodGet, "/posts/by-slug/missing", nil)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusNotFound, rr.Result().StatusCode)
+}
+
+func TestLookupPostBySlug_Draft(t *testing.T) {
+	server, r, engine, _ := setupServer(t)
+	defer server.Close()
+
+	authorID := uuid.New()
+	draft := &store.Post{ID: uuid.New(), AuthorID: authorID, Title: "Draft"}
+	require.NoError(t, engine.SetPost(t.Context(), draft))
+
+	for _, tt := range visibilityTests(authorID) {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/posts/by-slug/draft", nil)
+			req = tt.context(req)
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+
+			if tt.visible {
+				assert.Equal(t, http.StatusOK, rr.Result().StatusCode)
+			} else {
+				assert.Equal(t, http.StatusNotFound, rr.Result().StatusCode)
+			}
+		})
+	}
+}
+
 func TestUpdatePost(t *testing.T) {
 	server, r, engine, _ := setupServer(t)
 	defer server.Close()
@@ -196,6 +362,7 @@ func TestUpdatePost(t *testing.T) {
 		bytes.NewBuffer(jsonData),
 	)
 	req.Header.Set("content-type", "application/json")
+	req.Header.Set("If-Match", api_utils.ETag(post.Version))
 	req = userIDContext(req, userID)
 	rr := httptest.NewRecorder()
 	r.ServeHTTP(rr, req)
@@ -208,6 +375,7 @@ func TestUpdatePost(t *testing.T) {
 	// Check the response
 	assert.Equal(t, post.ID, res.Id)
 	assert.Equal(t, "Updated Title", res.Title)
+	assert.Equal(t, "updated-title", res.Slug)
 	assert.Equal(t, "Updated Content", res.Content)
 	assert.Equal(t, testutil.Ptr([]string{"updated", "tags"}), res.Tags)
 	assert.True(t, res.Published)
@@ -222,6 +390,52 @@ func TestUpdatePost(t *testing.T) {
 	assert.True(t, dbPost.Published)
 }
 
+func TestUpdatePost_Authorization(t *testing.T) {
+	authorID := uuid.New()
+	for _, tt := range authorizationTests(authorID, http.StatusOK) {
+		t.Run(tt.name, func(t *testing.T) {
+			server, r, engine, _ := setupServer(t)
+			defer server.Close()
+
+			post := &store.Post{
+				ID:        uuid.New(),
+				AuthorID:  authorID,
+				Title:     "someTitle",
+				Content:   "someContent",
+				Published: true,
+			}
+			require.NoError(t, engine.SetPost(t.Context(), post))
+
+			jsonData, err := json.Marshal(api.PostUpdate{
+				Title: testutil.Ptr("Updated Title"),
+			})
+			require.NoError(t, err)
+			req := httptest.NewRequest(
+				http.MethodPut,
+				fmt.Sprintf("/posts/%s", post.ID),
+				bytes.NewBuffer(jsonData),
+			)
+			req.Header.Set("content-type", "application/json")
+			req.Header.Set("If-Match", api_utils.ETag(post.Version))
+			req = userIDContext(req, tt.userID, tt.permissions...)
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.want, rr.Result().StatusCode)
+
+			// Forbidden requests leave the post untouched, overrides keep the author
+			dbPost, err := engine.LookupPost(t.Context(), post.ID)
+			require.NoError(t, err)
+			if tt.want == http.StatusForbidden {
+				assert.Equal(t, "someTitle", dbPost.Title)
+			} else {
+				assert.Equal(t, "Updated Title", dbPost.Title)
+			}
+			assert.Equal(t, authorID, dbPost.AuthorID)
+		})
+	}
+}
+
 func TestUpdatePost_NotFound(t *testing.T) {
 	server, r, _, _ := setupServer(t)
 	defer server.Close()
@@ -244,6 +458,86 @@ func TestUpdatePost_NotFound(t *testing.T) {
 	assert.Equal(t, http.StatusNotFound, rr.Result().StatusCode)
 }
 
+func TestUpdatePost_DraftOfOtherAuthor(t *testing.T) {
+	server, r, engine, _ := setupServer(t)
+	defer server.Close()
+
+	// Drafts of other authors are reported as missing to not leak them
+	draft := &store.Post{ID: uuid.New(), AuthorID: uuid.New(), Title: "Draft"}
+	require.NoError(t, engine.SetPost(t.Context(), draft))
+
+	jsonData, err := json.Marshal(api.PostUpdate{
+		Title: testutil.Ptr("Updated Title"),
+	})
+	require.NoError(t, err)
+	req := httptest.NewRequest(
+		http.MethodPut,
+		fmt.Sprintf("/posts/%s", draft.ID),
+		bytes.NewBuffer(jsonData),
+	)
+	req.Header.Set("content-type", "application/json")
+	req.Header.Set("If-Match", api_utils.ETag(draft.Version))
+	req = userIDContext(req, uuid.New())
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusNotFound, rr.Result().StatusCode)
+	dbPost, err := engine.LookupPost(t.Context(), draft.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Draft", dbPost.Title)
+}
+
+func TestUpdatePost_Precondition(t *testing.T) {
+	server, r, engine, _ := setupServer(t)
+	defer server.Close()
+
+	authorID := uuid.New()
+	post := &store.Post{ID: uuid.New(), AuthorID: authorID, Title: "someTitle", Published: true}
+	require.NoError(t, engine.SetPost(t.Context(), post))
+
+	// Reads return the current version as ETag
+	req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/posts/%s", post.ID), nil)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusOK, rr.Result().StatusCode)
+	etag := rr.Header().Get("ETag")
+	assert.Equal(t, `"1"`, etag)
+	var res api.Post
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&res))
+	assert.Equal(t, int64(1), res.Version)
+
+	update := func(title, ifMatch string) *httptest.ResponseRecorder {
+		jsonData, err := json.Marshal(api.PostUpdate{Title: testutil.Ptr(title)})
+		require.NoError(t, err)
+		req := httptest.NewRequest(http.MethodPut, fmt.Sprintf("/posts/%s", post.ID), bytes.NewBuffer(jsonData))
+		req.Header.Set("content-type", "application/json")
+		if ifMatch != "" {
+			req.Header.Set("If-Match", ifMatch)
+		}
+		req = userIDContext(req, authorID)
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		return rr
+	}
+
+	// Updates without a precondition are rejected
+	rr = update("Missing", "")
+	assert.Equal(t, http.StatusPreconditionRequired, rr.Result().StatusCode)
+
+	rr = update("First", etag)
+	require.Equal(t, http.StatusOK, rr.Result().StatusCode)
+	assert.Equal(t, `"2"`, rr.Header().Get("ETag"))
+
+	// A second update based on the same version lost the race
+	rr = update("Second", etag)
+	assert.Equal(t, http.StatusPreconditionFailed, rr.Result().StatusCode)
+
+	dbPost, err := engine.LookupPost(t.Context(), post.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "First", dbPost.Title)
+	assert.Equal(t, int64(2), dbPost.Version)
+}
+
 func TestListPosts(t *testing.T) {
 	server, r, engine, _ := setupServer(t)
 	defer server.Close()
@@ -275,20 +569,23 @@ func TestListPosts(t *testing.T) {
 	err = engine.SetPost(t.Context(), post2)
 	require.NoError(t, err)
 
-	// List posts
+	// List posts, the author sees their drafts
 	req := httptest.NewRequest(
 		http.MethodGet,
 		"/posts",
 		nil,
 	)
+	req = userIDContext(req, userID)
 	rr := httptest.NewRecorder()
 	r.ServeHTTP(rr, req)
 
 	assert.Equal(t, http.StatusOK, rr.Result().StatusCode)
-	var resList []api.Post
-	err = json.NewDecoder(rr.Body).Decode(&resList)
+	var res api.PostList
+	err = json.NewDecoder(rr.Body).Decode(&res)
 
 	require.NoError(t, err)
+	assert.Nil(t, res.NextCursor)
+	resList := res.Items
 	require.Equal(t, len(resList), 2)
 
 	// Check that our created posts exist in the response
@@ -299,3 +596,333 @@ func TestListPosts(t *testing.T) {
 	assert.Contains(t, ids, post1.ID)
 	assert.Contains(t, ids, post2.ID)
 }
+
+func TestListPosts_Cursor(t *testing.T) {
+	server, r, engine, _ := setupServer(t)
+	defer server.Close()
+
+	for range 3 {
+		err := engine.SetPost(t.Context(), &store.Post{
+			ID:        uuid.New(),
+			AuthorID:  uuid.New(),
+			Title:     "someTitle",
+			Content:   "someContent",
+			Published: true,
+		})
+		require.NoError(t, err)
+	}
+
+	// First page
+	req := httptest.NewRequest(http.MethodGet, "/posts?limit=2", nil)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Result().StatusCode)
+	var res api.PostList
+	err := json.NewDecoder(rr.Body).Decode(&res)
+	require.NoError(t, err)
+	require.Len(t, res.Items, 2)
+	require.NotNil(t, res.NextCursor)
+	ids := []uuid.UUID{res.Items[0].Id, res.Items[1].Id}
+
+	// Second and last page
+	req = httptest.NewRequest(http.MethodGet, "/posts?limit=2&cursor="+*res.NextCursor, nil)
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Result().StatusCode)
+	res = api.PostList{}
+	err = json.NewDecoder(rr.Body).Decode(&res)
+	require.NoError(t, err)
+	require.Len(t, res.Items, 1)
+	assert.Nil(t, res.NextCursor)
+	assert.NotContains(t, ids, res.Items[0].Id)
+
+	// Offset past the end
+	req = httptest.NewRequest(http.MethodGet, "/posts?offset=10", nil)
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Result().StatusCode)
+	res = api.PostList{}
+	err = json.NewDecoder(rr.Body).Decode(&res)
+	require.NoError(t, err)
+	assert.Empty(t, res.Items)
+	assert.Nil(t, res.NextCursor)
+
+	// Invalid cursor
+	req = httptest.NewRequest(http.MethodGet, "/posts?cursor=invalid", nil)
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Result().StatusCode)
+}
+
+func TestListPosts_FilterAndSort(t *testing.T) {
+	server, r, engine, _ := setupServer(t)
+	defer server.Close()
+
+	authorID := uuid.New()
+	posts := []*store.Post{
+		{ID: uuid.New(), AuthorID: authorID, Title: "Apple", Tags: []string{"fruit"}, Published: true},
+		{ID: uuid.New(), AuthorID: authorID, Title: "Cherry", Tags: []string{"fruit"}, Published: true},
+		{ID: uuid.New(), AuthorID: authorID, Title: "Banana", Tags: []string{"fruit"}, Published: false},
+		{ID: uuid.New(), AuthorID: uuid.New(), Title: "Carrot", Tags: []string{"vegetable"}, Published: true},
+	}
+	for _, post := range posts {
+		require.NoError(t, engine.SetPost(t.Context(), post))
+	}
+
+	req := httptest.NewRequest(
+		http.MethodGet,
+		fmt.Sprintf("/posts?tag=fruit&authorId=%s&published=true&sort=title&order=desc", authorID),
+		nil,
+	)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Result().StatusCode)
+	var res api.PostList
+	err := json.NewDecoder(rr.Body).Decode(&res)
+	require.NoError(t, err)
+	require.Len(t, res.Items, 2)
+	assert.Equal(t, "Cherry", res.Items[0].Title)
+	assert.Equal(t, "Apple", res.Items[1].Title)
+}
+
+func TestLookupPost_Draft(t *testing.T) {
+	server, r, engine, _ := setupServer(t)
+	defer server.Close()
+
+	authorID := uuid.New()
+	draft := &store.Post{ID: uuid.New(), AuthorID: authorID, Title: "Draft"}
+	require.NoError(t, engine.SetPost(t.Context(), draft))
+
+	for _, tt := range visibilityTests(authorID) {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/posts/%s", draft.ID), nil)
+			req = tt.context(req)
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+
+			if tt.visible {
+				assert.Equal(t, http.StatusOK, rr.Result().StatusCode)
+			} else {
+				assert.Equal(t, http.StatusNotFound, rr.Result().StatusCode)
+			}
+		})
+	}
+}
+
+func TestListPosts_Drafts(t *testing.T) {
+	server, r, engine, _ := setupServer(t)
+	defer server.Close()
+
+	authorID := uuid.New()
+	published := &store.Post{ID: uuid.New(), AuthorID: authorID, Title: "Published", Published: true}
+	draft := &store.Post{ID: uuid.New(), AuthorID: authorID, Title: "Draft"}
+	otherDraft := &store.Post{ID: uuid.New(), AuthorID: uuid.New(), Title: "Other draft"}
+	for _, post := range []*store.Post{published, draft, otherDraft} {
+		require.NoError(t, engine.SetPost(t.Context(), post))
+	}
+
+	for _, tt := range visibilityTests(authorID) {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/posts", nil)
+			req = tt.context(req)
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+
+			assert.Equal(t, http.StatusOK, rr.Result().StatusCode)
+			var res api.PostList
+			require.NoError(t, json.NewDecoder(rr.Body).Decode(&res))
+			var ids []uuid.UUID
+			for _, post := range res.Items {
+				ids = append(ids, post.Id)
+			}
+
+			assert.Contains(t, ids, published.ID)
+			if tt.visible {
+				assert.Contains(t, ids, draft.ID)
+			} else {
+				assert.NotContains(t, ids, draft.ID)
+			}
+		})
+	}
+}
+
+func TestListPosts_Mine(t *testing.T) {
+	server, r, engine, _ := setupServer(t)
+	defer server.Close()
+
+	authorID := uuid.New()
+	published := &store.Post{ID: uuid.New(), AuthorID: authorID, Title: "Published", Published: true}
+	draft := &store.Post{ID: uuid.New(), AuthorID: authorID, Title: "Draft"}
+	other := &store.Post{ID: uuid.New(), AuthorID: uuid.New(), Title: "Other", Published: true}
+	for _, post := range []*store.Post{published, draft, other} {
+		require.NoError(t, engine.SetPost(t.Context(), post))
+	}
+
+	// Drafts of the author
+	req := httptest.NewRequest(http.MethodGet, "/posts?mine=true&published=false", nil)
+	req = userIDContext(req, authorID)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Result().StatusCode)
+	var res api.PostList
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&res))
+	require.Len(t, res.Items, 1)
+	assert.Equal(t, draft.ID, res.Items[0].Id)
+
+	// All posts of the author
+	req = httptest.NewRequest(http.MethodGet, "/posts?mine=true", nil)
+	req = userIDContext(req, authorID)
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Result().StatusCode)
+	res = api.PostList{}
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&res))
+	assert.Len(t, res.Items, 2)
+
+	// Anonymous readers have no posts
+	req = httptest.NewRequest(http.MethodGet, "/posts?mine=true", nil)
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusUnauthorized, rr.Result().StatusCode)
+}
+
+func TestCreatePost_Schedule(t *testing.T) {
+	server, r, engine, c := setupServer(t)
+	defer server.Close()
+
+	publishAt := c.Now().Add(time.Hour).Truncate(time.Second)
+	unpublishAt := publishAt.Add(24 * time.Hour)
+	d := api.PostCreate{
+		Title:       "someTitle",
+		Content:     "someContent",
+		PublishAt:   &publishAt,
+		UnpublishAt: &unpublishAt,
+	}
+	jsonData, err := json.Marshal(d)
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, "/posts", bytes.NewBuffer(jsonData))
+	req.Header.Set("content-type", "application/json")
+	req = userIDContext(req, uuid.New())
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusCreated, rr.Result().StatusCode)
+	var res api.Post
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&res))
+	assert.False(t, res.Published)
+	require.NotNil(t, res.PublishAt)
+	assert.True(t, publishAt.Equal(*res.PublishAt))
+	require.NotNil(t, res.UnpublishAt)
+	assert.True(t, unpublishAt.Equal(*res.UnpublishAt))
+
+	dbPost, err := engine.LookupPost(t.Context(), res.Id)
+	require.NoError(t, err)
+	assert.False(t, dbPost.Published)
+	assert.True(t, publishAt.Equal(*dbPost.PublishAt))
+}
+
+func TestCreatePost_ScheduleInThePast(t *testing.T) {
+	server, r, _, c := setupServer(t)
+	defer server.Close()
+
+	d := api.PostCreate{
+		Title:     "someTitle",
+		Content:   "someContent",
+		PublishAt: testutil.Ptr(c.Now().Add(-time.Minute)),
+	}
+	jsonData, err := json.Marshal(d)
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, "/posts", bytes.NewBuffer(jsonData))
+	req.Header.Set("content-type", "application/json")
+	req = userIDContext(req, uuid.New())
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusCreated, rr.Result().StatusCode)
+	var res api.Post
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&res))
+	assert.True(t, res.Published)
+	assert.Nil(t, res.PublishAt)
+}
+
+func TestCreatePost_InvalidSchedule(t *testing.T) {
+	server, r, _, c := setupServer(t)
+	defer server.Close()
+
+	publishAt := c.Now().Add(time.Hour)
+	d := api.PostCreate{
+		Title:       "someTitle",
+		Content:     "someContent",
+		PublishAt:   &publishAt,
+		UnpublishAt: testutil.Ptr(publishAt.Add(-time.Minute)),
+	}
+	jsonData, err := json.Marshal(d)
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, "/posts", bytes.NewBuffer(jsonData))
+	req.Header.Set("content-type", "application/json")
+	req = userIDContext(req, uuid.New())
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Result().StatusCode)
+}
+
+func TestUpdatePost_Schedule(t *testing.T) {
+	server, r, engine, c := setupServer(t)
+	defer server.Close()
+
+	authorID := uuid.New()
+	post := &store.Post{
+		ID:        uuid.New(),
+		AuthorID:  authorID,
+		Title:     "someTitle",
+		PublishAt: testutil.Ptr(c.Now().Add(time.Hour)),
+	}
+	require.NoError(t, engine.SetPost(t.Context(), post))
+	version := post.Version
+
+	update := func(d api.PostUpdate) *store.Post {
+		jsonData, err := json.Marshal(d)
+		require.NoError(t, err)
+		req := httptest.NewRequest(http.MethodPut, fmt.Sprintf("/posts/%s", post.ID), bytes.NewBuffer(jsonData))
+		req.Header.Set("content-type", "application/json")
+		req.Header.Set("If-Match", api_utils.ETag(version))
+		req = userIDContext(req, authorID)
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		require.Equal(t, http.StatusOK, rr.Result().StatusCode)
+
+		dbPost, err := engine.LookupPost(t.Context(), post.ID)
+		require.NoError(t, err)
+		version = dbPost.Version
+		return dbPost
+	}
+
+	// Other changes keep the schedule
+	dbPost := update(api.PostUpdate{Title: testutil.Ptr("newTitle")})
+	assert.False(t, dbPost.Published)
+	assert.NotNil(t, dbPost.PublishAt)
+
+	// Publishing by hand cancels the schedule
+	dbPost = update(api.PostUpdate{Published: testutil.Ptr(true)})
+	assert.True(t, dbPost.Published)
+	assert.Nil(t, dbPost.PublishAt)
+
+	// Scheduling the unpublishing of a published post
+	unpublishAt := c.Now().Add(2 * time.Hour)
+	dbPost = update(api.PostUpdate{UnpublishAt: &unpublishAt})
+	assert.True(t, dbPost.Published)
+	require.NotNil(t, dbPost.UnpublishAt)
+	assert.True(t, unpublishAt.Equal(*dbPost.UnpublishAt))
+}
@@ -0,0 +1,185 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+
+	"github.com/chrishrb/blog-microservice/internal/api_utils"
+	"github.com/chrishrb/blog-microservice/internal/auth"
+	"github.com/chrishrb/blog-microservice/post-service/authz"
+	"github.com/chrishrb/blog-microservice/post-service/store"
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+)
+
+// errInvalidReaction is returned for reactions that are not available.
+var errInvalidReaction = errors.New("reaction must be like or one of the configured emojis")
+
+func (s *Server) ListReactions(w http.ResponseWriter, r *http.Request) {
+	_ = render.Render(w, r, &ReactionList{Items: s.reactions})
+}
+
+func (s *Server) SetPostReaction(w http.ResponseWriter, r *http.Request, id uuid.UUID) {
+	if _, ok := s.readablePost(w, r, id); !ok {
+		return
+	}
+	s.setReaction(w, r, store.ReactionTargetPost, id)
+}
+
+func (s *Server) DeletePostReaction(w http.ResponseWriter, r *http.Request, id uuid.UUID) {
+	if _, ok := s.readablePost(w, r, id); !ok {
+		return
+	}
+	s.deleteReaction(w, r, store.ReactionTargetPost, id)
+}
+
+func (s *Server) SetCommentReaction(w http.ResponseWriter, r *http.Request, postId, id uuid.UUID) {
+	if !s.checkReactionComment(w, r, postId, id) {
+		return
+	}
+	s.setReaction(w, r, store.ReactionTargetComment, id)
+}
+
+func (s *Server) DeleteCommentReaction(w http.ResponseWriter, r *http.Request, postId, id uuid.UUID) {
+	if !s.checkReactionComment(w, r, postId, id) {
+		return
+	}
+	s.deleteReaction(w, r, store.ReactionTargetComment, id)
+}
+
+// checkReactionComment renders a 404 response unless the comment exists, is
+// not deleted and is visible to the caller together with its post. It
+// reports whether the request may proceed.
+func (s *Server) checkReactionComment(w http.ResponseWriter, r *http.Request, postID, ID uuid.UUID) bool {
+	if _, ok := s.readablePost(w, r, postID); !ok {
+		return false
+	}
+	comment, err := s.engine.LookupComment(r.Context(), postID, ID)
+	if err != nil {
+		_ = render.Render(w, r, api_utils.ErrInternalError(err))
+		return false
+	}
+	if comment == nil || comment.Deleted || authz.CanReadComment(authz.CallerFromContext(r.Context()), comment) != nil {
+		_ = render.Render(w, r, api_utils.ErrNotFound)
+		return false
+	}
+	return true
+}
+
+// setReaction sets the reaction of the caller to the target and renders the
+// resulting reactions.
+func (s *Server) setReaction(w http.ResponseWriter, r *http.Request, target store.ReactionTarget, targetID uuid.UUID) {
+	userID, err := auth.GetUserIDFromContext(r.Context())
+	if err != nil {
+		_ = render.Render(w, r, api_utils.ErrInternalError(err))
+		return
+	}
+
+	req := new(ReactionUpdate)
+	if err := render.Bind(r, req); err != nil {
+		_ = render.Render(w, r, api_utils.ErrInvalidRequest(err))
+		return
+	}
+	if !slices.Contains(s.reactions, req.Reaction) {
+		_ = render.Render(w, r, api_utils.ErrInvalidRequest(errInvalidReaction))
+		return
+	}
+
+	err = s.engine.SetReaction(r.Context(), &store.Reaction{
+		Target:   target,
+		TargetID: targetID,
+		UserID:   userID,
+		Kind:     req.Reaction,
+	})
+	if err != nil {
+		_ = render.Render(w, r, api_utils.ErrInternalError(err))
+		return
+	}
+
+	summaries, err := s.engine.SummarizeReactions(r.Context(), target, []uuid.UUID{targetID}, userID)
+	if err != nil {
+		_ = render.Render(w, r, api_utils.ErrInternalError(err))
+		return
+	}
+	res := &ReactionSummary{}
+	res.Reactions, res.MyReaction = toReactions(summaries[targetID])
+	_ = render.Render(w, r, res)
+}
+
+// deleteReaction removes the reaction of the caller to the target.
+func (s *Server) deleteReaction(w http.ResponseWriter, r *http.Request, target store.ReactionTarget, targetID uuid.UUID) {
+	userID, err := auth.GetUserIDFromContext(r.Context())
+	if err != nil {
+		_ = render.Render(w, r, api_utils.ErrInternalError(err))
+		return
+	}
+
+	err = s.engine.DeleteReaction(r.Context(), target, targetID, userID)
+	if err != nil {
+		_ = render.Render(w, r, api_utils.ErrInternalError(err))
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// setPostReactions sets the reactions to the posts as seen by the caller.
+func (s *Server) setPostReactions(ctx context.Context, posts ...*Post) error {
+	IDs := make([]uuid.UUID, len(posts))
+	for i, post := range posts {
+		IDs[i] = post.Id
+	}
+	summaries, err := s.engine.SummarizeReactions(ctx, store.ReactionTargetPost, IDs,
+		authz.Reader(authz.CallerFromContext(ctx)))
+	if err != nil {
+		return err
+	}
+	for _, post := range posts {
+		post.Reactions, post.MyReaction = toReactions(summaries[post.Id])
+	}
+	return nil
+}
+
+// setCommentReactions sets the reactions to the comments and their nested
+// replies as seen by the caller.
+func (s *Server) setCommentReactions(ctx context.Context, comments ...*Comment) error {
+	var all []*Comment
+	var collect func(comment *Comment)
+	collect = func(comment *Comment) {
+		all = append(all, comment)
+		if comment.Replies != nil {
+			for i := range *comment.Replies {
+				collect(&(*comment.Replies)[i])
+			}
+		}
+	}
+	for _, comment := range comments {
+		collect(comment)
+	}
+
+	IDs := make([]uuid.UUID, len(all))
+	for i, comment := range all {
+		IDs[i] = comment.Id
+	}
+	summaries, err := s.engine.SummarizeReactions(ctx, store.ReactionTargetComment, IDs,
+		authz.Reader(authz.CallerFromContext(ctx)))
+	if err != nil {
+		return err
+	}
+	for _, comment := range all {
+		comment.Reactions, comment.MyReaction = toReactions(summaries[comment.Id])
+	}
+	return nil
+}
+
+func toReactions(summary *store.ReactionSummary) (ReactionCounts, *string) {
+	if summary == nil {
+		return ReactionCounts{}, nil
+	}
+	var mine *string
+	if summary.Mine != "" {
+		mine = &summary.Mine
+	}
+	return summary.Counts, mine
+}
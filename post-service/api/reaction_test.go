@@ -0,0 +1,220 @@
+package api_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/chrishrb/blog-microservice/internal/testutil"
+	"github.com/chrishrb/blog-microservice/post-service/api"
+	"github.com/chrishrb/blog-microservice/post-service/store"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// react sets the reaction of the user with a request to the URL of the
+// reaction.
+func react(t *testing.T, r http.Handler, url string, userID uuid.UUID, reaction string) *httptest.ResponseRecorder {
+	t.Helper()
+	jsonData, err := json.Marshal(api.ReactionUpdate{Reaction: reaction})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPut, url, bytes.NewBuffer(jsonData))
+	req.Header.Set("content-type", "application/json")
+	req = userIDContext(req, userID)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	return rr
+}
+
+func TestListReactions(t *testing.T) {
+	server, r, _, _ := setupServer(t, api.WithReactions("🎉", "👀"))
+	defer server.Close()
+
+	req := httptest.NewRequest(http.MethodGet, "/reactions", nil)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Result().StatusCode)
+	var res api.ReactionList
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&res))
+	assert.Equal(t, []string{"like", "🎉", "👀"}, res.Items)
+}
+
+func TestSetPostReaction(t *testing.T) {
+	server, r, engine, _ := setupServer(t, api.WithReactions("🎉"))
+	defer server.Close()
+
+	userID := uuid.New()
+	post := &store.Post{ID: uuid.New(), AuthorID: uuid.New(), Title: "Test Post", Published: true}
+	require.NoError(t, engine.SetPost(t.Context(), post))
+	url := fmt.Sprintf("/posts/%s/reaction", post.ID)
+
+	// Reacting twice has no effect
+	for range 2 {
+		rr := react(t, r, url, userID, "like")
+		assert.Equal(t, http.StatusOK, rr.Result().StatusCode)
+		var res api.ReactionSummary
+		require.NoError(t, json.NewDecoder(rr.Body).Decode(&res))
+		assert.Equal(t, api.ReactionCounts{"like": 1}, res.Reactions)
+		assert.Equal(t, testutil.Ptr("like"), res.MyReaction)
+	}
+
+	// Another reaction replaces the previous one
+	require.Equal(t, http.StatusOK, react(t, r, url, userID, "🎉").Result().StatusCode)
+	require.Equal(t, http.StatusOK, react(t, r, url, uuid.New(), "🎉").Result().StatusCode)
+
+	t.Run("lookup", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/posts/%s", post.ID), nil)
+		req = userIDContext(req, userID)
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Result().StatusCode)
+		var res api.Post
+		require.NoError(t, json.NewDecoder(rr.Body).Decode(&res))
+		assert.Equal(t, api.ReactionCounts{"🎉": 2}, res.Reactions)
+		assert.Equal(t, testutil.Ptr("🎉"), res.MyReaction)
+	})
+
+	t.Run("anonymous listing", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/posts", nil)
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Result().StatusCode)
+		var res api.PostList
+		require.NoError(t, json.NewDecoder(rr.Body).Decode(&res))
+		require.Len(t, res.Items, 1)
+		assert.Equal(t, api.ReactionCounts{"🎉": 2}, res.Items[0].Reactions)
+		assert.Nil(t, res.Items[0].MyReaction)
+	})
+}
+
+func TestSetPostReaction_Invalid(t *testing.T) {
+	server, r, engine, _ := setupServer(t)
+	defer server.Close()
+
+	authorID := uuid.New()
+	post := &store.Post{ID: uuid.New(), AuthorID: authorID, Title: "Test Post", Published: true}
+	draft := &store.Post{ID: uuid.New(), AuthorID: authorID, Title: "Test Draft"}
+	require.NoError(t, engine.SetPost(t.Context(), post))
+	require.NoError(t, engine.SetPost(t.Context(), draft))
+
+	tests := []struct {
+		name     string
+		postID   uuid.UUID
+		reaction string
+		want     int
+	}{
+		{"unknown reaction", post.ID, "🎉", http.StatusBadRequest},
+		{"missing post", uuid.New(), "like", http.StatusNotFound},
+		{"draft of other author", draft.ID, "like", http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := react(t, r, fmt.Sprintf("/posts/%s/reaction", tt.postID), uuid.New(), tt.reaction)
+			assert.Equal(t, tt.want, rr.Result().StatusCode)
+		})
+	}
+}
+
+func TestDeletePostReaction(t *testing.T) {
+	server, r, engine, _ := setupServer(t)
+	defer server.Close()
+
+	userID := uuid.New()
+	post := &store.Post{ID: uuid.New(), AuthorID: uuid.New(), Title: "Test Post", Published: true}
+	require.NoError(t, engine.SetPost(t.Context(), post))
+	url := fmt.Sprintf("/posts/%s/reaction", post.ID)
+	require.Equal(t, http.StatusOK, react(t, r, url, userID, "like").Result().StatusCode)
+
+	// Removing a reaction twice has no effect
+	for range 2 {
+		req := httptest.NewRequest(http.MethodDelete, url, nil)
+		req = userIDContext(req, userID)
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusNoContent, rr.Result().StatusCode)
+	}
+
+	summaries, err := engine.SummarizeReactions(t.Context(), store.ReactionTargetPost, []uuid.UUID{post.ID}, userID)
+	require.NoError(t, err)
+	assert.Empty(t, summaries[post.ID].Counts)
+}
+
+func TestSetCommentReaction(t *testing.T) {
+	server, r, engine, _ := setupServer(t)
+	defer server.Close()
+
+	userID := uuid.New()
+	post := &store.Post{ID: uuid.New(), AuthorID: uuid.New(), Title: "Test Post", Published: true}
+	require.NoError(t, engine.SetPost(t.Context(), post))
+	parent := &store.Comment{ID: uuid.New(), AuthorID: uuid.New(), PostID: post.ID, Content: "Parent"}
+	require.NoError(t, engine.SetComment(t.Context(), parent))
+	reply := &store.Comment{ID: uuid.New(), AuthorID: uuid.New(), PostID: post.ID, ParentID: &parent.ID, Depth: 1, Content: "Reply"}
+	require.NoError(t, engine.SetComment(t.Context(), reply))
+	pending := &store.Comment{
+		ID:       uuid.New(),
+		AuthorID: uuid.New(),
+		PostID:   post.ID,
+		Content:  "Pending",
+		State:    store.ModerationStatePending,
+	}
+	require.NoError(t, engine.SetComment(t.Context(), pending))
+
+	rr := react(t, r, fmt.Sprintf("/posts/%s/comments/%s/reaction", post.ID, reply.ID), userID, "like")
+	assert.Equal(t, http.StatusOK, rr.Result().StatusCode)
+
+	// Comments that are not visible to the caller can not be reacted to
+	rr = react(t, r, fmt.Sprintf("/posts/%s/comments/%s/reaction", post.ID, pending.ID), userID, "like")
+	assert.Equal(t, http.StatusNotFound, rr.Result().StatusCode)
+
+	// Replies nested in threads carry their reactions
+	req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/posts/%s/comments?thread=tree", post.ID), nil)
+	req = userIDContext(req, userID)
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Result().StatusCode)
+	var res api.CommentList
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&res))
+	require.Len(t, res.Items, 1)
+	assert.Equal(t, api.ReactionCounts{}, res.Items[0].Reactions)
+	require.Len(t, *res.Items[0].Replies, 1)
+	nested := (*res.Items[0].Replies)[0]
+	assert.Equal(t, api.ReactionCounts{"like": 1}, nested.Reactions)
+	assert.Equal(t, testutil.Ptr("like"), nested.MyReaction)
+}
+
+func TestSetPostReaction_Concurrent(t *testing.T) {
+	server, r, engine, _ := setupServer(t, api.WithReactions("🎉"))
+	defer server.Close()
+
+	post := &store.Post{ID: uuid.New(), AuthorID: uuid.New(), Title: "Test Post", Published: true}
+	require.NoError(t, engine.SetPost(t.Context(), post))
+	url := fmt.Sprintf("/posts/%s/reaction", post.ID)
+
+	const users = 20
+	var wg sync.WaitGroup
+	for range users {
+		userID := uuid.New()
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for _, reaction := range []string{"like", "🎉", "like"} {
+				assert.Equal(t, http.StatusOK, react(t, r, url, userID, reaction).Result().StatusCode)
+			}
+		}()
+	}
+	wg.Wait()
+
+	summaries, err := engine.SummarizeReactions(t.Context(), store.ReactionTargetPost, []uuid.UUID{post.ID}, uuid.Nil)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"like": users}, summaries[post.ID].Counts)
+}
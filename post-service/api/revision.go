@@ -0,0 +1,212 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+
+	"github.com/chrishrb/blog-microservice/internal/api_utils"
+	"github.com/chrishrb/blog-microservice/post-service/authz"
+	"github.com/chrishrb/blog-microservice/post-service/diff"
+	"github.com/chrishrb/blog-microservice/post-service/store"
+	"github.com/chrishrb/blog-microservice/post-service/tag"
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+)
+
+// revisionCursor is the encoded form of the position in a listing of
+// revisions that is handed out to clients.
+type revisionCursor struct {
+	Number int `json:"n"`
+}
+
+func (s *Server) ListPostRevisions(w http.ResponseWriter, r *http.Request, id uuid.UUID, params ListPostRevisionsParams) {
+	_, limit := api_utils.GetPaginationWithDefaults(nil, params.Limit)
+	after := 0
+	if params.Cursor != nil {
+		cursor, err := api_utils.DecodeCursor[revisionCursor](*params.Cursor)
+		if err != nil {
+			_ = render.Render(w, r, api_utils.ErrInvalidRequest(err))
+			return
+		}
+		after = cursor.Number
+	}
+	if _, ok := s.lookupEditablePost(w, r, id); !ok {
+		return
+	}
+
+	// Fetch one more revision to find out whether there is a next page
+	revisions, err := s.engine.ListPostRevisions(r.Context(), id, after, max(limit, 1)+1)
+	if err != nil {
+		_ = render.Render(w, r, api_utils.ErrInternalError(err))
+		return
+	}
+	var nextCursor *string
+	if len(revisions) > max(limit, 1) {
+		revisions = revisions[:max(limit, 1)]
+		next := api_utils.EncodeCursor(revisionCursor{Number: revisions[len(revisions)-1].Number})
+		nextCursor = &next
+	}
+
+	res := &PostRevisionList{
+		Items:      make([]PostRevision, len(revisions)),
+		NextCursor: nextCursor,
+	}
+	for i, revision := range revisions {
+		res.Items[i] = toPostRevision(revision)
+	}
+
+	_ = render.Render(w, r, res)
+}
+
+func (s *Server) LookupPostRevision(w http.ResponseWriter, r *http.Request, id uuid.UUID, revision int) {
+	if _, ok := s.lookupEditablePost(w, r, id); !ok {
+		return
+	}
+
+	rev, err := s.engine.LookupPostRevision(r.Context(), id, revision)
+	if err != nil {
+		_ = render.Render(w, r, api_utils.ErrInternalError(err))
+		return
+	}
+	if rev == nil {
+		_ = render.Render(w, r, api_utils.ErrNotFound)
+		return
+	}
+
+	res := toPostRevision(rev)
+	_ = render.Render(w, r, &res)
+}
+
+func (s *Server) DiffPostRevisions(w http.ResponseWriter, r *http.Request, id uuid.UUID, revision, other int) {
+	if _, ok := s.lookupEditablePost(w, r, id); !ok {
+		return
+	}
+
+	from, err := s.engine.LookupPostRevision(r.Context(), id, revision)
+	if err != nil {
+		_ = render.Render(w, r, api_utils.ErrInternalError(err))
+		return
+	}
+	to, err := s.engine.LookupPostRevision(r.Context(), id, other)
+	if err != nil {
+		_ = render.Render(w, r, api_utils.ErrInternalError(err))
+		return
+	}
+	if from == nil || to == nil {
+		_ = render.Render(w, r, api_utils.ErrNotFound)
+		return
+	}
+
+	res := &PostRevisionDiff{
+		From:        from.Number,
+		To:          to.Number,
+		Title:       toDiffLines(diff.Lines(from.Title, to.Title)),
+		Content:     toDiffLines(diff.Lines(from.Content, to.Content)),
+		AddedTags:   []string{},
+		RemovedTags: []string{},
+	}
+	for _, tag := range to.Tags {
+		if !slices.Contains(from.Tags, tag) {
+			res.AddedTags = append(res.AddedTags, tag)
+		}
+	}
+	for _, tag := range from.Tags {
+		if !slices.Contains(to.Tags, tag) {
+			res.RemovedTags = append(res.RemovedTags, tag)
+		}
+	}
+
+	_ = render.Render(w, r, res)
+}
+
+func (s *Server) RestorePostRevision(w http.ResponseWriter, r *http.Request, id uuid.UUID, revision int, params RestorePostRevisionParams) {
+	post, ok := s.lookupEditablePost(w, r, id)
+	if !ok {
+		return
+	}
+	if errResponse := api_utils.CheckIfMatch(r, post.Version); errResponse != nil {
+		_ = render.Render(w, r, errResponse)
+		return
+	}
+
+	rev, err := s.engine.LookupPostRevision(r.Context(), id, revision)
+	if err != nil {
+		_ = render.Render(w, r, api_utils.ErrInternalError(err))
+		return
+	}
+	if rev == nil {
+		_ = render.Render(w, r, api_utils.ErrNotFound)
+		return
+	}
+
+	// Restoring saves the old fields as a new revision, so the history is
+	// never rewritten
+	setTitle(post, rev.Title)
+	post.Content = rev.Content
+	post.Tags = tag.NormalizeAll(rev.Tags)
+	if err := renderPostContent(post); err != nil {
+		_ = render.Render(w, r, api_utils.ErrInternalError(err))
+		return
+	}
+	err = s.setPostWithRevision(r.Context(), post, authz.CallerFromContext(r.Context()).UserID, &rev.Number)
+	if errors.Is(err, store.ErrVersionConflict) {
+		_ = render.Render(w, r, api_utils.ErrPreconditionFailed)
+		return
+	}
+	if err != nil {
+		_ = render.Render(w, r, api_utils.ErrInternalError(err))
+		return
+	}
+	s.sitemap.Invalidate()
+	s.events.PostUpdated(r.Context(), post, post.Published)
+
+	res := toPost(post)
+	if err := s.enrichPosts(r.Context(), res); err != nil {
+		_ = render.Render(w, r, api_utils.ErrInternalError(err))
+		return
+	}
+	w.Header().Set("ETag", api_utils.ETag(post.Version))
+	_ = render.Render(w, r, res)
+}
+
+// setPostWithRevision stores the post and records its new state as a
+// revision in one go.
+func (s *Server) setPostWithRevision(ctx context.Context, post *store.Post, editorID uuid.UUID, restoredFrom *int) error {
+	return s.engine.SetPostWithRevision(ctx, post, &store.PostRevision{
+		EditorID:     editorID,
+		Title:        post.Title,
+		Content:      post.Content,
+		Tags:         slices.Clone(post.Tags),
+		RestoredFrom: restoredFrom,
+	})
+}
+
+func toPostRevision(revision *store.PostRevision) PostRevision {
+	tags := revision.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+	return PostRevision{
+		PostId:       revision.PostID,
+		Number:       revision.Number,
+		EditorId:     revision.EditorID,
+		Title:        revision.Title,
+		Content:      revision.Content,
+		Tags:         tags,
+		RestoredFrom: revision.RestoredFrom,
+		CreatedAt:    revision.CreatedAt,
+	}
+}
+
+func toDiffLines(lines []diff.Line) []DiffLine {
+	res := make([]DiffLine, len(lines))
+	for i, line := range lines {
+		res[i] = DiffLine{
+			Op:   DiffLineOp(line.Op),
+			Text: line.Text,
+		}
+	}
+	return res
+}
@@ -0,0 +1,311 @@
+package api_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/chrishrb/blog-microservice/internal/api_utils"
+	"github.com/chrishrb/blog-microservice/internal/auth"
+	"github.com/chrishrb/blog-microservice/internal/testutil"
+	"github.com/chrishrb/blog-microservice/post-service/api"
+	"github.com/chrishrb/blog-microservice/post-service/store"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// createPostWithRevisions creates a post through the API and updates it with
+// each of the updates, so that it has one revision more than updates.
+func createPostWithRevisions(t *testing.T, r http.Handler, authorID uuid.UUID, create api.PostCreate, updates ...api.PostUpdate) uuid.UUID {
+	jsonData, err := json.Marshal(create)
+	require.NoError(t, err)
+	req := httptest.NewRequest(http.MethodPost, "/posts", bytes.NewBuffer(jsonData))
+	req.Header.Set("content-type", "application/json")
+	req = userIDContext(req, authorID)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusCreated, rr.Result().StatusCode)
+	var post api.Post
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&post))
+	etag := rr.Header().Get("ETag")
+
+	for _, update := range updates {
+		jsonData, err := json.Marshal(update)
+		require.NoError(t, err)
+		req := httptest.NewRequest(http.MethodPut, fmt.Sprintf("/posts/%s", post.Id), bytes.NewBuffer(jsonData))
+		req.Header.Set("content-type", "application/json")
+		req.Header.Set("If-Match", etag)
+		req = userIDContext(req, authorID)
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		require.Equal(t, http.StatusOK, rr.Result().StatusCode)
+		etag = rr.Header().Get("ETag")
+	}
+	return post.Id
+}
+
+func TestListPostRevisions(t *testing.T) {
+	server, r, _, _ := setupServer(t)
+	defer server.Close()
+
+	authorID := uuid.New()
+	postID := createPostWithRevisions(t, r, authorID,
+		api.PostCreate{Title: "First", Content: "Content"},
+		api.PostUpdate{Title: testutil.Ptr("Second")},
+		api.PostUpdate{Title: testutil.Ptr("Third")},
+	)
+
+	// First page
+	req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/posts/%s/revisions?limit=2", postID), nil)
+	req = userIDContext(req, authorID)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Result().StatusCode)
+	var res api.PostRevisionList
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&res))
+	require.Len(t, res.Items, 2)
+	assert.Equal(t, 1, res.Items[0].Number)
+	assert.Equal(t, "First", res.Items[0].Title)
+	assert.Equal(t, authorID, res.Items[0].EditorId)
+	assert.Equal(t, 2, res.Items[1].Number)
+	assert.Equal(t, "Second", res.Items[1].Title)
+	require.NotNil(t, res.NextCursor)
+
+	// Second and last page
+	req = httptest.NewRequest(http.MethodGet, fmt.Sprintf("/posts/%s/revisions?limit=2&cursor=%s", postID, *res.NextCursor), nil)
+	req = userIDContext(req, authorID)
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Result().StatusCode)
+	res = api.PostRevisionList{}
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&res))
+	require.Len(t, res.Items, 1)
+	assert.Equal(t, 3, res.Items[0].Number)
+	assert.Equal(t, "Third", res.Items[0].Title)
+	assert.Nil(t, res.NextCursor)
+}
+
+func TestListPostRevisions_Authorization(t *testing.T) {
+	server, r, engine, _ := setupServer(t)
+	defer server.Close()
+
+	authorID := uuid.New()
+	post := &store.Post{ID: uuid.New(), AuthorID: authorID, Title: "Title", Published: true}
+	require.NoError(t, engine.SetPost(t.Context(), post))
+
+	for _, tt := range authorizationTests(authorID, http.StatusOK) {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/posts/%s/revisions", post.ID), nil)
+			req = userIDContext(req, tt.userID, tt.permissions...)
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.want, rr.Result().StatusCode)
+		})
+	}
+}
+
+func TestListPostRevisions_NotFound(t *testing.T) {
+	server, r, engine, _ := setupServer(t)
+	defer server.Close()
+
+	// Unknown post
+	req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/posts/%s/revisions", uuid.New()), nil)
+	req = userIDContext(req, uuid.New(), auth.PermissionAllPostsWrite)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusNotFound, rr.Result().StatusCode)
+
+	// Draft of another author
+	draft := &store.Post{ID: uuid.New(), AuthorID: uuid.New(), Title: "Draft"}
+	require.NoError(t, engine.SetPost(t.Context(), draft))
+	req = httptest.NewRequest(http.MethodGet, fmt.Sprintf("/posts/%s/revisions", draft.ID), nil)
+	req = userIDContext(req, uuid.New())
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusNotFound, rr.Result().StatusCode)
+}
+
+func TestLookupPostRevision(t *testing.T) {
+	server, r, _, _ := setupServer(t)
+	defer server.Close()
+
+	authorID := uuid.New()
+	postID := createPostWithRevisions(t, r, authorID,
+		api.PostCreate{Title: "First", Content: "Content", Tags: &[]string{"go"}},
+		api.PostUpdate{Title: testutil.Ptr("Second")},
+	)
+
+	req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/posts/%s/revisions/1", postID), nil)
+	req = userIDContext(req, authorID)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Result().StatusCode)
+	var res api.PostRevision
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&res))
+	assert.Equal(t, postID, res.PostId)
+	assert.Equal(t, 1, res.Number)
+	assert.Equal(t, "First", res.Title)
+	assert.Equal(t, "Content", res.Content)
+	assert.Equal(t, []string{"go"}, res.Tags)
+	assert.Nil(t, res.RestoredFrom)
+
+	// Unknown revision
+	req = httptest.NewRequest(http.MethodGet, fmt.Sprintf("/posts/%s/revisions/3", postID), nil)
+	req = userIDContext(req, authorID)
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusNotFound, rr.Result().StatusCode)
+}
+
+func TestDiffPostRevisions(t *testing.T) {
+	server, r, _, _ := setupServer(t)
+	defer server.Close()
+
+	authorID := uuid.New()
+	postID := createPostWithRevisions(t, r, authorID,
+		api.PostCreate{Title: "Title", Content: "one\ntwo\nthree", Tags: &[]string{"go", "sql"}},
+		api.PostUpdate{Content: testutil.Ptr("one\n2\nthree\nfour"), Tags: &[]string{"go", "rust"}},
+	)
+
+	req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/posts/%s/revisions/1/diff/2", postID), nil)
+	req = userIDContext(req, authorID)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Result().StatusCode)
+	var res api.PostRevisionDiff
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&res))
+	assert.Equal(t, 1, res.From)
+	assert.Equal(t, 2, res.To)
+	assert.Equal(t, []api.DiffLine{{Op: api.Equal, Text: "Title"}}, res.Title)
+	assert.Equal(t, []api.DiffLine{
+		{Op: api.Equal, Text: "one"},
+		{Op: api.Delete, Text: "two"},
+		{Op: api.Insert, Text: "2"},
+		{Op: api.Equal, Text: "three"},
+		{Op: api.Insert, Text: "four"},
+	}, res.Content)
+	assert.Equal(t, []string{"rust"}, res.AddedTags)
+	assert.Equal(t, []string{"sql"}, res.RemovedTags)
+
+	// Unknown revision
+	req = httptest.NewRequest(http.MethodGet, fmt.Sprintf("/posts/%s/revisions/1/diff/3", postID), nil)
+	req = userIDContext(req, authorID)
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusNotFound, rr.Result().StatusCode)
+}
+
+func TestRestorePostRevision(t *testing.T) {
+	server, r, engine, _ := setupServer(t)
+	defer server.Close()
+
+	authorID := uuid.New()
+	postID := createPostWithRevisions(t, r, authorID,
+		api.PostCreate{Title: "First", Content: "Old content", Tags: &[]string{"go"}},
+		api.PostUpdate{Title: testutil.Ptr("Second"), Content: testutil.Ptr("New content"), Tags: &[]string{}},
+	)
+
+	adminID := uuid.New()
+	req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/posts/%s/revisions/1/restore", postID), nil)
+	req.Header.Set("If-Match", api_utils.ETag(2))
+	req = userIDContext(req, adminID, auth.PermissionAllPostsWrite)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Result().StatusCode)
+	assert.Equal(t, api_utils.ETag(3), rr.Header().Get("ETag"))
+	var res api.Post
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&res))
+	assert.Equal(t, "First", res.Title)
+	assert.Equal(t, "Old content", res.Content)
+	assert.Equal(t, &[]string{"go"}, res.Tags)
+
+	// Check the database
+	dbPost, err := engine.LookupPost(t.Context(), postID)
+	require.NoError(t, err)
+	assert.Equal(t, "First", dbPost.Title)
+	assert.Equal(t, authorID, dbPost.AuthorID)
+
+	// The restore is a new revision, older revisions are kept
+	revisions, err := engine.ListPostRevisions(t.Context(), postID, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, revisions, 3)
+	assert.Equal(t, "Second", revisions[1].Title)
+	assert.Equal(t, "First", revisions[2].Title)
+	assert.Equal(t, adminID, revisions[2].EditorID)
+	assert.Equal(t, testutil.Ptr(1), revisions[2].RestoredFrom)
+}
+
+func TestRestorePostRevision_Authorization(t *testing.T) {
+	server, r, engine, _ := setupServer(t)
+	defer server.Close()
+
+	authorID := uuid.New()
+	postID := createPostWithRevisions(t, r, authorID,
+		api.PostCreate{Title: "First", Content: "Content", Published: testutil.Ptr(true)},
+	)
+
+	for _, tt := range authorizationTests(authorID, http.StatusOK) {
+		t.Run(tt.name, func(t *testing.T) {
+			// Every successful restore creates a new version of the post
+			post, err := engine.LookupPost(t.Context(), postID)
+			require.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/posts/%s/revisions/1/restore", postID), nil)
+			req.Header.Set("If-Match", api_utils.ETag(post.Version))
+			req = userIDContext(req, tt.userID, tt.permissions...)
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.want, rr.Result().StatusCode)
+		})
+	}
+}
+
+func TestRestorePostRevision_Precondition(t *testing.T) {
+	server, r, engine, _ := setupServer(t)
+	defer server.Close()
+
+	authorID := uuid.New()
+	postID := createPostWithRevisions(t, r, authorID,
+		api.PostCreate{Title: "First", Content: "Content"},
+		api.PostUpdate{Title: testutil.Ptr("Second")},
+	)
+
+	restore := func(ifMatch string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/posts/%s/revisions/1/restore", postID), nil)
+		if ifMatch != "" {
+			req.Header.Set("If-Match", ifMatch)
+		}
+		req = userIDContext(req, authorID)
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		return rr
+	}
+
+	// Restores without a precondition are rejected
+	rr := restore("")
+	assert.Equal(t, http.StatusPreconditionRequired, rr.Result().StatusCode)
+
+	// Restores based on an older version of the post lost the race
+	rr = restore(api_utils.ETag(1))
+	assert.Equal(t, http.StatusPreconditionFailed, rr.Result().StatusCode)
+
+	// Neither changed the post
+	post, err := engine.LookupPost(t.Context(), postID)
+	require.NoError(t, err)
+	assert.Equal(t, "Second", post.Title)
+	assert.Equal(t, int64(2), post.Version)
+	revisions, err := engine.ListPostRevisions(t.Context(), postID, 0, 10)
+	require.NoError(t, err)
+	assert.Len(t, revisions, 2)
+}
@@ -0,0 +1,78 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/chrishrb/blog-microservice/internal/api_utils"
+	"github.com/chrishrb/blog-microservice/post-service/authz"
+	"github.com/chrishrb/blog-microservice/post-service/store"
+	"github.com/go-chi/render"
+)
+
+func (s *Server) SearchPosts(w http.ResponseWriter, r *http.Request, params SearchPostsParams) {
+	_, limit := api_utils.GetPaginationWithDefaults(nil, params.Limit)
+
+	results, err := s.engine.SearchPosts(r.Context(), store.PostSearchQuery{
+		Text:   params.Q,
+		Reader: authz.ListingReader(authz.CallerFromContext(r.Context())),
+		Limit:  limit,
+	})
+	if err != nil {
+		_ = render.Render(w, r, api_utils.ErrInternalError(err))
+		return
+	}
+
+	res := &PostSearchResultList{
+		Items: make([]PostSearchResult, len(results)),
+	}
+	items := make([]*Post, len(results))
+	for i, result := range results {
+		res.Items[i] = PostSearchResult{
+			Post:    *toPost(result.Post),
+			Score:   result.Score,
+			Snippet: result.Snippet,
+		}
+		items[i] = &res.Items[i].Post
+	}
+	if err := s.enrichPosts(r.Context(), items...); err != nil {
+		_ = render.Render(w, r, api_utils.ErrInternalError(err))
+		return
+	}
+
+	_ = render.Render(w, r, res)
+}
+
+func (s *Server) SearchComments(w http.ResponseWriter, r *http.Request, params SearchCommentsParams) {
+	_, limit := api_utils.GetPaginationWithDefaults(nil, params.Limit)
+
+	caller := authz.CallerFromContext(r.Context())
+	results, err := s.engine.SearchComments(r.Context(), store.CommentSearchQuery{
+		Text:       params.Q,
+		PostReader: authz.ListingReader(caller),
+		Reader:     authz.CommentListingReader(caller),
+		Limit:      limit,
+	})
+	if err != nil {
+		_ = render.Render(w, r, api_utils.ErrInternalError(err))
+		return
+	}
+
+	res := &CommentSearchResultList{
+		Items: make([]CommentSearchResult, len(results)),
+	}
+	items := make([]*Comment, len(results))
+	for i, result := range results {
+		res.Items[i] = CommentSearchResult{
+			Comment: *toComment(result.Comment),
+			Score:   result.Score,
+			Snippet: result.Snippet,
+		}
+		items[i] = &res.Items[i].Comment
+	}
+	if err := s.setCommentReactions(r.Context(), items...); err != nil {
+		_ = render.Render(w, r, api_utils.ErrInternalError(err))
+		return
+	}
+
+	_ = render.Render(w, r, res)
+}
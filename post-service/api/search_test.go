@@ -0,0 +1,184 @@
+package api_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/chrishrb/blog-microservice/internal/auth"
+	"github.com/chrishrb/blog-microservice/post-service/api"
+	"github.com/chrishrb/blog-microservice/post-service/store"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchPosts(t *testing.T) {
+	server, r, engine, _ := setupServer(t)
+	defer server.Close()
+
+	post := &store.Post{
+		ID:        uuid.New(),
+		AuthorID:  uuid.New(),
+		Title:     "Running a marathon",
+		Content:   "Some thoughts about training.",
+		Tags:      []string{"sports"},
+		Published: true,
+	}
+	require.NoError(t, engine.SetPost(t.Context(), post))
+	err := engine.SetPost(t.Context(), &store.Post{
+		ID:       uuid.New(),
+		AuthorID: uuid.New(),
+		Title:    "Cooking",
+		Content:  "How to cook pasta.",
+	})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(
+		http.MethodGet,
+		"/posts/search?q=runs",
+		nil,
+	)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Result().StatusCode)
+	var res api.PostSearchResultList
+	err = json.NewDecoder(rr.Body).Decode(&res)
+	require.NoError(t, err)
+	require.Len(t, res.Items, 1)
+	assert.Equal(t, post.ID, res.Items[0].Post.Id)
+	assert.Equal(t, []string{"sports"}, *res.Items[0].Post.Tags)
+	assert.Equal(t, "<mark>Running</mark> a marathon", res.Items[0].Snippet)
+	assert.Greater(t, res.Items[0].Score, 0.0)
+}
+
+func TestSearchPosts_MissingQuery(t *testing.T) {
+	server, r, _, _ := setupServer(t)
+	defer server.Close()
+
+	req := httptest.NewRequest(
+		http.MethodGet,
+		"/posts/search",
+		nil,
+	)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Result().StatusCode)
+}
+
+func TestSearchPosts_Drafts(t *testing.T) {
+	server, r, engine, _ := setupServer(t)
+	defer server.Close()
+
+	authorID := uuid.New()
+	draft := &store.Post{
+		ID:       uuid.New(),
+		AuthorID: authorID,
+		Title:    "Running a marathon",
+	}
+	require.NoError(t, engine.SetPost(t.Context(), draft))
+
+	for _, tt := range visibilityTests(authorID) {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/posts/search?q=marathon", nil)
+			req = tt.context(req)
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+
+			assert.Equal(t, http.StatusOK, rr.Result().StatusCode)
+			var res api.PostSearchResultList
+			require.NoError(t, json.NewDecoder(rr.Body).Decode(&res))
+			if tt.visible {
+				require.Len(t, res.Items, 1)
+				assert.Equal(t, draft.ID, res.Items[0].Post.Id)
+			} else {
+				assert.Empty(t, res.Items)
+			}
+		})
+	}
+}
+
+func TestSearchComments(t *testing.T) {
+	server, r, engine, _ := setupServer(t)
+	defer server.Close()
+
+	post := &store.Post{ID: uuid.New(), AuthorID: uuid.New(), Title: "Training", Published: true}
+	require.NoError(t, engine.SetPost(t.Context(), post))
+	comment := &store.Comment{
+		ID:       uuid.New(),
+		AuthorID: uuid.New(),
+		PostID:   post.ID,
+		Content:  "Running a marathon",
+		State:    store.ModerationStateApproved,
+	}
+	require.NoError(t, engine.SetComment(t.Context(), comment))
+
+	req := httptest.NewRequest(http.MethodGet, "/comments/search?q=runs", nil)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Result().StatusCode)
+	var res api.CommentSearchResultList
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&res))
+	require.Len(t, res.Items, 1)
+	assert.Equal(t, comment.ID, res.Items[0].Comment.Id)
+	assert.Equal(t, post.ID, res.Items[0].Comment.PostId)
+	assert.Equal(t, "<mark>Running</mark> a marathon", res.Items[0].Snippet)
+	assert.Greater(t, res.Items[0].Score, 0.0)
+}
+
+func TestSearchComments_MissingQuery(t *testing.T) {
+	server, r, _, _ := setupServer(t)
+	defer server.Close()
+
+	req := httptest.NewRequest(http.MethodGet, "/comments/search", nil)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Result().StatusCode)
+}
+
+func TestSearchComments_Pending(t *testing.T) {
+	server, r, engine, _ := setupServer(t)
+	defer server.Close()
+
+	post := &store.Post{ID: uuid.New(), AuthorID: uuid.New(), Title: "Training", Published: true}
+	require.NoError(t, engine.SetPost(t.Context(), post))
+	authorID := uuid.New()
+	comment := &store.Comment{
+		ID:       uuid.New(),
+		AuthorID: authorID,
+		PostID:   post.ID,
+		Content:  "Running a marathon",
+		State:    store.ModerationStatePending,
+	}
+	require.NoError(t, engine.SetComment(t.Context(), comment))
+
+	tests := []visibilityTest{
+		{"author", authorID, nil, true},
+		{"moderator", uuid.New(), []string{auth.PermissionModerate}, true},
+		{"other user", uuid.New(), nil, false},
+		{"anonymous", uuid.Nil, nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/comments/search?q=marathon", nil)
+			req = tt.context(req)
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+
+			assert.Equal(t, http.StatusOK, rr.Result().StatusCode)
+			var res api.CommentSearchResultList
+			require.NoError(t, json.NewDecoder(rr.Body).Decode(&res))
+			if tt.visible {
+				require.Len(t, res.Items, 1)
+				assert.Equal(t, comment.ID, res.Items[0].Comment.Id)
+			} else {
+				assert.Empty(t, res.Items)
+			}
+		})
+	}
+}
@@ -0,0 +1,408 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/chrishrb/blog-microservice/internal/api_utils"
+	"github.com/chrishrb/blog-microservice/internal/auth"
+	"github.com/chrishrb/blog-microservice/post-service/authz"
+	"github.com/chrishrb/blog-microservice/post-service/store"
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+)
+
+// errInvalidSeriesTitle is returned for series without a title.
+var errInvalidSeriesTitle = errors.New("title must not be empty")
+
+// errInvalidSeriesOrder is returned if a new order does not list every post
+// of the series exactly once.
+var errInvalidSeriesOrder = errors.New("postIds must list every post of the series exactly once")
+
+func (s *Server) ListSeries(w http.ResponseWriter, r *http.Request, params ListSeriesParams) {
+	page, err := api_utils.GetPage[store.Cursor](params.Cursor, params.Offset, params.Limit)
+	if err != nil {
+		_ = render.Render(w, r, api_utils.ErrInvalidRequest(err))
+		return
+	}
+
+	list, err := s.engine.ListSeries(r.Context(), params.AuthorId, page)
+	if err != nil {
+		_ = render.Render(w, r, api_utils.ErrInternalError(err))
+		return
+	}
+	list, nextCursor := api_utils.NextPage(list, page, (*store.Series).Cursor)
+
+	res := &SeriesList{
+		Items:      make([]Series, len(list)),
+		NextCursor: nextCursor,
+	}
+	for i, series := range list {
+		res.Items[i] = *toSeries(series)
+	}
+
+	_ = render.Render(w, r, res)
+}
+
+func (s *Server) CreateSeries(w http.ResponseWriter, r *http.Request) {
+	userID, err := auth.GetUserIDFromContext(r.Context())
+	if err != nil {
+		_ = render.Render(w, r, api_utils.ErrInternalError(err))
+		return
+	}
+
+	req := new(SeriesCreate)
+	if err := render.Bind(r, req); err != nil {
+		_ = render.Render(w, r, api_utils.ErrInvalidRequest(err))
+		return
+	}
+	title, err := seriesTitle(req.Title)
+	if err != nil {
+		_ = render.Render(w, r, api_utils.ErrInvalidRequest(err))
+		return
+	}
+
+	series := &store.Series{
+		ID:       uuid.New(),
+		AuthorID: userID,
+		Title:    title,
+	}
+	if req.Description != nil {
+		series.Description = *req.Description
+	}
+	err = s.engine.SetSeries(r.Context(), series)
+	if err != nil {
+		_ = render.Render(w, r, api_utils.ErrInternalError(err))
+		return
+	}
+
+	render.Status(r, http.StatusCreated)
+	_ = render.Render(w, r, toSeries(series))
+}
+
+func (s *Server) LookupSeries(w http.ResponseWriter, r *http.Request, id uuid.UUID) {
+	series, err := s.engine.LookupSeries(r.Context(), id)
+	if err != nil {
+		_ = render.Render(w, r, api_utils.ErrInternalError(err))
+		return
+	}
+	if series == nil {
+		_ = render.Render(w, r, api_utils.ErrNotFound)
+		return
+	}
+	_ = render.Render(w, r, toSeries(series))
+}
+
+func (s *Server) UpdateSeries(w http.ResponseWriter, r *http.Request, id uuid.UUID) {
+	series, ok := s.modifiableSeries(w, r, id)
+	if !ok {
+		return
+	}
+
+	req := new(SeriesUpdate)
+	if err := render.Bind(r, req); err != nil {
+		_ = render.Render(w, r, api_utils.ErrInvalidRequest(err))
+		return
+	}
+	if req.Title != nil {
+		title, err := seriesTitle(*req.Title)
+		if err != nil {
+			_ = render.Render(w, r, api_utils.ErrInvalidRequest(err))
+			return
+		}
+		series.Title = title
+	}
+	if req.Description != nil {
+		series.Description = *req.Description
+	}
+
+	err := s.engine.SetSeries(r.Context(), series)
+	if err != nil {
+		_ = render.Render(w, r, api_utils.ErrInternalError(err))
+		return
+	}
+	s.sitemap.Invalidate()
+	_ = render.Render(w, r, toSeries(series))
+}
+
+func (s *Server) DeleteSeries(w http.ResponseWriter, r *http.Request, id uuid.UUID) {
+	if _, ok := s.modifiableSeries(w, r, id); !ok {
+		return
+	}
+
+	err := s.engine.DeleteSeries(r.Context(), id)
+	if err != nil {
+		_ = render.Render(w, r, api_utils.ErrInternalError(err))
+		return
+	}
+	s.sitemap.Invalidate()
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) ListSeriesPosts(w http.ResponseWriter, r *http.Request, id uuid.UUID) {
+	series, err := s.engine.LookupSeries(r.Context(), id)
+	if err != nil {
+		_ = render.Render(w, r, api_utils.ErrInternalError(err))
+		return
+	}
+	if series == nil {
+		_ = render.Render(w, r, api_utils.ErrNotFound)
+		return
+	}
+
+	posts, err := s.engine.ListSeriesPosts(r.Context(), id)
+	if err != nil {
+		_ = render.Render(w, r, api_utils.ErrInternalError(err))
+		return
+	}
+
+	// Drafts of other authors are left out to not leak them
+	caller := authz.CallerFromContext(r.Context())
+	res := &PostList{Items: []Post{}}
+	for _, post := range posts {
+		if authz.CanRead(caller, post) == nil {
+			res.Items = append(res.Items, *toPost(post))
+		}
+	}
+	items := make([]*Post, len(res.Items))
+	for i := range res.Items {
+		items[i] = &res.Items[i]
+	}
+	if err := s.enrichPosts(r.Context(), items...); err != nil {
+		_ = render.Render(w, r, api_utils.ErrInternalError(err))
+		return
+	}
+
+	_ = render.Render(w, r, res)
+}
+
+func (s *Server) AddSeriesPost(w http.ResponseWriter, r *http.Request, id uuid.UUID, postId uuid.UUID) {
+	// The request body is optional
+	req := new(SeriesPostUpdate)
+	if r.ContentLength != 0 {
+		if err := render.Bind(r, req); err != nil {
+			_ = render.Render(w, r, api_utils.ErrInvalidRequest(err))
+			return
+		}
+	}
+
+	series, ok := s.modifiableSeries(w, r, id)
+	if !ok {
+		return
+	}
+	post, ok := s.modifiableSeriesPost(w, r, postId)
+	if !ok {
+		return
+	}
+
+	// Positions after the last post append the post
+	position := 0
+	if req.Position != nil {
+		position = *req.Position
+	}
+	err := s.engine.AddSeriesPost(r.Context(), series.ID, post.ID, position)
+	if err != nil {
+		_ = render.Render(w, r, api_utils.ErrInternalError(err))
+		return
+	}
+	s.sitemap.Invalidate()
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) RemoveSeriesPost(w http.ResponseWriter, r *http.Request, id uuid.UUID, postId uuid.UUID) {
+	series, ok := s.modifiableSeries(w, r, id)
+	if !ok {
+		return
+	}
+	post, ok := s.modifiableSeriesPost(w, r, postId)
+	if !ok {
+		return
+	}
+	if post.SeriesID == nil || *post.SeriesID != series.ID {
+		_ = render.Render(w, r, api_utils.ErrNotFound)
+		return
+	}
+
+	err := s.engine.RemoveSeriesPost(r.Context(), post.ID)
+	if err != nil {
+		_ = render.Render(w, r, api_utils.ErrInternalError(err))
+		return
+	}
+	s.sitemap.Invalidate()
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) ReorderSeries(w http.ResponseWriter, r *http.Request, id uuid.UUID) {
+	series, ok := s.modifiableSeries(w, r, id)
+	if !ok {
+		return
+	}
+
+	req := new(SeriesOrder)
+	if err := render.Bind(r, req); err != nil {
+		_ = render.Render(w, r, api_utils.ErrInvalidRequest(err))
+		return
+	}
+
+	posts, err := s.engine.ListSeriesPosts(r.Context(), series.ID)
+	if err != nil {
+		_ = render.Render(w, r, api_utils.ErrInternalError(err))
+		return
+	}
+	current := make([]uuid.UUID, len(posts))
+	for i, post := range posts {
+		current[i] = post.ID
+	}
+	if !isPermutation(current, req.PostIds) {
+		_ = render.Render(w, r, api_utils.ErrInvalidRequest(errInvalidSeriesOrder))
+		return
+	}
+
+	err = s.engine.ReorderSeries(r.Context(), series.ID, req.PostIds)
+	if err != nil {
+		_ = render.Render(w, r, api_utils.ErrInternalError(err))
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// modifiableSeries looks up a series the caller may modify. It renders the
+// error response and returns false if the request may not proceed.
+func (s *Server) modifiableSeries(w http.ResponseWriter, r *http.Request, ID uuid.UUID) (*store.Series, bool) {
+	series, err := s.engine.LookupSeries(r.Context(), ID)
+	if err != nil {
+		_ = render.Render(w, r, api_utils.ErrInternalError(err))
+		return nil, false
+	}
+	if series == nil {
+		_ = render.Render(w, r, api_utils.ErrNotFound)
+		return nil, false
+	}
+	if err := authz.CanModify(authz.CallerFromContext(r.Context()), series.AuthorID); err != nil {
+		_ = render.Render(w, r, api_utils.ErrForbidden)
+		return nil, false
+	}
+	return series, true
+}
+
+// modifiableSeriesPost looks up a post the caller may add to or remove from
+// a series. Drafts of other authors are reported as missing to not leak
+// them. It renders the error response and returns false if the request may
+// not proceed.
+func (s *Server) modifiableSeriesPost(w http.ResponseWriter, r *http.Request, ID uuid.UUID) (*store.Post, bool) {
+	post, err := s.engine.LookupPost(r.Context(), ID)
+	if err != nil {
+		_ = render.Render(w, r, api_utils.ErrInternalError(err))
+		return nil, false
+	}
+	caller := authz.CallerFromContext(r.Context())
+	if post == nil || authz.CanRead(caller, post) != nil {
+		_ = render.Render(w, r, api_utils.ErrNotFound)
+		return nil, false
+	}
+	if err := authz.CanModify(caller, post.AuthorID); err != nil {
+		_ = render.Render(w, r, api_utils.ErrForbidden)
+		return nil, false
+	}
+	return post, true
+}
+
+// seriesNavigation is a series together with all its posts, ordered by
+// position.
+type seriesNavigation struct {
+	series *store.Series
+	posts  []*store.Post
+}
+
+// setPostSeries completes the series of the posts with the title of the
+// series and the neighbouring posts. Neighbours the caller may not read are
+// skipped, so drafts do not leak into the navigation.
+func (s *Server) setPostSeries(ctx context.Context, posts ...*Post) error {
+	caller := authz.CallerFromContext(ctx)
+	navigations := make(map[uuid.UUID]*seriesNavigation)
+	for _, post := range posts {
+		if post.Series == nil {
+			continue
+		}
+
+		nav, ok := navigations[post.Series.Id]
+		if !ok {
+			series, err := s.engine.LookupSeries(ctx, post.Series.Id)
+			if err != nil {
+				return err
+			}
+			nav = &seriesNavigation{series: series}
+			if series != nil {
+				nav.posts, err = s.engine.ListSeriesPosts(ctx, series.ID)
+				if err != nil {
+					return err
+				}
+			}
+			navigations[post.Series.Id] = nav
+		}
+		if nav.series == nil {
+			post.Series = nil
+			continue
+		}
+
+		post.Series.Title = nav.series.Title
+		for _, p := range nav.posts {
+			if authz.CanRead(caller, p) != nil {
+				continue
+			}
+			if p.SeriesPosition < post.Series.Position {
+				post.Series.Previous = toPostLink(p)
+			} else if p.SeriesPosition > post.Series.Position && post.Series.Next == nil {
+				post.Series.Next = toPostLink(p)
+			}
+		}
+	}
+	return nil
+}
+
+// isPermutation reports whether the IDs list every one of current exactly
+// once.
+func isPermutation(current, IDs []uuid.UUID) bool {
+	if len(IDs) != len(current) {
+		return false
+	}
+	seen := make(map[uuid.UUID]bool, len(IDs))
+	for _, ID := range IDs {
+		if seen[ID] || !slices.Contains(current, ID) {
+			return false
+		}
+		seen[ID] = true
+	}
+	return true
+}
+
+// seriesTitle returns the trimmed title of a series.
+func seriesTitle(title string) (string, error) {
+	title = strings.TrimSpace(title)
+	if title == "" {
+		return "", errInvalidSeriesTitle
+	}
+	return title, nil
+}
+
+func toSeries(series *store.Series) *Series {
+	return &Series{
+		Id:          series.ID,
+		AuthorId:    series.AuthorID,
+		Title:       series.Title,
+		Description: series.Description,
+		CreatedAt:   series.CreatedAt,
+		UpdatedAt:   series.UpdatedAt,
+	}
+}
+
+func toPostLink(post *store.Post) *PostLink {
+	return &PostLink{
+		Id:    post.ID,
+		Slug:  post.Slug,
+		Title: post.Title,
+	}
+}
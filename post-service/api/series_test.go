@@ -0,0 +1,280 @@
+package api_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/chrishrb/blog-microservice/internal/testutil"
+	"github.com/chrishrb/blog-microservice/post-service/api"
+	"github.com/chrishrb/blog-microservice/post-service/store"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateSeries(t *testing.T) {
+	server, r, engine, _ := setupServer(t)
+	defer server.Close()
+
+	userID := uuid.New()
+	jsonData, err := json.Marshal(api.SeriesCreate{Title: " Go Tutorial ", Description: testutil.Ptr("Step by step")})
+	require.NoError(t, err)
+	req := httptest.NewRequest(http.MethodPost, "/series", bytes.NewBuffer(jsonData))
+	req.Header.Set("content-type", "application/json")
+	req = userIDContext(req, userID)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusCreated, rr.Result().StatusCode)
+	var res api.Series
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&res))
+	assert.Equal(t, "Go Tutorial", res.Title)
+	assert.Equal(t, "Step by step", res.Description)
+	assert.Equal(t, userID, res.AuthorId)
+
+	dbSeries, err := engine.LookupSeries(t.Context(), res.Id)
+	require.NoError(t, err)
+	require.NotNil(t, dbSeries)
+	assert.Equal(t, "Go Tutorial", dbSeries.Title)
+
+	// The title must not be empty
+	jsonData, err = json.Marshal(api.SeriesCreate{Title: "  "})
+	require.NoError(t, err)
+	req = httptest.NewRequest(http.MethodPost, "/series", bytes.NewBuffer(jsonData))
+	req.Header.Set("content-type", "application/json")
+	req = userIDContext(req, userID)
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Result().StatusCode)
+}
+
+func TestListSeries(t *testing.T) {
+	server, r, engine, _ := setupServer(t)
+	defer server.Close()
+
+	authorID := uuid.New()
+	first := &store.Series{ID: uuid.New(), AuthorID: authorID, Title: "First"}
+	second := &store.Series{ID: uuid.New(), AuthorID: uuid.New(), Title: "Second"}
+	require.NoError(t, engine.SetSeries(t.Context(), first))
+	require.NoError(t, engine.SetSeries(t.Context(), second))
+
+	req := httptest.NewRequest(http.MethodGet, "/series?authorId="+authorID.String(), nil)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Result().StatusCode)
+	var res api.SeriesList
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&res))
+	require.Len(t, res.Items, 1)
+	assert.Equal(t, first.ID, res.Items[0].Id)
+}
+
+func TestUpdateSeries_Authorization(t *testing.T) {
+	authorID := uuid.New()
+	for _, tt := range authorizationTests(authorID, http.StatusOK) {
+		t.Run(tt.name, func(t *testing.T) {
+			server, r, engine, _ := setupServer(t)
+			defer server.Close()
+
+			series := &store.Series{ID: uuid.New(), AuthorID: authorID, Title: "Tutorial"}
+			require.NoError(t, engine.SetSeries(t.Context(), series))
+
+			jsonData, err := json.Marshal(api.SeriesUpdate{Title: testutil.Ptr("Updated")})
+			require.NoError(t, err)
+			req := httptest.NewRequest(http.MethodPut, "/series/"+series.ID.String(), bytes.NewBuffer(jsonData))
+			req.Header.Set("content-type", "application/json")
+			req = userIDContext(req, tt.userID, tt.permissions...)
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.want, rr.Result().StatusCode)
+		})
+	}
+}
+
+func TestDeleteSeries(t *testing.T) {
+	server, r, engine, _ := setupServer(t)
+	defer server.Close()
+
+	authorID := uuid.New()
+	series := &store.Series{ID: uuid.New(), AuthorID: authorID, Title: "Tutorial"}
+	require.NoError(t, engine.SetSeries(t.Context(), series))
+	post := &store.Post{ID: uuid.New(), AuthorID: authorID, Title: "Part 1", Published: true}
+	require.NoError(t, engine.SetPost(t.Context(), post))
+	require.NoError(t, engine.AddSeriesPost(t.Context(), series.ID, post.ID, 0))
+
+	req := httptest.NewRequest(http.MethodDelete, "/series/"+series.ID.String(), nil)
+	req = userIDContext(req, authorID)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusNoContent, rr.Result().StatusCode)
+	dbSeries, err := engine.LookupSeries(t.Context(), series.ID)
+	require.NoError(t, err)
+	assert.Nil(t, dbSeries)
+	dbPost, err := engine.LookupPost(t.Context(), post.ID)
+	require.NoError(t, err)
+	require.NotNil(t, dbPost)
+	assert.Nil(t, dbPost.SeriesID)
+
+	req = httptest.NewRequest(http.MethodGet, "/series/"+series.ID.String(), nil)
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusNotFound, rr.Result().StatusCode)
+}
+
+func TestSeriesPosts(t *testing.T) {
+	server, r, engine, _ := setupServer(t)
+	defer server.Close()
+
+	authorID := uuid.New()
+	series := &store.Series{ID: uuid.New(), AuthorID: authorID, Title: "Tutorial"}
+	require.NoError(t, engine.SetSeries(t.Context(), series))
+	first := &store.Post{ID: uuid.New(), AuthorID: authorID, Title: "Part 1", Slug: "part-1", Published: true}
+	draft := &store.Post{ID: uuid.New(), AuthorID: authorID, Title: "Part 2", Slug: "part-2"}
+	third := &store.Post{ID: uuid.New(), AuthorID: authorID, Title: "Part 3", Slug: "part-3", Published: true}
+	for _, post := range []*store.Post{first, draft, third} {
+		require.NoError(t, engine.SetPost(t.Context(), post))
+	}
+
+	// Add the posts out of order, the second one at the front
+	for _, tt := range []struct {
+		post     *store.Post
+		position *int
+	}{
+		{first, nil},
+		{third, nil},
+		{draft, testutil.Ptr(1)},
+	} {
+		var body []byte
+		if tt.position != nil {
+			var err error
+			body, err = json.Marshal(api.SeriesPostUpdate{Position: tt.position})
+			require.NoError(t, err)
+		}
+		req := httptest.NewRequest(http.MethodPut, "/series/"+series.ID.String()+"/posts/"+tt.post.ID.String(), bytes.NewBuffer(body))
+		req.Header.Set("content-type", "application/json")
+		req = userIDContext(req, authorID)
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		require.Equal(t, http.StatusNoContent, rr.Result().StatusCode)
+	}
+
+	jsonData, err := json.Marshal(api.SeriesOrder{PostIds: []uuid.UUID{first.ID, draft.ID, third.ID}})
+	require.NoError(t, err)
+	req := httptest.NewRequest(http.MethodPut, "/series/"+series.ID.String()+"/order", bytes.NewBuffer(jsonData))
+	req.Header.Set("content-type", "application/json")
+	req = userIDContext(req, authorID)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusNoContent, rr.Result().StatusCode)
+
+	// The author sees the draft in the listing and the navigation
+	req = httptest.NewRequest(http.MethodGet, "/series/"+series.ID.String()+"/posts", nil)
+	req = userIDContext(req, authorID)
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Result().StatusCode)
+	var list api.PostList
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&list))
+	require.Len(t, list.Items, 3)
+	assert.Equal(t, first.ID, list.Items[0].Id)
+	assert.Equal(t, &api.PostSeries{
+		Id:       series.ID,
+		Title:    "Tutorial",
+		Position: 1,
+		Next:     &api.PostLink{Id: draft.ID, Slug: "part-2", Title: "Part 2"},
+	}, list.Items[0].Series)
+
+	// Other readers skip the draft
+	req = httptest.NewRequest(http.MethodGet, "/series/"+series.ID.String()+"/posts", nil)
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Result().StatusCode)
+	list = api.PostList{}
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&list))
+	require.Len(t, list.Items, 2)
+	assert.Equal(t, []uuid.UUID{first.ID, third.ID}, []uuid.UUID{list.Items[0].Id, list.Items[1].Id})
+
+	req = httptest.NewRequest(http.MethodGet, "/posts/"+third.ID.String(), nil)
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Result().StatusCode)
+	var post api.Post
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&post))
+	assert.Equal(t, &api.PostSeries{
+		Id:       series.ID,
+		Title:    "Tutorial",
+		Position: 3,
+		Previous: &api.PostLink{Id: first.ID, Slug: "part-1", Title: "Part 1"},
+	}, post.Series)
+
+	// Removing a post closes the gap
+	req = httptest.NewRequest(http.MethodDelete, "/series/"+series.ID.String()+"/posts/"+draft.ID.String(), nil)
+	req = userIDContext(req, authorID)
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusNoContent, rr.Result().StatusCode)
+	dbPost, err := engine.LookupPost(t.Context(), third.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, dbPost.SeriesPosition)
+}
+
+func TestSeriesPosts_Invalid(t *testing.T) {
+	server, r, engine, _ := setupServer(t)
+	defer server.Close()
+
+	authorID := uuid.New()
+	series := &store.Series{ID: uuid.New(), AuthorID: authorID, Title: "Tutorial"}
+	require.NoError(t, engine.SetSeries(t.Context(), series))
+	post := &store.Post{ID: uuid.New(), AuthorID: authorID, Title: "Part 1", Published: true}
+	other := &store.Post{ID: uuid.New(), AuthorID: uuid.New(), Title: "Other", Published: true}
+	unrelated := &store.Post{ID: uuid.New(), AuthorID: authorID, Title: "Unrelated", Published: true}
+	for _, p := range []*store.Post{post, other, unrelated} {
+		require.NoError(t, engine.SetPost(t.Context(), p))
+	}
+	require.NoError(t, engine.AddSeriesPost(t.Context(), series.ID, post.ID, 0))
+
+	seriesURL := "/series/" + series.ID.String()
+	tests := []struct {
+		name   string
+		method string
+		url    string
+		body   any
+		want   int
+	}{
+		{"unknown series", http.MethodPut, "/series/" + uuid.NewString() + "/posts/" + unrelated.ID.String(), nil, http.StatusNotFound},
+		{"unknown post", http.MethodPut, seriesURL + "/posts/" + uuid.NewString(), nil, http.StatusNotFound},
+		{"post of another author", http.MethodPut, seriesURL + "/posts/" + other.ID.String(), nil, http.StatusForbidden},
+		{"remove post of another series", http.MethodDelete, seriesURL + "/posts/" + unrelated.ID.String(), nil, http.StatusNotFound},
+		{"order missing a post", http.MethodPut, seriesURL + "/order", api.SeriesOrder{PostIds: []uuid.UUID{}}, http.StatusBadRequest},
+		{"order with duplicates", http.MethodPut, seriesURL + "/order", api.SeriesOrder{PostIds: []uuid.UUID{post.ID, post.ID}}, http.StatusBadRequest},
+		{"order with unknown post", http.MethodPut, seriesURL + "/order", api.SeriesOrder{PostIds: []uuid.UUID{unrelated.ID}}, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var body []byte
+			if tt.body != nil {
+				var err error
+				body, err = json.Marshal(tt.body)
+				require.NoError(t, err)
+			}
+			req := httptest.NewRequest(tt.method, tt.url, bytes.NewBuffer(body))
+			req.Header.Set("content-type", "application/json")
+			req = userIDContext(req, authorID)
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.want, rr.Result().StatusCode)
+		})
+	}
+}
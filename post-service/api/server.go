@@ -1,26 +1,144 @@
 package api
 
 import (
+	"strings"
+
+	"github.com/chrishrb/blog-microservice/internal/transport"
+	"github.com/chrishrb/blog-microservice/post-service/blob"
+	"github.com/chrishrb/blog-microservice/post-service/events"
+	"github.com/chrishrb/blog-microservice/post-service/sitemap"
 	"github.com/chrishrb/blog-microservice/post-service/store"
 	"github.com/getkin/kin-openapi/openapi3"
 	"k8s.io/utils/clock"
 )
 
+// DefaultMaxCommentDepth is how deeply replies to comments can be nested
+// unless configured otherwise.
+const DefaultMaxCommentDepth = 5
+
+// DefaultFeedLimit is the maximum number of posts in a feed unless configured
+// otherwise.
+const DefaultFeedLimit = 20
+
+// DefaultMaxMediaSize is the maximum size of uploaded files in bytes unless
+// configured otherwise.
+const DefaultMaxMediaSize = 10 << 20
+
 type Server struct {
-	engine  store.Engine
-	clock   clock.PassiveClock
-	openapi *openapi3.T
+	engine            store.Engine
+	clock             clock.PassiveClock
+	openapi           *openapi3.T
+	maxCommentDepth   int
+	commentModeration store.ModerationPolicy
+	trustedAfter      int
+	reactions         []string
+	siteTitle         string
+	siteURL           string
+	feedLimit         int
+	sitemap           *sitemap.Cache
+	blobs             blob.Store
+	maxMediaSize      int64
+	events            *events.Publisher
+}
+
+// Opt configures optional settings of a Server.
+type Opt func(s *Server)
+
+// WithMaxCommentDepth sets how deeply replies to comments can be nested. A
+// depth of 0 disables replies.
+func WithMaxCommentDepth(depth int) Opt {
+	return func(s *Server) {
+		s.maxCommentDepth = depth
+	}
 }
 
-func NewServer(engine store.Engine, clock clock.PassiveClock) (*Server, error) {
+// WithCommentModeration sets the moderation policy of new comments on posts
+// that do not set their own, and the number of approved comments after which
+// a user is trusted. A count of 0 trusts nobody based on their comments.
+func WithCommentModeration(policy store.ModerationPolicy, trustedAfter int) Opt {
+	return func(s *Server) {
+		s.commentModeration = policy
+		s.trustedAfter = trustedAfter
+	}
+}
+
+// WithReactions sets the emojis readers can react with in addition to like.
+func WithReactions(emojis ...string) Opt {
+	return func(s *Server) {
+		s.reactions = append([]string{store.ReactionLike}, emojis...)
+	}
+}
+
+// WithFeeds sets the title and base URL of the site the feeds link to, and
+// the maximum number of posts in a feed. A host without a scheme is served
+// over https, a limit below 1 keeps DefaultFeedLimit.
+func WithFeeds(title, host string, limit int) Opt {
+	return func(s *Server) {
+		s.siteTitle = title
+		s.siteURL = strings.TrimSuffix(host, "/")
+		if !strings.Contains(s.siteURL, "://") {
+			s.siteURL = "https://" + s.siteURL
+		}
+		if limit > 0 {
+			s.feedLimit = limit
+		}
+	}
+}
+
+// WithSitemap sets the cache of the sitemap, which is shared with other
+// components that change posts and invalidate it.
+func WithSitemap(cache *sitemap.Cache) Opt {
+	return func(s *Server) {
+		s.sitemap = cache
+	}
+}
+
+// WithBlobStore sets the store of the files of uploaded media. Without it
+// media cannot be uploaded.
+func WithBlobStore(blobs blob.Store) Opt {
+	return func(s *Server) {
+		s.blobs = blobs
+	}
+}
+
+// WithMaxMediaSize sets the maximum size of uploaded files in bytes. A size
+// below 1 keeps DefaultMaxMediaSize.
+func WithMaxMediaSize(size int64) Opt {
+	return func(s *Server) {
+		if size > 0 {
+			s.maxMediaSize = size
+		}
+	}
+}
+
+// WithProducer sets the producer the domain events of posts and comments are
+// published through. Without it no events are published.
+func WithProducer(producer transport.Producer) Opt {
+	return func(s *Server) {
+		s.events = events.NewPublisher(producer, s.clock)
+	}
+}
+
+func NewServer(engine store.Engine, clock clock.PassiveClock, opts ...Opt) (*Server, error) {
 	swagger, err := GetSwagger()
 	if err != nil {
 		return nil, err
 	}
 
-	return &Server{
-		engine:  engine,
-		clock:   clock,
-		openapi: swagger,
-	}, nil
+	s := &Server{
+		engine:            engine,
+		clock:             clock,
+		openapi:           swagger,
+		maxCommentDepth:   DefaultMaxCommentDepth,
+		commentModeration: store.ModerationPolicyNone,
+		reactions:         []string{store.ReactionLike},
+		feedLimit:         DefaultFeedLimit,
+		sitemap:           sitemap.NewCache(sitemap.MaxURLs),
+		maxMediaSize:      DefaultMaxMediaSize,
+		events:            events.NewPublisher(nil, clock),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s, nil
 }
@@ -7,6 +7,8 @@ import (
 	"testing"
 	"time"
 
+	"github.com/chrishrb/blog-microservice/internal/auth"
+	"github.com/chrishrb/blog-microservice/internal/transport"
 	"github.com/chrishrb/blog-microservice/internal/writeablecontext"
 	"github.com/chrishrb/blog-microservice/post-service/api"
 	"github.com/chrishrb/blog-microservice/post-service/store"
@@ -18,12 +20,12 @@ import (
 	clockTest "k8s.io/utils/clock/testing"
 )
 
-func setupServer(t *testing.T) (*httptest.Server, *chi.Mux, store.Engine, clock.PassiveClock) {
+func setupServer(t *testing.T, opts ...api.Opt) (*httptest.Server, *chi.Mux, store.Engine, clock.PassiveClock) {
 	engine := inmemory.NewStore(clock.RealClock{})
 
 	now := time.Now().UTC()
 	c := clockTest.NewFakePassiveClock(now)
-	srv, err := api.NewServer(engine, c)
+	srv, err := api.NewServer(engine, c, opts...)
 	require.NoError(t, err)
 
 	r := chi.NewRouter()
@@ -33,8 +35,85 @@ func setupServer(t *testing.T) (*httptest.Server, *chi.Mux, store.Engine, clock.
 	return server, r, engine, c
 }
 
-func userIDContext(req *http.Request, userID uuid.UUID) *http.Request {
+func userIDContext(req *http.Request, userID uuid.UUID, permissions ...string) *http.Request {
 	store := writeablecontext.NewStore()
 	store.Set("userID", userID.String())
+	store.Set("permissions", permissions)
 	return req.WithContext(context.WithValue(req.Context(), writeablecontext.ContextKey, store))
 }
+
+// authorizationTest is a caller that is checked for every mutation of a post
+// or comment, together with the status expected for it.
+type authorizationTest struct {
+	name        string
+	userID      uuid.UUID
+	permissions []string
+	want        int
+}
+
+// authorizationTests returns the callers to check for a resource of the
+// author. The allowed status is expected for callers that may modify it.
+func authorizationTests(authorID uuid.UUID, allowed int) []authorizationTest {
+	return []authorizationTest{
+		{"author", authorID, nil, allowed},
+		{"admin", uuid.New(), []string{auth.PermissionAllPostsWrite}, allowed},
+		{"moderator", uuid.New(), []string{auth.PermissionModerate}, allowed},
+		{"other user", uuid.New(), nil, http.StatusForbidden},
+		{"other user with unrelated permissions", uuid.New(), []string{auth.PermissionAllUsersWrite}, http.StatusForbidden},
+	}
+}
+
+// visibilityTest is a reader of a draft, together with whether they may see
+// it. Anonymous readers have no user ID.
+type visibilityTest struct {
+	name        string
+	userID      uuid.UUID
+	permissions []string
+	visible     bool
+}
+
+// context authenticates the request as the reader.
+func (tt visibilityTest) context(req *http.Request) *http.Request {
+	if tt.userID == uuid.Nil {
+		return req
+	}
+	return userIDContext(req, tt.userID, tt.permissions...)
+}
+
+// visibilityTests returns the readers to check for a draft of the author.
+func visibilityTests(authorID uuid.UUID) []visibilityTest {
+	return []visibilityTest{
+		{"author", authorID, nil, true},
+		{"admin", uuid.New(), []string{auth.PermissionAllPostsRead}, true},
+		{"moderator", uuid.New(), []string{auth.PermissionModerate}, false},
+		{"other user", uuid.New(), nil, false},
+		{"anonymous", uuid.Nil, nil, false},
+	}
+}
+
+// MockProducer records the messages produced by the server.
+type MockProducer struct {
+	ProducedMessages []ProducedMessage
+}
+
+type ProducedMessage struct {
+	Topic   string
+	Message *transport.Message
+}
+
+func (p *MockProducer) Produce(ctx context.Context, topic string, message *transport.Message) error {
+	p.ProducedMessages = append(p.ProducedMessages, ProducedMessage{
+		Topic:   topic,
+		Message: message,
+	})
+	return nil
+}
+
+// Topics returns the topics of the produced messages in order.
+func (p *MockProducer) Topics() []string {
+	topics := []string{}
+	for _, m := range p.ProducedMessages {
+		topics = append(topics, m.Topic)
+	}
+	return topics
+}
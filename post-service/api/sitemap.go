@@ -0,0 +1,153 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/chrishrb/blog-microservice/internal/api_utils"
+	"github.com/chrishrb/blog-microservice/post-service/sitemap"
+	"github.com/chrishrb/blog-microservice/post-service/store"
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+)
+
+// sitemapBatchSize is the number of posts and series read at once while
+// building the sitemap.
+const sitemapBatchSize = 1000
+
+func (s *Server) GetSitemap(w http.ResponseWriter, r *http.Request) {
+	pages, err := s.sitemap.Pages(r.Context(), s.buildSitemap)
+	if err != nil {
+		_ = render.Render(w, r, api_utils.ErrInternalError(err))
+		return
+	}
+	if len(pages) == 1 {
+		serveSitemap(w, r, pages[0], sitemap.EncodeURLSet)
+		return
+	}
+
+	// Large sites are split, the index links to the sitemaps next to it
+	base := s.siteURL + strings.TrimSuffix(r.URL.Path, "sitemap.xml") + "sitemaps/"
+	sitemaps := make([]sitemap.URL, len(pages))
+	for i, page := range pages {
+		sitemaps[i] = sitemap.URL{Loc: base + strconv.Itoa(i+1), LastMod: sitemap.LastMod(page)}
+	}
+	serveSitemap(w, r, sitemaps, sitemap.EncodeIndex)
+}
+
+func (s *Server) GetSitemapPage(w http.ResponseWriter, r *http.Request, page int) {
+	pages, err := s.sitemap.Pages(r.Context(), s.buildSitemap)
+	if err != nil {
+		_ = render.Render(w, r, api_utils.ErrInternalError(err))
+		return
+	}
+	if page < 1 || page > len(pages) {
+		_ = render.Render(w, r, api_utils.ErrNotFound)
+		return
+	}
+	serveSitemap(w, r, pages[page-1], sitemap.EncodeURLSet)
+}
+
+// buildSitemap returns the pages of all published posts, of the tags of
+// published posts and of the series with published posts. Tags and series
+// were last modified with their most recently updated post.
+func (s *Server) buildSitemap(ctx context.Context) ([]sitemap.URL, error) {
+	var posts []sitemap.URL
+	tags := make(map[string]*sitemap.URL)
+	var tagNames []string
+	series := make(map[uuid.UUID]*sitemap.URL)
+
+	published := true
+	query := store.PostQuery{Published: &published, Page: store.Page{Limit: sitemapBatchSize}}
+	for {
+		batch, err := s.engine.ListPosts(ctx, query)
+		if err != nil {
+			return nil, err
+		}
+		for _, post := range batch {
+			posts = append(posts, sitemap.URL{
+				Loc:     s.siteURL + "/posts/" + url.PathEscape(post.Slug),
+				LastMod: post.UpdatedAt,
+			})
+			for _, t := range post.Tags {
+				u, ok := tags[t]
+				if !ok {
+					u = &sitemap.URL{Loc: s.siteURL + "/tags/" + url.PathEscape(t)}
+					tags[t] = u
+					tagNames = append(tagNames, t)
+				}
+				if post.UpdatedAt.After(u.LastMod) {
+					u.LastMod = post.UpdatedAt
+				}
+			}
+			if post.SeriesID != nil {
+				u, ok := series[*post.SeriesID]
+				if !ok {
+					u = &sitemap.URL{Loc: s.siteURL + "/series/" + post.SeriesID.String()}
+					series[*post.SeriesID] = u
+				}
+				if post.UpdatedAt.After(u.LastMod) {
+					u.LastMod = post.UpdatedAt
+				}
+			}
+		}
+		if len(batch) < sitemapBatchSize {
+			break
+		}
+		cursor := query.Cursor(batch[len(batch)-1])
+		query.Page.After = &cursor
+	}
+
+	urls := posts
+	for _, t := range tagNames {
+		urls = append(urls, *tags[t])
+	}
+
+	// Series are listed in their order, changes of the series itself count
+	// as modifications as well
+	page := store.Page{Limit: sitemapBatchSize}
+	for {
+		batch, err := s.engine.ListSeries(ctx, nil, page)
+		if err != nil {
+			return nil, err
+		}
+		for _, ser := range batch {
+			u, ok := series[ser.ID]
+			if !ok {
+				continue
+			}
+			if ser.UpdatedAt.After(u.LastMod) {
+				u.LastMod = ser.UpdatedAt
+			}
+			urls = append(urls, *u)
+		}
+		if len(batch) < sitemapBatchSize {
+			break
+		}
+		cursor := batch[len(batch)-1].Cursor()
+		page.After = &cursor
+	}
+	return urls, nil
+}
+
+// serveSitemap responds with the encoded URLs. Conditional requests are
+// answered based on the ETag derived from the sitemap and its last
+// modification.
+func serveSitemap(w http.ResponseWriter, r *http.Request, urls []sitemap.URL, encode func([]sitemap.URL) ([]byte, error)) {
+	body, err := encode(urls)
+	if err != nil {
+		_ = render.Render(w, r, api_utils.ErrInternalError(err))
+		return
+	}
+
+	sum := sha256.Sum256(body)
+	w.Header().Set("Content-Type", sitemap.ContentType)
+	w.Header().Set("ETag", `"`+hex.EncodeToString(sum[:16])+`"`)
+	http.ServeContent(w, r, "", sitemap.LastMod(urls), bytes.NewReader(body))
+}
@@ -0,0 +1,170 @@
+package api_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/chrishrb/blog-microservice/internal/api_utils"
+	"github.com/chrishrb/blog-microservice/internal/testutil"
+	"github.com/chrishrb/blog-microservice/post-service/api"
+	"github.com/chrishrb/blog-microservice/post-service/sitemap"
+	"github.com/chrishrb/blog-microservice/post-service/store"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type sitemapEntry struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod"`
+}
+
+// getSitemap requests the sitemap and returns its entries, which are the
+// pages of a sitemap or the sitemaps of an index.
+func getSitemap(t *testing.T, r http.Handler, url string) (int, []sitemapEntry) {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, url, nil)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	if rr.Result().StatusCode != http.StatusOK {
+		return rr.Result().StatusCode, nil
+	}
+
+	assert.Equal(t, "application/xml; charset=utf-8", rr.Header().Get("Content-Type"))
+	var doc struct {
+		XMLName  xml.Name
+		URLs     []sitemapEntry `xml:"url"`
+		Sitemaps []sitemapEntry `xml:"sitemap"`
+	}
+	require.NoError(t, xml.Unmarshal(rr.Body.Bytes(), &doc))
+	if doc.XMLName.Local == "sitemapindex" {
+		return http.StatusOK, doc.Sitemaps
+	}
+	return http.StatusOK, doc.URLs
+}
+
+func TestGetSitemap(t *testing.T) {
+	server, r, engine, _ := setupServer(t, api.WithFeeds("Example", "example.com", 0))
+	defer server.Close()
+
+	first := &store.Post{ID: uuid.New(), Title: "First", Slug: "first", Tags: []string{"go"}, Published: true}
+	second := &store.Post{ID: uuid.New(), Title: "Second", Slug: "second", Tags: []string{"go", "web"}, Published: true}
+	draft := &store.Post{ID: uuid.New(), Title: "Draft", Slug: "draft", Tags: []string{"drafts"}}
+	for _, post := range []*store.Post{first, second, draft} {
+		require.NoError(t, engine.SetPost(t.Context(), post))
+		time.Sleep(time.Millisecond)
+	}
+	series := &store.Series{ID: uuid.New(), Title: "Tutorial"}
+	empty := &store.Series{ID: uuid.New(), Title: "Empty"}
+	require.NoError(t, engine.SetSeries(t.Context(), series))
+	require.NoError(t, engine.SetSeries(t.Context(), empty))
+	require.NoError(t, engine.AddSeriesPost(t.Context(), series.ID, first.ID, 0))
+	require.NoError(t, engine.AddSeriesPost(t.Context(), empty.ID, draft.ID, 0))
+
+	lastMod := func(p *store.Post) string { return p.UpdatedAt.UTC().Format(time.RFC3339) }
+	status, entries := getSitemap(t, r, "/sitemap.xml")
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, []sitemapEntry{
+		{"https://example.com/posts/first", lastMod(first)},
+		{"https://example.com/posts/second", lastMod(second)},
+		{"https://example.com/tags/go", lastMod(second)},
+		{"https://example.com/tags/web", lastMod(second)},
+		{"https://example.com/series/" + series.ID.String(), series.UpdatedAt.UTC().Format(time.RFC3339)},
+	}, entries)
+}
+
+func TestGetSitemap_Cache(t *testing.T) {
+	server, r, engine, _ := setupServer(t, api.WithFeeds("Example", "example.com", 0))
+	defer server.Close()
+
+	authorID := uuid.New()
+	post := &store.Post{ID: uuid.New(), AuthorID: authorID, Title: "First", Slug: "first", Published: true}
+	require.NoError(t, engine.SetPost(t.Context(), post))
+
+	_, entries := getSitemap(t, r, "/sitemap.xml")
+	require.Len(t, entries, 1)
+
+	// Changes bypassing the API are not seen until the cache is invalidated
+	other := &store.Post{ID: uuid.New(), AuthorID: authorID, Title: "Other", Slug: "other", Published: true}
+	require.NoError(t, engine.SetPost(t.Context(), other))
+	_, entries = getSitemap(t, r, "/sitemap.xml")
+	assert.Len(t, entries, 1)
+
+	jsonData, err := json.Marshal(api.PostUpdate{Title: testutil.Ptr("Renamed")})
+	require.NoError(t, err)
+	req := httptest.NewRequest(http.MethodPut, "/posts/"+post.ID.String(), bytes.NewBuffer(jsonData))
+	req.Header.Set("content-type", "application/json")
+	req.Header.Set("If-Match", api_utils.ETag(post.Version))
+	req = userIDContext(req, authorID)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusOK, rr.Result().StatusCode)
+
+	_, entries = getSitemap(t, r, "/sitemap.xml")
+	require.Len(t, entries, 2)
+	assert.Equal(t, "https://example.com/posts/renamed", entries[0].Loc)
+	assert.Equal(t, "https://example.com/posts/other", entries[1].Loc)
+
+	req = httptest.NewRequest(http.MethodDelete, "/posts/"+other.ID.String(), nil)
+	req = userIDContext(req, authorID)
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusNoContent, rr.Result().StatusCode)
+
+	_, entries = getSitemap(t, r, "/sitemap.xml")
+	assert.Len(t, entries, 1)
+}
+
+func TestGetSitemap_Index(t *testing.T) {
+	server, r, engine, _ := setupServer(t,
+		api.WithFeeds("Example", "example.com", 0),
+		api.WithSitemap(sitemap.NewCache(2)))
+	defer server.Close()
+
+	for _, slug := range []string{"first", "second", "third"} {
+		require.NoError(t, engine.SetPost(t.Context(), &store.Post{ID: uuid.New(), Title: slug, Slug: slug, Published: true}))
+		time.Sleep(time.Millisecond)
+	}
+
+	status, entries := getSitemap(t, r, "/sitemap.xml")
+	assert.Equal(t, http.StatusOK, status)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "https://example.com/sitemaps/1", entries[0].Loc)
+	assert.Equal(t, "https://example.com/sitemaps/2", entries[1].Loc)
+	assert.NotEmpty(t, entries[0].LastMod)
+
+	_, entries = getSitemap(t, r, "/sitemaps/1")
+	require.Len(t, entries, 2)
+	assert.Equal(t, "https://example.com/posts/first", entries[0].Loc)
+	_, entries = getSitemap(t, r, "/sitemaps/2")
+	require.Len(t, entries, 1)
+	assert.Equal(t, "https://example.com/posts/third", entries[0].Loc)
+
+	status, _ = getSitemap(t, r, "/sitemaps/3")
+	assert.Equal(t, http.StatusNotFound, status)
+}
+
+func TestGetSitemap_ConditionalRequests(t *testing.T) {
+	server, r, engine, _ := setupServer(t)
+	defer server.Close()
+
+	require.NoError(t, engine.SetPost(t.Context(), &store.Post{ID: uuid.New(), Title: "Hello", Slug: "hello", Published: true}))
+
+	req := httptest.NewRequest(http.MethodGet, "/sitemap.xml", nil)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusOK, rr.Result().StatusCode)
+	etag := rr.Header().Get("ETag")
+	require.NotEmpty(t, etag)
+
+	req = httptest.NewRequest(http.MethodGet, "/sitemap.xml", nil)
+	req.Header.Set("If-None-Match", etag)
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusNotModified, rr.Result().StatusCode)
+}
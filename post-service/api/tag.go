@@ -0,0 +1,86 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"slices"
+
+	"github.com/chrishrb/blog-microservice/internal/api_utils"
+	"github.com/chrishrb/blog-microservice/post-service/authz"
+	"github.com/chrishrb/blog-microservice/post-service/store"
+	"github.com/chrishrb/blog-microservice/post-service/tag"
+	"github.com/go-chi/render"
+)
+
+// errInvalidTag is returned for tags that are empty once normalized.
+var errInvalidTag = errors.New("tags must contain other characters than whitespace")
+
+func (s *Server) ListTags(w http.ResponseWriter, r *http.Request, params ListTagsParams) {
+	_, limit := api_utils.GetPaginationWithDefaults(nil, params.Limit)
+	query := store.TagQuery{Limit: limit}
+	if params.Prefix != nil {
+		query.Prefix = tag.Normalize(*params.Prefix)
+	}
+
+	tags, err := s.engine.ListTags(r.Context(), query)
+	if err != nil {
+		_ = render.Render(w, r, api_utils.ErrInternalError(err))
+		return
+	}
+
+	res := &TagList{Items: make([]Tag, len(tags))}
+	for i, t := range tags {
+		res.Items[i] = Tag{Name: t.Tag, Count: t.Count}
+	}
+	_ = render.Render(w, r, res)
+}
+
+func (s *Server) RenameTag(w http.ResponseWriter, r *http.Request, name string) {
+	req := new(TagRename)
+	if err := render.Bind(r, req); err != nil {
+		_ = render.Render(w, r, api_utils.ErrInvalidRequest(err))
+		return
+	}
+	s.mergeTags(w, r, []string{name}, req.Name)
+}
+
+func (s *Server) MergeTags(w http.ResponseWriter, r *http.Request) {
+	req := new(TagMerge)
+	if err := render.Bind(r, req); err != nil {
+		_ = render.Render(w, r, api_utils.ErrInvalidRequest(err))
+		return
+	}
+	s.mergeTags(w, r, req.Tags, req.Into)
+}
+
+// mergeTags replaces the source tags by the target across all posts. Tags
+// are normalized first, so sources that differ from the stored tags only in
+// case or whitespace are found as well.
+func (s *Server) mergeTags(w http.ResponseWriter, r *http.Request, sources []string, target string) {
+	if err := authz.CanManageTaxonomy(authz.CallerFromContext(r.Context())); err != nil {
+		_ = render.Render(w, r, api_utils.ErrForbidden)
+		return
+	}
+
+	target = tag.Normalize(target)
+	normalized := tag.NormalizeAll(sources)
+	if target == "" || len(normalized) == 0 {
+		_ = render.Render(w, r, api_utils.ErrInvalidRequest(errInvalidTag))
+		return
+	}
+	// Stored tags may predate normalization, so their original spelling is
+	// merged as well
+	for _, source := range sources {
+		if source != "" && !slices.Contains(normalized, source) {
+			normalized = append(normalized, source)
+		}
+	}
+
+	changed, err := s.engine.MergeTags(r.Context(), normalized, target)
+	if err != nil {
+		_ = render.Render(w, r, api_utils.ErrInternalError(err))
+		return
+	}
+	s.sitemap.Invalidate()
+	_ = render.Render(w, r, &TagMergeResult{Name: target, UpdatedPosts: changed})
+}
@@ -0,0 +1,190 @@
+package api_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/chrishrb/blog-microservice/internal/auth"
+	"github.com/chrishrb/blog-microservice/internal/testutil"
+	"github.com/chrishrb/blog-microservice/post-service/api"
+	"github.com/chrishrb/blog-microservice/post-service/store"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreatePost_NormalizesTags(t *testing.T) {
+	server, r, engine, _ := setupServer(t)
+	defer server.Close()
+
+	jsonData, err := json.Marshal(api.PostCreate{
+		Title:     "someTitle",
+		Content:   "someContent",
+		Published: testutil.Ptr(true),
+		Tags:      &[]string{"Go", "go ", "Web  Development", " "},
+	})
+	require.NoError(t, err)
+	req := httptest.NewRequest(http.MethodPost, "/posts", bytes.NewBuffer(jsonData))
+	req.Header.Set("content-type", "application/json")
+	req = userIDContext(req, uuid.New())
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusCreated, rr.Result().StatusCode)
+	var res api.Post
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&res))
+	assert.Equal(t, testutil.Ptr([]string{"go", "web-development"}), res.Tags)
+
+	dbPost, err := engine.LookupPost(t.Context(), res.Id)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"go", "web-development"}, dbPost.Tags)
+
+	// The tag filter is normalized as well
+	req = httptest.NewRequest(http.MethodGet, "/posts?tag=GO", nil)
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Result().StatusCode)
+	var list api.PostList
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&list))
+	require.Len(t, list.Items, 1)
+	assert.Equal(t, res.Id, list.Items[0].Id)
+}
+
+func TestListTags(t *testing.T) {
+	server, r, engine, _ := setupServer(t)
+	defer server.Close()
+
+	for _, post := range []*store.Post{
+		{ID: uuid.New(), Title: "Post 1", Tags: []string{"go", "web"}, Published: true},
+		{ID: uuid.New(), Title: "Post 2", Tags: []string{"go", "golang"}, Published: true},
+		{ID: uuid.New(), Title: "Draft", Tags: []string{"golang", "drafts"}},
+	} {
+		require.NoError(t, engine.SetPost(t.Context(), post))
+	}
+
+	tests := []struct {
+		name  string
+		query string
+		want  []api.Tag
+	}{
+		{"all", "", []api.Tag{{Name: "go", Count: 2}, {Name: "golang", Count: 1}, {Name: "web", Count: 1}}},
+		{"prefix", "?prefix=GO", []api.Tag{{Name: "go", Count: 2}, {Name: "golang", Count: 1}}},
+		{"limit", "?limit=1", []api.Tag{{Name: "go", Count: 2}}},
+		{"no match", "?prefix=rust", []api.Tag{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/tags"+tt.query, nil)
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+
+			assert.Equal(t, http.StatusOK, rr.Result().StatusCode)
+			var res api.TagList
+			require.NoError(t, json.NewDecoder(rr.Body).Decode(&res))
+			assert.Equal(t, tt.want, res.Items)
+		})
+	}
+}
+
+func TestRenameTag(t *testing.T) {
+	server, r, engine, _ := setupServer(t)
+	defer server.Close()
+
+	post := &store.Post{ID: uuid.New(), Title: "Post", Tags: []string{"golang", "web"}, Published: true}
+	require.NoError(t, engine.SetPost(t.Context(), post))
+
+	jsonData, err := json.Marshal(api.TagRename{Name: "Go"})
+	require.NoError(t, err)
+	req := httptest.NewRequest(http.MethodPut, "/tags/golang", bytes.NewBuffer(jsonData))
+	req.Header.Set("content-type", "application/json")
+	req = userIDContext(req, uuid.New(), auth.PermissionAllPostsWrite)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Result().StatusCode)
+	var res api.TagMergeResult
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&res))
+	assert.Equal(t, api.TagMergeResult{Name: "go", UpdatedPosts: 1}, res)
+
+	dbPost, err := engine.LookupPost(t.Context(), post.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"go", "web"}, dbPost.Tags)
+}
+
+func TestMergeTags(t *testing.T) {
+	server, r, engine, _ := setupServer(t)
+	defer server.Close()
+
+	first := &store.Post{ID: uuid.New(), Title: "Post 1", Tags: []string{"golang", "web"}, Published: true}
+	// Tags stored before they were normalized are merged as well
+	second := &store.Post{ID: uuid.New(), Title: "Post 2", Tags: []string{"Go ", "go"}, Published: true}
+	third := &store.Post{ID: uuid.New(), Title: "Post 3", Tags: []string{"rust"}, Published: true}
+	for _, post := range []*store.Post{first, second, third} {
+		require.NoError(t, engine.SetPost(t.Context(), post))
+	}
+
+	jsonData, err := json.Marshal(api.TagMerge{Tags: []string{"golang", "Go "}, Into: "go"})
+	require.NoError(t, err)
+	req := httptest.NewRequest(http.MethodPost, "/tags/merge", bytes.NewBuffer(jsonData))
+	req.Header.Set("content-type", "application/json")
+	req = userIDContext(req, uuid.New(), auth.PermissionAllPostsWrite)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Result().StatusCode)
+	var res api.TagMergeResult
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&res))
+	assert.Equal(t, api.TagMergeResult{Name: "go", UpdatedPosts: 2}, res)
+
+	tests := []struct {
+		ID   uuid.UUID
+		want []string
+	}{
+		{first.ID, []string{"go", "web"}},
+		{second.ID, []string{"go"}},
+		{third.ID, []string{"rust"}},
+	}
+	for _, tt := range tests {
+		dbPost, err := engine.LookupPost(t.Context(), tt.ID)
+		require.NoError(t, err)
+		assert.Equal(t, tt.want, dbPost.Tags)
+	}
+}
+
+func TestMergeTags_Invalid(t *testing.T) {
+	server, r, _, _ := setupServer(t)
+	defer server.Close()
+
+	admin := []string{auth.PermissionAllPostsWrite}
+	tests := []struct {
+		name        string
+		method      string
+		url         string
+		body        any
+		permissions []string
+		want        int
+	}{
+		{"rename by moderator", http.MethodPut, "/tags/go", api.TagRename{Name: "golang"}, []string{auth.PermissionModerate}, http.StatusForbidden},
+		{"merge by user", http.MethodPost, "/tags/merge", api.TagMerge{Tags: []string{"golang"}, Into: "go"}, nil, http.StatusForbidden},
+		{"empty name", http.MethodPut, "/tags/go", api.TagRename{Name: "  "}, admin, http.StatusBadRequest},
+		{"empty target", http.MethodPost, "/tags/merge", api.TagMerge{Tags: []string{"golang"}, Into: ""}, admin, http.StatusBadRequest},
+		{"no sources", http.MethodPost, "/tags/merge", api.TagMerge{Tags: []string{}, Into: "go"}, admin, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jsonData, err := json.Marshal(tt.body)
+			require.NoError(t, err)
+			req := httptest.NewRequest(tt.method, tt.url, bytes.NewBuffer(jsonData))
+			req.Header.Set("content-type", "application/json")
+			req = userIDContext(req, uuid.New(), tt.permissions...)
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.want, rr.Result().StatusCode)
+		})
+	}
+}
@@ -0,0 +1,158 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/chrishrb/blog-microservice/internal/api_utils"
+	"github.com/chrishrb/blog-microservice/post-service/authz"
+	"github.com/chrishrb/blog-microservice/post-service/store"
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+)
+
+func (s *Server) ListTrashedPosts(w http.ResponseWriter, r *http.Request, params ListTrashedPostsParams) {
+	page, err := api_utils.GetPage[store.Cursor](params.Cursor, params.Offset, params.Limit)
+	if err != nil {
+		_ = render.Render(w, r, api_utils.ErrInvalidRequest(err))
+		return
+	}
+
+	authorID := authz.TrashListingAuthor(authz.CallerFromContext(r.Context()))
+	posts, err := s.engine.ListTrashedPosts(r.Context(), authorID, page)
+	if err != nil {
+		_ = render.Render(w, r, api_utils.ErrInternalError(err))
+		return
+	}
+	posts, nextCursor := api_utils.NextPage(posts, page, (*store.Post).Cursor)
+
+	res := &PostList{
+		Items:      make([]Post, len(posts)),
+		NextCursor: nextCursor,
+	}
+	items := make([]*Post, len(posts))
+	for i, p := range posts {
+		res.Items[i] = *toPost(p)
+		items[i] = &res.Items[i]
+	}
+	if err := s.setPostReactions(r.Context(), items...); err != nil {
+		_ = render.Render(w, r, api_utils.ErrInternalError(err))
+		return
+	}
+
+	_ = render.Render(w, r, res)
+}
+
+func (s *Server) RestorePost(w http.ResponseWriter, r *http.Request, id uuid.UUID) {
+	// Check if the post is in the trash
+	post, err := s.engine.LookupTrashedPost(r.Context(), id)
+	if err != nil {
+		_ = render.Render(w, r, api_utils.ErrInternalError(err))
+		return
+	}
+	if post == nil {
+		_ = render.Render(w, r, api_utils.ErrNotFound)
+		return
+	}
+	if err := authz.CanRestore(authz.CallerFromContext(r.Context()), post.AuthorID); err != nil {
+		_ = render.Render(w, r, api_utils.ErrForbidden)
+		return
+	}
+
+	// Afterwards restore the post
+	if err := s.engine.RestorePost(r.Context(), id); err != nil {
+		_ = render.Render(w, r, api_utils.ErrInternalError(err))
+		return
+	}
+	s.sitemap.Invalidate()
+
+	post, err = s.engine.LookupPost(r.Context(), id)
+	if err != nil {
+		_ = render.Render(w, r, api_utils.ErrInternalError(err))
+		return
+	}
+	if post == nil {
+		_ = render.Render(w, r, api_utils.ErrNotFound)
+		return
+	}
+	s.events.PostRestored(r.Context(), post)
+
+	res := toPost(post)
+	if err := s.enrichPosts(r.Context(), res); err != nil {
+		_ = render.Render(w, r, api_utils.ErrInternalError(err))
+		return
+	}
+	w.Header().Set("ETag", api_utils.ETag(post.Version))
+	_ = render.Render(w, r, res)
+}
+
+func (s *Server) ListTrashedComments(w http.ResponseWriter, r *http.Request, params ListTrashedCommentsParams) {
+	page, err := api_utils.GetPage[store.Cursor](params.Cursor, params.Offset, params.Limit)
+	if err != nil {
+		_ = render.Render(w, r, api_utils.ErrInvalidRequest(err))
+		return
+	}
+
+	authorID := authz.TrashListingAuthor(authz.CallerFromContext(r.Context()))
+	comments, err := s.engine.ListTrashedComments(r.Context(), authorID, page)
+	if err != nil {
+		_ = render.Render(w, r, api_utils.ErrInternalError(err))
+		return
+	}
+	comments, nextCursor := api_utils.NextPage(comments, page, (*store.Comment).Cursor)
+
+	res := &CommentList{
+		Items:      make([]Comment, len(comments)),
+		NextCursor: nextCursor,
+	}
+	items := make([]*Comment, len(comments))
+	for i, c := range comments {
+		res.Items[i] = *toComment(c)
+		items[i] = &res.Items[i]
+	}
+	if err := s.setCommentReactions(r.Context(), items...); err != nil {
+		_ = render.Render(w, r, api_utils.ErrInternalError(err))
+		return
+	}
+
+	_ = render.Render(w, r, res)
+}
+
+func (s *Server) RestoreComment(w http.ResponseWriter, r *http.Request, postId, id uuid.UUID) {
+	// Check if the comment is in the trash
+	comment, err := s.engine.LookupTrashedComment(r.Context(), postId, id)
+	if err != nil {
+		_ = render.Render(w, r, api_utils.ErrInternalError(err))
+		return
+	}
+	if comment == nil {
+		_ = render.Render(w, r, api_utils.ErrNotFound)
+		return
+	}
+	if err := authz.CanRestore(authz.CallerFromContext(r.Context()), comment.AuthorID); err != nil {
+		_ = render.Render(w, r, api_utils.ErrForbidden)
+		return
+	}
+
+	// Afterwards restore the comment
+	if err := s.engine.RestoreComment(r.Context(), postId, id); err != nil {
+		_ = render.Render(w, r, api_utils.ErrInternalError(err))
+		return
+	}
+
+	comment, err = s.engine.LookupComment(r.Context(), postId, id)
+	if err != nil {
+		_ = render.Render(w, r, api_utils.ErrInternalError(err))
+		return
+	}
+	if comment == nil {
+		_ = render.Render(w, r, api_utils.ErrNotFound)
+		return
+	}
+
+	res := toComment(comment)
+	if err := s.setCommentReactions(r.Context(), res); err != nil {
+		_ = render.Render(w, r, api_utils.ErrInternalError(err))
+		return
+	}
+	_ = render.Render(w, r, res)
+}
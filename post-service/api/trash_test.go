@@ -0,0 +1,252 @@
+package api_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/chrishrb/blog-microservice/internal/auth"
+	"github.com/chrishrb/blog-microservice/post-service/api"
+	"github.com/chrishrb/blog-microservice/post-service/store"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// restoreTests returns the callers to check for restoring a trashed resource
+// of the author. Moderators may trash resources but not restore them.
+func restoreTests(authorID uuid.UUID) []authorizationTest {
+	return []authorizationTest{
+		{"author", authorID, nil, http.StatusOK},
+		{"admin", uuid.New(), []string{auth.PermissionAllPostsWrite}, http.StatusOK},
+		{"moderator", uuid.New(), []string{auth.PermissionModerate}, http.StatusForbidden},
+		{"other user", uuid.New(), nil, http.StatusForbidden},
+	}
+}
+
+func TestDeletePost_Trash(t *testing.T) {
+	server, r, engine, _ := setupServer(t)
+	defer server.Close()
+
+	userID := uuid.New()
+	post := &store.Post{ID: uuid.New(), AuthorID: userID, Title: "someTitle", Published: true}
+	require.NoError(t, engine.SetPost(t.Context(), post))
+
+	req := httptest.NewRequest(http.MethodDelete, fmt.Sprintf("/posts/%s", post.ID), nil)
+	req = userIDContext(req, userID)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusNoContent, rr.Result().StatusCode)
+
+	// The post is gone from normal reads but listed in the trash of its
+	// author
+	req = httptest.NewRequest(http.MethodGet, fmt.Sprintf("/posts/%s", post.ID), nil)
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusNotFound, rr.Result().StatusCode)
+
+	req = httptest.NewRequest(http.MethodGet, "/trash/posts", nil)
+	req = userIDContext(req, userID)
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusOK, rr.Result().StatusCode)
+	var res api.PostList
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&res))
+	require.Len(t, res.Items, 1)
+	assert.Equal(t, post.ID, res.Items[0].Id)
+	assert.NotNil(t, res.Items[0].DeletedAt)
+}
+
+func TestListTrashedPosts(t *testing.T) {
+	server, r, engine, _ := setupServer(t)
+	defer server.Close()
+
+	userID := uuid.New()
+	own := &store.Post{ID: uuid.New(), AuthorID: userID, Title: "Own"}
+	other := &store.Post{ID: uuid.New(), AuthorID: uuid.New(), Title: "Other"}
+	for _, post := range []*store.Post{own, other} {
+		require.NoError(t, engine.SetPost(t.Context(), post))
+		require.NoError(t, engine.TrashPost(t.Context(), post.ID))
+	}
+
+	tests := []struct {
+		name        string
+		userID      uuid.UUID
+		permissions []string
+		want        []uuid.UUID
+	}{
+		{"author", userID, nil, []uuid.UUID{own.ID}},
+		{"moderator", uuid.New(), []string{auth.PermissionModerate}, []uuid.UUID{}},
+		{"admin", uuid.New(), []string{auth.PermissionAllPostsWrite}, []uuid.UUID{own.ID, other.ID}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/trash/posts", nil)
+			req = userIDContext(req, tt.userID, tt.permissions...)
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+
+			require.Equal(t, http.StatusOK, rr.Result().StatusCode)
+			var res api.PostList
+			require.NoError(t, json.NewDecoder(rr.Body).Decode(&res))
+			IDs := []uuid.UUID{}
+			for _, item := range res.Items {
+				IDs = append(IDs, item.Id)
+			}
+			assert.ElementsMatch(t, tt.want, IDs)
+		})
+	}
+}
+
+func TestRestorePost(t *testing.T) {
+	authorID := uuid.New()
+	for _, tt := range restoreTests(authorID) {
+		t.Run(tt.name, func(t *testing.T) {
+			server, r, engine, _ := setupServer(t)
+			defer server.Close()
+
+			post := &store.Post{ID: uuid.New(), AuthorID: authorID, Title: "someTitle", Published: true}
+			require.NoError(t, engine.SetPost(t.Context(), post))
+			require.NoError(t, engine.TrashPost(t.Context(), post.ID))
+
+			req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/trash/posts/%s/restore", post.ID), nil)
+			req = userIDContext(req, tt.userID, tt.permissions...)
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.want, rr.Result().StatusCode)
+			dbPost, err := engine.LookupPost(t.Context(), post.ID)
+			require.NoError(t, err)
+			if tt.want != http.StatusOK {
+				assert.Nil(t, dbPost)
+				return
+			}
+			require.NotNil(t, dbPost)
+			var res api.Post
+			require.NoError(t, json.NewDecoder(rr.Body).Decode(&res))
+			assert.Equal(t, post.ID, res.Id)
+			assert.Nil(t, res.DeletedAt)
+		})
+	}
+}
+
+func TestRestorePost_NotFound(t *testing.T) {
+	server, r, engine, _ := setupServer(t)
+	defer server.Close()
+
+	// Posts outside of the trash cannot be restored
+	userID := uuid.New()
+	post := &store.Post{ID: uuid.New(), AuthorID: userID, Title: "someTitle"}
+	require.NoError(t, engine.SetPost(t.Context(), post))
+
+	for _, ID := range []uuid.UUID{post.ID, uuid.New()} {
+		req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/trash/posts/%s/restore", ID), nil)
+		req = userIDContext(req, userID)
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusNotFound, rr.Result().StatusCode)
+	}
+}
+
+func TestDeleteComment_Trash(t *testing.T) {
+	server, r, engine, _ := setupServer(t)
+	defer server.Close()
+
+	userID := uuid.New()
+	post := &store.Post{ID: uuid.New(), AuthorID: uuid.New(), Title: "someTitle", Published: true}
+	require.NoError(t, engine.SetPost(t.Context(), post))
+	comment := &store.Comment{ID: uuid.New(), AuthorID: userID, PostID: post.ID, Content: "Some comment"}
+	require.NoError(t, engine.SetComment(t.Context(), comment))
+
+	req := httptest.NewRequest(http.MethodDelete, fmt.Sprintf("/posts/%s/comments/%s", post.ID, comment.ID), nil)
+	req = userIDContext(req, userID)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusNoContent, rr.Result().StatusCode)
+
+	// The comment is gone from normal reads but listed in the trash of its
+	// author with its content
+	req = httptest.NewRequest(http.MethodGet, fmt.Sprintf("/posts/%s/comments/%s", post.ID, comment.ID), nil)
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusNotFound, rr.Result().StatusCode)
+
+	req = httptest.NewRequest(http.MethodGet, "/trash/comments", nil)
+	req = userIDContext(req, userID)
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusOK, rr.Result().StatusCode)
+	var res api.CommentList
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&res))
+	require.Len(t, res.Items, 1)
+	assert.Equal(t, comment.ID, res.Items[0].Id)
+	assert.Equal(t, "Some comment", res.Items[0].Content)
+	assert.NotNil(t, res.Items[0].DeletedAt)
+
+	// Others do not see it in their trash
+	req = httptest.NewRequest(http.MethodGet, "/trash/comments", nil)
+	req = userIDContext(req, uuid.New())
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusOK, rr.Result().StatusCode)
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&res))
+	assert.Empty(t, res.Items)
+}
+
+func TestRestoreComment(t *testing.T) {
+	authorID := uuid.New()
+	for _, tt := range restoreTests(authorID) {
+		t.Run(tt.name, func(t *testing.T) {
+			server, r, engine, _ := setupServer(t)
+			defer server.Close()
+
+			post := &store.Post{ID: uuid.New(), AuthorID: uuid.New(), Title: "someTitle", Published: true}
+			require.NoError(t, engine.SetPost(t.Context(), post))
+			comment := &store.Comment{ID: uuid.New(), AuthorID: authorID, PostID: post.ID, Content: "Some comment"}
+			require.NoError(t, engine.SetComment(t.Context(), comment))
+			require.NoError(t, engine.TrashComment(t.Context(), post.ID, comment.ID))
+
+			req := httptest.NewRequest(http.MethodPost,
+				fmt.Sprintf("/trash/posts/%s/comments/%s/restore", post.ID, comment.ID), nil)
+			req = userIDContext(req, tt.userID, tt.permissions...)
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.want, rr.Result().StatusCode)
+			dbComment, err := engine.LookupComment(t.Context(), post.ID, comment.ID)
+			require.NoError(t, err)
+			if tt.want != http.StatusOK {
+				assert.Nil(t, dbComment)
+				return
+			}
+			require.NotNil(t, dbComment)
+			var res api.Comment
+			require.NoError(t, json.NewDecoder(rr.Body).Decode(&res))
+			assert.Equal(t, "Some comment", res.Content)
+			assert.Nil(t, res.DeletedAt)
+		})
+	}
+}
+
+func TestRestoreComment_PostTrashed(t *testing.T) {
+	server, r, engine, _ := setupServer(t)
+	defer server.Close()
+
+	// Comments cannot be restored while their post is in the trash
+	userID := uuid.New()
+	post := &store.Post{ID: uuid.New(), AuthorID: userID, Title: "someTitle", Published: true}
+	require.NoError(t, engine.SetPost(t.Context(), post))
+	comment := &store.Comment{ID: uuid.New(), AuthorID: userID, PostID: post.ID, Content: "Some comment"}
+	require.NoError(t, engine.SetComment(t.Context(), comment))
+	require.NoError(t, engine.TrashComment(t.Context(), post.ID, comment.ID))
+	require.NoError(t, engine.TrashPost(t.Context(), post.ID))
+
+	req := httptest.NewRequest(http.MethodPost,
+		fmt.Sprintf("/trash/posts/%s/comments/%s/restore", post.ID, comment.ID), nil)
+	req = userIDContext(req, userID)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusNotFound, rr.Result().StatusCode)
+}
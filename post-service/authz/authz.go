@@ -0,0 +1,145 @@
+// Package authz decides whether the caller of a request may access a
+// resource of the post-service. Handlers check every mutation of a post or
+// comment and every read of a post through this package.
+package authz
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/chrishrb/blog-microservice/internal/auth"
+	"github.com/chrishrb/blog-microservice/post-service/store"
+	"github.com/google/uuid"
+)
+
+// ErrForbidden is returned if the caller is not allowed to perform an action.
+var ErrForbidden = errors.New("forbidden")
+
+// Caller is the authenticated user of a request.
+type Caller struct {
+	UserID      uuid.UUID
+	Permissions []string
+}
+
+// CallerFromContext returns the caller of the request, or nil if the request
+// is not authenticated.
+func CallerFromContext(ctx context.Context) *Caller {
+	userID, err := auth.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil
+	}
+	return &Caller{
+		UserID:      userID,
+		Permissions: auth.GetPermissionsFromContext(ctx),
+	}
+}
+
+// HasPermission reports whether the caller has the permission.
+func (c *Caller) HasPermission(permission string) bool {
+	return c != nil && slices.Contains(c.Permissions, permission)
+}
+
+// CanModify returns ErrForbidden unless the caller is the author of the
+// resource. Admins and moderators may modify the resources of every author.
+func CanModify(caller *Caller, authorID uuid.UUID) error {
+	if caller == nil {
+		return ErrForbidden
+	}
+	if caller.UserID == authorID {
+		return nil
+	}
+	if caller.HasPermission(auth.PermissionAllPostsWrite) || caller.HasPermission(auth.PermissionModerate) {
+		return nil
+	}
+	return ErrForbidden
+}
+
+// CanRead returns ErrForbidden if the post is a draft that the caller may
+// not see. Drafts are only visible to their author and to admins.
+func CanRead(caller *Caller, post *store.Post) error {
+	if post.VisibleTo(Reader(caller)) || caller.HasPermission(auth.PermissionAllPostsRead) {
+		return nil
+	}
+	return ErrForbidden
+}
+
+// Reader returns the user whose view of the posts the caller gets, which is
+// uuid.Nil for unauthenticated callers.
+func Reader(caller *Caller) uuid.UUID {
+	if caller == nil {
+		return uuid.Nil
+	}
+	return caller.UserID
+}
+
+// ListingReader returns the reader to restrict listings of posts to, or nil
+// if the caller may see the drafts of all authors.
+func ListingReader(caller *Caller) *uuid.UUID {
+	if caller.HasPermission(auth.PermissionAllPostsRead) {
+		return nil
+	}
+	reader := Reader(caller)
+	return &reader
+}
+
+// CanModerate returns ErrForbidden unless the caller may moderate comments,
+// which admins and moderators may.
+func CanModerate(caller *Caller) error {
+	if caller.HasPermission(auth.PermissionAllPostsWrite) || caller.HasPermission(auth.PermissionModerate) {
+		return nil
+	}
+	return ErrForbidden
+}
+
+// CanManageTaxonomy returns ErrForbidden unless the caller may rename and
+// merge tags across all posts and manage categories, which only admins may.
+func CanManageTaxonomy(caller *Caller) error {
+	if caller.HasPermission(auth.PermissionAllPostsWrite) {
+		return nil
+	}
+	return ErrForbidden
+}
+
+// CanReadComment returns ErrForbidden if the comment is held for moderation
+// or was rejected, and the caller may not see it. Pending comments are only
+// visible to their author, all comments are visible to moderators.
+func CanReadComment(caller *Caller, comment *store.Comment) error {
+	if comment.VisibleTo(Reader(caller)) || CanModerate(caller) == nil {
+		return nil
+	}
+	return ErrForbidden
+}
+
+// CommentListingReader returns the reader to restrict listings of comments
+// to, or nil if the caller may see comments in every moderation state.
+func CommentListingReader(caller *Caller) *uuid.UUID {
+	if CanModerate(caller) == nil {
+		return nil
+	}
+	reader := Reader(caller)
+	return &reader
+}
+
+// CanRestore returns ErrForbidden unless the caller may restore a trashed
+// resource, which only its author and admins may. Moderators may trash the
+// resources of every author but not bring them back.
+func CanRestore(caller *Caller, authorID uuid.UUID) error {
+	if caller == nil {
+		return ErrForbidden
+	}
+	if caller.UserID == authorID || caller.HasPermission(auth.PermissionAllPostsWrite) {
+		return nil
+	}
+	return ErrForbidden
+}
+
+// TrashListingAuthor returns the author to restrict listings of the trash
+// to, or nil if the caller is an admin and may see the trash of all authors.
+func TrashListingAuthor(caller *Caller) *uuid.UUID {
+	if caller.HasPermission(auth.PermissionAllPostsWrite) {
+		return nil
+	}
+	author := Reader(caller)
+	return &author
+}
@@ -0,0 +1,230 @@
+package authz_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/chrishrb/blog-microservice/internal/auth"
+	"github.com/chrishrb/blog-microservice/internal/writeablecontext"
+	"github.com/chrishrb/blog-microservice/post-service/authz"
+	"github.com/chrishrb/blog-microservice/post-service/store"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCallerFromContext(t *testing.T) {
+	userID := uuid.New()
+	store := writeablecontext.NewStore()
+	store.Set(auth.UserIDContextKey, userID.String())
+	store.Set(auth.PermissionsContextKey, []string{auth.PermissionModerate})
+	ctx := context.WithValue(t.Context(), writeablecontext.ContextKey, store)
+
+	caller := authz.CallerFromContext(ctx)
+	require.NotNil(t, caller)
+	assert.Equal(t, userID, caller.UserID)
+	assert.True(t, caller.HasPermission(auth.PermissionModerate))
+	assert.False(t, caller.HasPermission(auth.PermissionAllPostsWrite))
+}
+
+func TestCallerFromContext_Unauthenticated(t *testing.T) {
+	ctx := context.WithValue(t.Context(), writeablecontext.ContextKey, writeablecontext.NewStore())
+	assert.Nil(t, authz.CallerFromContext(ctx))
+	assert.Nil(t, authz.CallerFromContext(t.Context()))
+}
+
+func TestCanModify(t *testing.T) {
+	authorID := uuid.New()
+
+	tests := []struct {
+		name    string
+		caller  *authz.Caller
+		wantErr error
+	}{
+		{
+			name:   "author",
+			caller: &authz.Caller{UserID: authorID},
+		},
+		{
+			name:   "admin",
+			caller: &authz.Caller{UserID: uuid.New(), Permissions: []string{auth.PermissionAllPostsWrite}},
+		},
+		{
+			name:   "moderator",
+			caller: &authz.Caller{UserID: uuid.New(), Permissions: []string{auth.PermissionModerate}},
+		},
+		{
+			name:    "other user",
+			caller:  &authz.Caller{UserID: uuid.New()},
+			wantErr: authz.ErrForbidden,
+		},
+		{
+			name:    "other user with unrelated permissions",
+			caller:  &authz.Caller{UserID: uuid.New(), Permissions: []string{auth.PermissionAllUsersWrite}},
+			wantErr: authz.ErrForbidden,
+		},
+		{
+			name:    "unauthenticated",
+			caller:  nil,
+			wantErr: authz.ErrForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := authz.CanModify(tt.caller, authorID)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCanRead(t *testing.T) {
+	authorID := uuid.New()
+	published := &store.Post{ID: uuid.New(), AuthorID: authorID, Published: true}
+	draft := &store.Post{ID: uuid.New(), AuthorID: authorID}
+
+	tests := []struct {
+		name      string
+		caller    *authz.Caller
+		wantDraft error
+	}{
+		{
+			name:   "author",
+			caller: &authz.Caller{UserID: authorID},
+		},
+		{
+			name:   "admin",
+			caller: &authz.Caller{UserID: uuid.New(), Permissions: []string{auth.PermissionAllPostsRead}},
+		},
+		{
+			name:      "moderator",
+			caller:    &authz.Caller{UserID: uuid.New(), Permissions: []string{auth.PermissionModerate}},
+			wantDraft: authz.ErrForbidden,
+		},
+		{
+			name:      "other user",
+			caller:    &authz.Caller{UserID: uuid.New()},
+			wantDraft: authz.ErrForbidden,
+		},
+		{
+			name:      "unauthenticated",
+			caller:    nil,
+			wantDraft: authz.ErrForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.NoError(t, authz.CanRead(tt.caller, published))
+
+			err := authz.CanRead(tt.caller, draft)
+			if tt.wantDraft == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tt.wantDraft)
+			}
+		})
+	}
+}
+
+func TestListingReader(t *testing.T) {
+	userID := uuid.New()
+
+	assert.Nil(t, authz.ListingReader(&authz.Caller{UserID: userID, Permissions: []string{auth.PermissionAllPostsRead}}))
+	assert.Equal(t, &userID, authz.ListingReader(&authz.Caller{UserID: userID}))
+	assert.Equal(t, &uuid.Nil, authz.ListingReader(nil))
+}
+
+func TestCanModerate(t *testing.T) {
+	assert.NoError(t, authz.CanModerate(&authz.Caller{UserID: uuid.New(), Permissions: []string{auth.PermissionModerate}}))
+	assert.NoError(t, authz.CanModerate(&authz.Caller{UserID: uuid.New(), Permissions: []string{auth.PermissionAllPostsWrite}}))
+	assert.ErrorIs(t, authz.CanModerate(&authz.Caller{UserID: uuid.New(), Permissions: []string{auth.PermissionAllPostsRead}}), authz.ErrForbidden)
+	assert.ErrorIs(t, authz.CanModerate(nil), authz.ErrForbidden)
+}
+
+func TestCanManageTaxonomy(t *testing.T) {
+	assert.NoError(t, authz.CanManageTaxonomy(&authz.Caller{UserID: uuid.New(), Permissions: []string{auth.PermissionAllPostsWrite}}))
+	assert.ErrorIs(t, authz.CanManageTaxonomy(&authz.Caller{UserID: uuid.New(), Permissions: []string{auth.PermissionModerate}}), authz.ErrForbidden)
+	assert.ErrorIs(t, authz.CanManageTaxonomy(&authz.Caller{UserID: uuid.New()}), authz.ErrForbidden)
+	assert.ErrorIs(t, authz.CanManageTaxonomy(nil), authz.ErrForbidden)
+}
+
+func TestCanReadComment(t *testing.T) {
+	authorID := uuid.New()
+	approved := &store.Comment{ID: uuid.New(), AuthorID: authorID, State: store.ModerationStateApproved}
+	pending := &store.Comment{ID: uuid.New(), AuthorID: authorID, State: store.ModerationStatePending}
+	rejected := &store.Comment{ID: uuid.New(), AuthorID: authorID, State: store.ModerationStateRejected}
+
+	tests := []struct {
+		name         string
+		caller       *authz.Caller
+		wantPending  error
+		wantRejected error
+	}{
+		{
+			name:         "author",
+			caller:       &authz.Caller{UserID: authorID},
+			wantRejected: authz.ErrForbidden,
+		},
+		{
+			name:   "moderator",
+			caller: &authz.Caller{UserID: uuid.New(), Permissions: []string{auth.PermissionModerate}},
+		},
+		{
+			name:         "other user",
+			caller:       &authz.Caller{UserID: uuid.New()},
+			wantPending:  authz.ErrForbidden,
+			wantRejected: authz.ErrForbidden,
+		},
+		{
+			name:         "unauthenticated",
+			caller:       nil,
+			wantPending:  authz.ErrForbidden,
+			wantRejected: authz.ErrForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.NoError(t, authz.CanReadComment(tt.caller, approved))
+			for comment, want := range map[*store.Comment]error{pending: tt.wantPending, rejected: tt.wantRejected} {
+				err := authz.CanReadComment(tt.caller, comment)
+				if want == nil {
+					assert.NoError(t, err)
+				} else {
+					assert.ErrorIs(t, err, want)
+				}
+			}
+		})
+	}
+}
+
+func TestCommentListingReader(t *testing.T) {
+	userID := uuid.New()
+
+	assert.Nil(t, authz.CommentListingReader(&authz.Caller{UserID: userID, Permissions: []string{auth.PermissionModerate}}))
+	assert.Equal(t, &userID, authz.CommentListingReader(&authz.Caller{UserID: userID, Permissions: []string{auth.PermissionAllPostsRead}}))
+	assert.Equal(t, &uuid.Nil, authz.CommentListingReader(nil))
+}
+
+func TestCanRestore(t *testing.T) {
+	authorID := uuid.New()
+
+	assert.NoError(t, authz.CanRestore(&authz.Caller{UserID: authorID}, authorID))
+	assert.NoError(t, authz.CanRestore(&authz.Caller{UserID: uuid.New(), Permissions: []string{auth.PermissionAllPostsWrite}}, authorID))
+	assert.ErrorIs(t, authz.CanRestore(&authz.Caller{UserID: uuid.New(), Permissions: []string{auth.PermissionModerate}}, authorID), authz.ErrForbidden)
+	assert.ErrorIs(t, authz.CanRestore(&authz.Caller{UserID: uuid.New()}, authorID), authz.ErrForbidden)
+	assert.ErrorIs(t, authz.CanRestore(nil, authorID), authz.ErrForbidden)
+}
+
+func TestTrashListingAuthor(t *testing.T) {
+	userID := uuid.New()
+
+	assert.Nil(t, authz.TrashListingAuthor(&authz.Caller{UserID: userID, Permissions: []string{auth.PermissionAllPostsWrite}}))
+	assert.Equal(t, &userID, authz.TrashListingAuthor(&authz.Caller{UserID: userID, Permissions: []string{auth.PermissionModerate}}))
+	assert.Equal(t, &uuid.Nil, authz.TrashListingAuthor(nil))
+}
@@ -0,0 +1,101 @@
+// Package blob stores the files of uploaded media, like images and their
+// resized variants, under string keys.
+package blob
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+)
+
+// ErrNotFound is returned by Get for keys that are not stored.
+var ErrNotFound = errors.New("blob not found")
+
+// Store keeps blobs under keys made up of letters, digits, dots, dashes and
+// underscores.
+type Store interface {
+	// Put stores the content of the reader under the key, replacing any
+	// blob stored under it.
+	Put(ctx context.Context, key string, r io.Reader) error
+	// Get returns the blob stored under the key, which the caller has to
+	// close.
+	Get(ctx context.Context, key string) (io.ReadSeekCloser, error)
+	// Delete removes the blob stored under the key. Deleting a missing blob
+	// is not an error.
+	Delete(ctx context.Context, key string) error
+}
+
+var validKey = regexp.MustCompile(`^[A-Za-z0-9_-][A-Za-z0-9._-]*$`)
+
+func checkKey(key string) error {
+	if !validKey.MatchString(key) {
+		return fmt.Errorf("invalid blob key %q", key)
+	}
+	return nil
+}
+
+// LocalStore is a Store that keeps every blob in a file of a directory on the
+// local filesystem. The directory is created when the first blob is stored.
+type LocalStore struct {
+	dir string
+}
+
+func NewLocalStore(dir string) *LocalStore {
+	return &LocalStore{dir: dir}
+}
+
+func (s *LocalStore) Put(ctx context.Context, key string, r io.Reader) error {
+	if err := checkKey(key); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(s.dir, 0o750); err != nil {
+		return fmt.Errorf("creating blob directory: %w", err)
+	}
+
+	// Write to a temporary file first, so readers never see partial blobs
+	f, err := os.CreateTemp(s.dir, ".upload-*")
+	if err != nil {
+		return fmt.Errorf("storing blob %s: %w", key, err)
+	}
+	defer func() { _ = os.Remove(f.Name()) }()
+	if _, err := io.Copy(f, r); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("storing blob %s: %w", key, err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("storing blob %s: %w", key, err)
+	}
+	if err := os.Rename(f.Name(), filepath.Join(s.dir, key)); err != nil {
+		return fmt.Errorf("storing blob %s: %w", key, err)
+	}
+	return nil
+}
+
+func (s *LocalStore) Get(ctx context.Context, key string) (io.ReadSeekCloser, error) {
+	if err := checkKey(key); err != nil {
+		return nil, err
+	}
+	f, err := os.Open(filepath.Join(s.dir, key))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading blob %s: %w", key, err)
+	}
+	return f, nil
+}
+
+func (s *LocalStore) Delete(ctx context.Context, key string) error {
+	if err := checkKey(key); err != nil {
+		return err
+	}
+	err := os.Remove(filepath.Join(s.dir, key))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("deleting blob %s: %w", key, err)
+	}
+	return nil
+}
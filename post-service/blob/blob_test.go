@@ -0,0 +1,47 @@
+package blob_test
+
+import (
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/chrishrb/blog-microservice/post-service/blob"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalStore(t *testing.T) {
+	s := blob.NewLocalStore(filepath.Join(t.TempDir(), "media"))
+
+	_, err := s.Get(t.Context(), "image")
+	assert.ErrorIs(t, err, blob.ErrNotFound)
+
+	require.NoError(t, s.Put(t.Context(), "image", strings.NewReader("first")))
+	require.NoError(t, s.Put(t.Context(), "image", strings.NewReader("second")))
+
+	r, err := s.Get(t.Context(), "image")
+	require.NoError(t, err)
+	b, err := io.ReadAll(r)
+	require.NoError(t, err)
+	require.NoError(t, r.Close())
+	assert.Equal(t, "second", string(b))
+
+	require.NoError(t, s.Delete(t.Context(), "image"))
+	_, err = s.Get(t.Context(), "image")
+	assert.ErrorIs(t, err, blob.ErrNotFound)
+
+	// Deleting a missing blob is not an error
+	assert.NoError(t, s.Delete(t.Context(), "image"))
+}
+
+func TestLocalStore_InvalidKeys(t *testing.T) {
+	s := blob.NewLocalStore(t.TempDir())
+
+	for _, key := range []string{"", "..", "../image", "a/b", ".hidden"} {
+		assert.Error(t, s.Put(t.Context(), key, strings.NewReader("x")), key)
+		_, err := s.Get(t.Context(), key)
+		assert.Error(t, err, key)
+		assert.Error(t, s.Delete(t.Context(), key), key)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"log/slog"
 
+	"github.com/chrishrb/blog-microservice/post-service/api"
 	"github.com/chrishrb/blog-microservice/post-service/config"
 	"github.com/chrishrb/blog-microservice/post-service/server"
 	"github.com/spf13/cobra"
@@ -37,8 +38,17 @@ var serveCmd = &cobra.Command{
 			}
 		}()
 
+		// Start the scheduler for publishing posts and the purger of the
+		// trash
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go settings.Scheduler.Run(ctx)
+		go settings.Purger.Run(ctx)
+
 		apiServer := server.New("api", cfg.Api.Addr, nil,
-			server.NewApiHandler(settings.Api, settings.Storage, settings.JWSVerifier))
+			server.NewApiHandler(settings.Api, settings.Storage, settings.JWSVerifier,
+				api.WithSitemap(settings.Sitemap), api.WithBlobStore(settings.Blobs),
+				api.WithProducer(settings.MsgProducer)))
 		errCh := make(chan error, 1)
 		apiServer.Start(errCh)
 		err = <-errCh
@@ -17,6 +17,12 @@ type BaseConfig struct {
 	Observability ObservabilitySettingsConfig `mapstructure:"observability" json:"observability" validate:"required"`
 	Storage       StorageConfig               `mapstructure:"storage" json:"storage" validate:"required"`
 	Auth          AuthConfig                  `mapstructure:"auth" json:"auth" validate:"required"`
+	Scheduler     SchedulerConfig             `mapstructure:"scheduler" json:"scheduler" validate:"required"`
+	Trash         TrashConfig                 `mapstructure:"trash" json:"trash" validate:"required"`
+	Comments      CommentsConfig              `mapstructure:"comments" json:"comments"`
+	Reactions     ReactionsConfig             `mapstructure:"reactions" json:"reactions"`
+	Feeds         FeedsConfig                 `mapstructure:"feeds" json:"feeds"`
+	Media         MediaConfig                 `mapstructure:"media" json:"media"`
 }
 
 // DefaultConfig provides the default configuration. The configuration
@@ -49,6 +55,28 @@ var DefaultConfig = BaseConfig{
 			File: "testdata/jwt.pub.pem",
 		},
 	},
+	Scheduler: SchedulerConfig{
+		Interval: "30s",
+	},
+	Trash: TrashConfig{
+		Retention: "720h",
+		Interval:  "1h",
+	},
+	Comments: CommentsConfig{
+		MaxDepth:     5,
+		Moderation:   "none",
+		TrustedAfter: 3,
+	},
+	Reactions: ReactionsConfig{
+		Emojis: []string{"❤️", "🎉", "😂", "😮", "😢"},
+	},
+	Feeds: FeedsConfig{
+		Limit: 20,
+	},
+	Media: MediaConfig{
+		Path:    "media",
+		MaxSize: 10 << 20,
+	},
 }
 
 // Load reads YAML configuration from a reader.
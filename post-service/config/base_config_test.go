@@ -44,6 +44,28 @@ func TestParseConfig(t *testing.T) {
 				File: "testdata/jwt.pub.pem",
 			},
 		},
+		Scheduler: config.SchedulerConfig{
+			Interval: "1m",
+		},
+		Trash: config.TrashConfig{
+			Retention: "168h",
+			Interval:  "30m",
+		},
+		Comments: config.CommentsConfig{
+			MaxDepth:     3,
+			Moderation:   "trusted",
+			TrustedAfter: 2,
+		},
+		Reactions: config.ReactionsConfig{
+			Emojis: []string{"🚀", "👀"},
+		},
+		Feeds: config.FeedsConfig{
+			Limit: 50,
+		},
+		Media: config.MediaConfig{
+			Path:    "/data/media",
+			MaxSize: 5242880,
+		},
 	}
 
 	assert.Equal(t, want, cfg)
@@ -12,8 +12,14 @@ import (
 	"github.com/chrishrb/blog-microservice/internal/source"
 	"github.com/chrishrb/blog-microservice/internal/transport"
 	"github.com/chrishrb/blog-microservice/internal/transport/kafka"
+	"github.com/chrishrb/blog-microservice/post-service/blob"
+	"github.com/chrishrb/blog-microservice/post-service/events"
+	"github.com/chrishrb/blog-microservice/post-service/scheduler"
+	"github.com/chrishrb/blog-microservice/post-service/sitemap"
 	"github.com/chrishrb/blog-microservice/post-service/store"
 	"github.com/chrishrb/blog-microservice/post-service/store/inmemory"
+	"github.com/chrishrb/blog-microservice/post-service/store/sqlite"
+	"github.com/chrishrb/blog-microservice/post-service/trash"
 	"github.com/subnova/slog-exporter/slogtrace"
 	"go.opentelemetry.io/contrib/detectors/gcp"
 	"go.opentelemetry.io/otel"
@@ -30,10 +36,21 @@ import (
 )
 
 type ApiSettings struct {
-	Addr    string
-	Host    string
-	OrgName string
-	Cors    *CorsConfig
+	Addr            string
+	Host            string
+	OrgName         string
+	Cors            *CorsConfig
+	MaxCommentDepth int
+	// CommentModeration and TrustedAfter configure the moderation of new
+	// comments, see CommentsConfig.
+	CommentModeration store.ModerationPolicy
+	TrustedAfter      int
+	// Reactions are the emojis readers can react with in addition to like.
+	Reactions []string
+	// FeedLimit is the maximum number of posts in a feed.
+	FeedLimit int
+	// MaxMediaSize is the maximum size of uploaded files in bytes.
+	MaxMediaSize int64
 }
 
 type Config struct {
@@ -43,6 +60,14 @@ type Config struct {
 	Storage        store.Engine
 	MsgProducer    transport.Producer
 	JWSVerifier    auth.JWSVerifier
+	Scheduler      *scheduler.Scheduler
+	// Purger purges the posts and comments that are due from the trash.
+	Purger *trash.Purger
+	// Sitemap caches the sitemap, which the API builds and the scheduler
+	// invalidates when it publishes or unpublishes posts.
+	Sitemap *sitemap.Cache
+	// Blobs stores the files of uploaded media.
+	Blobs blob.Store
 }
 
 func Configure(ctx context.Context, cfg *BaseConfig) (c *Config, err error) {
@@ -53,10 +78,16 @@ func Configure(ctx context.Context, cfg *BaseConfig) (c *Config, err error) {
 
 	c = &Config{
 		Api: ApiSettings{
-			Addr:    cfg.Api.Addr,
-			Host:    cfg.Api.Host,
-			OrgName: cfg.Api.OrgName,
-			Cors:    cfg.Api.Cors,
+			Addr:              cfg.Api.Addr,
+			Host:              cfg.Api.Host,
+			OrgName:           cfg.Api.OrgName,
+			Cors:              cfg.Api.Cors,
+			MaxCommentDepth:   cfg.Comments.MaxDepth,
+			CommentModeration: store.ModerationPolicy(cfg.Comments.Moderation),
+			TrustedAfter:      cfg.Comments.TrustedAfter,
+			Reactions:         cfg.Reactions.Emojis,
+			FeedLimit:         cfg.Feeds.Limit,
+			MaxMediaSize:      cfg.Media.MaxSize,
 		},
 	}
 
@@ -81,6 +112,8 @@ func Configure(ctx context.Context, cfg *BaseConfig) (c *Config, err error) {
 		return nil, err
 	}
 
+	c.Blobs = blob.NewLocalStore(cfg.Media.Path)
+
 	c.MsgProducer, err = getMsgProducer(&cfg.Transport, c.Tracer)
 	if err != nil {
 		return nil, err
@@ -91,13 +124,30 @@ func Configure(ctx context.Context, cfg *BaseConfig) (c *Config, err error) {
 		return nil, err
 	}
 
+	c.Sitemap = sitemap.NewCache(sitemap.MaxURLs)
+
+	c.Scheduler, err = getScheduler(&cfg.Scheduler, c.Storage, c.Sitemap, c.MsgProducer)
+	if err != nil {
+		return nil, err
+	}
+
+	c.Purger, err = getPurger(&cfg.Trash, c.Storage)
+	if err != nil {
+		return nil, err
+	}
+
 	return
 }
 
-func getStorage(_ context.Context, cfg *StorageConfig) (engine store.Engine, err error) {
+func getStorage(ctx context.Context, cfg *StorageConfig) (engine store.Engine, err error) {
 	switch cfg.Type {
 	case "in_memory":
 		engine = inmemory.NewStore(clock.RealClock{})
+	case "sqlite":
+		engine, err = sqlite.NewStore(ctx, cfg.SqliteStorage.Path, clock.RealClock{})
+		if err != nil {
+			return nil, fmt.Errorf("create sqlite storage: %w", err)
+		}
 	default:
 		return nil, fmt.Errorf("unknown storage type: %s", cfg.Type)
 	}
@@ -105,6 +155,41 @@ func getStorage(_ context.Context, cfg *StorageConfig) (engine store.Engine, err
 	return
 }
 
+func getScheduler(cfg *SchedulerConfig, engine store.Engine, cache *sitemap.Cache, producer transport.Producer) (*scheduler.Scheduler, error) {
+	interval, err := time.ParseDuration(cfg.Interval)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse scheduler interval: %w", err)
+	}
+	if interval <= 0 {
+		return nil, fmt.Errorf("scheduler interval must be positive: %s", cfg.Interval)
+	}
+
+	publisher := events.NewPublisher(producer, clock.RealClock{})
+	return scheduler.New(engine, clock.RealClock{}, interval, scheduler.WithOnApply(func(posts []*store.Post) {
+		cache.Invalidate()
+		publisher.SchedulesApplied(context.Background(), posts)
+	})), nil
+}
+
+func getPurger(cfg *TrashConfig, engine store.Engine) (*trash.Purger, error) {
+	retention, err := time.ParseDuration(cfg.Retention)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse trash retention: %w", err)
+	}
+	if retention <= 0 {
+		return nil, fmt.Errorf("trash retention must be positive: %s", cfg.Retention)
+	}
+	interval, err := time.ParseDuration(cfg.Interval)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse trash interval: %w", err)
+	}
+	if interval <= 0 {
+		return nil, fmt.Errorf("trash interval must be positive: %s", cfg.Interval)
+	}
+
+	return trash.New(engine, clock.RealClock{}, interval, retention), nil
+}
+
 func attributeFilter(_ attribute.KeyValue) bool {
 	return true
 }
@@ -1,9 +1,11 @@
 package config_test
 
 import (
+	"path/filepath"
 	"testing"
 
 	"github.com/chrishrb/blog-microservice/post-service/config"
+	"github.com/chrishrb/blog-microservice/post-service/store"
 	clone "github.com/huandu/go-clone/generic"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -16,9 +18,15 @@ func TestConfigure(t *testing.T) {
 	require.NoError(t, err)
 
 	wantApiSettings := config.ApiSettings{
-		Addr:    "localhost:9411",
-		Host:    "localhost",
-		OrgName: "chrishrb",
+		Addr:              "localhost:9411",
+		Host:              "localhost",
+		OrgName:           "chrishrb",
+		MaxCommentDepth:   5,
+		CommentModeration: store.ModerationPolicyNone,
+		TrustedAfter:      3,
+		Reactions:         []string{"❤️", "🎉", "😂", "😮", "😢"},
+		FeedLimit:         20,
+		MaxMediaSize:      10 << 20,
 	}
 
 	assert.Equal(t, wantApiSettings, settings.Api)
@@ -27,6 +35,10 @@ func TestConfigure(t *testing.T) {
 	assert.NotNil(t, settings.Storage)
 	assert.NotNil(t, settings.MsgProducer)
 	assert.NotNil(t, settings.JWSVerifier)
+	assert.NotNil(t, settings.Scheduler)
+	assert.NotNil(t, settings.Purger)
+	assert.NotNil(t, settings.Sitemap)
+	assert.NotNil(t, settings.Blobs)
 }
 
 func TestConfigureInMemoryStorage(t *testing.T) {
@@ -37,3 +49,49 @@ func TestConfigureInMemoryStorage(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, settings.Storage)
 }
+
+func TestConfigureSqliteStorage(t *testing.T) {
+	cfg := clone.Clone(&config.DefaultConfig)
+	cfg.Storage.Type = "sqlite"
+	cfg.Storage.SqliteStorage = &config.SqliteStorageConfig{
+		Path: filepath.Join(t.TempDir(), "post-service.db"),
+	}
+
+	settings, err := config.Configure(t.Context(), cfg)
+	require.NoError(t, err)
+	require.NotNil(t, settings.Storage)
+}
+
+func TestConfigureSqliteStorageRequiresPath(t *testing.T) {
+	cfg := clone.Clone(&config.DefaultConfig)
+	cfg.Storage.Type = "sqlite"
+
+	_, err := config.Configure(t.Context(), cfg)
+	assert.Error(t, err)
+}
+
+func TestConfigureInvalidSchedulerInterval(t *testing.T) {
+	for _, interval := range []string{"soon", "0s"} {
+		cfg := clone.Clone(&config.DefaultConfig)
+		cfg.Scheduler.Interval = interval
+
+		_, err := config.Configure(t.Context(), cfg)
+		assert.Error(t, err, interval)
+	}
+}
+
+func TestConfigureInvalidTrash(t *testing.T) {
+	for _, value := range []string{"soon", "0s"} {
+		cfg := clone.Clone(&config.DefaultConfig)
+		cfg.Trash.Retention = value
+
+		_, err := config.Configure(t.Context(), cfg)
+		assert.Error(t, err, value)
+
+		cfg = clone.Clone(&config.DefaultConfig)
+		cfg.Trash.Interval = value
+
+		_, err = config.Configure(t.Context(), cfg)
+		assert.Error(t, err, value)
+	}
+}
@@ -18,3 +18,48 @@ type ObservabilitySettingsConfig struct {
 	OtelCollectorAddr string `mapstructure:"otel_collector_addr" json:"otel_collector_addr"`
 	TlsKeylogFile     string `mapstructure:"tls_keylog_file" json:"tls_keylog_file"`
 }
+
+type CommentsConfig struct {
+	// MaxDepth is how deeply replies to comments can be nested, 0 disables
+	// replies.
+	MaxDepth int `mapstructure:"max_depth" json:"max_depth" validate:"min=0"`
+	// Moderation is the policy for new comments on posts that do not set
+	// their own: none approves all comments, trusted holds the comments of
+	// users that are not trusted and all holds every comment.
+	Moderation string `mapstructure:"moderation" json:"moderation" validate:"oneof=none trusted all"`
+	// TrustedAfter is the number of approved comments after which a user is
+	// trusted, 0 trusts nobody based on their comments.
+	TrustedAfter int `mapstructure:"trusted_after" json:"trusted_after" validate:"min=0"`
+}
+
+type ReactionsConfig struct {
+	// Emojis are the reactions readers can give in addition to like.
+	Emojis []string `mapstructure:"emojis" json:"emojis" validate:"unique,dive,required"`
+}
+
+type SchedulerConfig struct {
+	// Interval is the time between two checks for posts that are due to be
+	// published or unpublished.
+	Interval string `mapstructure:"interval" json:"interval" validate:"required"`
+}
+
+type TrashConfig struct {
+	// Retention is how long deleted posts and comments are kept in the trash
+	// before they are purged.
+	Retention string `mapstructure:"retention" json:"retention" validate:"required"`
+	// Interval is the time between two checks for items that are due to be
+	// purged.
+	Interval string `mapstructure:"interval" json:"interval" validate:"required"`
+}
+
+type FeedsConfig struct {
+	// Limit is the maximum number of posts in a feed.
+	Limit int `mapstructure:"limit" json:"limit" validate:"min=1"`
+}
+
+type MediaConfig struct {
+	// Path is the directory the files of uploaded media are stored in.
+	Path string `mapstructure:"path" json:"path" validate:"required"`
+	// MaxSize is the maximum size of uploaded files in bytes.
+	MaxSize int64 `mapstructure:"max_size" json:"max_size" validate:"min=1"`
+}
@@ -0,0 +1,122 @@
+// Package diff computes line-based differences between texts, which are used
+// to compare revisions of a post.
+package diff
+
+import (
+	"slices"
+	"strings"
+)
+
+// Op is the kind of change of a line.
+type Op string
+
+const (
+	OpEqual  Op = "equal"
+	OpInsert Op = "insert"
+	OpDelete Op = "delete"
+)
+
+// Line is a line of a diff. Equal and deleted lines are taken from the old
+// text, inserted lines from the new text.
+type Line struct {
+	Op   Op
+	Text string
+}
+
+// Lines returns a shortest edit script that turns the text a into the text
+// b. Within a change, deleted lines come before inserted lines.
+func Lines(a, b string) []Line {
+	return diff(splitLines(a), splitLines(b))
+}
+
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	return strings.Split(text, "\n")
+}
+
+func diff(a, b []string) []Line {
+	// Lines at the start and end are often unchanged, which keeps the part
+	// the edit script has to be searched for small.
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	lines := make([]Line, 0, len(a)+len(b)-prefix-suffix)
+	for _, text := range a[:prefix] {
+		lines = append(lines, Line{OpEqual, text})
+	}
+	lines = append(lines, myers(a[prefix:len(a)-suffix], b[prefix:len(b)-suffix])...)
+	for _, text := range a[len(a)-suffix:] {
+		lines = append(lines, Line{OpEqual, text})
+	}
+	return lines
+}
+
+// myers implements the greedy algorithm of "An O(ND) Difference Algorithm
+// and Its Variations" by Eugene W. Myers. v[offset+k] is the furthest x
+// reached on diagonal k = x - y, the states of v are kept to backtrack the
+// edit script.
+func myers(a, b []string) []Line {
+	n, m := len(a), len(b)
+	offset := n + m + 1
+	v := make([]int, 2*offset+1)
+
+	var trace [][]int
+	for d := 0; d <= n+m; d++ {
+		trace = append(trace, slices.Clone(v))
+		for k := -d; k <= d; k += 2 {
+			x := v[offset+k-1] + 1
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[offset+k] = x
+			if x >= n && y >= m {
+				return backtrack(a, b, trace, offset)
+			}
+		}
+	}
+	return nil
+}
+
+func backtrack(a, b []string, trace [][]int, offset int) []Line {
+	var lines []Line
+	x, y := len(a), len(b)
+	for d := len(trace) - 1; d >= 0; d-- {
+		v := trace[d]
+		k := x - y
+		prevK := k - 1
+		if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+			prevK = k + 1
+		}
+		prevX := v[offset+prevK]
+		prevY := prevX - prevK
+
+		for x > prevX && y > prevY {
+			lines = append(lines, Line{OpEqual, a[x-1]})
+			x--
+			y--
+		}
+		if d > 0 {
+			if x == prevX {
+				lines = append(lines, Line{OpInsert, b[y-1]})
+			} else {
+				lines = append(lines, Line{OpDelete, a[x-1]})
+			}
+		}
+		x, y = prevX, prevY
+	}
+	slices.Reverse(lines)
+	return lines
+}
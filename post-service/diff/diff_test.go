@@ -0,0 +1,88 @@
+package diff_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/chrishrb/blog-microservice/post-service/diff"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLines(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+		want []diff.Line
+	}{
+		{
+			name: "empty",
+			want: []diff.Line{},
+		},
+		{
+			name: "equal",
+			a:    "a\nb",
+			b:    "a\nb",
+			want: []diff.Line{{diff.OpEqual, "a"}, {diff.OpEqual, "b"}},
+		},
+		{
+			name: "insert",
+			a:    "a\nc",
+			b:    "a\nb\nc",
+			want: []diff.Line{{diff.OpEqual, "a"}, {diff.OpInsert, "b"}, {diff.OpEqual, "c"}},
+		},
+		{
+			name: "delete",
+			a:    "a\nb\nc",
+			b:    "a\nc",
+			want: []diff.Line{{diff.OpEqual, "a"}, {diff.OpDelete, "b"}, {diff.OpEqual, "c"}},
+		},
+		{
+			name: "replace",
+			a:    "a\nb\nc",
+			b:    "a\nx\nc",
+			want: []diff.Line{{diff.OpEqual, "a"}, {diff.OpDelete, "b"}, {diff.OpInsert, "x"}, {diff.OpEqual, "c"}},
+		},
+		{
+			name: "from empty",
+			b:    "a\nb",
+			want: []diff.Line{{diff.OpInsert, "a"}, {diff.OpInsert, "b"}},
+		},
+		{
+			name: "to empty",
+			a:    "a\nb",
+			want: []diff.Line{{diff.OpDelete, "a"}, {diff.OpDelete, "b"}},
+		},
+		{
+			name: "moved line",
+			a:    "a\nb\nc\nd",
+			b:    "b\nc\na\nd",
+			want: []diff.Line{
+				{diff.OpDelete, "a"}, {diff.OpEqual, "b"}, {diff.OpEqual, "c"}, {diff.OpInsert, "a"}, {diff.OpEqual, "d"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, diff.Lines(tt.a, tt.b))
+		})
+	}
+}
+
+// TestLines_Apply checks that the diff turns the old text into the new text.
+func TestLines_Apply(t *testing.T) {
+	a := "the\nquick\nbrown\nfox\njumps\nover\nthe\nlazy\ndog"
+	b := "a\nquick\nfox\njumps\nhigh\nover\nthe\ndog\n"
+
+	var old, updated []string
+	for _, line := range diff.Lines(a, b) {
+		if line.Op != diff.OpInsert {
+			old = append(old, line.Text)
+		}
+		if line.Op != diff.OpDelete {
+			updated = append(updated, line.Text)
+		}
+	}
+	assert.Equal(t, a, strings.Join(old, "\n"))
+	assert.Equal(t, b, strings.Join(updated, "\n"))
+}
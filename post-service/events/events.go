@@ -0,0 +1,169 @@
+// Package events publishes the domain events of the post-service to the
+// message broker, see the topics in transport.
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"time"
+
+	"github.com/chrishrb/blog-microservice/internal/transport"
+	"github.com/chrishrb/blog-microservice/post-service/store"
+	"github.com/google/uuid"
+	"k8s.io/utils/clock"
+)
+
+// Publisher turns changes of posts and comments into events. Events are
+// published after the change is stored, so a failure to publish them is
+// logged instead of failing the change.
+type Publisher struct {
+	producer transport.Producer
+	clock    clock.PassiveClock
+}
+
+// NewPublisher returns a Publisher that sends the events through the
+// producer. Without a producer the events are dropped.
+func NewPublisher(producer transport.Producer, clock clock.PassiveClock) *Publisher {
+	return &Publisher{
+		producer: producer,
+		clock:    clock,
+	}
+}
+
+// PostCreated publishes that the post was created, and that it was published
+// if it is.
+func (p *Publisher) PostCreated(ctx context.Context, post *store.Post) {
+	p.publish(ctx, transport.PostCreatedTopic, transport.PostCreatedEvent{
+		PostID:    post.ID,
+		AuthorID:  post.AuthorID,
+		Title:     post.Title,
+		Slug:      post.Slug,
+		Tags:      post.Tags,
+		Published: post.Published,
+		Version:   post.Version,
+		CreatedAt: post.CreatedAt,
+	})
+	if post.Published {
+		p.postPublished(ctx, post)
+	}
+}
+
+// PostUpdated publishes that the post was changed, and that it was published
+// if it was not published before.
+func (p *Publisher) PostUpdated(ctx context.Context, post *store.Post, wasPublished bool) {
+	p.postUpdated(ctx, post, post.UpdatedAt)
+	if post.Published && !wasPublished {
+		p.postPublished(ctx, post)
+	}
+}
+
+// SchedulesApplied publishes the changes of posts whose schedules were
+// applied, see scheduler.WithOnApply.
+func (p *Publisher) SchedulesApplied(ctx context.Context, posts []*store.Post) {
+	for _, post := range posts {
+		p.postUpdated(ctx, post, post.UpdatedAt)
+		if post.Published {
+			p.postPublished(ctx, post)
+		}
+	}
+}
+
+// PostRestored publishes that the post was moved out of the trash, which
+// consumers see as an update that brings it back.
+func (p *Publisher) PostRestored(ctx context.Context, post *store.Post) {
+	p.postUpdated(ctx, post, p.clock.Now())
+}
+
+// PostDeleted publishes that the post was moved to the trash.
+func (p *Publisher) PostDeleted(ctx context.Context, post *store.Post) {
+	p.publish(ctx, transport.PostDeletedTopic, transport.PostDeletedEvent{
+		PostID:    post.ID,
+		AuthorID:  post.AuthorID,
+		DeletedAt: p.clock.Now(),
+	})
+}
+
+// CommentCreated publishes that the comment was created on the post.
+func (p *Publisher) CommentCreated(ctx context.Context, post *store.Post, comment *store.Comment) {
+	p.publish(ctx, transport.CommentCreatedTopic, transport.CommentCreatedEvent{
+		CommentID:    comment.ID,
+		PostID:       post.ID,
+		PostAuthorID: post.AuthorID,
+		PostTitle:    post.Title,
+		PostSlug:     post.Slug,
+		AuthorID:     comment.AuthorID,
+		ParentID:     comment.ParentID,
+		Content:      comment.Content,
+		State:        string(comment.State),
+		CreatedAt:    comment.CreatedAt,
+	})
+}
+
+// CommentApproved publishes that a moderator approved the comment on the
+// post.
+func (p *Publisher) CommentApproved(ctx context.Context, post *store.Post, comment *store.Comment) {
+	p.publish(ctx, transport.CommentApprovedTopic, transport.CommentApprovedEvent{
+		CommentID:    comment.ID,
+		PostID:       post.ID,
+		PostAuthorID: post.AuthorID,
+		PostTitle:    post.Title,
+		PostSlug:     post.Slug,
+		AuthorID:     comment.AuthorID,
+		ParentID:     comment.ParentID,
+		Content:      comment.Content,
+		ApprovedAt:   p.clock.Now(),
+	})
+}
+
+// CommentDeleted publishes that the comment was moved to the trash.
+func (p *Publisher) CommentDeleted(ctx context.Context, comment *store.Comment) {
+	p.publish(ctx, transport.CommentDeletedTopic, transport.CommentDeletedEvent{
+		CommentID: comment.ID,
+		PostID:    comment.PostID,
+		AuthorID:  comment.AuthorID,
+		DeletedAt: p.clock.Now(),
+	})
+}
+
+func (p *Publisher) postUpdated(ctx context.Context, post *store.Post, updatedAt time.Time) {
+	p.publish(ctx, transport.PostUpdatedTopic, transport.PostUpdatedEvent{
+		PostID:    post.ID,
+		AuthorID:  post.AuthorID,
+		Title:     post.Title,
+		Slug:      post.Slug,
+		Tags:      post.Tags,
+		Published: post.Published,
+		Version:   post.Version,
+		UpdatedAt: updatedAt,
+	})
+}
+
+func (p *Publisher) postPublished(ctx context.Context, post *store.Post) {
+	p.publish(ctx, transport.PostPublishedTopic, transport.PostPublishedEvent{
+		PostID:      post.ID,
+		AuthorID:    post.AuthorID,
+		Title:       post.Title,
+		Slug:        post.Slug,
+		PublishedAt: p.clock.Now(),
+	})
+}
+
+// publish sends the event to the topic.
+func (p *Publisher) publish(ctx context.Context, topic string, event any) {
+	if p.producer == nil {
+		return
+	}
+	data, err := json.Marshal(event)
+	if err != nil {
+		slog.Error("encoding event", slog.String("topic", topic), "err", err)
+		return
+	}
+	err = p.producer.Produce(ctx, topic, &transport.Message{
+		ID:   uuid.New().String(),
+		Data: data,
+	})
+	if err != nil {
+		slog.Error("publishing event", slog.String("topic", topic), "err", err)
+	}
+}
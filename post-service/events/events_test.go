@@ -0,0 +1,225 @@
+package events_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/chrishrb/blog-microservice/internal/transport"
+	"github.com/chrishrb/blog-microservice/post-service/events"
+	"github.com/chrishrb/blog-microservice/post-service/store"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	clock_testing "k8s.io/utils/clock/testing"
+)
+
+type producedMessage struct {
+	Topic   string
+	Message *transport.Message
+}
+
+// fakeProducer records the produced messages and fails with err if set.
+type fakeProducer struct {
+	messages []producedMessage
+	err      error
+}
+
+func (p *fakeProducer) Produce(ctx context.Context, topic string, message *transport.Message) error {
+	p.messages = append(p.messages, producedMessage{Topic: topic, Message: message})
+	return p.err
+}
+
+func (p *fakeProducer) topics() []string {
+	topics := []string{}
+	for _, m := range p.messages {
+		topics = append(topics, m.Topic)
+	}
+	return topics
+}
+
+func TestPostCreated(t *testing.T) {
+	fakeClock := clock_testing.NewFakePassiveClock(time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC))
+	producer := &fakeProducer{}
+	publisher := events.NewPublisher(producer, fakeClock)
+
+	post := &store.Post{
+		ID:        uuid.New(),
+		AuthorID:  uuid.New(),
+		Title:     "Some Title",
+		Slug:      "some-title",
+		Tags:      []string{"go"},
+		Version:   1,
+		CreatedAt: fakeClock.Now().Add(-time.Second),
+	}
+
+	// Drafts are not published
+	publisher.PostCreated(t.Context(), post)
+	require.Equal(t, []string{transport.PostCreatedTopic}, producer.topics())
+	assert.NotEmpty(t, producer.messages[0].Message.ID)
+	var created transport.PostCreatedEvent
+	require.NoError(t, json.Unmarshal(producer.messages[0].Message.Data, &created))
+	assert.Equal(t, transport.PostCreatedEvent{
+		PostID:    post.ID,
+		AuthorID:  post.AuthorID,
+		Title:     "Some Title",
+		Slug:      "some-title",
+		Tags:      []string{"go"},
+		Version:   1,
+		CreatedAt: post.CreatedAt,
+	}, created)
+
+	producer.messages = nil
+	post.Published = true
+	publisher.PostCreated(t.Context(), post)
+	require.Equal(t, []string{transport.PostCreatedTopic, transport.PostPublishedTopic}, producer.topics())
+	var published transport.PostPublishedEvent
+	require.NoError(t, json.Unmarshal(producer.messages[1].Message.Data, &published))
+	assert.Equal(t, post.ID, published.PostID)
+	assert.Equal(t, fakeClock.Now(), published.PublishedAt)
+}
+
+func TestPostUpdated(t *testing.T) {
+	fakeClock := clock_testing.NewFakePassiveClock(time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC))
+	post := &store.Post{ID: uuid.New(), AuthorID: uuid.New(), Title: "Some Title", Published: true}
+
+	tests := []struct {
+		name         string
+		wasPublished bool
+		published    bool
+		want         []string
+	}{
+		{"publish", false, true, []string{transport.PostUpdatedTopic, transport.PostPublishedTopic}},
+		{"stay published", true, true, []string{transport.PostUpdatedTopic}},
+		{"unpublish", true, false, []string{transport.PostUpdatedTopic}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			producer := &fakeProducer{}
+			post.Published = tt.published
+			events.NewPublisher(producer, fakeClock).PostUpdated(t.Context(), post, tt.wasPublished)
+			assert.Equal(t, tt.want, producer.topics())
+		})
+	}
+}
+
+func TestSchedulesApplied(t *testing.T) {
+	fakeClock := clock_testing.NewFakePassiveClock(time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC))
+	producer := &fakeProducer{}
+
+	published := &store.Post{ID: uuid.New(), AuthorID: uuid.New(), Title: "Published", Published: true}
+	unpublished := &store.Post{
+		ID:        uuid.New(),
+		AuthorID:  uuid.New(),
+		Title:     "Unpublished",
+		UpdatedAt: fakeClock.Now().Add(-time.Minute),
+	}
+	events.NewPublisher(producer, fakeClock).SchedulesApplied(t.Context(), []*store.Post{published, unpublished})
+
+	assert.Equal(t, []string{transport.PostUpdatedTopic, transport.PostPublishedTopic, transport.PostUpdatedTopic},
+		producer.topics())
+	var updated transport.PostUpdatedEvent
+	require.NoError(t, json.Unmarshal(producer.messages[2].Message.Data, &updated))
+	assert.Equal(t, unpublished.ID, updated.PostID)
+	assert.Equal(t, unpublished.UpdatedAt, updated.UpdatedAt)
+}
+
+func TestCommentCreated(t *testing.T) {
+	fakeClock := clock_testing.NewFakePassiveClock(time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC))
+	producer := &fakeProducer{}
+
+	post := &store.Post{ID: uuid.New(), AuthorID: uuid.New(), Title: "Some Title", Slug: "some-title"}
+	parentID := uuid.New()
+	comment := &store.Comment{
+		ID:        uuid.New(),
+		PostID:    post.ID,
+		AuthorID:  uuid.New(),
+		ParentID:  &parentID,
+		Content:   "Some Comment",
+		State:     store.ModerationStatePending,
+		CreatedAt: fakeClock.Now(),
+	}
+	events.NewPublisher(producer, fakeClock).CommentCreated(t.Context(), post, comment)
+
+	require.Equal(t, []string{transport.CommentCreatedTopic}, producer.topics())
+	var created transport.CommentCreatedEvent
+	require.NoError(t, json.Unmarshal(producer.messages[0].Message.Data, &created))
+	assert.Equal(t, transport.CommentCreatedEvent{
+		CommentID:    comment.ID,
+		PostID:       post.ID,
+		PostAuthorID: post.AuthorID,
+		PostTitle:    "Some Title",
+		PostSlug:     "some-title",
+		AuthorID:     comment.AuthorID,
+		ParentID:     &parentID,
+		Content:      "Some Comment",
+		State:        "pending",
+		CreatedAt:    fakeClock.Now(),
+	}, created)
+}
+
+func TestCommentApproved(t *testing.T) {
+	fakeClock := clock_testing.NewFakePassiveClock(time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC))
+	producer := &fakeProducer{}
+
+	post := &store.Post{ID: uuid.New(), AuthorID: uuid.New(), Title: "Some Title", Slug: "some-title"}
+	comment := &store.Comment{
+		ID:        uuid.New(),
+		PostID:    post.ID,
+		AuthorID:  uuid.New(),
+		Content:   "Some Comment",
+		State:     store.ModerationStateApproved,
+		CreatedAt: fakeClock.Now().Add(-time.Hour),
+	}
+	events.NewPublisher(producer, fakeClock).CommentApproved(t.Context(), post, comment)
+
+	require.Equal(t, []string{transport.CommentApprovedTopic}, producer.topics())
+	var approved transport.CommentApprovedEvent
+	require.NoError(t, json.Unmarshal(producer.messages[0].Message.Data, &approved))
+	assert.Equal(t, transport.CommentApprovedEvent{
+		CommentID:    comment.ID,
+		PostID:       post.ID,
+		PostAuthorID: post.AuthorID,
+		PostTitle:    "Some Title",
+		PostSlug:     "some-title",
+		AuthorID:     comment.AuthorID,
+		Content:      "Some Comment",
+		ApprovedAt:   fakeClock.Now(),
+	}, approved)
+}
+
+func TestDeleted(t *testing.T) {
+	fakeClock := clock_testing.NewFakePassiveClock(time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC))
+	producer := &fakeProducer{}
+	publisher := events.NewPublisher(producer, fakeClock)
+
+	post := &store.Post{ID: uuid.New(), AuthorID: uuid.New(), Title: "Some Title"}
+	comment := &store.Comment{ID: uuid.New(), PostID: post.ID, AuthorID: uuid.New()}
+	publisher.PostDeleted(t.Context(), post)
+	publisher.CommentDeleted(t.Context(), comment)
+
+	require.Equal(t, []string{transport.PostDeletedTopic, transport.CommentDeletedTopic}, producer.topics())
+	var postDeleted transport.PostDeletedEvent
+	require.NoError(t, json.Unmarshal(producer.messages[0].Message.Data, &postDeleted))
+	assert.Equal(t, transport.PostDeletedEvent{PostID: post.ID, AuthorID: post.AuthorID, DeletedAt: fakeClock.Now()}, postDeleted)
+	var commentDeleted transport.CommentDeletedEvent
+	require.NoError(t, json.Unmarshal(producer.messages[1].Message.Data, &commentDeleted))
+	assert.Equal(t, transport.CommentDeletedEvent{
+		CommentID: comment.ID, PostID: post.ID, AuthorID: comment.AuthorID, DeletedAt: fakeClock.Now(),
+	}, commentDeleted)
+}
+
+func TestPublish_Failure(t *testing.T) {
+	fakeClock := clock_testing.NewFakePassiveClock(time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC))
+
+	// Failures are logged and do not stop further events
+	producer := &fakeProducer{err: errors.New("broker unavailable")}
+	post := &store.Post{ID: uuid.New(), AuthorID: uuid.New(), Title: "Some Title", Published: true}
+	events.NewPublisher(producer, fakeClock).PostCreated(t.Context(), post)
+	assert.Equal(t, []string{transport.PostCreatedTopic, transport.PostPublishedTopic}, producer.topics())
+
+	// Without a producer events are dropped
+	events.NewPublisher(nil, fakeClock).PostCreated(t.Context(), post)
+}
@@ -0,0 +1,137 @@
+package feed_test
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"testing"
+	"time"
+
+	"github.com/chrishrb/blog-microservice/post-service/feed"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testFeed() *feed.Feed {
+	created := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	return &feed.Feed{
+		Title:  "Example",
+		Link:   "https://example.com",
+		Self:   "https://example.com/feeds/atom.xml",
+		Author: "Example",
+		Items: []feed.Item{
+			{
+				ID:          "urn:uuid:1",
+				Title:       "Second <post>",
+				Link:        "https://example.com/posts/second",
+				ContentHTML: "<p>Hello &amp; welcome</p>",
+				Tags:        []string{"go", "web"},
+				Published:   created.Add(time.Hour),
+				Updated:     created.Add(2 * time.Hour),
+			},
+			{
+				ID:        "urn:uuid:2",
+				Title:     "First",
+				Link:      "https://example.com/posts/first",
+				Published: created,
+				Updated:   created.Add(3 * time.Hour),
+			},
+		},
+	}
+}
+
+func TestUpdated(t *testing.T) {
+	assert.Equal(t, time.Date(2023, 1, 1, 15, 0, 0, 0, time.UTC), testFeed().Updated())
+	assert.Equal(t, time.Unix(0, 0).UTC(), (&feed.Feed{}).Updated())
+}
+
+func TestRSS(t *testing.T) {
+	b, err := testFeed().RSS()
+	require.NoError(t, err)
+
+	var doc struct {
+		Version string `xml:"version,attr"`
+		Channel struct {
+			Title         string `xml:"title"`
+			LastBuildDate string `xml:"lastBuildDate"`
+			Items         []struct {
+				Title       string   `xml:"title"`
+				Link        string   `xml:"link"`
+				GUID        string   `xml:"guid"`
+				PubDate     string   `xml:"pubDate"`
+				Categories  []string `xml:"category"`
+				Description string   `xml:"description"`
+			} `xml:"item"`
+		} `xml:"channel"`
+	}
+	require.NoError(t, xml.Unmarshal(b, &doc))
+	assert.Equal(t, "2.0", doc.Version)
+	assert.Equal(t, "Example", doc.Channel.Title)
+	assert.Equal(t, "Sun, 01 Jan 2023 15:00:00 +0000", doc.Channel.LastBuildDate)
+	require.Len(t, doc.Channel.Items, 2)
+	item := doc.Channel.Items[0]
+	assert.Equal(t, "Second <post>", item.Title)
+	assert.Equal(t, "https://example.com/posts/second", item.Link)
+	assert.Equal(t, "urn:uuid:1", item.GUID)
+	assert.Equal(t, "Sun, 01 Jan 2023 13:00:00 +0000", item.PubDate)
+	assert.Equal(t, []string{"go", "web"}, item.Categories)
+	assert.Equal(t, "<p>Hello &amp; welcome</p>", item.Description)
+}
+
+func TestAtom(t *testing.T) {
+	b, err := testFeed().Atom()
+	require.NoError(t, err)
+
+	var doc struct {
+		XMLName xml.Name `xml:"http://www.w3.org/2005/Atom feed"`
+		ID      string   `xml:"id"`
+		Updated string   `xml:"updated"`
+		Author  string   `xml:"author>name"`
+		Entries []struct {
+			ID         string `xml:"id"`
+			Published  string `xml:"published"`
+			Updated    string `xml:"updated"`
+			Categories []struct {
+				Term string `xml:"term,attr"`
+			} `xml:"category"`
+			Content struct {
+				Type  string `xml:"type,attr"`
+				Value string `xml:",chardata"`
+			} `xml:"content"`
+		} `xml:"entry"`
+	}
+	require.NoError(t, xml.Unmarshal(b, &doc))
+	assert.Equal(t, "https://example.com/feeds/atom.xml", doc.ID)
+	assert.Equal(t, "2023-01-01T15:00:00Z", doc.Updated)
+	assert.Equal(t, "Example", doc.Author)
+	require.Len(t, doc.Entries, 2)
+	entry := doc.Entries[0]
+	assert.Equal(t, "urn:uuid:1", entry.ID)
+	assert.Equal(t, "2023-01-01T13:00:00Z", entry.Published)
+	assert.Equal(t, "2023-01-01T14:00:00Z", entry.Updated)
+	require.Len(t, entry.Categories, 2)
+	assert.Equal(t, "go", entry.Categories[0].Term)
+	assert.Equal(t, "html", entry.Content.Type)
+	assert.Equal(t, "<p>Hello &amp; welcome</p>", entry.Content.Value)
+}
+
+func TestJSON(t *testing.T) {
+	b, err := testFeed().JSON()
+	require.NoError(t, err)
+
+	var doc map[string]any
+	require.NoError(t, json.Unmarshal(b, &doc))
+	assert.Equal(t, "https://jsonfeed.org/version/1.1", doc["version"])
+	assert.Equal(t, "https://example.com/feeds/atom.xml", doc["feed_url"])
+	items := doc["items"].([]any)
+	require.Len(t, items, 2)
+	item := items[0].(map[string]any)
+	assert.Equal(t, "urn:uuid:1", item["id"])
+	assert.Equal(t, "https://example.com/posts/second", item["url"])
+	assert.Equal(t, "2023-01-01T13:00:00Z", item["date_published"])
+	assert.Equal(t, "2023-01-01T14:00:00Z", item["date_modified"])
+
+	// Empty feeds have an empty list of items
+	b, err = (&feed.Feed{Title: "Example"}).JSON()
+	require.NoError(t, err)
+	assert.Contains(t, string(b), `"items":[]`)
+}
@@ -0,0 +1,152 @@
+// Package markdown renders the content of posts and comments to HTML. The
+// output is sanitized with an allowlist policy, so it can be embedded by
+// clients without further escaping.
+package markdown
+
+import (
+	"bytes"
+	"html"
+	"regexp"
+	"strings"
+
+	chromahtml "github.com/alecthomas/chroma/v2/formatters/html"
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	highlighting "github.com/yuin/goldmark-highlighting/v2"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/util"
+
+	"github.com/chrishrb/blog-microservice/post-service/slug"
+)
+
+// posts renders GitHub Flavored Markdown. Fenced code blocks are highlighted
+// with CSS classes instead of inline styles, so clients can choose a theme.
+var posts = goldmark.New(
+	goldmark.WithExtensions(
+		extension.GFM,
+		highlighting.NewHighlighting(
+			highlighting.WithFormatOptions(chromahtml.WithClasses(true)),
+		),
+	),
+	goldmark.WithParserOptions(parser.WithAutoHeadingID()),
+)
+
+// comments renders a subset of Markdown without headings, tables, thematic
+// breaks and raw HTML. Images are parsed like links but removed by the
+// comment policy.
+var comments = goldmark.New(
+	goldmark.WithParser(parser.NewParser(
+		parser.WithBlockParsers(
+			util.Prioritized(parser.NewListParser(), 300),
+			util.Prioritized(parser.NewListItemParser(), 400),
+			util.Prioritized(parser.NewCodeBlockParser(), 500),
+			util.Prioritized(parser.NewFencedCodeBlockParser(), 700),
+			util.Prioritized(parser.NewBlockquoteParser(), 800),
+			util.Prioritized(parser.NewParagraphParser(), 1000),
+		),
+		parser.WithInlineParsers(
+			util.Prioritized(parser.NewCodeSpanParser(), 100),
+			util.Prioritized(parser.NewLinkParser(), 200),
+			util.Prioritized(parser.NewAutoLinkParser(), 300),
+			util.Prioritized(parser.NewEmphasisParser(), 500),
+		),
+		parser.WithParagraphTransformers(parser.DefaultParagraphTransformers()...),
+	)),
+	goldmark.WithExtensions(extension.Strikethrough, extension.Linkify),
+)
+
+// classNames matches the classes of highlighted code and task lists.
+var classNames = regexp.MustCompile(`^[\w -]+$`)
+
+// headingIDs matches the IDs generated for heading anchors.
+var headingIDs = regexp.MustCompile(`^[a-z0-9-]+$`)
+
+var postPolicy = newPostPolicy()
+
+var commentPolicy = newCommentPolicy()
+
+func newPostPolicy() *bluemonday.Policy {
+	p := bluemonday.UGCPolicy()
+	p.AllowAttrs("id").Matching(headingIDs).OnElements("h1", "h2", "h3", "h4", "h5", "h6")
+	p.AllowAttrs("class").Matching(classNames).OnElements("pre", "code", "span")
+	// Task list items are rendered as disabled checkboxes
+	p.AllowAttrs("type").Matching(regexp.MustCompile(`^checkbox$`)).OnElements("input")
+	p.AllowAttrs("checked", "disabled").OnElements("input")
+	p.RequireNoFollowOnLinks(true)
+	return p
+}
+
+func newCommentPolicy() *bluemonday.Policy {
+	p := bluemonday.NewPolicy()
+	p.AllowStandardURLs()
+	p.AllowElements("p", "br", "em", "strong", "del", "code", "pre", "ul", "ol", "li", "blockquote")
+	p.AllowAttrs("href").OnElements("a")
+	p.AllowAttrs("start").Matching(bluemonday.Integer).OnElements("ol")
+	p.RequireNoFollowOnLinks(true)
+	p.AddTargetBlankToFullyQualifiedLinks(false)
+	return p
+}
+
+// Post renders the content of a post given as GitHub Flavored Markdown.
+// Headings get IDs derived from their text, which serve as anchors.
+func Post(source string) (string, error) {
+	var buf bytes.Buffer
+	ctx := parser.NewContext(parser.WithIDs(&anchors{used: make(map[string]bool)}))
+	if err := posts.Convert([]byte(source), &buf, parser.WithContext(ctx)); err != nil {
+		return "", err
+	}
+	return postPolicy.Sanitize(buf.String()), nil
+}
+
+// Comment renders the content of a comment given in the Markdown subset
+// supported for comments.
+func Comment(source string) (string, error) {
+	var buf bytes.Buffer
+	if err := comments.Convert([]byte(source), &buf); err != nil {
+		return "", err
+	}
+	return commentPolicy.Sanitize(buf.String()), nil
+}
+
+// Plain renders plain text. Blank lines separate paragraphs and single line
+// breaks are kept.
+func Plain(source string) string {
+	var b strings.Builder
+	source = strings.ReplaceAll(source, "\r\n", "\n")
+	for _, paragraph := range strings.Split(source, "\n\n") {
+		paragraph = strings.Trim(paragraph, "\n")
+		if paragraph == "" {
+			continue
+		}
+		b.WriteString("<p>")
+		b.WriteString(strings.ReplaceAll(html.EscapeString(paragraph), "\n", "<br>\n"))
+		b.WriteString("</p>\n")
+	}
+	return b.String()
+}
+
+// anchors generates the IDs of headings from their text, using the same
+// rules as the slugs of posts. Repeated headings get a numeric suffix.
+type anchors struct {
+	used map[string]bool
+}
+
+func (a *anchors) Generate(value []byte, _ ast.NodeKind) []byte {
+	base := slug.Derive(string(value))
+	if base == "" {
+		base = "section"
+	}
+	for n := 1; ; n++ {
+		candidate := slug.WithSuffix(base, n)
+		if !a.used[candidate] {
+			a.used[candidate] = true
+			return []byte(candidate)
+		}
+	}
+}
+
+func (a *anchors) Put(value []byte) {
+	a.used[string(value)] = true
+}
@@ -0,0 +1,75 @@
+package markdown_test
+
+import (
+	"testing"
+
+	"github.com/chrishrb/blog-microservice/post-service/markdown"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPost(t *testing.T) {
+	tests := []struct {
+		name     string
+		source   string
+		contains []string
+		excludes []string
+	}{
+		{
+			name:     "heading anchors",
+			source:   "# Grüße\n\n## Grüße\n\n## !!!",
+			contains: []string{`<h1 id="gruesse">Grüße</h1>`, `<h2 id="gruesse-2">Grüße</h2>`, `<h2 id="section">!!!</h2>`},
+		},
+		{
+			name:     "highlighted code",
+			source:   "```go\nfunc main() {}\n```",
+			contains: []string{`<pre class="chroma">`, `<span class="kd">func</span>`},
+		},
+		{
+			name:     "gfm",
+			source:   "- [x] done\n\n|a|b|\n|-|-|\n|1|2|\n\n~~old~~ https://example.com",
+			contains: []string{`<input checked="" disabled="" type="checkbox">`, `<td>1</td>`, `<del>old</del>`, `<a href="https://example.com" rel="nofollow">`},
+		},
+		{
+			name:     "raw html",
+			source:   "<script>alert(1)</script>\n\nsome <b onclick=\"alert(1)\">text</b>",
+			excludes: []string{"<script", "alert(1)", "onclick"},
+		},
+		{
+			name:     "unsafe links",
+			source:   "[click](javascript:alert(1)) ![image](https://example.com/a.png)",
+			contains: []string{`<img src="https://example.com/a.png" alt="image">`},
+			excludes: []string{"javascript:"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := markdown.Post(tt.source)
+			require.NoError(t, err)
+			for _, s := range tt.contains {
+				assert.Contains(t, got, s)
+			}
+			for _, s := range tt.excludes {
+				assert.NotContains(t, got, s)
+			}
+		})
+	}
+}
+
+func TestComment(t *testing.T) {
+	got, err := markdown.Comment("# Title\n\n**bold** `code` <script>alert(1)</script>\n\n![image](https://example.com/a.png) [link](https://example.com)\n\n> quote")
+	require.NoError(t, err)
+
+	assert.Contains(t, got, "<p># Title</p>")
+	assert.Contains(t, got, "<strong>bold</strong> <code>code</code> &lt;script&gt;")
+	assert.Contains(t, got, `<a href="https://example.com" rel="nofollow">link</a>`)
+	assert.Contains(t, got, "<blockquote>")
+	assert.NotContains(t, got, "<h1")
+	assert.NotContains(t, got, "<img")
+	assert.NotContains(t, got, "<script")
+}
+
+func TestPlain(t *testing.T) {
+	got := markdown.Plain("a <b>\r\nc\n\n\n**d**")
+	assert.Equal(t, "<p>a &lt;b&gt;<br>\nc</p>\n<p>**d**</p>\n", got)
+}
@@ -0,0 +1,189 @@
+// Package media inspects uploaded images and renders their resized variants.
+// Images are decoded and encoded with the standard library only.
+package media
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/draw"
+	"image/jpeg"
+	"image/png"
+	"net/http"
+	"slices"
+
+	// Register the GIF decoder with image.Decode
+	_ "image/gif"
+)
+
+// ErrUnsupportedType is returned for uploads that are not of one of the
+// supported ContentTypes.
+var ErrUnsupportedType = errors.New("unsupported media type")
+
+// ErrTooManyPixels is returned for images whose decoded size would exceed
+// MaxPixels.
+var ErrTooManyPixels = errors.New("image has too many pixels")
+
+// ContentTypes are the types of media that can be uploaded.
+var ContentTypes = []string{"image/jpeg", "image/png", "image/gif"}
+
+// MaxPixels bounds the number of pixels of images, which protects against
+// small files that decode to huge images.
+const MaxPixels = 50_000_000
+
+const (
+	VariantThumbnail = "thumbnail"
+	VariantMedium    = "medium"
+)
+
+// Variant is a resized rendition of images whose longer side is scaled down
+// to MaxSize pixels.
+type Variant struct {
+	Name    string
+	MaxSize int
+}
+
+// Variants are the renditions rendered for uploaded images.
+var Variants = []Variant{
+	{Name: VariantThumbnail, MaxSize: 320},
+	{Name: VariantMedium, MaxSize: 1280},
+}
+
+// Image is an uploaded image with its variants.
+type Image struct {
+	ContentType string
+	Width       int
+	Height      int
+	Renditions  []Rendition
+}
+
+// Rendition is the encoded data of a variant of an image.
+type Rendition struct {
+	Variant     string
+	ContentType string
+	Data        []byte
+}
+
+// Sniff returns the content type of the data, which must be one of the
+// supported ContentTypes.
+func Sniff(data []byte) (string, error) {
+	contentType := http.DetectContentType(data)
+	if !slices.Contains(ContentTypes, contentType) {
+		return "", fmt.Errorf("%w: %s", ErrUnsupportedType, contentType)
+	}
+	return contentType, nil
+}
+
+// VariantContentType returns the content type of the variants of images of
+// the given type. JPEG images stay JPEG, other images become PNG so that
+// transparency is kept.
+func VariantContentType(contentType string) string {
+	if contentType == "image/jpeg" {
+		return "image/jpeg"
+	}
+	return "image/png"
+}
+
+// Process sniffs and decodes the image and renders the variants that are
+// smaller than the image itself. Animated images are rendered from their
+// first frame.
+func Process(data []byte) (*Image, error) {
+	contentType, err := Sniff(data)
+	if err != nil {
+		return nil, err
+	}
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("decoding image: %w", err)
+	}
+	if cfg.Width <= 0 || cfg.Height <= 0 {
+		return nil, fmt.Errorf("decoding image: invalid dimensions %dx%d", cfg.Width, cfg.Height)
+	}
+	if int64(cfg.Width)*int64(cfg.Height) > MaxPixels {
+		return nil, ErrTooManyPixels
+	}
+
+	img := &Image{ContentType: contentType, Width: cfg.Width, Height: cfg.Height}
+	var src *image.RGBA
+	for _, variant := range Variants {
+		if max(cfg.Width, cfg.Height) <= variant.MaxSize {
+			continue
+		}
+		if src == nil {
+			decoded, _, err := image.Decode(bytes.NewReader(data))
+			if err != nil {
+				return nil, fmt.Errorf("decoding image: %w", err)
+			}
+			src = toRGBA(decoded)
+		}
+
+		var buf bytes.Buffer
+		resized := Resize(src, variant.MaxSize)
+		variantType := VariantContentType(contentType)
+		if variantType == "image/jpeg" {
+			err = jpeg.Encode(&buf, resized, &jpeg.Options{Quality: 85})
+		} else {
+			err = png.Encode(&buf, resized)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("encoding %s variant: %w", variant.Name, err)
+		}
+		img.Renditions = append(img.Renditions, Rendition{
+			Variant:     variant.Name,
+			ContentType: variantType,
+			Data:        buf.Bytes(),
+		})
+	}
+	return img, nil
+}
+
+// Resize scales the image down so that its longer side is at most maxSize
+// pixels, keeping the aspect ratio. Every pixel of the result is the average
+// of the pixels of the image it covers. Images that fit already are returned
+// as they are.
+func Resize(src *image.RGBA, maxSize int) *image.RGBA {
+	sw, sh := src.Bounds().Dx(), src.Bounds().Dy()
+	if max(sw, sh) <= maxSize {
+		return src
+	}
+	dw, dh := maxSize, max(1, sh*maxSize/sw)
+	if sh > sw {
+		dw, dh = max(1, sw*maxSize/sh), maxSize
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+	origin := src.Bounds().Min
+	for dy := range dh {
+		y0, y1 := dy*sh/dh, max((dy+1)*sh/dh, dy*sh/dh+1)
+		for dx := range dw {
+			x0, x1 := dx*sw/dw, max((dx+1)*sw/dw, dx*sw/dw+1)
+			var sum [4]uint64
+			for y := y0; y < y1; y++ {
+				row := src.PixOffset(origin.X+x0, origin.Y+y)
+				for x := x0; x < x1; x++ {
+					for c := range sum {
+						sum[c] += uint64(src.Pix[row+c])
+					}
+					row += 4
+				}
+			}
+			n := uint64((x1 - x0) * (y1 - y0))
+			i := dst.PixOffset(dx, dy)
+			for c := range sum {
+				dst.Pix[i+c] = uint8((sum[c] + n/2) / n)
+			}
+		}
+	}
+	return dst
+}
+
+func toRGBA(img image.Image) *image.RGBA {
+	if rgba, ok := img.(*image.RGBA); ok {
+		return rgba
+	}
+	bounds := img.Bounds()
+	rgba := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(rgba, rgba.Bounds(), img, bounds.Min, draw.Src)
+	return rgba
+}
@@ -0,0 +1,111 @@
+package media_test
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"github.com/chrishrb/blog-microservice/post-service/media"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func encodePNG(t *testing.T, width, height int) []byte {
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := range height {
+		for x := range width {
+			img.Set(x, y, color.NRGBA{R: 200, G: 100, B: 50, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+func TestSniff(t *testing.T) {
+	contentType, err := media.Sniff(encodePNG(t, 1, 1))
+	require.NoError(t, err)
+	assert.Equal(t, "image/png", contentType)
+
+	_, err = media.Sniff([]byte("<html><script>alert(1)</script></html>"))
+	assert.ErrorIs(t, err, media.ErrUnsupportedType)
+}
+
+func TestProcess(t *testing.T) {
+	img, err := media.Process(encodePNG(t, 800, 400))
+	require.NoError(t, err)
+
+	assert.Equal(t, "image/png", img.ContentType)
+	assert.Equal(t, 800, img.Width)
+	assert.Equal(t, 400, img.Height)
+
+	// The medium variant would not be smaller than the image
+	require.Len(t, img.Renditions, 1)
+	thumbnail := img.Renditions[0]
+	assert.Equal(t, media.VariantThumbnail, thumbnail.Variant)
+	assert.Equal(t, "image/png", thumbnail.ContentType)
+	cfg, format, err := image.DecodeConfig(bytes.NewReader(thumbnail.Data))
+	require.NoError(t, err)
+	assert.Equal(t, "png", format)
+	assert.Equal(t, 320, cfg.Width)
+	assert.Equal(t, 160, cfg.Height)
+}
+
+func TestProcess_Formats(t *testing.T) {
+	src := image.NewPaletted(image.Rect(0, 0, 400, 1600), color.Palette{color.White, color.Black})
+	var gifData, jpegData bytes.Buffer
+	require.NoError(t, gif.Encode(&gifData, src, nil))
+	require.NoError(t, jpeg.Encode(&jpegData, src, nil))
+
+	img, err := media.Process(gifData.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, "image/gif", img.ContentType)
+	require.Len(t, img.Renditions, 2)
+	assert.Equal(t, "image/png", img.Renditions[0].ContentType)
+	assert.Equal(t, media.VariantMedium, img.Renditions[1].Variant)
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(img.Renditions[1].Data))
+	require.NoError(t, err)
+	assert.Equal(t, 320, cfg.Width)
+	assert.Equal(t, 1280, cfg.Height)
+
+	img, err = media.Process(jpegData.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, "image/jpeg", img.ContentType)
+	require.Len(t, img.Renditions, 2)
+	assert.Equal(t, "image/jpeg", img.Renditions[0].ContentType)
+}
+
+func TestProcess_Invalid(t *testing.T) {
+	_, err := media.Process([]byte("plain text"))
+	assert.ErrorIs(t, err, media.ErrUnsupportedType)
+
+	// A PNG signature without an image
+	_, err = media.Process([]byte("\x89PNG\x0D\x0A\x1A\x0Atruncated"))
+	assert.Error(t, err)
+}
+
+func TestResize(t *testing.T) {
+	src := image.NewRGBA(image.Rect(0, 0, 4, 2))
+	// Left half black, right half white
+	for y := range 2 {
+		for x := range 4 {
+			c := uint8(0)
+			if x >= 2 {
+				c = 255
+			}
+			src.Set(x, y, color.RGBA{R: c, G: c, B: c, A: 255})
+		}
+	}
+
+	dst := media.Resize(src, 2)
+	require.Equal(t, image.Rect(0, 0, 2, 1), dst.Bounds())
+	assert.Equal(t, color.RGBA{A: 255}, dst.RGBAAt(0, 0))
+	assert.Equal(t, color.RGBA{R: 255, G: 255, B: 255, A: 255}, dst.RGBAAt(1, 0))
+
+	// Images that fit are kept
+	assert.Same(t, src, media.Resize(src, 4))
+}
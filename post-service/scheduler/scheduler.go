@@ -0,0 +1,82 @@
+// Package scheduler publishes and unpublishes posts at the times their
+// authors scheduled.
+package scheduler
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/chrishrb/blog-microservice/post-service/store"
+	"k8s.io/utils/clock"
+)
+
+// Scheduler periodically applies the publishing schedules of posts. The
+// schedules are kept in the store, so a scheduler does not hold any state
+// and schedules that became due while the service was down are applied as
+// soon as it runs again.
+type Scheduler struct {
+	engine   store.PostScheduleStore
+	clock    clock.Clock
+	interval time.Duration
+	onApply  func(posts []*store.Post)
+}
+
+// Opt configures optional settings of a Scheduler.
+type Opt func(s *Scheduler)
+
+// WithOnApply sets a function that is called with the changed posts whenever
+// schedules were applied, like to invalidate caches of published posts.
+func WithOnApply(f func(posts []*store.Post)) Opt {
+	return func(s *Scheduler) {
+		s.onApply = f
+	}
+}
+
+func New(engine store.PostScheduleStore, clock clock.Clock, interval time.Duration, opts ...Opt) *Scheduler {
+	s := &Scheduler{
+		engine:   engine,
+		clock:    clock,
+		interval: interval,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Run applies the due schedules immediately and then every interval until
+// the context is cancelled.
+func (s *Scheduler) Run(ctx context.Context) {
+	timer := s.clock.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C():
+		}
+
+		if _, err := s.ApplyDue(ctx); err != nil {
+			slog.Error("applying post schedules", "err", err)
+		}
+		timer.Reset(s.interval)
+	}
+}
+
+// ApplyDue applies the schedules that are due now and returns the changed
+// posts.
+func (s *Scheduler) ApplyDue(ctx context.Context) ([]*store.Post, error) {
+	posts, err := s.engine.ApplyPostSchedules(ctx, s.clock.Now())
+	if err != nil {
+		return nil, err
+	}
+	for _, post := range posts {
+		slog.Info("applied post schedule", slog.String("post_id", post.ID.String()), slog.Bool("published", post.Published))
+	}
+	if len(posts) > 0 && s.onApply != nil {
+		s.onApply(posts)
+	}
+	return posts, nil
+}
@@ -0,0 +1,124 @@
+package scheduler_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/chrishrb/blog-microservice/internal/testutil"
+	"github.com/chrishrb/blog-microservice/post-service/scheduler"
+	"github.com/chrishrb/blog-microservice/post-service/store"
+	"github.com/chrishrb/blog-microservice/post-service/store/inmemory"
+	"github.com/chrishrb/blog-microservice/post-service/store/sqlite"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	clock_testing "k8s.io/utils/clock/testing"
+)
+
+func TestScheduler_Run(t *testing.T) {
+	fakeClock := clock_testing.NewFakeClock(time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC))
+	engine := inmemory.NewStore(fakeClock)
+
+	post := &store.Post{
+		ID:        uuid.New(),
+		AuthorID:  uuid.New(),
+		Title:     "Scheduled",
+		PublishAt: testutil.Ptr(fakeClock.Now().Add(90 * time.Second)),
+	}
+	require.NoError(t, engine.SetPost(t.Context(), post))
+
+	ctx, cancel := context.WithCancel(t.Context())
+	done := make(chan struct{})
+	go func() {
+		scheduler.New(engine, fakeClock, time.Minute).Run(ctx)
+		close(done)
+	}()
+	defer func() {
+		cancel()
+		<-done
+	}()
+
+	isPublished := func() bool {
+		p, err := engine.LookupPost(t.Context(), post.ID)
+		require.NoError(t, err)
+		return p.Published
+	}
+
+	// Not yet due at the first tick
+	require.Eventually(t, fakeClock.HasWaiters, time.Second, time.Millisecond)
+	fakeClock.Step(time.Minute)
+	require.Eventually(t, fakeClock.HasWaiters, time.Second, time.Millisecond)
+	assert.False(t, isPublished())
+
+	// Published at the second tick
+	fakeClock.Step(time.Minute)
+	assert.Eventually(t, isPublished, time.Second, time.Millisecond)
+}
+
+func TestScheduler_Restart(t *testing.T) {
+	fakeClock := clock_testing.NewFakeClock(time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC))
+	path := filepath.Join(t.TempDir(), "post-service.db")
+
+	engine, err := sqlite.NewStore(t.Context(), path, fakeClock)
+	require.NoError(t, err)
+	post := &store.Post{
+		ID:          uuid.New(),
+		AuthorID:    uuid.New(),
+		Title:       "Scheduled",
+		PublishAt:   testutil.Ptr(fakeClock.Now().Add(time.Hour)),
+		UnpublishAt: testutil.Ptr(fakeClock.Now().Add(48 * time.Hour)),
+	}
+	require.NoError(t, engine.SetPost(t.Context(), post))
+	require.NoError(t, engine.Close())
+
+	// The service is down while the post becomes due
+	fakeClock.Step(2 * time.Hour)
+
+	engine, err = sqlite.NewStore(t.Context(), path, fakeClock)
+	require.NoError(t, err)
+	defer func() {
+		_ = engine.Close()
+	}()
+
+	changed, err := scheduler.New(engine, fakeClock, time.Minute).ApplyDue(t.Context())
+	require.NoError(t, err)
+	require.Len(t, changed, 1)
+
+	got, err := engine.LookupPost(t.Context(), post.ID)
+	require.NoError(t, err)
+	assert.True(t, got.Published)
+	assert.Nil(t, got.PublishAt)
+	require.NotNil(t, got.UnpublishAt)
+	assert.Equal(t, post.UnpublishAt.UTC(), got.UnpublishAt.UTC())
+}
+
+func TestScheduler_OnApply(t *testing.T) {
+	fakeClock := clock_testing.NewFakeClock(time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC))
+	engine := inmemory.NewStore(fakeClock)
+	post := &store.Post{
+		ID:        uuid.New(),
+		AuthorID:  uuid.New(),
+		Title:     "Scheduled",
+		PublishAt: testutil.Ptr(fakeClock.Now().Add(time.Hour)),
+	}
+	require.NoError(t, engine.SetPost(t.Context(), post))
+
+	var applied [][]*store.Post
+	s := scheduler.New(engine, fakeClock, time.Minute, scheduler.WithOnApply(func(posts []*store.Post) {
+		applied = append(applied, posts)
+	}))
+
+	// Nothing is due yet
+	_, err := s.ApplyDue(t.Context())
+	require.NoError(t, err)
+	assert.Empty(t, applied)
+
+	fakeClock.Step(time.Hour)
+	_, err = s.ApplyDue(t.Context())
+	require.NoError(t, err)
+	require.Len(t, applied, 1)
+	require.Len(t, applied[0], 1)
+	assert.Equal(t, post.ID, applied[0][0].ID)
+}
@@ -0,0 +1,228 @@
+package search
+
+import (
+	"bytes"
+	"math"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// Document is the searchable text of a post.
+type Document struct {
+	ID      uuid.UUID
+	Title   string
+	Content string
+	Tags    []string
+}
+
+// Result is a document matching a search query.
+type Result struct {
+	ID    uuid.UUID
+	Score float64
+	// Snippet is an HTML escaped excerpt of the document in which the
+	// matching words are wrapped in <mark> elements.
+	Snippet string
+}
+
+// field is a part of a document that is indexed separately, so that matches
+// in the title can be ranked higher than matches in the content.
+type field int
+
+const (
+	fieldTitle field = iota
+	fieldTags
+	fieldContent
+	numFields
+)
+
+// fieldWeights boosts matches in the title and tags of a document.
+var fieldWeights = [numFields]float64{
+	fieldTitle:   3,
+	fieldTags:    2,
+	fieldContent: 1,
+}
+
+// BM25 parameters
+const (
+	k1 = 1.2
+	b  = 0.75
+)
+
+type indexedDocument struct {
+	text    [numFields]string
+	tokens  [numFields][]Token
+	lengths [numFields]int
+}
+
+// Index is an in-memory inverted index over documents. Documents are ranked
+// with BM25F, which weights the term frequencies of the fields. It is safe
+// for concurrent use.
+type Index struct {
+	sync.RWMutex
+	docs map[uuid.UUID]*indexedDocument
+	// postings maps a term to the documents containing it and the term
+	// frequency per field.
+	postings     map[string]map[uuid.UUID]*[numFields]int
+	totalLengths [numFields]int
+}
+
+func NewIndex() *Index {
+	return &Index{
+		docs:     make(map[uuid.UUID]*indexedDocument),
+		postings: make(map[string]map[uuid.UUID]*[numFields]int),
+	}
+}
+
+// Add indexes the document, replacing an earlier version with the same ID.
+func (i *Index) Add(doc Document) {
+	i.Lock()
+	defer i.Unlock()
+
+	i.remove(doc.ID)
+
+	indexed := &indexedDocument{}
+	indexed.text[fieldTitle] = doc.Title
+	indexed.text[fieldTags] = strings.Join(doc.Tags, " ")
+	indexed.text[fieldContent] = doc.Content
+	for f := range numFields {
+		indexed.tokens[f] = Tokenize(indexed.text[f])
+		indexed.lengths[f] = len(indexed.tokens[f])
+		i.totalLengths[f] += indexed.lengths[f]
+
+		for _, token := range indexed.tokens[f] {
+			docs, ok := i.postings[token.Term]
+			if !ok {
+				docs = make(map[uuid.UUID]*[numFields]int)
+				i.postings[token.Term] = docs
+			}
+			freqs, ok := docs[doc.ID]
+			if !ok {
+				freqs = &[numFields]int{}
+				docs[doc.ID] = freqs
+			}
+			freqs[f]++
+		}
+	}
+	i.docs[doc.ID] = indexed
+}
+
+// Remove deletes the document from the index.
+func (i *Index) Remove(ID uuid.UUID) {
+	i.Lock()
+	defer i.Unlock()
+
+	i.remove(ID)
+}
+
+func (i *Index) remove(ID uuid.UUID) {
+	indexed, ok := i.docs[ID]
+	if !ok {
+		return
+	}
+
+	for f := range numFields {
+		i.totalLengths[f] -= indexed.lengths[f]
+		for _, token := range indexed.tokens[f] {
+			docs := i.postings[token.Term]
+			delete(docs, ID)
+			if len(docs) == 0 {
+				delete(i.postings, token.Term)
+			}
+		}
+	}
+	delete(i.docs, ID)
+}
+
+// Search returns the documents containing all terms of the query, ordered by
+// relevance. At most limit results are returned. If include is not nil, only
+// documents for which it returns true are part of the results.
+func (i *Index) Search(query string, limit int, include func(ID uuid.UUID) bool) []Result {
+	i.RLock()
+	defer i.RUnlock()
+
+	terms := Terms(query)
+	if len(terms) == 0 || limit <= 0 {
+		return []Result{}
+	}
+
+	// Only documents containing every term match
+	var candidates []uuid.UUID
+	for ID := range i.postings[terms[0]] {
+		if i.containsAll(ID, terms[1:]) && (include == nil || include(ID)) {
+			candidates = append(candidates, ID)
+		}
+	}
+
+	results := make([]Result, 0, len(candidates))
+	for _, ID := range candidates {
+		results = append(results, Result{
+			ID:    ID,
+			Score: i.score(ID, terms),
+		})
+	}
+	slices.SortFunc(results, func(a, b Result) int {
+		if a.Score != b.Score {
+			if a.Score > b.Score {
+				return -1
+			}
+			return 1
+		}
+		return bytes.Compare(a.ID[:], b.ID[:])
+	})
+	results = results[:min(limit, len(results))]
+
+	for n := range results {
+		results[n].Snippet = i.snippet(results[n].ID, terms)
+	}
+	return results
+}
+
+func (i *Index) containsAll(ID uuid.UUID, terms []string) bool {
+	for _, term := range terms {
+		if _, ok := i.postings[term][ID]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// score computes the BM25F score of the document for the terms.
+func (i *Index) score(ID uuid.UUID, terms []string) float64 {
+	indexed := i.docs[ID]
+	n := float64(len(i.docs))
+
+	var score float64
+	for _, term := range terms {
+		freqs := i.postings[term][ID]
+
+		var tf float64
+		for f := range numFields {
+			if freqs[f] == 0 {
+				continue
+			}
+			avgLength := float64(i.totalLengths[f]) / n
+			norm := 1 - b + b*float64(indexed.lengths[f])/avgLength
+			tf += fieldWeights[f] * float64(freqs[f]) / norm
+		}
+
+		df := float64(len(i.postings[term]))
+		idf := math.Log(1 + (n-df+0.5)/(df+0.5))
+		score += idf * tf / (k1 + tf)
+	}
+	return score
+}
+
+// snippet returns an excerpt of the content of the document around the
+// matches. The title is used if the content does not contain any term.
+func (i *Index) snippet(ID uuid.UUID, terms []string) string {
+	indexed := i.docs[ID]
+	for _, f := range []field{fieldContent, fieldTitle, fieldTags} {
+		if s, ok := Snippet(indexed.text[f], indexed.tokens[f], terms); ok {
+			return s
+		}
+	}
+	return ""
+}
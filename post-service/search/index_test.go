@@ -0,0 +1,101 @@
+package search_test
+
+import (
+	"testing"
+
+	"github.com/chrishrb/blog-microservice/post-service/search"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIndex_Search(t *testing.T) {
+	index := search.NewIndex()
+
+	inTitle := uuid.New()
+	index.Add(search.Document{
+		ID:      inTitle,
+		Title:   "Running a marathon",
+		Content: "Some thoughts about training.",
+	})
+	inContent := uuid.New()
+	index.Add(search.Document{
+		ID:      inContent,
+		Title:   "My weekend",
+		Content: "I went for a run and then I was running again.",
+	})
+	inTags := uuid.New()
+	index.Add(search.Document{
+		ID:      inTags,
+		Title:   "Shoes",
+		Content: "A review of my new shoes.",
+		Tags:    []string{"running"},
+	})
+	index.Add(search.Document{
+		ID:      uuid.New(),
+		Title:   "Cooking",
+		Content: "How to cook pasta.",
+	})
+
+	results := index.Search("runs", 10, nil)
+	require.Len(t, results, 3)
+	assert.Equal(t, inTitle, results[0].ID)
+	assert.Equal(t, "<mark>Running</mark> a marathon", results[0].Snippet)
+	assert.Contains(t, []uuid.UUID{inContent, inTags}, results[1].ID)
+	assert.Greater(t, results[0].Score, results[1].Score)
+	assert.GreaterOrEqual(t, results[1].Score, results[2].Score)
+
+	// All terms have to match
+	results = index.Search("running marathon", 10, nil)
+	require.Len(t, results, 1)
+	assert.Equal(t, inTitle, results[0].ID)
+
+	// Limit
+	results = index.Search("run", 1, nil)
+	assert.Len(t, results, 1)
+
+	// No match
+	assert.Empty(t, index.Search("swimming", 10, nil))
+	assert.Empty(t, index.Search("the", 10, nil))
+}
+
+func TestIndex_UpdateAndRemove(t *testing.T) {
+	index := search.NewIndex()
+
+	ID := uuid.New()
+	index.Add(search.Document{
+		ID:      ID,
+		Title:   "Old title",
+		Content: "Old content",
+	})
+	require.Len(t, index.Search("old", 10, nil), 1)
+
+	// Replacing the document removes the old terms
+	index.Add(search.Document{
+		ID:      ID,
+		Title:   "New title",
+		Content: "New content",
+	})
+	assert.Empty(t, index.Search("old", 10, nil))
+	assert.Len(t, index.Search("new", 10, nil), 1)
+
+	index.Remove(ID)
+	assert.Empty(t, index.Search("new", 10, nil))
+
+	// Removing an unknown document is a no-op
+	index.Remove(uuid.New())
+}
+
+func TestIndex_SearchInclude(t *testing.T) {
+	index := search.NewIndex()
+
+	included, excluded := uuid.New(), uuid.New()
+	index.Add(search.Document{ID: included, Title: "Running"})
+	index.Add(search.Document{ID: excluded, Title: "Running"})
+
+	results := index.Search("running", 1, func(ID uuid.UUID) bool {
+		return ID == included
+	})
+	require.Len(t, results, 1)
+	assert.Equal(t, included, results[0].ID)
+}
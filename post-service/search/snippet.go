@@ -0,0 +1,75 @@
+package search
+
+import (
+	"html"
+	"slices"
+	"strings"
+)
+
+// snippetLength is the number of words a snippet is made of.
+const snippetLength = 24
+
+const (
+	highlightStart = "<mark>"
+	highlightEnd   = "</mark>"
+	ellipsis       = "…"
+)
+
+// Snippet returns an excerpt of text around the part with the most matches
+// of terms. The text is HTML escaped and the matching words are wrapped in
+// <mark> elements. tokens must be the result of Tokenize(text). The second
+// return value is false if the text does not contain any of the terms.
+func Snippet(text string, tokens []Token, terms []string) (string, bool) {
+	matches := make([]bool, len(tokens))
+	found := false
+	for n, token := range tokens {
+		if slices.Contains(terms, token.Term) {
+			matches[n] = true
+			found = true
+		}
+	}
+	if !found {
+		return "", false
+	}
+
+	// Find the window of tokens with the most matches
+	first, best, count := 0, -1, 0
+	for n := range tokens {
+		if matches[n] {
+			count++
+		}
+		if n >= snippetLength && matches[n-snippetLength] {
+			count--
+		}
+		if count > best {
+			best = count
+			first = max(n-snippetLength+1, 0)
+		}
+	}
+	last := min(first+snippetLength, len(tokens)) - 1
+
+	var sb strings.Builder
+	pos := 0
+	if first > 0 {
+		sb.WriteString(ellipsis)
+		pos = tokens[first].Start
+	}
+	for n := first; n <= last; n++ {
+		token := tokens[n]
+		if !matches[n] {
+			continue
+		}
+		sb.WriteString(html.EscapeString(text[pos:token.Start]))
+		sb.WriteString(highlightStart)
+		sb.WriteString(html.EscapeString(text[token.Start:token.End]))
+		sb.WriteString(highlightEnd)
+		pos = token.End
+	}
+	if last < len(tokens)-1 {
+		sb.WriteString(html.EscapeString(text[pos:tokens[last].End]))
+		sb.WriteString(ellipsis)
+	} else {
+		sb.WriteString(html.EscapeString(text[pos:]))
+	}
+	return strings.TrimSpace(sb.String()), true
+}
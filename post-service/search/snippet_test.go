@@ -0,0 +1,35 @@
+package search_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/chrishrb/blog-microservice/post-service/search"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSnippet(t *testing.T) {
+	text := "Go is <great> for running servers."
+	snippet, ok := search.Snippet(text, search.Tokenize(text), []string{"run"})
+
+	assert.True(t, ok)
+	assert.Equal(t, "Go is &lt;great&gt; for <mark>running</mark> servers.", snippet)
+}
+
+func TestSnippet_Window(t *testing.T) {
+	text := strings.Repeat("filler ", 50) + "needle " + strings.Repeat("filler ", 50)
+	snippet, ok := search.Snippet(text, search.Tokenize(text), []string{"needl"})
+
+	assert.True(t, ok)
+	assert.True(t, strings.HasPrefix(snippet, "…"))
+	assert.True(t, strings.HasSuffix(snippet, "…"))
+	assert.Contains(t, snippet, "<mark>needle</mark>")
+	assert.Less(t, len(snippet), len(text)/2)
+}
+
+func TestSnippet_NoMatch(t *testing.T) {
+	text := "Nothing to see here"
+	_, ok := search.Snippet(text, search.Tokenize(text), []string{"needl"})
+
+	assert.False(t, ok)
+}
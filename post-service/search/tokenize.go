@@ -0,0 +1,83 @@
+package search
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/kljensen/snowball/english"
+)
+
+// Token is a normalized term of a text. Start and End are the byte offsets of
+// the original word in the text.
+type Token struct {
+	Term  string
+	Start int
+	End   int
+}
+
+// Tokenize splits text into words, lowercases and stems them. Stop words are
+// dropped as they match almost every document.
+func Tokenize(text string) []Token {
+	var tokens []Token
+	start := -1
+	for i, r := range text {
+		isWordRune := unicode.IsLetter(r) || unicode.IsDigit(r)
+		switch {
+		case isWordRune && start < 0:
+			start = i
+		case !isWordRune && start >= 0:
+			tokens = appendToken(tokens, text, start, i)
+			start = -1
+		}
+	}
+	if start >= 0 {
+		tokens = appendToken(tokens, text, start, len(text))
+	}
+	return tokens
+}
+
+// Terms returns the distinct terms of text in the order of their first
+// occurrence.
+func Terms(text string) []string {
+	var terms []string
+	seen := make(map[string]bool)
+	for _, token := range Tokenize(text) {
+		if !seen[token.Term] {
+			seen[token.Term] = true
+			terms = append(terms, token.Term)
+		}
+	}
+	return terms
+}
+
+func appendToken(tokens []Token, text string, start, end int) []Token {
+	word := strings.ToLower(text[start:end])
+	if english.IsStopWord(word) {
+		return tokens
+	}
+	return append(tokens, Token{
+		Term:  Stem(word),
+		Start: start,
+		End:   end,
+	})
+}
+
+// Stem reduces a lowercase word to its stem, so that e.g. "running" and
+// "runs" match the same documents. Words that are not plain ASCII, like
+// numbers with units or non-english words, are kept as they are.
+func Stem(word string) string {
+	if utf8.RuneCountInString(word) < 3 || !isASCIILetters(word) {
+		return word
+	}
+	return english.Stem(word, false)
+}
+
+func isASCIILetters(word string) bool {
+	for i := 0; i < len(word); i++ {
+		if word[i] < 'a' || word[i] > 'z' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,28 @@
+package search_test
+
+import (
+	"testing"
+
+	"github.com/chrishrb/blog-microservice/post-service/search"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenize(t *testing.T) {
+	text := "The Runner was running, Über-fast in 2023!"
+	tokens := search.Tokenize(text)
+
+	assert.Equal(t, []search.Token{
+		{Term: "runner", Start: 4, End: 10},
+		{Term: "run", Start: 15, End: 22},
+		{Term: "über", Start: 24, End: 29},
+		{Term: "fast", Start: 30, End: 34},
+		{Term: "2023", Start: 38, End: 42},
+	}, tokens)
+	assert.Equal(t, "Runner", text[tokens[0].Start:tokens[0].End])
+}
+
+func TestTerms(t *testing.T) {
+	assert.Equal(t, []string{"run", "fast"}, search.Terms("running runs FAST"))
+	assert.Empty(t, search.Terms("the and of"))
+	assert.Empty(t, search.Terms(""))
+}
@@ -5,10 +5,10 @@ import (
 	"os"
 
 	"github.com/chrishrb/blog-microservice/internal/auth"
+	"github.com/chrishrb/blog-microservice/internal/writeablecontext"
 	"github.com/chrishrb/blog-microservice/post-service/api"
 	"github.com/chrishrb/blog-microservice/post-service/config"
 	"github.com/chrishrb/blog-microservice/post-service/store"
-	"github.com/induzo/gocom/http/middleware/writablecontext"
 	"github.com/riandyrn/otelchi"
 	"github.com/rs/cors"
 	"github.com/unrolled/secure"
@@ -19,8 +19,17 @@ import (
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
-func NewApiHandler(settings config.ApiSettings, engine store.Engine, jwsVerifier auth.JWSVerifier) http.Handler {
-	apiServer, err := api.NewServer(engine, clock.RealClock{})
+// NewApiHandler returns the handler of the API. The options are applied
+// after the ones derived from the settings.
+func NewApiHandler(settings config.ApiSettings, engine store.Engine, jwsVerifier auth.JWSVerifier, opts ...api.Opt) http.Handler {
+	opts = append([]api.Opt{
+		api.WithMaxCommentDepth(settings.MaxCommentDepth),
+		api.WithCommentModeration(settings.CommentModeration, settings.TrustedAfter),
+		api.WithReactions(settings.Reactions...),
+		api.WithFeeds(settings.OrgName, settings.Host, settings.FeedLimit),
+		api.WithMaxMediaSize(settings.MaxMediaSize),
+	}, opts...)
+	apiServer, err := api.NewServer(engine, clock.RealClock{}, opts...)
 	if err != nil {
 		panic(err)
 	}
@@ -57,7 +66,7 @@ func NewApiHandler(settings config.ApiSettings, engine store.Engine, jwsVerifier
 	r.Use(
 		middleware.Recoverer,
 		secureMiddleware.Handler,
-		writablecontext.Middleware, // workaround to inject userID into chi context
+		writeablecontext.Middleware, // workaround to inject userID into chi context
 		c.Handler,
 		otelchi.Middleware("api", otelchi.WithChiRoutes(r)),
 	)
@@ -1,15 +1,25 @@
 package server_test
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
+	"image"
+	"image/png"
 	"io"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
+	"github.com/chrishrb/blog-microservice/post-service/api"
+	"github.com/chrishrb/blog-microservice/post-service/blob"
 	"github.com/chrishrb/blog-microservice/post-service/config"
 	"github.com/chrishrb/blog-microservice/post-service/server"
+	"github.com/chrishrb/blog-microservice/post-service/store"
 	"github.com/chrishrb/blog-microservice/post-service/store/inmemory"
+	"github.com/google/uuid"
+	"github.com/lestrrat-go/jwx/jwt"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"k8s.io/utils/clock"
@@ -84,3 +94,152 @@ func TestSwaggerHandler(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, jsonData["info"].(map[string]any)["title"], "Post Service API")
 }
+
+// mockJWSVerifier accepts the token "valid" for its user and rejects all
+// other tokens.
+type mockJWSVerifier struct {
+	userID uuid.UUID
+}
+
+func (m *mockJWSVerifier) ValidateToken(jws string) (jwt.Token, error) {
+	if jws != "valid" {
+		return nil, errors.New("unauthorized")
+	}
+	token := jwt.New()
+	if err := token.Set(jwt.SubjectKey, m.userID.String()); err != nil {
+		return nil, err
+	}
+	return token, nil
+}
+
+func (m *mockJWSVerifier) ValidatePasswordResetToken(jws string) (jwt.Token, error) {
+	return nil, errors.New("unauthorized")
+}
+
+func TestOptionalAuth(t *testing.T) {
+	authorID := uuid.New()
+	engine := inmemory.NewStore(clock.RealClock{})
+	draft := &store.Post{ID: uuid.New(), AuthorID: authorID, Title: "Draft"}
+	require.NoError(t, engine.SetPost(t.Context(), draft))
+	handler := server.NewApiHandler(config.ApiSettings{}, engine, &mockJWSVerifier{userID: authorID})
+
+	tests := []struct {
+		name          string
+		authorization string
+		want          int
+	}{
+		{"anonymous", "", http.StatusNotFound},
+		{"valid token", "Bearer valid", http.StatusOK},
+		{"invalid token", "Bearer invalid", http.StatusUnauthorized},
+		{"malformed header", "Basic abc", http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/post-service/v1/posts/"+draft.ID.String(), nil)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.want, w.Result().StatusCode)
+		})
+	}
+
+	// Mutations still require authentication
+	req := httptest.NewRequest(http.MethodDelete, "/post-service/v1/posts/"+draft.ID.String(), nil)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusUnauthorized, w.Result().StatusCode)
+}
+
+func TestFeedHandler(t *testing.T) {
+	engine := inmemory.NewStore(clock.RealClock{})
+	post := &store.Post{ID: uuid.New(), Title: "Hello", Published: true}
+	require.NoError(t, engine.SetPost(t.Context(), post))
+	handler := server.NewApiHandler(config.ApiSettings{Host: "example.com", OrgName: "Example"}, engine, nil)
+
+	tests := []struct {
+		url         string
+		want        int
+		contentType string
+	}{
+		{"/post-service/v1/feeds/rss.xml", http.StatusOK, "application/rss+xml; charset=utf-8"},
+		{"/post-service/v1/feeds/tags/go/atom.xml", http.StatusOK, "application/atom+xml; charset=utf-8"},
+		{"/post-service/v1/feeds/authors/" + uuid.NewString() + "/feed.json", http.StatusOK, "application/feed+json; charset=utf-8"},
+		{"/post-service/v1/feeds/feed.html", http.StatusBadRequest, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			// Feeds are public
+			req := httptest.NewRequest(http.MethodGet, tt.url, nil)
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.want, w.Result().StatusCode)
+			if tt.contentType != "" {
+				assert.Equal(t, tt.contentType, w.Result().Header.Get("Content-Type"))
+			}
+		})
+	}
+}
+
+func TestSitemapHandler(t *testing.T) {
+	handler := server.NewApiHandler(config.ApiSettings{Host: "example.com"}, inmemory.NewStore(clock.RealClock{}), nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/post-service/v1/sitemap.xml", nil)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+	assert.Equal(t, "application/xml; charset=utf-8", w.Result().Header.Get("Content-Type"))
+
+	req = httptest.NewRequest(http.MethodGet, "/post-service/v1/sitemaps/0", nil)
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+}
+
+func TestMediaHandler(t *testing.T) {
+	userID := uuid.New()
+	handler := server.NewApiHandler(config.ApiSettings{}, inmemory.NewStore(clock.RealClock{}),
+		&mockJWSVerifier{userID: userID}, api.WithBlobStore(blob.NewLocalStore(t.TempDir())))
+
+	var file bytes.Buffer
+	require.NoError(t, png.Encode(&file, image.NewGray(image.Rect(0, 0, 4, 4))))
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	fw, err := mw.CreateFormFile("file", "image.png")
+	require.NoError(t, err)
+	_, err = fw.Write(file.Bytes())
+	require.NoError(t, err)
+	require.NoError(t, mw.WriteField("altText", "Gray"))
+	require.NoError(t, mw.Close())
+
+	// Uploads require authentication
+	req := httptest.NewRequest(http.MethodPost, "/post-service/v1/media", bytes.NewReader(body.Bytes()))
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusUnauthorized, w.Result().StatusCode)
+
+	req = httptest.NewRequest(http.MethodPost, "/post-service/v1/media", bytes.NewReader(body.Bytes()))
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	req.Header.Set("Authorization", "Bearer valid")
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	require.Equal(t, http.StatusCreated, w.Result().StatusCode)
+	var media api.Media
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&media))
+
+	// The content is public
+	req = httptest.NewRequest(http.MethodGet, "/post-service/v1/media/"+media.Id.String()+"/content", nil)
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+	assert.Equal(t, "image/png", w.Result().Header.Get("Content-Type"))
+	assert.Equal(t, "nosniff", w.Result().Header.Get("X-Content-Type-Options"))
+	assert.Equal(t, file.Bytes(), w.Body.Bytes())
+}
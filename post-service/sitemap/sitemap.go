@@ -0,0 +1,140 @@
+// Package sitemap encodes sitemaps and sitemap indexes following the
+// sitemaps.org protocol, and caches the URLs of a site between changes.
+package sitemap
+
+import (
+	"context"
+	"encoding/xml"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// MaxURLs is the maximum number of URLs of a single sitemap allowed by the
+// protocol. Larger sites are split into several sitemaps listed by an index.
+const MaxURLs = 50000
+
+// ContentType is the content type of sitemaps and sitemap indexes.
+const ContentType = "application/xml; charset=utf-8"
+
+const namespace = "http://www.sitemaps.org/schemas/sitemap/0.9"
+
+// URL is a page of a site. LastMod is left out if it is zero.
+type URL struct {
+	Loc     string
+	LastMod time.Time
+}
+
+type urlSet struct {
+	XMLName xml.Name `xml:"urlset"`
+	Xmlns   string   `xml:"xmlns,attr"`
+	URLs    []entry  `xml:"url"`
+}
+
+type index struct {
+	XMLName  xml.Name `xml:"sitemapindex"`
+	Xmlns    string   `xml:"xmlns,attr"`
+	Sitemaps []entry  `xml:"sitemap"`
+}
+
+type entry struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod,omitempty"`
+}
+
+// EncodeURLSet encodes the URLs as a sitemap.
+func EncodeURLSet(urls []URL) ([]byte, error) {
+	return encode(urlSet{Xmlns: namespace, URLs: entries(urls)})
+}
+
+// EncodeIndex encodes a sitemap index listing the sitemaps.
+func EncodeIndex(sitemaps []URL) ([]byte, error) {
+	return encode(index{Xmlns: namespace, Sitemaps: entries(sitemaps)})
+}
+
+// LastMod returns the most recent modification of the URLs, or the zero
+// time if none is known.
+func LastMod(urls []URL) time.Time {
+	var lastMod time.Time
+	for _, u := range urls {
+		if u.LastMod.After(lastMod) {
+			lastMod = u.LastMod
+		}
+	}
+	return lastMod
+}
+
+// Split splits the URLs into sitemaps of at most max URLs each. A site
+// without URLs has a single empty sitemap.
+func Split(urls []URL, max int) [][]URL {
+	pages := [][]URL{}
+	for len(urls) > max {
+		pages = append(pages, urls[:max])
+		urls = urls[max:]
+	}
+	return append(pages, urls)
+}
+
+func entries(urls []URL) []entry {
+	res := make([]entry, len(urls))
+	for i, u := range urls {
+		res[i] = entry{Loc: u.Loc}
+		if !u.LastMod.IsZero() {
+			res[i].LastMod = u.LastMod.UTC().Format(time.RFC3339)
+		}
+	}
+	return res
+}
+
+func encode(doc any) ([]byte, error) {
+	b, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), b...), nil
+}
+
+// Cache keeps the sitemaps of a site until it is invalidated. They are built
+// lazily by the first request after an invalidation.
+type Cache struct {
+	maxURLs int
+	// generation is incremented by every invalidation, so invalidations
+	// while the sitemaps are built are not lost
+	generation atomic.Uint64
+
+	mu        sync.Mutex
+	pages     [][]URL
+	builtFrom uint64
+	built     bool
+}
+
+// NewCache returns an empty cache of sitemaps with at most maxURLs each.
+func NewCache(maxURLs int) *Cache {
+	return &Cache{maxURLs: maxURLs}
+}
+
+// Pages returns the URLs of the site split into sitemaps, see Split. If the
+// cache was invalidated, the URLs are built again first.
+func (c *Cache) Pages(ctx context.Context, build func(ctx context.Context) ([]URL, error)) ([][]URL, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	generation := c.generation.Load()
+	if c.built && c.builtFrom == generation {
+		return c.pages, nil
+	}
+	urls, err := build(ctx)
+	if err != nil {
+		return nil, err
+	}
+	c.pages = Split(urls, c.maxURLs)
+	c.builtFrom = generation
+	c.built = true
+	return c.pages, nil
+}
+
+// Invalidate drops the cached sitemaps. It does not wait for sitemaps that
+// are being built.
+func (c *Cache) Invalidate() {
+	c.generation.Add(1)
+}
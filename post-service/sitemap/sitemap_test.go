@@ -0,0 +1,110 @@
+package sitemap_test
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/chrishrb/blog-microservice/post-service/sitemap"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeURLSet(t *testing.T) {
+	b, err := sitemap.EncodeURLSet([]sitemap.URL{
+		{Loc: "https://example.com/posts/hello", LastMod: time.Date(2023, 1, 1, 13, 0, 0, 0, time.FixedZone("CET", 3600))},
+		{Loc: "https://example.com/tags/go?a=1&b=2"},
+	})
+	require.NoError(t, err)
+
+	var doc struct {
+		XMLName xml.Name `xml:"http://www.sitemaps.org/schemas/sitemap/0.9 urlset"`
+		URLs    []struct {
+			Loc     string `xml:"loc"`
+			LastMod string `xml:"lastmod"`
+		} `xml:"url"`
+	}
+	require.NoError(t, xml.Unmarshal(b, &doc))
+	require.Len(t, doc.URLs, 2)
+	assert.Equal(t, "https://example.com/posts/hello", doc.URLs[0].Loc)
+	assert.Equal(t, "2023-01-01T12:00:00Z", doc.URLs[0].LastMod)
+	assert.Equal(t, "https://example.com/tags/go?a=1&b=2", doc.URLs[1].Loc)
+	assert.Empty(t, doc.URLs[1].LastMod)
+	assert.NotContains(t, string(b), "<lastmod></lastmod>")
+}
+
+func TestEncodeIndex(t *testing.T) {
+	b, err := sitemap.EncodeIndex([]sitemap.URL{
+		{Loc: "https://example.com/sitemaps/1", LastMod: time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)},
+		{Loc: "https://example.com/sitemaps/2"},
+	})
+	require.NoError(t, err)
+
+	var doc struct {
+		XMLName  xml.Name `xml:"http://www.sitemaps.org/schemas/sitemap/0.9 sitemapindex"`
+		Sitemaps []struct {
+			Loc string `xml:"loc"`
+		} `xml:"sitemap"`
+	}
+	require.NoError(t, xml.Unmarshal(b, &doc))
+	require.Len(t, doc.Sitemaps, 2)
+	assert.Equal(t, "https://example.com/sitemaps/2", doc.Sitemaps[1].Loc)
+}
+
+func TestSplit(t *testing.T) {
+	urls := []sitemap.URL{{Loc: "a"}, {Loc: "b"}, {Loc: "c"}}
+
+	assert.Equal(t, [][]sitemap.URL{{{Loc: "a"}, {Loc: "b"}}, {{Loc: "c"}}}, sitemap.Split(urls, 2))
+	assert.Equal(t, [][]sitemap.URL{urls}, sitemap.Split(urls, 3))
+	assert.Equal(t, [][]sitemap.URL{nil}, sitemap.Split(nil, 3))
+}
+
+func TestLastMod(t *testing.T) {
+	now := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	assert.Equal(t, now, sitemap.LastMod([]sitemap.URL{{LastMod: now.Add(-time.Hour)}, {LastMod: now}, {}}))
+	assert.True(t, sitemap.LastMod(nil).IsZero())
+}
+
+func TestCache(t *testing.T) {
+	cache := sitemap.NewCache(2)
+	builds := 0
+	build := func(ctx context.Context) ([]sitemap.URL, error) {
+		builds++
+		return []sitemap.URL{{Loc: "a"}, {Loc: "b"}, {Loc: "c"}}, nil
+	}
+
+	pages, err := cache.Pages(t.Context(), build)
+	require.NoError(t, err)
+	assert.Len(t, pages, 2)
+	_, err = cache.Pages(t.Context(), build)
+	require.NoError(t, err)
+	assert.Equal(t, 1, builds)
+
+	cache.Invalidate()
+	_, err = cache.Pages(t.Context(), build)
+	require.NoError(t, err)
+	assert.Equal(t, 2, builds)
+
+	// Failed builds are not cached
+	cache.Invalidate()
+	_, err = cache.Pages(t.Context(), func(ctx context.Context) ([]sitemap.URL, error) {
+		return nil, errors.New("failed")
+	})
+	assert.Error(t, err)
+	_, err = cache.Pages(t.Context(), build)
+	require.NoError(t, err)
+	assert.Equal(t, 3, builds)
+
+	// Invalidations while building are not lost
+	cache.Invalidate()
+	_, err = cache.Pages(t.Context(), func(ctx context.Context) ([]sitemap.URL, error) {
+		cache.Invalidate()
+		return build(ctx)
+	})
+	require.NoError(t, err)
+	_, err = cache.Pages(t.Context(), build)
+	require.NoError(t, err)
+	assert.Equal(t, 5, builds)
+}
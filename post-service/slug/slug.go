@@ -0,0 +1,108 @@
+// Package slug derives human-readable, URL-safe identifiers of posts from
+// their titles.
+package slug
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// MaxLength is the maximum length of a slug returned by Derive. Suffixes added
+// by WithSuffix may exceed it.
+const MaxLength = 80
+
+// Fallback is the slug of titles without any letters or digits.
+const Fallback = "post"
+
+// transliterations replaces letters that do not decompose into an ASCII base
+// letter and diacritics, or that have a conventional ASCII spelling.
+var transliterations = map[rune]string{
+	'ä': "ae", 'ö': "oe", 'ü': "ue", 'ß': "ss",
+	'æ': "ae", 'œ': "oe", 'ø': "o", 'å': "aa",
+	'đ': "d", 'ð': "d", 'þ': "th", 'ł': "l", 'ı': "i", 'ħ': "h",
+	// Cyrillic
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "e",
+	'ж': "zh", 'з': "z", 'и': "i", 'й': "i", 'к': "k", 'л': "l", 'м': "m",
+	'н': "n", 'о': "o", 'п': "p", 'р': "r", 'с': "s", 'т': "t", 'у': "u",
+	'ф': "f", 'х': "kh", 'ц': "ts", 'ч': "ch", 'ш': "sh", 'щ': "shch",
+	'ъ': "", 'ы': "y", 'ь': "", 'э': "e", 'ю': "iu", 'я': "ia",
+	'є': "ie", 'і': "i", 'ї': "i", 'ґ': "g",
+	// Greek
+	'α': "a", 'β': "v", 'γ': "g", 'δ': "d", 'ε': "e", 'ζ': "z", 'η': "i",
+	'θ': "th", 'ι': "i", 'κ': "k", 'λ': "l", 'μ': "m", 'ν': "n", 'ξ': "x",
+	'ο': "o", 'π': "p", 'ρ': "r", 'σ': "s", 'ς': "s", 'τ': "t", 'υ': "y",
+	'φ': "f", 'χ': "ch", 'ψ': "ps", 'ω': "o",
+}
+
+// Make returns the slug of a title, see Derive. Titles without letters or
+// digits get the Fallback slug.
+func Make(title string) string {
+	if s := Derive(title); s != "" {
+		return s
+	}
+	return Fallback
+}
+
+// Derive returns the slug of a text, which is empty if the text has no
+// letters or digits. Letters are transliterated to lower case ASCII,
+// diacritics are dropped and every other run of characters becomes a single
+// hyphen. Slugs longer than MaxLength are cut at a word boundary if possible.
+func Derive(text string) string {
+	var b strings.Builder
+	hyphen := false
+	write := func(s string) {
+		if s == "" {
+			return
+		}
+		if hyphen && b.Len() > 0 {
+			b.WriteByte('-')
+		}
+		hyphen = false
+		b.WriteString(s)
+	}
+
+	for _, r := range strings.ToLower(text) {
+		if t, ok := transliterations[r]; ok {
+			write(t)
+			continue
+		}
+		for _, d := range norm.NFKD.String(string(r)) {
+			t, ok := transliterations[d]
+			switch {
+			case ok:
+				write(t)
+			case d >= 'a' && d <= 'z', d >= '0' && d <= '9':
+				write(string(d))
+			case d >= 'A' && d <= 'Z':
+				write(string(unicode.ToLower(d)))
+			case unicode.Is(unicode.Mn, d):
+				// Diacritics of the preceding letter are dropped
+			default:
+				hyphen = true
+			}
+		}
+	}
+
+	s := b.String()
+	if len(s) > MaxLength {
+		s = s[:MaxLength]
+		if i := strings.LastIndexByte(s, '-'); i > 0 {
+			s = s[:i]
+		}
+		s = strings.TrimSuffix(s, "-")
+	}
+	return s
+}
+
+// WithSuffix returns the n-th candidate for a slug that is already taken.
+// The first candidate is the slug itself, later ones carry a numeric suffix
+// starting at 2.
+func WithSuffix(slug string, n int) string {
+	if n <= 1 {
+		return slug
+	}
+	return slug + "-" + strconv.Itoa(n)
+}
@@ -0,0 +1,58 @@
+package slug_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/chrishrb/blog-microservice/post-service/slug"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMake(t *testing.T) {
+	tests := []struct {
+		name  string
+		title string
+		want  string
+	}{
+		{"lower case", "Hello World", "hello-world"},
+		{"punctuation", "  Go: the (good) parts!  ", "go-the-good-parts"},
+		{"digits", "Top 10 tips for 2024", "top-10-tips-for-2024"},
+		{"diacritics", "Crème brûlée à la française", "creme-brulee-a-la-francaise"},
+		{"german", "Größe und Übermaß", "groesse-und-uebermass"},
+		{"nordic", "Smørrebrød på Ærø", "smorrebrod-paa-aero"},
+		{"polish", "Łódź", "lodz"},
+		{"cyrillic", "Привет, мир", "privet-mir"},
+		{"greek", "Καλημέρα", "kalimera"},
+		{"compatibility", "ﬁne ½", "fine-1-2"},
+		{"no letters", "!!!", slug.Fallback},
+		{"unsupported script", "你好", slug.Fallback},
+		{"empty", "", slug.Fallback},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, slug.Make(tt.title))
+		})
+	}
+}
+
+func TestMake_Length(t *testing.T) {
+	title := strings.Repeat("word ", 30)
+	got := slug.Make(title)
+	assert.LessOrEqual(t, len(got), slug.MaxLength)
+	assert.True(t, strings.HasPrefix(got, "word-word"))
+	assert.True(t, strings.HasSuffix(got, "word"))
+
+	got = slug.Make(strings.Repeat("a", 100))
+	assert.Equal(t, strings.Repeat("a", slug.MaxLength), got)
+}
+
+func TestWithSuffix(t *testing.T) {
+	assert.Equal(t, "hello", slug.WithSuffix("hello", 1))
+	assert.Equal(t, "hello-2", slug.WithSuffix("hello", 2))
+	assert.Equal(t, "hello-10", slug.WithSuffix("hello", 10))
+}
+
+func TestDerive(t *testing.T) {
+	assert.Equal(t, "hello-world", slug.Derive("Hello World"))
+	assert.Equal(t, "", slug.Derive("!!!"))
+}
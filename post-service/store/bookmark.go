@@ -0,0 +1,73 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Bookmark is a post a user saved for later. Every user bookmarks a post at
+// most once, optionally filed in one of their reading lists.
+type Bookmark struct {
+	UserID uuid.UUID
+	PostID uuid.UUID
+	// ListID is the reading list the bookmark is filed in, or nil if it is
+	// not filed in a list.
+	ListID    *uuid.UUID
+	CreatedAt time.Time
+	// Post is the bookmarked post, which is set by ListBookmarks.
+	Post *Post
+}
+
+// Cursor returns the position of the bookmark in a listing.
+func (b *Bookmark) Cursor() Cursor {
+	return Cursor{CreatedAt: b.CreatedAt, ID: b.PostID}
+}
+
+// ReadingList is a named collection of bookmarks of a user.
+type ReadingList struct {
+	ID        uuid.UUID
+	UserID    uuid.UUID
+	Name      string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Cursor returns the position of the reading list in a listing.
+func (l *ReadingList) Cursor() Cursor {
+	return Cursor{CreatedAt: l.CreatedAt, ID: l.ID}
+}
+
+// BookmarkQuery selects the bookmarks of a listing. Bookmarks are sorted by
+// creation time, most recent first, and ties are broken by post ID.
+type BookmarkQuery struct {
+	UserID uuid.UUID
+	// ListID restricts the listing to a reading list. If nil, bookmarks of
+	// all lists are listed.
+	ListID *uuid.UUID
+	Page   Page
+}
+
+type BookmarkStore interface {
+	// SetBookmark stores the bookmark, replacing the previous bookmark of
+	// the user to the post. The creation time of a bookmark is kept if it is
+	// moved to another list. Bookmarks of posts that do not exist are
+	// ignored. The reading list must belong to the user.
+	SetBookmark(ctx context.Context, bookmark *Bookmark) error
+	// DeleteBookmark removes the bookmark of the user to the post, if any.
+	DeleteBookmark(ctx context.Context, userID, postID uuid.UUID) error
+	// ListBookmarks returns the bookmarks of published posts. Bookmarks of
+	// deleted posts are removed together with the post, bookmarks of
+	// unpublished posts are kept but not listed until the post is published
+	// again.
+	ListBookmarks(ctx context.Context, query BookmarkQuery) ([]*Bookmark, error)
+
+	SetReadingList(ctx context.Context, list *ReadingList) error
+	LookupReadingList(ctx context.Context, ID uuid.UUID) (*ReadingList, error)
+	// ListReadingLists returns the reading lists of the user, oldest first.
+	ListReadingLists(ctx context.Context, userID uuid.UUID, page Page) ([]*ReadingList, error)
+	// DeleteReadingList removes the reading list. Its bookmarks are kept and
+	// are no longer filed in a list.
+	DeleteReadingList(ctx context.Context, ID uuid.UUID) error
+}
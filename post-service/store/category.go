@@ -0,0 +1,31 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Category is a node of the category taxonomy, which is a hierarchy separate
+// from tags. Every post is filed in at most one category.
+type Category struct {
+	ID uuid.UUID
+	// ParentID is the category this category is nested in, or nil for
+	// top-level categories.
+	ParentID    *uuid.UUID
+	Name        string
+	Description string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+type CategoryStore interface {
+	SetCategory(ctx context.Context, category *Category) error
+	LookupCategory(ctx context.Context, ID uuid.UUID) (*Category, error)
+	// ListCategories returns all categories ordered by name.
+	ListCategories(ctx context.Context) ([]*Category, error)
+	// DeleteCategory removes the category. Its subcategories move to its
+	// parent and its posts are no longer categorized.
+	DeleteCategory(ctx context.Context, ID uuid.UUID) error
+}
@@ -2,23 +2,109 @@ package store
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+// ErrPostNotFound is returned by SetComment if the post of the comment does
+// not exist.
+var ErrPostNotFound = errors.New("post not found")
+
 type Comment struct {
-	ID        uuid.UUID
-	AuthorID  uuid.UUID
-	PostID    uuid.UUID
-	Content   string
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	ID       uuid.UUID
+	AuthorID uuid.UUID
+	PostID   uuid.UUID
+	// ParentID is the comment this comment replies to, which belongs to the
+	// same post. Depth is 0 for top-level comments and grows by one for every
+	// level of replies.
+	ParentID *uuid.UUID
+	Depth    int
+	Content  string
+	// ContentHTML is the sanitized rendition of Content, which is empty for
+	// comments stored before renditions were introduced.
+	ContentHTML string
+	// State is the moderation state, comments without a state are stored as
+	// approved.
+	State ModerationState
+	// Deleted marks a tombstone, see DeleteComment.
+	Deleted bool
+	// DeletedAt is the time the comment was moved to the trash, see
+	// TrashStore.
+	DeletedAt *time.Time
+	// ReplyCount is the number of approved direct replies, which is set when
+	// reading comments.
+	ReplyCount int
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// Cursor returns the position of the comment in a listing.
+func (c *Comment) Cursor() Cursor {
+	return Cursor{CreatedAt: c.CreatedAt, ID: c.ID}
 }
 
+// VisibleTo reports whether the user may read the comment. Approved comments
+// are public, pending comments are only visible to their author. Anonymous
+// readers are represented by uuid.Nil.
+func (c *Comment) VisibleTo(reader uuid.UUID) bool {
+	switch c.State {
+	case ModerationStateApproved, "":
+		return true
+	case ModerationStatePending:
+		return reader != uuid.Nil && c.AuthorID == reader
+	}
+	return false
+}
+
+// ModerationState tells whether a comment has been reviewed by a moderator.
+type ModerationState string
+
+const (
+	ModerationStatePending  ModerationState = "pending"
+	ModerationStateApproved ModerationState = "approved"
+	ModerationStateRejected ModerationState = "rejected"
+	ModerationStateSpam     ModerationState = "spam"
+)
+
+// ModerationPolicy decides which new comments are approved right away and
+// which are held for moderation.
+type ModerationPolicy string
+
+const (
+	// ModerationPolicyNone approves all comments.
+	ModerationPolicyNone ModerationPolicy = "none"
+	// ModerationPolicyTrusted approves the comments of trusted users and
+	// holds the others.
+	ModerationPolicyTrusted ModerationPolicy = "trusted"
+	// ModerationPolicyAll holds all comments except the ones of moderators
+	// and of the author of the post.
+	ModerationPolicyAll ModerationPolicy = "all"
+)
+
 type CommentStore interface {
+	// SetComment stores the comment. It fails with ErrPostNotFound unless the
+	// post of the comment exists.
 	SetComment(ctx context.Context, comment *Comment) error
 	LookupComment(ctx context.Context, postId, ID uuid.UUID) (*Comment, error)
-	ListCommentsByPostID(ctx context.Context, postID uuid.UUID, offset, limit int) ([]*Comment, error)
+	// ListCommentsByPostID returns a page of the comments of the post. If
+	// reader is set, only the comments visible to the reader are listed, see
+	// VisibleTo.
+	ListCommentsByPostID(ctx context.Context, postID uuid.UUID, reader *uuid.UUID, page Page) ([]*Comment, error)
+	// ListCommentThreads returns a page of the top-level comments of the post
+	// together with all of their replies, ordered by creation time. The page
+	// only applies to the top-level comments. If reader is set, comments that
+	// are not visible to the reader are left out together with their replies.
+	ListCommentThreads(ctx context.Context, postID uuid.UUID, reader *uuid.UUID, page Page) ([]*Comment, error)
+	// ListCommentsByState returns a page of the comments of all posts in the
+	// moderation state, ordered by creation time.
+	ListCommentsByState(ctx context.Context, state ModerationState, page Page) ([]*Comment, error)
+	// CountApprovedComments returns the number of approved comments of the
+	// author on all posts.
+	CountApprovedComments(ctx context.Context, authorID uuid.UUID) (int, error)
+	// DeleteComment removes a comment. Comments with replies are kept as
+	// tombstones without content to keep the thread intact. Tombstones are
+	// removed together with their last reply.
 	DeleteComment(ctx context.Context, postID, ID uuid.UUID) error
 }
@@ -2,5 +2,16 @@ package store
 
 type Engine interface {
 	PostStore
+	PostSearchStore
+	CommentSearchStore
+	PostRevisionStore
+	PostScheduleStore
 	CommentStore
+	ReactionStore
+	BookmarkStore
+	TagStore
+	SeriesStore
+	CategoryStore
+	MediaStore
+	TrashStore
 }
@@ -0,0 +1,127 @@
+package inmemory
+
+import (
+	"context"
+
+	"github.com/chrishrb/blog-microservice/internal/api_utils"
+	"github.com/chrishrb/blog-microservice/post-service/store"
+	"github.com/google/uuid"
+)
+
+func (s *Store) SetBookmark(ctx context.Context, bookmark *store.Bookmark) error {
+	s.Lock()
+	defer s.Unlock()
+
+	if _, ok := s.posts[bookmark.PostID]; !ok {
+		return nil
+	}
+
+	if _, ok := s.bookmarks[bookmark.UserID]; !ok {
+		s.bookmarks[bookmark.UserID] = make(map[uuid.UUID]*store.Bookmark)
+	}
+	createdAt := s.clock.Now()
+	if existing, ok := s.bookmarks[bookmark.UserID][bookmark.PostID]; ok {
+		createdAt = existing.CreatedAt
+	}
+	bookmark.CreatedAt = createdAt
+	s.bookmarks[bookmark.UserID][bookmark.PostID] = &store.Bookmark{
+		UserID:    bookmark.UserID,
+		PostID:    bookmark.PostID,
+		ListID:    bookmark.ListID,
+		CreatedAt: createdAt,
+	}
+	return nil
+}
+
+func (s *Store) DeleteBookmark(ctx context.Context, userID, postID uuid.UUID) error {
+	s.Lock()
+	defer s.Unlock()
+
+	delete(s.bookmarks[userID], postID)
+	return nil
+}
+
+func (s *Store) ListBookmarks(ctx context.Context, query store.BookmarkQuery) ([]*store.Bookmark, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	bookmarks := []*store.Bookmark{}
+	for _, bookmark := range s.bookmarks[query.UserID] {
+		if query.ListID != nil && (bookmark.ListID == nil || *bookmark.ListID != *query.ListID) {
+			continue
+		}
+		post, ok := s.posts[bookmark.PostID]
+		if !ok || !post.Published {
+			continue
+		}
+		listed := *bookmark
+		listed.Post = clonePost(post)
+		bookmarks = append(bookmarks, &listed)
+	}
+
+	mostRecentFirst := func(a, b store.Cursor) int {
+		return store.CompareCursor(b, a)
+	}
+	return api_utils.Paginate(bookmarks, (*store.Bookmark).Cursor, mostRecentFirst, query.Page), nil
+}
+
+func (s *Store) SetReadingList(ctx context.Context, list *store.ReadingList) error {
+	s.Lock()
+	defer s.Unlock()
+
+	// Set timestamps, the creation time of an existing list is kept
+	now := s.clock.Now()
+	createdAt := now
+	if existing, ok := s.lists[list.ID]; ok {
+		createdAt = existing.CreatedAt
+	}
+	list.CreatedAt = createdAt
+	list.UpdatedAt = now
+
+	stored := *list
+	s.lists[list.ID] = &stored
+	return nil
+}
+
+func (s *Store) LookupReadingList(ctx context.Context, ID uuid.UUID) (*store.ReadingList, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	list, ok := s.lists[ID]
+	if !ok {
+		return nil, nil
+	}
+	found := *list
+	return &found, nil
+}
+
+func (s *Store) ListReadingLists(ctx context.Context, userID uuid.UUID, page store.Page) ([]*store.ReadingList, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	lists := []*store.ReadingList{}
+	for _, list := range s.lists {
+		if list.UserID == userID {
+			listed := *list
+			lists = append(lists, &listed)
+		}
+	}
+	return api_utils.Paginate(lists, (*store.ReadingList).Cursor, store.CompareCursor, page), nil
+}
+
+func (s *Store) DeleteReadingList(ctx context.Context, ID uuid.UUID) error {
+	s.Lock()
+	defer s.Unlock()
+
+	list, ok := s.lists[ID]
+	if !ok {
+		return nil
+	}
+	delete(s.lists, ID)
+	for _, bookmark := range s.bookmarks[list.UserID] {
+		if bookmark.ListID != nil && *bookmark.ListID == ID {
+			bookmark.ListID = nil
+		}
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package inmemory
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/chrishrb/blog-microservice/post-service/store"
+	"github.com/google/uuid"
+)
+
+func (s *Store) SetCategory(ctx context.Context, category *store.Category) error {
+	s.Lock()
+	defer s.Unlock()
+
+	// Set timestamps, the creation time of an existing category is kept
+	now := s.clock.Now()
+	createdAt := now
+	if existing, ok := s.categories[category.ID]; ok {
+		createdAt = existing.CreatedAt
+	}
+	category.CreatedAt = createdAt
+	category.UpdatedAt = now
+
+	stored := *category
+	s.categories[category.ID] = &stored
+	return nil
+}
+
+func (s *Store) LookupCategory(ctx context.Context, ID uuid.UUID) (*store.Category, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	category, ok := s.categories[ID]
+	if !ok {
+		return nil, nil
+	}
+	found := *category
+	return &found, nil
+}
+
+func (s *Store) ListCategories(ctx context.Context) ([]*store.Category, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	categories := []*store.Category{}
+	for _, category := range s.categories {
+		listed := *category
+		categories = append(categories, &listed)
+	}
+	slices.SortFunc(categories, func(a, b *store.Category) int {
+		return cmp.Or(strings.Compare(a.Name, b.Name), slices.Compare(a.ID[:], b.ID[:]))
+	})
+	return categories, nil
+}
+
+func (s *Store) DeleteCategory(ctx context.Context, ID uuid.UUID) error {
+	s.Lock()
+	defer s.Unlock()
+
+	category, ok := s.categories[ID]
+	if !ok {
+		return nil
+	}
+	delete(s.categories, ID)
+	for _, child := range s.categories {
+		if child.ParentID != nil && *child.ParentID == ID {
+			child.ParentID = category.ParentID
+		}
+	}
+	for _, posts := range []map[uuid.UUID]*store.Post{s.posts, s.trash} {
+		for _, post := range posts {
+			if post.CategoryID != nil && *post.CategoryID == ID {
+				post.CategoryID = nil
+			}
+		}
+	}
+	return nil
+}
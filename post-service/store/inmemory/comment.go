@@ -2,7 +2,10 @@ package inmemory
 
 import (
 	"context"
+	"slices"
 
+	"github.com/chrishrb/blog-microservice/internal/api_utils"
+	"github.com/chrishrb/blog-microservice/post-service/search"
 	"github.com/chrishrb/blog-microservice/post-service/store"
 	"github.com/google/uuid"
 )
@@ -13,12 +16,20 @@ func (s *Store) SetComment(ctx context.Context, comment *store.Comment) error {
 
 	// Verify the post exists
 	if _, ok := s.posts[comment.PostID]; !ok {
-		return nil
+		return store.ErrPostNotFound
 	}
 
-	// Set timestamps
+	if comment.State == "" {
+		comment.State = store.ModerationStateApproved
+	}
+
+	// Set timestamps, the creation time of an existing comment is kept
 	now := s.clock.Now()
-	comment.CreatedAt = now
+	createdAt := now
+	if existing, ok := s.comments[comment.PostID][comment.ID]; ok {
+		createdAt = existing.CreatedAt
+	}
+	comment.CreatedAt = createdAt
 	comment.UpdatedAt = now
 
 	// Store the comment
@@ -26,6 +37,7 @@ func (s *Store) SetComment(ctx context.Context, comment *store.Comment) error {
 		s.comments[comment.PostID] = make(map[uuid.UUID]*store.Comment)
 	}
 	s.comments[comment.PostID][comment.ID] = comment
+	s.commentIndex.Add(search.Document{ID: comment.ID, Content: comment.Content})
 	return nil
 }
 
@@ -34,13 +46,40 @@ func (s *Store) LookupComment(ctx context.Context, postID, ID uuid.UUID) (*store
 	defer s.Unlock()
 
 	comment, ok := s.comments[postID][ID]
+	if _, found := s.posts[postID]; !ok || !found {
+		return nil, nil
+	}
+	comment, ok = readComment(comment, s.replyCounts(postID), s.approvedReplyCounts(postID))
 	if !ok {
 		return nil, nil
 	}
 	return comment, nil
 }
 
-func (s *Store) ListCommentsByPostID(ctx context.Context, postID uuid.UUID, offset, limit int) ([]*store.Comment, error) {
+func (s *Store) ListCommentsByPostID(ctx context.Context, postID uuid.UUID, reader *uuid.UUID, page store.Page) ([]*store.Comment, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	// Check if post exists
+	if _, ok := s.posts[postID]; !ok {
+		return nil, nil
+	}
+
+	replies, approved := s.replyCounts(postID), s.approvedReplyCounts(postID)
+	comments := []*store.Comment{}
+	for _, comment := range s.comments[postID] {
+		if reader != nil && !comment.VisibleTo(*reader) {
+			continue
+		}
+		if comment, ok := readComment(comment, replies, approved); ok {
+			comments = append(comments, comment)
+		}
+	}
+
+	return api_utils.Paginate(comments, (*store.Comment).Cursor, store.CompareCursor, page), nil
+}
+
+func (s *Store) ListCommentThreads(ctx context.Context, postID uuid.UUID, reader *uuid.UUID, page store.Page) ([]*store.Comment, error) {
 	s.Lock()
 	defer s.Unlock()
 
@@ -49,27 +88,162 @@ func (s *Store) ListCommentsByPostID(ctx context.Context, postID uuid.UUID, offs
 		return nil, nil
 	}
 
-	var comments []*store.Comment
+	counts, approved := s.replyCounts(postID), s.approvedReplyCounts(postID)
+	roots := []*store.Comment{}
+	replies := make(map[uuid.UUID][]*store.Comment)
 	for _, comment := range s.comments[postID] {
-		comments = append(comments, comment)
+		if reader != nil && !comment.VisibleTo(*reader) {
+			continue
+		}
+		comment, ok := readComment(comment, counts, approved)
+		if !ok {
+			continue
+		}
+		if comment.ParentID == nil {
+			roots = append(roots, comment)
+		} else {
+			replies[*comment.ParentID] = append(replies[*comment.ParentID], comment)
+		}
 	}
 
-	if offset >= len(comments) {
-		return []*store.Comment{}, nil
+	// Collect the replies of the top-level comments on the page
+	threads := api_utils.Paginate(roots, (*store.Comment).Cursor, store.CompareCursor, page)
+	for i := 0; i < len(threads); i++ {
+		threads = append(threads, replies[threads[i].ID]...)
 	}
+	slices.SortFunc(threads, func(a, b *store.Comment) int {
+		return store.CompareCursor(a.Cursor(), b.Cursor())
+	})
+	return threads, nil
+}
 
-	end := min(offset+limit, len(comments))
-	return comments[offset:end], nil
+func (s *Store) ListCommentsByState(ctx context.Context, state store.ModerationState, page store.Page) ([]*store.Comment, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	comments := []*store.Comment{}
+	for postID, postComments := range s.comments {
+		if _, ok := s.posts[postID]; !ok {
+			continue
+		}
+		counts := s.approvedReplyCounts(postID)
+		for _, comment := range postComments {
+			if comment.State == state && comment.DeletedAt == nil {
+				comments = append(comments, withReplyCount(comment, counts))
+			}
+		}
+	}
+
+	return api_utils.Paginate(comments, (*store.Comment).Cursor, store.CompareCursor, page), nil
 }
 
-func (s *Store) DeleteComment(ctx context.Context, postID, ID uuid.UUID) error {
+func (s *Store) CountApprovedComments(ctx context.Context, authorID uuid.UUID) (int, error) {
 	s.Lock()
 	defer s.Unlock()
 
-	if _, ok := s.comments[postID][ID]; !ok {
-		return nil
+	count := 0
+	for postID, comments := range s.comments {
+		if _, ok := s.posts[postID]; !ok {
+			continue
+		}
+		for _, comment := range comments {
+			if comment.AuthorID == authorID && comment.State == store.ModerationStateApproved && comment.DeletedAt == nil {
+				count++
+			}
+		}
 	}
+	return count, nil
+}
 
-	delete(s.comments[postID], ID)
+func (s *Store) DeleteComment(ctx context.Context, postID, ID uuid.UUID) error {
+	s.Lock()
+	defer s.Unlock()
+
+	s.deleteComment(postID, ID)
 	return nil
 }
+
+// deleteComment removes the comment, which may be in the trash, or turns it
+// into a tombstone if it has replies.
+func (s *Store) deleteComment(postID, ID uuid.UUID) {
+	comments := s.comments[postID]
+	comment, ok := comments[ID]
+	if !ok {
+		return
+	}
+
+	// Keep a tombstone if there are replies
+	counts := s.replyCounts(postID)
+	if counts[ID] > 0 {
+		comment.Deleted = true
+		comment.DeletedAt = nil
+		comment.Content = ""
+		comment.ContentHTML = ""
+		comment.UpdatedAt = s.clock.Now()
+		s.commentIndex.Remove(ID)
+		return
+	}
+
+	// Remove tombstones that lost their last reply
+	delete(comments, ID)
+	s.commentIndex.Remove(ID)
+	delete(s.reactions, reactionKey{store.ReactionTargetComment, ID})
+	for comment.ParentID != nil {
+		counts[*comment.ParentID]--
+		parent, ok := comments[*comment.ParentID]
+		if !ok || !parent.Deleted || counts[parent.ID] > 0 {
+			break
+		}
+		delete(comments, parent.ID)
+		s.commentIndex.Remove(parent.ID)
+		delete(s.reactions, reactionKey{store.ReactionTargetComment, parent.ID})
+		comment = parent
+	}
+}
+
+// replyCounts returns the number of direct replies to the comments of the
+// post.
+func (s *Store) replyCounts(postID uuid.UUID) map[uuid.UUID]int {
+	counts := make(map[uuid.UUID]int)
+	for _, comment := range s.comments[postID] {
+		if comment.ParentID != nil {
+			counts[*comment.ParentID]++
+		}
+	}
+	return counts
+}
+
+// approvedReplyCounts returns the number of approved direct replies to the
+// comments of the post that are not trashed.
+func (s *Store) approvedReplyCounts(postID uuid.UUID) map[uuid.UUID]int {
+	counts := make(map[uuid.UUID]int)
+	for _, comment := range s.comments[postID] {
+		if comment.ParentID != nil && comment.State == store.ModerationStateApproved && comment.DeletedAt == nil {
+			counts[*comment.ParentID]++
+		}
+	}
+	return counts
+}
+
+// withReplyCount returns a copy of the comment with its reply count set.
+func withReplyCount(comment *store.Comment, counts map[uuid.UUID]int) *store.Comment {
+	c := *comment
+	c.ReplyCount = counts[comment.ID]
+	return &c
+}
+
+// readComment returns a copy of the comment as it is read outside of the
+// trash, with its reply count set. Trashed comments are read as tombstones
+// while they have replies and are left out otherwise.
+func readComment(comment *store.Comment, replies, approved map[uuid.UUID]int) (*store.Comment, bool) {
+	if comment.DeletedAt != nil && replies[comment.ID] == 0 {
+		return nil, false
+	}
+	c := withReplyCount(comment, approved)
+	if c.DeletedAt != nil {
+		c.Deleted = true
+		c.Content = ""
+		c.ContentHTML = ""
+	}
+	return c, true
+}
@@ -0,0 +1,80 @@
+package inmemory
+
+import (
+	"context"
+	"slices"
+
+	"github.com/chrishrb/blog-microservice/internal/api_utils"
+	"github.com/chrishrb/blog-microservice/post-service/store"
+	"github.com/google/uuid"
+)
+
+func (s *Store) SetMedia(ctx context.Context, media *store.Media) error {
+	s.Lock()
+	defer s.Unlock()
+
+	// Set timestamps, the creation time of existing media is kept
+	now := s.clock.Now()
+	createdAt := now
+	if existing, ok := s.media[media.ID]; ok {
+		createdAt = existing.CreatedAt
+	}
+	media.CreatedAt = createdAt
+	media.UpdatedAt = now
+
+	stored := *media
+	stored.Variants = slices.Clone(media.Variants)
+	s.media[media.ID] = &stored
+	return nil
+}
+
+func (s *Store) LookupMedia(ctx context.Context, ID uuid.UUID) (*store.Media, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	media, ok := s.media[ID]
+	if !ok {
+		return nil, nil
+	}
+	found := *media
+	found.Variants = slices.Clone(media.Variants)
+	return &found, nil
+}
+
+func (s *Store) ListMedia(ctx context.Context, ownerID uuid.UUID, page store.Page) ([]*store.Media, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	list := []*store.Media{}
+	for _, media := range s.media {
+		if media.OwnerID == ownerID {
+			listed := *media
+			listed.Variants = slices.Clone(media.Variants)
+			list = append(list, &listed)
+		}
+	}
+	return api_utils.Paginate(list, (*store.Media).Cursor, store.CompareCursor, page), nil
+}
+
+func (s *Store) DeleteMedia(ctx context.Context, ID uuid.UUID) error {
+	s.Lock()
+	defer s.Unlock()
+
+	if _, ok := s.media[ID]; !ok {
+		return nil
+	}
+	delete(s.media, ID)
+	for _, posts := range []map[uuid.UUID]*store.Post{s.posts, s.trash} {
+		for _, post := range posts {
+			if slices.Contains(post.MediaIDs, ID) {
+				post.MediaIDs = slices.DeleteFunc(slices.Clone(post.MediaIDs), func(mediaID uuid.UUID) bool {
+					return mediaID == ID
+				})
+			}
+			if post.CoverMediaID != nil && *post.CoverMediaID == ID {
+				post.CoverMediaID = nil
+			}
+		}
+	}
+	return nil
+}
@@ -2,7 +2,12 @@ package inmemory
 
 import (
 	"context"
+	"slices"
+	"time"
 
+	"github.com/chrishrb/blog-microservice/internal/api_utils"
+	"github.com/chrishrb/blog-microservice/post-service/search"
+	"github.com/chrishrb/blog-microservice/post-service/slug"
 	"github.com/chrishrb/blog-microservice/post-service/store"
 	"github.com/google/uuid"
 )
@@ -11,13 +16,65 @@ func (s *Store) SetPost(ctx context.Context, post *store.Post) error {
 	s.Lock()
 	defer s.Unlock()
 
-	// Set timestamps
+	return s.setPost(post)
+}
+
+// setPost stores the post while the store is locked, see SetPost.
+func (s *Store) setPost(post *store.Post) error {
+	// Only the current version of the post can be replaced
+	var version int64
+	if existing, ok := s.posts[post.ID]; ok {
+		version = existing.Version
+	}
+	if post.Version != 0 && post.Version != version {
+		return store.ErrVersionConflict
+	}
+
+	// Set timestamps, the creation time and the place in a series of an
+	// existing post are kept. The publication time is only set when the post
+	// is published now and was not before.
 	now := s.clock.Now()
-	post.CreatedAt = now
+	createdAt := now
+	seriesID, seriesPosition := (*uuid.UUID)(nil), 0
+	wasPublished, publishedAt := false, (*time.Time)(nil)
+	if existing, ok := s.posts[post.ID]; ok {
+		createdAt = existing.CreatedAt
+		seriesID, seriesPosition = existing.SeriesID, existing.SeriesPosition
+		wasPublished, publishedAt = existing.Published, existing.PublishedAt
+	}
+	if post.Published && !wasPublished {
+		publishedAt = &now
+	}
+	post.SeriesID, post.SeriesPosition = seriesID, seriesPosition
+	post.Version = version + 1
+	post.PublishedAt = publishedAt
+	post.CreatedAt = createdAt
 	post.UpdatedAt = now
 
-	// Store the post
-	s.posts[post.ID] = post
+	// Reserve the first candidate slug that is not used by another post,
+	// former slugs of the post stay reserved as well
+	base := post.Slug
+	if base == "" {
+		base = slug.Make(post.Title)
+	}
+	for n := 1; ; n++ {
+		candidate := slug.WithSuffix(base, n)
+		if owner, ok := s.slugs[candidate]; !ok || owner == post.ID {
+			post.Slug = candidate
+			break
+		}
+	}
+	s.slugs[post.Slug] = post.ID
+
+	// Store a copy of the post so that callers cannot modify the stored
+	// state without calling SetPost
+	s.posts[post.ID] = clonePost(post)
+	s.index.Add(search.Document{
+		ID:      post.ID,
+		Title:   post.Title,
+		Content: post.Content,
+		Tags:    post.Tags,
+	})
 	return nil
 }
 
@@ -29,30 +86,78 @@ func (s *Store) LookupPost(ctx context.Context, ID uuid.UUID) (*store.Post, erro
 	if !ok {
 		return nil, nil
 	}
-	return post, nil
+	return clonePost(post), nil
 }
 
-func (s *Store) ListPosts(ctx context.Context, offset, limit int) ([]*store.Post, error) {
+func (s *Store) LookupPostBySlug(ctx context.Context, slug string) (*store.Post, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	post, ok := s.posts[s.slugs[slug]]
+	if !ok {
+		return nil, nil
+	}
+	return clonePost(post), nil
+}
+
+func (s *Store) ListPosts(ctx context.Context, query store.PostQuery) ([]*store.Post, error) {
 	s.Lock()
 	defer s.Unlock()
 
 	var posts []*store.Post
 	for _, post := range s.posts {
-		posts = append(posts, post)
+		if query.Matches(post) {
+			posts = append(posts, clonePost(post))
+		}
 	}
 
-	end := min(offset+limit, len(posts))
-	return posts[offset:end], nil
+	return api_utils.Paginate(posts, query.Cursor, query.Compare, query.Page), nil
 }
 
 func (s *Store) DeletePost(ctx context.Context, ID uuid.UUID) error {
 	s.Lock()
 	defer s.Unlock()
 
-	if _, ok := s.posts[ID]; !ok {
-		return nil
+	s.deletePost(ID)
+	return nil
+}
+
+// deletePost removes the post, which may be in the trash, together with
+// everything that belongs to it.
+func (s *Store) deletePost(ID uuid.UUID) {
+	post, ok := s.posts[ID]
+	if !ok {
+		post, ok = s.trash[ID]
+	}
+	if !ok {
+		return
 	}
 
+	s.removeSeriesPost(post)
 	delete(s.posts, ID)
-	return nil
+	delete(s.trash, ID)
+	for key, owner := range s.slugs {
+		if owner == ID {
+			delete(s.slugs, key)
+		}
+	}
+	delete(s.revisions, ID)
+	delete(s.reactions, reactionKey{store.ReactionTargetPost, ID})
+	for commentID := range s.comments[ID] {
+		delete(s.reactions, reactionKey{store.ReactionTargetComment, commentID})
+		s.commentIndex.Remove(commentID)
+	}
+	delete(s.comments, ID)
+	for _, bookmarks := range s.bookmarks {
+		delete(bookmarks, ID)
+	}
+	s.index.Remove(ID)
+}
+
+// clonePost returns a copy of the post that shares no state with it.
+func clonePost(post *store.Post) *store.Post {
+	c := *post
+	c.Tags = slices.Clone(post.Tags)
+	c.MediaIDs = slices.Clone(post.MediaIDs)
+	return &c
 }
@@ -0,0 +1,78 @@
+package inmemory
+
+import (
+	"context"
+
+	"github.com/chrishrb/blog-microservice/post-service/store"
+	"github.com/google/uuid"
+)
+
+// reactionKey identifies the target of reactions.
+type reactionKey struct {
+	target store.ReactionTarget
+	ID     uuid.UUID
+}
+
+func (s *Store) SetReaction(ctx context.Context, reaction *store.Reaction) error {
+	s.Lock()
+	defer s.Unlock()
+
+	if !s.reactionTargetExists(reaction.Target, reaction.TargetID) {
+		return nil
+	}
+
+	key := reactionKey{reaction.Target, reaction.TargetID}
+	if _, ok := s.reactions[key]; !ok {
+		s.reactions[key] = make(map[uuid.UUID]*store.Reaction)
+	}
+	existing, ok := s.reactions[key][reaction.UserID]
+	if ok && existing.Kind == reaction.Kind {
+		reaction.CreatedAt = existing.CreatedAt
+		return nil
+	}
+	reaction.CreatedAt = s.clock.Now()
+	s.reactions[key][reaction.UserID] = reaction
+	return nil
+}
+
+func (s *Store) DeleteReaction(ctx context.Context, target store.ReactionTarget, targetID, userID uuid.UUID) error {
+	s.Lock()
+	defer s.Unlock()
+
+	delete(s.reactions[reactionKey{target, targetID}], userID)
+	return nil
+}
+
+func (s *Store) SummarizeReactions(ctx context.Context, target store.ReactionTarget, targetIDs []uuid.UUID, reader uuid.UUID) (map[uuid.UUID]*store.ReactionSummary, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	summaries := make(map[uuid.UUID]*store.ReactionSummary, len(targetIDs))
+	for _, ID := range targetIDs {
+		summary := &store.ReactionSummary{Counts: make(map[string]int)}
+		for userID, reaction := range s.reactions[reactionKey{target, ID}] {
+			summary.Counts[reaction.Kind]++
+			if reader != uuid.Nil && userID == reader {
+				summary.Mine = reaction.Kind
+			}
+		}
+		summaries[ID] = summary
+	}
+	return summaries, nil
+}
+
+// reactionTargetExists reports whether the target of a reaction exists.
+func (s *Store) reactionTargetExists(target store.ReactionTarget, ID uuid.UUID) bool {
+	switch target {
+	case store.ReactionTargetPost:
+		_, ok := s.posts[ID]
+		return ok
+	case store.ReactionTargetComment:
+		for _, comments := range s.comments {
+			if _, ok := comments[ID]; ok {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,59 @@
+package inmemory
+
+import (
+	"context"
+	"slices"
+
+	"github.com/chrishrb/blog-microservice/post-service/store"
+	"github.com/google/uuid"
+)
+
+func (s *Store) SetPostWithRevision(ctx context.Context, post *store.Post, revision *store.PostRevision) error {
+	s.Lock()
+	defer s.Unlock()
+
+	if err := s.setPost(post); err != nil {
+		return err
+	}
+	revision.PostID = post.ID
+	s.addPostRevision(revision)
+	return nil
+}
+
+// addPostRevision stores the revision while the store is locked and assigns
+// its number and creation time, see SetPostWithRevision.
+func (s *Store) addPostRevision(revision *store.PostRevision) {
+	// Verify the post exists
+	if _, ok := s.posts[revision.PostID]; !ok {
+		return
+	}
+
+	revision.Number = len(s.revisions[revision.PostID]) + 1
+	revision.CreatedAt = s.clock.Now()
+
+	// Store a copy, so that the revision cannot be changed afterwards
+	stored := *revision
+	stored.Tags = slices.Clone(revision.Tags)
+	s.revisions[revision.PostID] = append(s.revisions[revision.PostID], &stored)
+}
+
+func (s *Store) LookupPostRevision(ctx context.Context, postID uuid.UUID, number int) (*store.PostRevision, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	revisions := s.revisions[postID]
+	if number < 1 || number > len(revisions) {
+		return nil, nil
+	}
+	return revisions[number-1], nil
+}
+
+func (s *Store) ListPostRevisions(ctx context.Context, postID uuid.UUID, after, limit int) ([]*store.PostRevision, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	revisions := s.revisions[postID]
+	start := min(max(after, 0), len(revisions))
+	end := min(start+max(limit, 0), len(revisions))
+	return slices.Clone(revisions[start:end]), nil
+}
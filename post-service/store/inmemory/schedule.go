@@ -0,0 +1,27 @@
+package inmemory
+
+import (
+	"context"
+	"time"
+
+	"github.com/chrishrb/blog-microservice/post-service/store"
+)
+
+func (s *Store) ApplyPostSchedules(ctx context.Context, now time.Time) ([]*store.Post, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	var changed []*store.Post
+	for _, post := range s.posts {
+		if post.ApplySchedule(now) {
+			if post.Published {
+				publishedAt := now
+				post.PublishedAt = &publishedAt
+			}
+			post.Version++
+			post.UpdatedAt = now
+			changed = append(changed, clonePost(post))
+		}
+	}
+	return changed, nil
+}
@@ -0,0 +1,69 @@
+package inmemory
+
+import (
+	"context"
+
+	"github.com/chrishrb/blog-microservice/post-service/store"
+	"github.com/google/uuid"
+)
+
+func (s *Store) SearchPosts(ctx context.Context, query store.PostSearchQuery) ([]*store.PostSearchResult, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	var include func(ID uuid.UUID) bool
+	if query.Reader != nil {
+		include = func(ID uuid.UUID) bool {
+			return s.posts[ID].VisibleTo(*query.Reader)
+		}
+	}
+
+	results := []*store.PostSearchResult{}
+	for _, result := range s.index.Search(query.Text, query.Limit, include) {
+		results = append(results, &store.PostSearchResult{
+			Post:    clonePost(s.posts[result.ID]),
+			Score:   result.Score,
+			Snippet: result.Snippet,
+		})
+	}
+	return results, nil
+}
+
+func (s *Store) SearchComments(ctx context.Context, query store.CommentSearchQuery) ([]*store.CommentSearchResult, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	// The index only follows changes of the content, so trashed comments,
+	// comments of trashed posts and visibility are checked here
+	comments := make(map[uuid.UUID]*store.Comment)
+	for postID, postComments := range s.comments {
+		post, ok := s.posts[postID]
+		if !ok || (query.PostReader != nil && !post.VisibleTo(*query.PostReader)) {
+			continue
+		}
+		for ID, comment := range postComments {
+			if comment.Deleted || comment.DeletedAt != nil {
+				continue
+			}
+			if query.Reader != nil && !comment.VisibleTo(*query.Reader) {
+				continue
+			}
+			comments[ID] = comment
+		}
+	}
+	include := func(ID uuid.UUID) bool {
+		_, ok := comments[ID]
+		return ok
+	}
+
+	results := []*store.CommentSearchResult{}
+	for _, result := range s.commentIndex.Search(query.Text, query.Limit, include) {
+		comment := comments[result.ID]
+		results = append(results, &store.CommentSearchResult{
+			Comment: withReplyCount(comment, s.approvedReplyCounts(comment.PostID)),
+			Score:   result.Score,
+			Snippet: result.Snippet,
+		})
+	}
+	return results, nil
+}
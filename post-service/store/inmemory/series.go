@@ -0,0 +1,154 @@
+package inmemory
+
+import (
+	"context"
+	"slices"
+
+	"github.com/chrishrb/blog-microservice/internal/api_utils"
+	"github.com/chrishrb/blog-microservice/post-service/store"
+	"github.com/google/uuid"
+)
+
+func (s *Store) SetSeries(ctx context.Context, series *store.Series) error {
+	s.Lock()
+	defer s.Unlock()
+
+	// Set timestamps, the creation time of an existing series is kept
+	now := s.clock.Now()
+	createdAt := now
+	if existing, ok := s.series[series.ID]; ok {
+		createdAt = existing.CreatedAt
+	}
+	series.CreatedAt = createdAt
+	series.UpdatedAt = now
+
+	stored := *series
+	s.series[series.ID] = &stored
+	return nil
+}
+
+func (s *Store) LookupSeries(ctx context.Context, ID uuid.UUID) (*store.Series, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	series, ok := s.series[ID]
+	if !ok {
+		return nil, nil
+	}
+	found := *series
+	return &found, nil
+}
+
+func (s *Store) ListSeries(ctx context.Context, authorID *uuid.UUID, page store.Page) ([]*store.Series, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	list := []*store.Series{}
+	for _, series := range s.series {
+		if authorID == nil || series.AuthorID == *authorID {
+			listed := *series
+			list = append(list, &listed)
+		}
+	}
+	return api_utils.Paginate(list, (*store.Series).Cursor, store.CompareCursor, page), nil
+}
+
+func (s *Store) DeleteSeries(ctx context.Context, ID uuid.UUID) error {
+	s.Lock()
+	defer s.Unlock()
+
+	delete(s.series, ID)
+	for _, post := range s.seriesPosts(ID) {
+		post.SeriesID, post.SeriesPosition = nil, 0
+	}
+	return nil
+}
+
+func (s *Store) AddSeriesPost(ctx context.Context, seriesID, postID uuid.UUID, position int) error {
+	s.Lock()
+	defer s.Unlock()
+
+	post, ok := s.posts[postID]
+	if _, found := s.series[seriesID]; !ok || !found {
+		return nil
+	}
+	s.removeSeriesPost(post)
+
+	posts := s.seriesPosts(seriesID)
+	if position < 1 || position > len(posts) {
+		position = len(posts) + 1
+	}
+	posts = slices.Insert(posts, position-1, post)
+	post.SeriesID = &seriesID
+	renumber(posts)
+	return nil
+}
+
+func (s *Store) RemoveSeriesPost(ctx context.Context, postID uuid.UUID) error {
+	s.Lock()
+	defer s.Unlock()
+
+	if post, ok := s.posts[postID]; ok {
+		s.removeSeriesPost(post)
+	}
+	return nil
+}
+
+func (s *Store) ListSeriesPosts(ctx context.Context, seriesID uuid.UUID) ([]*store.Post, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	posts := s.seriesPosts(seriesID)
+	for i, post := range posts {
+		posts[i] = clonePost(post)
+	}
+	return posts, nil
+}
+
+func (s *Store) ReorderSeries(ctx context.Context, seriesID uuid.UUID, postIDs []uuid.UUID) error {
+	s.Lock()
+	defer s.Unlock()
+
+	posts := s.seriesPosts(seriesID)
+	current := make([]uuid.UUID, len(posts))
+	for i, post := range posts {
+		current[i] = post.ID
+	}
+	for i, ID := range store.SeriesOrder(current, postIDs) {
+		s.posts[ID].SeriesPosition = i + 1
+	}
+	return nil
+}
+
+// seriesPosts returns the posts of the series ordered by position.
+func (s *Store) seriesPosts(seriesID uuid.UUID) []*store.Post {
+	posts := []*store.Post{}
+	for _, post := range s.posts {
+		if post.SeriesID != nil && *post.SeriesID == seriesID {
+			posts = append(posts, post)
+		}
+	}
+	slices.SortFunc(posts, func(a, b *store.Post) int {
+		return a.SeriesPosition - b.SeriesPosition
+	})
+	return posts
+}
+
+// removeSeriesPost removes the post from its series and closes the gap it
+// leaves.
+func (s *Store) removeSeriesPost(post *store.Post) {
+	if post.SeriesID == nil {
+		return
+	}
+	seriesID := *post.SeriesID
+	post.SeriesID, post.SeriesPosition = nil, 0
+	renumber(s.seriesPosts(seriesID))
+}
+
+// renumber assigns consecutive positions to the posts of a series in their
+// order.
+func renumber(posts []*store.Post) {
+	for i, post := range posts {
+		post.SeriesPosition = i + 1
+	}
+}
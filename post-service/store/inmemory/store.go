@@ -5,6 +5,7 @@ import (
 
 	"k8s.io/utils/clock"
 
+	"github.com/chrishrb/blog-microservice/post-service/search"
 	"github.com/chrishrb/blog-microservice/post-service/store"
 	"github.com/google/uuid"
 )
@@ -14,15 +15,40 @@ import (
 // It is primarily provided to support unit testing.
 type Store struct {
 	sync.Mutex
-	clock    clock.PassiveClock
-	posts    map[uuid.UUID]*store.Post
-	comments map[uuid.UUID]map[uuid.UUID]*store.Comment
+	clock      clock.PassiveClock
+	posts      map[uuid.UUID]*store.Post
+	trash      map[uuid.UUID]*store.Post // trashed posts, which are not in posts
+	slugs      map[string]uuid.UUID
+	comments   map[uuid.UUID]map[uuid.UUID]*store.Comment
+	revisions  map[uuid.UUID][]*store.PostRevision
+	reactions  map[reactionKey]map[uuid.UUID]*store.Reaction
+	bookmarks  map[uuid.UUID]map[uuid.UUID]*store.Bookmark
+	lists      map[uuid.UUID]*store.ReadingList
+	series     map[uuid.UUID]*store.Series
+	categories map[uuid.UUID]*store.Category
+	media      map[uuid.UUID]*store.Media
+	index      *search.Index
+	// commentIndex contains the content of all comments, which are
+	// filtered when searching, see SearchComments
+	commentIndex *search.Index
 }
 
 func NewStore(clock clock.PassiveClock) *Store {
 	return &Store{
-		clock:    clock,
-		posts:    make(map[uuid.UUID]*store.Post),
-		comments: make(map[uuid.UUID]map[uuid.UUID]*store.Comment),
+		clock:      clock,
+		posts:      make(map[uuid.UUID]*store.Post),
+		trash:      make(map[uuid.UUID]*store.Post),
+		slugs:      make(map[string]uuid.UUID),
+		comments:   make(map[uuid.UUID]map[uuid.UUID]*store.Comment),
+		revisions:  make(map[uuid.UUID][]*store.PostRevision),
+		reactions:  make(map[reactionKey]map[uuid.UUID]*store.Reaction),
+		bookmarks:  make(map[uuid.UUID]map[uuid.UUID]*store.Bookmark),
+		lists:      make(map[uuid.UUID]*store.ReadingList),
+		series:     make(map[uuid.UUID]*store.Series),
+		categories: make(map[uuid.UUID]*store.Category),
+		media:      make(map[uuid.UUID]*store.Media),
+		index:      search.NewIndex(),
+
+		commentIndex: search.NewIndex(),
 	}
 }
@@ -0,0 +1,16 @@
+package inmemory_test
+
+import (
+	"testing"
+
+	"github.com/chrishrb/blog-microservice/post-service/store"
+	"github.com/chrishrb/blog-microservice/post-service/store/inmemory"
+	"github.com/chrishrb/blog-microservice/post-service/store/storetest"
+	"k8s.io/utils/clock"
+)
+
+func TestStore(t *testing.T) {
+	storetest.Run(t, func(t *testing.T, clock clock.PassiveClock) store.Engine {
+		return inmemory.NewStore(clock)
+	})
+}
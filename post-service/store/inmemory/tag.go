@@ -0,0 +1,63 @@
+package inmemory
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/chrishrb/blog-microservice/post-service/search"
+	"github.com/chrishrb/blog-microservice/post-service/store"
+)
+
+func (s *Store) ListTags(ctx context.Context, query store.TagQuery) ([]*store.TagCount, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	counts := make(map[string]int)
+	for _, post := range s.posts {
+		if !post.Published {
+			continue
+		}
+		for _, t := range post.Tags {
+			if strings.HasPrefix(t, query.Prefix) {
+				counts[t]++
+			}
+		}
+	}
+
+	tags := []*store.TagCount{}
+	for t, count := range counts {
+		tags = append(tags, &store.TagCount{Tag: t, Count: count})
+	}
+	slices.SortFunc(tags, func(a, b *store.TagCount) int {
+		if c := cmp.Compare(b.Count, a.Count); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Tag, b.Tag)
+	})
+	return tags[:min(max(query.Limit, 0), len(tags))], nil
+}
+
+func (s *Store) MergeTags(ctx context.Context, sources []string, target string) (int, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	changed := 0
+	for _, post := range s.posts {
+		tags, ok := store.ReplaceTags(post.Tags, sources, target)
+		if !ok {
+			continue
+		}
+		post.Tags = tags
+		post.Version++
+		s.index.Add(search.Document{
+			ID:      post.ID,
+			Title:   post.Title,
+			Content: post.Content,
+			Tags:    post.Tags,
+		})
+		changed++
+	}
+	return changed, nil
+}
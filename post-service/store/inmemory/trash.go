@@ -0,0 +1,164 @@
+package inmemory
+
+import (
+	"context"
+	"time"
+
+	"github.com/chrishrb/blog-microservice/internal/api_utils"
+	"github.com/chrishrb/blog-microservice/post-service/search"
+	"github.com/chrishrb/blog-microservice/post-service/store"
+	"github.com/google/uuid"
+)
+
+func (s *Store) TrashPost(ctx context.Context, ID uuid.UUID) error {
+	s.Lock()
+	defer s.Unlock()
+
+	post, ok := s.posts[ID]
+	if !ok {
+		return nil
+	}
+
+	s.removeSeriesPost(post)
+	now := s.clock.Now()
+	post.DeletedAt = &now
+	delete(s.posts, ID)
+	s.trash[ID] = post
+	s.index.Remove(ID)
+	return nil
+}
+
+func (s *Store) LookupTrashedPost(ctx context.Context, ID uuid.UUID) (*store.Post, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	post, ok := s.trash[ID]
+	if !ok {
+		return nil, nil
+	}
+	return clonePost(post), nil
+}
+
+func (s *Store) ListTrashedPosts(ctx context.Context, authorID *uuid.UUID, page store.Page) ([]*store.Post, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	posts := []*store.Post{}
+	for _, post := range s.trash {
+		if authorID == nil || post.AuthorID == *authorID {
+			posts = append(posts, clonePost(post))
+		}
+	}
+
+	return api_utils.Paginate(posts, (*store.Post).Cursor, store.CompareCursor, page), nil
+}
+
+func (s *Store) RestorePost(ctx context.Context, ID uuid.UUID) error {
+	s.Lock()
+	defer s.Unlock()
+
+	post, ok := s.trash[ID]
+	if !ok {
+		return nil
+	}
+
+	post.DeletedAt = nil
+	delete(s.trash, ID)
+	s.posts[ID] = post
+	s.index.Add(search.Document{
+		ID:      post.ID,
+		Title:   post.Title,
+		Content: post.Content,
+		Tags:    post.Tags,
+	})
+	return nil
+}
+
+func (s *Store) TrashComment(ctx context.Context, postID, ID uuid.UUID) error {
+	s.Lock()
+	defer s.Unlock()
+
+	comment, ok := s.comments[postID][ID]
+	if _, found := s.posts[postID]; !ok || !found || comment.DeletedAt != nil {
+		return nil
+	}
+
+	now := s.clock.Now()
+	comment.DeletedAt = &now
+	return nil
+}
+
+func (s *Store) LookupTrashedComment(ctx context.Context, postID, ID uuid.UUID) (*store.Comment, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	comment, ok := s.comments[postID][ID]
+	if _, found := s.posts[postID]; !ok || !found || comment.DeletedAt == nil {
+		return nil, nil
+	}
+	return withReplyCount(comment, s.approvedReplyCounts(postID)), nil
+}
+
+func (s *Store) ListTrashedComments(ctx context.Context, authorID *uuid.UUID, page store.Page) ([]*store.Comment, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	comments := []*store.Comment{}
+	for postID, postComments := range s.comments {
+		if _, ok := s.posts[postID]; !ok {
+			continue
+		}
+		counts := s.approvedReplyCounts(postID)
+		for _, comment := range postComments {
+			if comment.DeletedAt != nil && (authorID == nil || comment.AuthorID == *authorID) {
+				comments = append(comments, withReplyCount(comment, counts))
+			}
+		}
+	}
+
+	return api_utils.Paginate(comments, (*store.Comment).Cursor, store.CompareCursor, page), nil
+}
+
+func (s *Store) RestoreComment(ctx context.Context, postID, ID uuid.UUID) error {
+	s.Lock()
+	defer s.Unlock()
+
+	comment, ok := s.comments[postID][ID]
+	if _, found := s.posts[postID]; !ok || !found {
+		return nil
+	}
+
+	comment.DeletedAt = nil
+	return nil
+}
+
+func (s *Store) PurgeTrash(ctx context.Context, before time.Time) (int, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	// Collect the due posts and comments before deleting them, as deleting
+	// changes the maps. Comments of purged posts are purged with them.
+	var posts []uuid.UUID
+	for ID, post := range s.trash {
+		if post.DeletedAt.Before(before) {
+			posts = append(posts, ID)
+		}
+	}
+	for _, ID := range posts {
+		s.deletePost(ID)
+	}
+
+	type commentKey struct{ postID, ID uuid.UUID }
+	var comments []commentKey
+	for postID, postComments := range s.comments {
+		for ID, comment := range postComments {
+			if comment.DeletedAt != nil && comment.DeletedAt.Before(before) {
+				comments = append(comments, commentKey{postID, ID})
+			}
+		}
+	}
+	for _, key := range comments {
+		s.deleteComment(key.postID, key.ID)
+	}
+	return len(posts) + len(comments), nil
+}
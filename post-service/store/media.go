@@ -0,0 +1,42 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Media is an uploaded file, like an image embedded in a post. The files of
+// the media and its variants are kept in a blob store, the store only holds
+// their metadata.
+type Media struct {
+	ID          uuid.UUID
+	OwnerID     uuid.UUID
+	ContentType string
+	Size        int64
+	AltText     string
+	// Width and Height are the dimensions of images in pixels.
+	Width  int
+	Height int
+	// Variants are the names of the resized renditions of the media that
+	// have been generated.
+	Variants  []string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Cursor returns the position of the media in a listing.
+func (m *Media) Cursor() Cursor {
+	return Cursor{CreatedAt: m.CreatedAt, ID: m.ID}
+}
+
+type MediaStore interface {
+	SetMedia(ctx context.Context, media *Media) error
+	LookupMedia(ctx context.Context, ID uuid.UUID) (*Media, error)
+	// ListMedia returns the media of the owner, oldest first.
+	ListMedia(ctx context.Context, ownerID uuid.UUID, page Page) ([]*Media, error)
+	// DeleteMedia removes the media. Posts no longer reference it, neither
+	// as embedded media nor as cover image.
+	DeleteMedia(ctx context.Context, ID uuid.UUID) error
+}
@@ -0,0 +1,34 @@
+package store
+
+import (
+	"time"
+
+	"github.com/chrishrb/blog-microservice/internal/api_utils"
+	"github.com/google/uuid"
+)
+
+// Cursor marks the position of an item in a listing. Listings are ordered by
+// creation time and ID, so the cursor is stable even if items are added or
+// removed between two requests. Listings of posts can be sorted by other keys
+// first, see PostQuery. These sort keys are only set if the listing is sorted
+// by them, otherwise they are left out of the token handed out to clients.
+type Cursor struct {
+	CreatedAt   time.Time `json:"t"`
+	ID          uuid.UUID `json:"id"`
+	UpdatedAt   time.Time `json:"u,omitzero"`
+	PublishedAt time.Time `json:"p,omitzero"`
+	Title       string    `json:"k,omitempty"`
+}
+
+// Page selects a part of a listing. If After is set, the page starts with the
+// first item after the cursor and Offset is ignored.
+type Page = api_utils.Page[Cursor]
+
+// CompareCursor orders two listing positions by creation time and ID. It
+// returns -1 if a comes before b, +1 if a comes after b and 0 otherwise.
+func CompareCursor(a, b Cursor) int {
+	return api_utils.CompareCursor(
+		api_utils.Cursor{CreatedAt: a.CreatedAt, ID: a.ID},
+		api_utils.Cursor{CreatedAt: b.CreatedAt, ID: b.ID},
+	)
+}
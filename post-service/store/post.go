@@ -2,25 +2,220 @@ package store
 
 import (
 	"context"
+	"errors"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+// ErrVersionConflict is returned by SetPost if the post was changed since the
+// version that is stored.
+var ErrVersionConflict = errors.New("version conflict")
+
 type Post struct {
-	ID        uuid.UUID
-	AuthorID  uuid.UUID
-	Title     string
-	Content   string
-	Tags      []string
-	Published bool
+	ID       uuid.UUID
+	AuthorID uuid.UUID
+	Title    string
+	// Slug is the human-readable identifier of the post, which is assigned
+	// by SetPost.
+	Slug    string
+	Content string
+	// ContentFormat is the markup language of Content and ContentHTML its
+	// sanitized rendition. ContentHTML is empty for posts stored before
+	// renditions were introduced.
+	ContentFormat ContentFormat
+	ContentHTML   string
+	Tags          []string
+	Published     bool
+	// CommentModeration overrides the moderation policy of new comments on
+	// the post. If empty, the global policy applies.
+	CommentModeration ModerationPolicy
+	// CategoryID is the category the post is filed in, or nil if it is not
+	// categorized.
+	CategoryID *uuid.UUID
+	// SeriesID and SeriesPosition place the post in a series, see
+	// SeriesStore. They are managed by the series operations and kept by
+	// SetPost.
+	SeriesID       *uuid.UUID
+	SeriesPosition int
+	// MediaIDs are the media embedded in the post and CoverMediaID is the
+	// media shown as its cover image, or nil.
+	MediaIDs     []uuid.UUID
+	CoverMediaID *uuid.UUID
+	// PublishAt and UnpublishAt schedule changes of Published, which are
+	// applied by ApplySchedule.
+	PublishAt   *time.Time
+	UnpublishAt *time.Time
+	// PublishedAt is the time the post was last published, or nil if it
+	// never was. It is set by the store whenever Published changes from
+	// false to true, by SetPost or ApplyPostSchedules.
+	PublishedAt *time.Time
+	// Version is incremented whenever the post is stored or its schedule or
+	// tags are changed by the store. It is assigned by SetPost.
+	Version int64
+	// DeletedAt is the time the post was moved to the trash, see TrashStore.
+	DeletedAt *time.Time
 	CreatedAt time.Time
 	UpdatedAt time.Time
 }
 
+// ContentFormat is the markup language the content of a post is written in.
+type ContentFormat string
+
+const (
+	ContentFormatMarkdown ContentFormat = "markdown"
+	ContentFormatPlain    ContentFormat = "plain"
+)
+
+// Cursor returns the position of the post in a listing.
+func (p *Post) Cursor() Cursor {
+	return Cursor{CreatedAt: p.CreatedAt, ID: p.ID}
+}
+
+// ApplySchedule publishes or unpublishes the post if the scheduled time has
+// come. Applied schedules are cleared, so applying them again has no effect.
+// If both are due the post ends up unpublished. It reports whether the post
+// was changed.
+func (p *Post) ApplySchedule(now time.Time) bool {
+	changed := false
+	if p.PublishAt != nil && !p.PublishAt.After(now) {
+		p.Published = true
+		p.PublishAt = nil
+		changed = true
+	}
+	if p.UnpublishAt != nil && !p.UnpublishAt.After(now) {
+		p.Published = false
+		p.UnpublishAt = nil
+		changed = true
+	}
+	return changed
+}
+
+// VisibleTo reports whether the user may read the post. Unpublished posts
+// are drafts which are only visible to their author. Anonymous readers are
+// represented by uuid.Nil.
+func (p *Post) VisibleTo(reader uuid.UUID) bool {
+	return p.Published || (reader != uuid.Nil && p.AuthorID == reader)
+}
+
+// PostSort is the key a listing of posts is sorted by.
+type PostSort string
+
+const (
+	PostSortCreatedAt   PostSort = "created_at"
+	PostSortUpdatedAt   PostSort = "updated_at"
+	PostSortPublishedAt PostSort = "published_at"
+	PostSortTitle       PostSort = "title"
+)
+
+// PostQuery selects the posts of a listing. Filters that are not set match
+// every post. Posts are sorted by Sort, which defaults to the creation time,
+// and ties are broken by creation time and ID.
+type PostQuery struct {
+	Tag       string
+	AuthorID  *uuid.UUID
+	Published *bool
+	// CategoryIDs restricts the listing to posts filed in any of the
+	// categories.
+	CategoryIDs []uuid.UUID
+	// Reader restricts the listing to the posts visible to a user, see
+	// VisibleTo. If nil, unpublished posts of all authors are listed.
+	Reader *uuid.UUID
+	// CreatedFrom is inclusive, CreatedTo is exclusive
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+
+	Sort       PostSort
+	Descending bool
+	Page       Page
+}
+
+// Matches reports whether the post passes all filters of the query.
+func (q PostQuery) Matches(p *Post) bool {
+	if q.Tag != "" && !slices.Contains(p.Tags, q.Tag) {
+		return false
+	}
+	if q.AuthorID != nil && p.AuthorID != *q.AuthorID {
+		return false
+	}
+	if q.Published != nil && p.Published != *q.Published {
+		return false
+	}
+	if q.CategoryIDs != nil && (p.CategoryID == nil || !slices.Contains(q.CategoryIDs, *p.CategoryID)) {
+		return false
+	}
+	if q.Reader != nil && !p.VisibleTo(*q.Reader) {
+		return false
+	}
+	if q.CreatedFrom != nil && p.CreatedAt.Before(*q.CreatedFrom) {
+		return false
+	}
+	if q.CreatedTo != nil && !p.CreatedAt.Before(*q.CreatedTo) {
+		return false
+	}
+	return true
+}
+
+// Cursor returns the position of the post in a listing sorted as selected by
+// the query.
+func (q PostQuery) Cursor(p *Post) Cursor {
+	cursor := p.Cursor()
+	switch q.Sort {
+	case PostSortUpdatedAt:
+		cursor.UpdatedAt = p.UpdatedAt
+	case PostSortPublishedAt:
+		// Posts that were never published come first
+		if p.PublishedAt != nil {
+			cursor.PublishedAt = *p.PublishedAt
+		}
+	case PostSortTitle:
+		cursor.Title = p.Title
+	}
+	return cursor
+}
+
+// Compare orders two listing positions according to the sort of the query.
+// It returns -1 if a comes before b, +1 if a comes after b and 0 otherwise.
+func (q PostQuery) Compare(a, b Cursor) int {
+	c := 0
+	switch q.Sort {
+	case PostSortUpdatedAt:
+		c = a.UpdatedAt.Compare(b.UpdatedAt)
+	case PostSortPublishedAt:
+		c = a.PublishedAt.Compare(b.PublishedAt)
+	case PostSortTitle:
+		c = strings.Compare(a.Title, b.Title)
+	}
+	if c == 0 {
+		c = CompareCursor(a, b)
+	}
+	if q.Descending {
+		return -c
+	}
+	return c
+}
+
 type PostStore interface {
+	// SetPost stores the post under a unique slug. The slug is taken from
+	// post.Slug, or derived from the title if it is empty, and gets a numeric
+	// suffix if another post uses it already. The slug that is finally used
+	// is written back to post.Slug. Slugs a post had before stay reserved
+	// for it, so they can be redirected to its current slug.
+	//
+	// If post.Version is not 0, the post is only stored if it is the version
+	// that is currently stored, otherwise ErrVersionConflict is returned. The
+	// check and the update are atomic, which lets callers detect concurrent
+	// changes between reading and storing a post. A version of 0 stores the
+	// post unconditionally.
 	SetPost(ctx context.Context, post *Post) error
 	LookupPost(ctx context.Context, ID uuid.UUID) (*Post, error)
-	ListPosts(ctx context.Context, offset, limit int) ([]*Post, error)
+	// LookupPostBySlug returns the post with the given current or former
+	// slug. Callers detect former slugs by comparing them with post.Slug.
+	LookupPostBySlug(ctx context.Context, slug string) (*Post, error)
+	ListPosts(ctx context.Context, query PostQuery) ([]*Post, error)
+	// DeletePost removes the post together with its comments in one atomic
+	// step, so no comment outlives its post.
 	DeletePost(ctx context.Context, ID uuid.UUID) error
 }
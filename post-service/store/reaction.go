@@ -0,0 +1,51 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ReactionTarget is the kind of resource a reaction is given to.
+type ReactionTarget string
+
+const (
+	ReactionTargetPost    ReactionTarget = "post"
+	ReactionTargetComment ReactionTarget = "comment"
+)
+
+// ReactionLike is the reaction that is always available, other reactions
+// are configured.
+const ReactionLike = "like"
+
+// Reaction is the reaction of a user to a post or comment. Every user has at
+// most one reaction per target.
+type Reaction struct {
+	Target    ReactionTarget
+	TargetID  uuid.UUID
+	UserID    uuid.UUID
+	Kind      string
+	CreatedAt time.Time
+}
+
+// ReactionSummary aggregates the reactions to a target.
+type ReactionSummary struct {
+	// Counts is the number of users per kind of reaction.
+	Counts map[string]int
+	// Mine is the reaction of the reader, or empty if they did not react.
+	Mine string
+}
+
+type ReactionStore interface {
+	// SetReaction stores the reaction, replacing the previous reaction of the
+	// user to the target. Setting the same reaction again keeps its creation
+	// time. Reactions to targets that do not exist are ignored.
+	SetReaction(ctx context.Context, reaction *Reaction) error
+	// DeleteReaction removes the reaction of the user to the target, if any.
+	DeleteReaction(ctx context.Context, target ReactionTarget, targetID, userID uuid.UUID) error
+	// SummarizeReactions returns the reactions to the targets as seen by the
+	// reader, which is uuid.Nil for anonymous readers. Every target gets a
+	// summary, even if nobody reacted to it.
+	SummarizeReactions(ctx context.Context, target ReactionTarget, targetIDs []uuid.UUID, reader uuid.UUID) (map[uuid.UUID]*ReactionSummary, error)
+}
@@ -0,0 +1,38 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PostRevision is an immutable snapshot of a post, which is taken every time
+// the post is saved. Revisions are numbered per post starting at 1.
+type PostRevision struct {
+	PostID uuid.UUID
+	Number int
+	// EditorID is the user who saved the revision, which is not necessarily
+	// the author of the post.
+	EditorID uuid.UUID
+	Title    string
+	Content  string
+	Tags     []string
+	// RestoredFrom is the number of the revision this revision restored, if
+	// any.
+	RestoredFrom *int
+	CreatedAt    time.Time
+}
+
+type PostRevisionStore interface {
+	// SetPostWithRevision stores the post like SetPost and adds the revision
+	// for it. The store assigns the post, the next number of the post and the
+	// creation time to the revision. Both are stored atomically, if the post
+	// cannot be stored the revision is not added either.
+	SetPostWithRevision(ctx context.Context, post *Post, revision *PostRevision) error
+	LookupPostRevision(ctx context.Context, postID uuid.UUID, number int) (*PostRevision, error)
+	// ListPostRevisions returns the revisions of the post with a number
+	// greater than after, ordered by number. At most limit revisions are
+	// returned.
+	ListPostRevisions(ctx context.Context, postID uuid.UUID, after, limit int) ([]*PostRevision, error)
+}
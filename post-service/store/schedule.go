@@ -0,0 +1,16 @@
+package store
+
+import (
+	"context"
+	"time"
+)
+
+// PostScheduleStore applies the scheduled publishing of posts. The schedules
+// are stored with the posts, so schedules that became due while the service
+// was not running are applied on the next call.
+type PostScheduleStore interface {
+	// ApplyPostSchedules applies the schedules of all posts that are due at
+	// now, see Post.ApplySchedule, and returns the changed posts. The update
+	// time of the changed posts is set to now.
+	ApplyPostSchedules(ctx context.Context, now time.Time) ([]*Post, error)
+}
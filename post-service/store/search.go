@@ -0,0 +1,68 @@
+package store
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// PostSearchResult is a post matching a search query.
+type PostSearchResult struct {
+	Post  *Post
+	Score float64
+	// Snippet is an HTML escaped excerpt of the post in which the matching
+	// words are wrapped in <mark> elements.
+	Snippet string
+}
+
+// PostSearchQuery is a full-text search over posts.
+type PostSearchQuery struct {
+	// Text contains the words the posts have to contain
+	Text string
+	// Reader restricts the results to the posts visible to a user, see
+	// Post.VisibleTo. If nil, unpublished posts of all authors are found.
+	Reader *uuid.UUID
+	Limit  int
+}
+
+// PostSearchStore provides full-text search over the title, content and tags
+// of posts. Every storage engine maintains its own index, which has to be
+// updated whenever a post is stored or deleted.
+type PostSearchStore interface {
+	// SearchPosts returns the posts matching all words of the query text
+	// ordered by relevance, where matches in the title rank highest.
+	SearchPosts(ctx context.Context, query PostSearchQuery) ([]*PostSearchResult, error)
+}
+
+// CommentSearchResult is a comment matching a search query.
+type CommentSearchResult struct {
+	Comment *Comment
+	Score   float64
+	// Snippet is an HTML escaped excerpt of the comment in which the
+	// matching words are wrapped in <mark> elements.
+	Snippet string
+}
+
+// CommentSearchQuery is a full-text search over comments.
+type CommentSearchQuery struct {
+	// Text contains the words the comments have to contain
+	Text string
+	// PostReader restricts the results to the comments on posts visible to
+	// a user, see Post.VisibleTo. If nil, comments on unpublished posts of
+	// all authors are found.
+	PostReader *uuid.UUID
+	// Reader restricts the results to the comments visible to a user, see
+	// Comment.VisibleTo. If nil, comments in every moderation state are
+	// found.
+	Reader *uuid.UUID
+	Limit  int
+}
+
+// CommentSearchStore provides full-text search over the content of comments.
+// Comments in the trash, tombstones and comments of trashed posts are never
+// found.
+type CommentSearchStore interface {
+	// SearchComments returns the comments matching all words of the query
+	// text ordered by relevance.
+	SearchComments(ctx context.Context, query CommentSearchQuery) ([]*CommentSearchResult, error)
+}
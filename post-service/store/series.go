@@ -0,0 +1,67 @@
+package store
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Series is an ordered group of posts, like the parts of a tutorial.
+type Series struct {
+	ID          uuid.UUID
+	AuthorID    uuid.UUID
+	Title       string
+	Description string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// Cursor returns the position of the series in a listing.
+func (s *Series) Cursor() Cursor {
+	return Cursor{CreatedAt: s.CreatedAt, ID: s.ID}
+}
+
+// SeriesStore manages series and the positions of their posts. A post is part
+// of at most one series. The positions of the posts of a series start at 1
+// and have no gaps, they are updated whenever a post is added, removed or
+// deleted.
+type SeriesStore interface {
+	SetSeries(ctx context.Context, series *Series) error
+	LookupSeries(ctx context.Context, ID uuid.UUID) (*Series, error)
+	// ListSeries returns the series, oldest first. If authorID is not nil,
+	// only the series of the author are listed.
+	ListSeries(ctx context.Context, authorID *uuid.UUID, page Page) ([]*Series, error)
+	// DeleteSeries removes the series. Its posts are kept and are no longer
+	// part of a series.
+	DeleteSeries(ctx context.Context, ID uuid.UUID) error
+
+	// AddSeriesPost inserts the post into the series at the position, which
+	// moves the posts at and after it back by one. Positions out of range
+	// append the post. A post that is part of a series already is moved.
+	// Missing series and posts are ignored.
+	AddSeriesPost(ctx context.Context, seriesID, postID uuid.UUID, position int) error
+	// RemoveSeriesPost removes the post from its series, if any.
+	RemoveSeriesPost(ctx context.Context, postID uuid.UUID) error
+	// ListSeriesPosts returns the posts of the series ordered by position.
+	ListSeriesPosts(ctx context.Context, seriesID uuid.UUID) ([]*Post, error)
+	// ReorderSeries moves the posts of the series to the front in the given
+	// order. Posts of the series that are not given follow in their previous
+	// order, posts that are not part of the series are ignored.
+	ReorderSeries(ctx context.Context, seriesID uuid.UUID, postIDs []uuid.UUID) error
+}
+
+// SeriesOrder returns the IDs of the posts of a series, ordered by position,
+// in their order after ReorderSeries.
+func SeriesOrder(current, postIDs []uuid.UUID) []uuid.UUID {
+	order := make([]uuid.UUID, 0, len(current))
+	seen := make(map[uuid.UUID]bool, len(current))
+	for _, ID := range append(slices.Clone(postIDs), current...) {
+		if !seen[ID] && slices.Contains(current, ID) {
+			seen[ID] = true
+			order = append(order, ID)
+		}
+	}
+	return order
+}
@@ -0,0 +1,166 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/chrishrb/blog-microservice/post-service/store"
+	"github.com/google/uuid"
+)
+
+// selectBookmark selects bookmarks together with their post, the columns of
+// the post are scanned by scanPost.
+var selectBookmark = strings.Replace(selectPost, "SELECT ", "SELECT b.user_id, b.post_id, b.list_id, b.created_at, ", 1) +
+	` JOIN bookmarks b ON b.post_id = p.id`
+
+const selectReadingList = `SELECT id, user_id, name, created_at, updated_at FROM reading_lists`
+
+func (s *Store) SetBookmark(ctx context.Context, bookmark *store.Bookmark) error {
+	// The creation time is kept if the bookmark is moved to another list
+	var createdAt int64
+	err := s.db.QueryRowContext(ctx, `INSERT INTO bookmarks (user_id, post_id, list_id, created_at)
+		SELECT ?, ?, ?, ? WHERE EXISTS (SELECT 1 FROM posts WHERE id = ? AND deleted_at IS NULL)
+		ON CONFLICT (user_id, post_id) DO UPDATE SET list_id = excluded.list_id
+		RETURNING created_at`,
+		bookmark.UserID, bookmark.PostID, bookmark.ListID, toUnix(s.clock.Now()), bookmark.PostID,
+	).Scan(&createdAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("storing bookmark of post %s: %w", bookmark.PostID, err)
+	}
+	bookmark.CreatedAt = fromUnix(createdAt)
+	return nil
+}
+
+func (s *Store) DeleteBookmark(ctx context.Context, userID, postID uuid.UUID) error {
+	_, err := s.db.ExecContext(ctx, `DELETE FROM bookmarks WHERE user_id = ? AND post_id = ?`, userID, postID)
+	if err != nil {
+		return fmt.Errorf("deleting bookmark of post %s: %w", postID, err)
+	}
+	return nil
+}
+
+func (s *Store) ListBookmarks(ctx context.Context, query store.BookmarkQuery) ([]*store.Bookmark, error) {
+	where := []string{"b.user_id = ?", "p.published = 1"}
+	args := []any{query.UserID}
+	if query.ListID != nil {
+		where = append(where, "b.list_id = ?")
+		args = append(args, *query.ListID)
+	}
+
+	order := listing{
+		columns: []string{"b.created_at", "b.post_id"},
+		keys: func(c store.Cursor) []any {
+			return []any{toUnix(c.CreatedAt), c.ID}
+		},
+		descending: true,
+	}
+	q, args := pageQuery(selectBookmark, where, args, order, query.Page)
+	rows, err := s.db.QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, fmt.Errorf("listing bookmarks: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	bookmarks := []*store.Bookmark{}
+	for rows.Next() {
+		var bookmark store.Bookmark
+		var createdAt int64
+		bookmark.Post, err = scanPost(prefixScanner{rows, []any{&bookmark.UserID, &bookmark.PostID, &bookmark.ListID, &createdAt}})
+		if err != nil {
+			return nil, fmt.Errorf("listing bookmarks: %w", err)
+		}
+		bookmark.CreatedAt = fromUnix(createdAt)
+		bookmarks = append(bookmarks, &bookmark)
+	}
+	return bookmarks, rows.Err()
+}
+
+func (s *Store) SetReadingList(ctx context.Context, list *store.ReadingList) error {
+	// Set timestamps, the creation time of an existing list is kept
+	now := toUnix(s.clock.Now())
+	var createdAt int64
+	err := s.db.QueryRowContext(ctx, `INSERT INTO reading_lists (id, user_id, name, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT (id) DO UPDATE SET
+			name = excluded.name,
+			updated_at = excluded.updated_at
+		RETURNING created_at`,
+		list.ID, list.UserID, list.Name, now, now,
+	).Scan(&createdAt)
+	if err != nil {
+		return fmt.Errorf("storing reading list %s: %w", list.ID, err)
+	}
+
+	list.CreatedAt = fromUnix(createdAt)
+	list.UpdatedAt = fromUnix(now)
+	return nil
+}
+
+func (s *Store) LookupReadingList(ctx context.Context, ID uuid.UUID) (*store.ReadingList, error) {
+	row := s.db.QueryRowContext(ctx, selectReadingList+` WHERE id = ?`, ID)
+	list, err := scanReadingList(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("looking up reading list %s: %w", ID, err)
+	}
+	return list, nil
+}
+
+func (s *Store) ListReadingLists(ctx context.Context, userID uuid.UUID, page store.Page) ([]*store.ReadingList, error) {
+	q, args := pageQuery(selectReadingList, []string{"user_id = ?"}, []any{userID}, byCreation(""), page)
+	rows, err := s.db.QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, fmt.Errorf("listing reading lists: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	lists := []*store.ReadingList{}
+	for rows.Next() {
+		list, err := scanReadingList(rows)
+		if err != nil {
+			return nil, fmt.Errorf("listing reading lists: %w", err)
+		}
+		lists = append(lists, list)
+	}
+	return lists, rows.Err()
+}
+
+func (s *Store) DeleteReadingList(ctx context.Context, ID uuid.UUID) error {
+	_, err := s.db.ExecContext(ctx, `DELETE FROM reading_lists WHERE id = ?`, ID)
+	if err != nil {
+		return fmt.Errorf("deleting reading list %s: %w", ID, err)
+	}
+	return nil
+}
+
+func scanReadingList(row scanner) (*store.ReadingList, error) {
+	var list store.ReadingList
+	var createdAt, updatedAt int64
+	err := row.Scan(&list.ID, &list.UserID, &list.Name, &createdAt, &updatedAt)
+	if err != nil {
+		return nil, err
+	}
+	list.CreatedAt = fromUnix(createdAt)
+	list.UpdatedAt = fromUnix(updatedAt)
+	return &list, nil
+}
+
+// prefixScanner scans the leading columns of a row into prefix and passes
+// the remaining columns on, so rows that join other tables can be scanned by
+// the scan function of the selected resource.
+type prefixScanner struct {
+	row    scanner
+	prefix []any
+}
+
+func (s prefixScanner) Scan(dest ...any) error {
+	return s.row.Scan(append(s.prefix, dest...)...)
+}
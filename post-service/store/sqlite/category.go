@@ -0,0 +1,98 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/chrishrb/blog-microservice/post-service/store"
+	"github.com/google/uuid"
+)
+
+const selectCategory = `SELECT id, parent_id, name, description, created_at, updated_at FROM categories`
+
+func (s *Store) SetCategory(ctx context.Context, category *store.Category) error {
+	// Set timestamps, the creation time of an existing category is kept
+	now := toUnix(s.clock.Now())
+	var createdAt int64
+	err := s.db.QueryRowContext(ctx, `INSERT INTO categories (id, parent_id, name, description, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+		ON CONFLICT (id) DO UPDATE SET
+			parent_id = excluded.parent_id,
+			name = excluded.name,
+			description = excluded.description,
+			updated_at = excluded.updated_at
+		RETURNING created_at`,
+		category.ID, category.ParentID, category.Name, category.Description, now, now,
+	).Scan(&createdAt)
+	if err != nil {
+		return fmt.Errorf("storing category %s: %w", category.ID, err)
+	}
+
+	category.CreatedAt = fromUnix(createdAt)
+	category.UpdatedAt = fromUnix(now)
+	return nil
+}
+
+func (s *Store) LookupCategory(ctx context.Context, ID uuid.UUID) (*store.Category, error) {
+	row := s.db.QueryRowContext(ctx, selectCategory+` WHERE id = ?`, ID)
+	category, err := scanCategory(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("looking up category %s: %w", ID, err)
+	}
+	return category, nil
+}
+
+func (s *Store) ListCategories(ctx context.Context) ([]*store.Category, error) {
+	rows, err := s.db.QueryContext(ctx, selectCategory+` ORDER BY name, id`)
+	if err != nil {
+		return nil, fmt.Errorf("listing categories: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	categories := []*store.Category{}
+	for rows.Next() {
+		category, err := scanCategory(rows)
+		if err != nil {
+			return nil, fmt.Errorf("listing categories: %w", err)
+		}
+		categories = append(categories, category)
+	}
+	return categories, rows.Err()
+}
+
+func (s *Store) DeleteCategory(ctx context.Context, ID uuid.UUID) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	// Subcategories move to the parent, posts are uncategorized by the
+	// foreign key
+	_, err = tx.ExecContext(ctx, `UPDATE categories SET parent_id = (SELECT parent_id FROM categories WHERE id = ?)
+		WHERE parent_id = ?`, ID, ID)
+	if err != nil {
+		return fmt.Errorf("deleting category %s: %w", ID, err)
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM categories WHERE id = ?`, ID); err != nil {
+		return fmt.Errorf("deleting category %s: %w", ID, err)
+	}
+	return tx.Commit()
+}
+
+func scanCategory(row scanner) (*store.Category, error) {
+	var category store.Category
+	var createdAt, updatedAt int64
+	err := row.Scan(&category.ID, &category.ParentID, &category.Name, &category.Description, &createdAt, &updatedAt)
+	if err != nil {
+		return nil, err
+	}
+	category.CreatedAt = fromUnix(createdAt)
+	category.UpdatedAt = fromUnix(updatedAt)
+	return &category, nil
+}
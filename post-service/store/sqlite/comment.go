@@ -0,0 +1,264 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/chrishrb/blog-microservice/post-service/store"
+	"github.com/google/uuid"
+)
+
+// selectComment reads the comments outside of the trash, which includes
+// trashed comments with replies as tombstones without content, see
+// store.TrashStore.
+const selectComment = `SELECT c.id, c.post_id, c.author_id, c.parent_id, c.depth,
+	CASE WHEN c.deleted_at IS NULL THEN c.content ELSE '' END, CASE WHEN c.deleted_at IS NULL THEN c.content_html ELSE '' END,
+	c.state, c.deleted OR c.deleted_at IS NOT NULL, ` + approvedReplyCount + `, c.deleted_at, c.created_at, c.updated_at
+	FROM ` + readableComments + ` c`
+
+// selectTrashedComment reads the trashed comments of posts that are not
+// trashed, with their content.
+const selectTrashedComment = `SELECT c.id, c.post_id, c.author_id, c.parent_id, c.depth, c.content, c.content_html, c.state, c.deleted,
+	` + approvedReplyCount + `, c.deleted_at, c.created_at, c.updated_at
+	FROM comments c JOIN posts p ON p.id = c.post_id AND p.deleted_at IS NULL
+	WHERE c.deleted_at IS NOT NULL`
+
+// readableComments are the comments of the posts outside of the trash,
+// leaving out trashed comments without replies.
+const readableComments = `(SELECT * FROM comments
+	WHERE (deleted_at IS NULL OR id IN (SELECT parent_id FROM comments WHERE parent_id IS NOT NULL))
+	AND post_id IN (SELECT id FROM posts WHERE deleted_at IS NULL))`
+
+// approvedReplyCount counts the approved direct replies of the comment c that
+// are not trashed.
+const approvedReplyCount = `(SELECT COUNT(*) FROM comments r WHERE r.parent_id = c.id AND r.state = 'approved' AND r.deleted_at IS NULL)`
+
+// visibleComment is the condition for the comments of alias that are visible
+// to the reader given as argument, see store.Comment.VisibleTo.
+func visibleComment(alias string) string {
+	return fmt.Sprintf(`(%[1]s.state = 'approved' OR (%[1]s.state = 'pending' AND %[1]s.author_id = ?))`, alias)
+}
+
+func (s *Store) SetComment(ctx context.Context, comment *store.Comment) error {
+	if comment.State == "" {
+		comment.State = store.ModerationStateApproved
+	}
+
+	// Set timestamps, the creation time of an existing comment is kept. The
+	// comment is only stored if its post exists, which is checked in the same
+	// statement so a concurrent deletion of the post cannot orphan it.
+	now := toUnix(s.clock.Now())
+	var createdAt int64
+	err := s.db.QueryRowContext(ctx, `INSERT INTO comments
+			(id, post_id, author_id, parent_id, depth, content, content_html, state, created_at, updated_at)
+		SELECT ?, ?, ?, ?, ?, ?, ?, ?, ?, ?
+		WHERE EXISTS (SELECT 1 FROM posts WHERE id = ? AND deleted_at IS NULL)
+		ON CONFLICT (id) DO UPDATE SET
+			content = excluded.content,
+			content_html = excluded.content_html,
+			state = excluded.state,
+			updated_at = excluded.updated_at
+		RETURNING created_at`,
+		comment.ID, comment.PostID, comment.AuthorID, comment.ParentID, comment.Depth, comment.Content, comment.ContentHTML,
+		comment.State, now, now, comment.PostID,
+	).Scan(&createdAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return store.ErrPostNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("storing comment %s: %w", comment.ID, err)
+	}
+
+	comment.CreatedAt = fromUnix(createdAt)
+	comment.UpdatedAt = fromUnix(now)
+	return nil
+}
+
+func (s *Store) LookupComment(ctx context.Context, postID, ID uuid.UUID) (*store.Comment, error) {
+	row := s.db.QueryRowContext(ctx, selectComment+` WHERE c.post_id = ? AND c.id = ?`, postID, ID)
+	comment, err := scanComment(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("looking up comment %s: %w", ID, err)
+	}
+	return comment, nil
+}
+
+// lookupComment returns the comment with the ID regardless of its post.
+func (s *Store) lookupComment(ctx context.Context, ID uuid.UUID) (*store.Comment, error) {
+	row := s.db.QueryRowContext(ctx, selectComment+` WHERE c.id = ?`, ID)
+	comment, err := scanComment(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("looking up comment %s: %w", ID, err)
+	}
+	return comment, nil
+}
+
+func (s *Store) ListCommentsByPostID(ctx context.Context, postID uuid.UUID, reader *uuid.UUID, page store.Page) ([]*store.Comment, error) {
+	// Check if post exists
+	exists, err := s.postExists(ctx, postID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, nil
+	}
+
+	where, args := []string{"c.post_id = ?"}, []any{postID}
+	if reader != nil {
+		where = append(where, visibleComment("c"))
+		args = append(args, *reader)
+	}
+	query, args := pageQuery(selectComment, where, args, byCreation("c"), page)
+	return s.queryComments(ctx, query, args...)
+}
+
+func (s *Store) ListCommentThreads(ctx context.Context, postID uuid.UUID, reader *uuid.UUID, page store.Page) ([]*store.Comment, error) {
+	// Check if post exists
+	exists, err := s.postExists(ctx, postID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, nil
+	}
+
+	where, args := []string{"c.post_id = ?", "c.parent_id IS NULL"}, []any{postID}
+	replies := `SELECT r.id FROM ` + readableComments + ` r JOIN threads t ON r.parent_id = t.id`
+	if reader != nil {
+		where = append(where, visibleComment("c"))
+		args = append(args, *reader)
+		replies += ` WHERE ` + visibleComment("r")
+	}
+	roots, args := pageQuery(`SELECT c.id FROM `+readableComments+` c`, where, args, byCreation("c"), page)
+	query := `WITH RECURSIVE threads (id) AS (
+			SELECT id FROM (` + roots + `)
+			UNION ALL
+			` + replies + `
+		) ` + selectComment + ` WHERE c.id IN (SELECT id FROM threads) ORDER BY c.created_at, c.id`
+	if reader != nil {
+		args = append(args, *reader)
+	}
+	return s.queryComments(ctx, query, args...)
+}
+
+func (s *Store) ListCommentsByState(ctx context.Context, state store.ModerationState, page store.Page) ([]*store.Comment, error) {
+	query, args := pageQuery(selectComment, []string{"c.state = ?", "c.deleted_at IS NULL"}, []any{state}, byCreation("c"), page)
+	return s.queryComments(ctx, query, args...)
+}
+
+func (s *Store) CountApprovedComments(ctx context.Context, authorID uuid.UUID) (int, error) {
+	var count int
+	err := s.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM comments
+		WHERE author_id = ? AND state = 'approved' AND deleted_at IS NULL
+		AND post_id IN (SELECT id FROM posts WHERE deleted_at IS NULL)`,
+		authorID).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("counting comments of author %s: %w", authorID, err)
+	}
+	return count, nil
+}
+
+func (s *Store) queryComments(ctx context.Context, query string, args ...any) ([]*store.Comment, error) {
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("listing comments: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	comments := []*store.Comment{}
+	for rows.Next() {
+		comment, err := scanComment(rows)
+		if err != nil {
+			return nil, fmt.Errorf("listing comments: %w", err)
+		}
+		comments = append(comments, comment)
+	}
+	return comments, rows.Err()
+}
+
+func (s *Store) DeleteComment(ctx context.Context, postID, ID uuid.UUID) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if err := s.deleteComment(ctx, tx, postID, ID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// deleteComment removes the comment, which may be in the trash, or turns it
+// into a tombstone if it has replies.
+func (s *Store) deleteComment(ctx context.Context, tx *sql.Tx, postID, ID uuid.UUID) error {
+	// Keep a tombstone if there are replies
+	res, err := tx.ExecContext(ctx, `UPDATE comments SET deleted = 1, deleted_at = NULL, content = '', content_html = '', updated_at = ?
+		WHERE post_id = ? AND id = ? AND EXISTS (SELECT 1 FROM comments r WHERE r.parent_id = comments.id)`,
+		toUnix(s.clock.Now()), postID, ID)
+	if err != nil {
+		return fmt.Errorf("deleting comment %s: %w", ID, err)
+	}
+	tombstoned, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("deleting comment %s: %w", ID, err)
+	}
+	if tombstoned > 0 {
+		return nil
+	}
+
+	// Remove tombstones that lost their last reply
+	var parentID *uuid.UUID
+	err = tx.QueryRowContext(ctx, `DELETE FROM comments WHERE post_id = ? AND id = ? RETURNING parent_id`,
+		postID, ID).Scan(&parentID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("deleting comment %s: %w", ID, err)
+	}
+	for parentID != nil {
+		err = tx.QueryRowContext(ctx, `DELETE FROM comments WHERE id = ? AND deleted = 1
+			AND NOT EXISTS (SELECT 1 FROM comments r WHERE r.parent_id = comments.id)
+			RETURNING parent_id`, *parentID).Scan(&parentID)
+		if errors.Is(err, sql.ErrNoRows) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("deleting tombstone of comment %s: %w", ID, err)
+		}
+	}
+	return nil
+}
+
+func (s *Store) postExists(ctx context.Context, ID uuid.UUID) (bool, error) {
+	var exists bool
+	err := s.db.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM posts WHERE id = ? AND deleted_at IS NULL)`, ID).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("checking post %s: %w", ID, err)
+	}
+	return exists, nil
+}
+
+func scanComment(row scanner) (*store.Comment, error) {
+	var comment store.Comment
+	var deletedAt sql.NullInt64
+	var createdAt, updatedAt int64
+	err := row.Scan(&comment.ID, &comment.PostID, &comment.AuthorID, &comment.ParentID, &comment.Depth, &comment.Content,
+		&comment.ContentHTML, &comment.State, &comment.Deleted, &comment.ReplyCount, &deletedAt, &createdAt, &updatedAt)
+	if err != nil {
+		return nil, err
+	}
+	comment.DeletedAt = fromNullUnix(deletedAt)
+	comment.CreatedAt = fromUnix(createdAt)
+	comment.UpdatedAt = fromUnix(updatedAt)
+	return &comment, nil
+}
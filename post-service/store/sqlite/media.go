@@ -0,0 +1,106 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/chrishrb/blog-microservice/post-service/store"
+	"github.com/google/uuid"
+)
+
+const selectMedia = `SELECT id, owner_id, content_type, size, alt_text, width, height, variants, created_at, updated_at
+	FROM media`
+
+func (s *Store) SetMedia(ctx context.Context, media *store.Media) error {
+	variants, err := json.Marshal(media.Variants)
+	if err != nil {
+		return fmt.Errorf("encoding variants: %w", err)
+	}
+
+	// Set timestamps, the creation time of existing media is kept
+	now := toUnix(s.clock.Now())
+	var createdAt int64
+	err = s.db.QueryRowContext(ctx, `INSERT INTO media
+			(id, owner_id, content_type, size, alt_text, width, height, variants, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (id) DO UPDATE SET
+			owner_id = excluded.owner_id,
+			content_type = excluded.content_type,
+			size = excluded.size,
+			alt_text = excluded.alt_text,
+			width = excluded.width,
+			height = excluded.height,
+			variants = excluded.variants,
+			updated_at = excluded.updated_at
+		RETURNING created_at`,
+		media.ID, media.OwnerID, media.ContentType, media.Size, media.AltText, media.Width, media.Height,
+		string(variants), now, now,
+	).Scan(&createdAt)
+	if err != nil {
+		return fmt.Errorf("storing media %s: %w", media.ID, err)
+	}
+
+	media.CreatedAt = fromUnix(createdAt)
+	media.UpdatedAt = fromUnix(now)
+	return nil
+}
+
+func (s *Store) LookupMedia(ctx context.Context, ID uuid.UUID) (*store.Media, error) {
+	row := s.db.QueryRowContext(ctx, selectMedia+` WHERE id = ?`, ID)
+	media, err := scanMedia(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("looking up media %s: %w", ID, err)
+	}
+	return media, nil
+}
+
+func (s *Store) ListMedia(ctx context.Context, ownerID uuid.UUID, page store.Page) ([]*store.Media, error) {
+	q, args := pageQuery(selectMedia, []string{`owner_id = ?`}, []any{ownerID}, byCreation(""), page)
+	rows, err := s.db.QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, fmt.Errorf("listing media: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	list := []*store.Media{}
+	for rows.Next() {
+		media, err := scanMedia(rows)
+		if err != nil {
+			return nil, fmt.Errorf("listing media: %w", err)
+		}
+		list = append(list, media)
+	}
+	return list, rows.Err()
+}
+
+func (s *Store) DeleteMedia(ctx context.Context, ID uuid.UUID) error {
+	// References from posts are removed by the foreign keys
+	_, err := s.db.ExecContext(ctx, `DELETE FROM media WHERE id = ?`, ID)
+	if err != nil {
+		return fmt.Errorf("deleting media %s: %w", ID, err)
+	}
+	return nil
+}
+
+func scanMedia(row scanner) (*store.Media, error) {
+	var media store.Media
+	var variants string
+	var createdAt, updatedAt int64
+	err := row.Scan(&media.ID, &media.OwnerID, &media.ContentType, &media.Size, &media.AltText, &media.Width,
+		&media.Height, &variants, &createdAt, &updatedAt)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(variants), &media.Variants); err != nil {
+		return nil, fmt.Errorf("decoding variants: %w", err)
+	}
+	media.CreatedAt = fromUnix(createdAt)
+	media.UpdatedAt = fromUnix(updatedAt)
+	return &media, nil
+}
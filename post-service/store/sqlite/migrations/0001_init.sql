@@ -0,0 +1,32 @@
+CREATE TABLE posts (
+    id         TEXT PRIMARY KEY,
+    author_id  TEXT NOT NULL,
+    title      TEXT NOT NULL,
+    content    TEXT NOT NULL,
+    published  INTEGER NOT NULL DEFAULT 0,
+    created_at INTEGER NOT NULL,
+    updated_at INTEGER NOT NULL
+);
+
+CREATE INDEX idx_posts_created_at ON posts (created_at, id);
+CREATE INDEX idx_posts_author_id ON posts (author_id);
+
+CREATE TABLE post_tags (
+    post_id  TEXT NOT NULL REFERENCES posts (id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    tag      TEXT NOT NULL,
+    PRIMARY KEY (post_id, position)
+);
+
+CREATE INDEX idx_post_tags_tag ON post_tags (tag);
+
+CREATE TABLE comments (
+    id         TEXT PRIMARY KEY,
+    post_id    TEXT NOT NULL REFERENCES posts (id) ON DELETE CASCADE,
+    author_id  TEXT NOT NULL,
+    content    TEXT NOT NULL,
+    created_at INTEGER NOT NULL,
+    updated_at INTEGER NOT NULL
+);
+
+CREATE INDEX idx_comments_post_id ON comments (post_id, created_at, id);
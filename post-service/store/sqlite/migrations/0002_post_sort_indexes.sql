@@ -0,0 +1,2 @@
+CREATE INDEX idx_posts_updated_at ON posts (updated_at, created_at, id);
+CREATE INDEX idx_posts_title ON posts (title, created_at, id);
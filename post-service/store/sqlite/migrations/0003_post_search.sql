@@ -0,0 +1,11 @@
+CREATE VIRTUAL TABLE posts_fts USING fts5 (
+    post_id UNINDEXED,
+    title,
+    tags,
+    content,
+    tokenize = 'porter unicode61'
+);
+
+INSERT INTO posts_fts (post_id, title, tags, content)
+SELECT p.id, p.title, COALESCE((SELECT group_concat(tag, ' ') FROM post_tags WHERE post_id = p.id), ''), p.content
+FROM posts p;
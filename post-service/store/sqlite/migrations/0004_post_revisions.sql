@@ -0,0 +1,11 @@
+CREATE TABLE post_revisions (
+    post_id       TEXT NOT NULL REFERENCES posts (id) ON DELETE CASCADE,
+    number        INTEGER NOT NULL,
+    editor_id     TEXT NOT NULL,
+    title         TEXT NOT NULL,
+    content       TEXT NOT NULL,
+    tags          TEXT NOT NULL,
+    restored_from INTEGER,
+    created_at    INTEGER NOT NULL,
+    PRIMARY KEY (post_id, number)
+);
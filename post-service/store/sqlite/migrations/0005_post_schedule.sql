@@ -0,0 +1,5 @@
+ALTER TABLE posts ADD COLUMN publish_at INTEGER;
+ALTER TABLE posts ADD COLUMN unpublish_at INTEGER;
+
+CREATE INDEX idx_posts_publish_at ON posts (publish_at) WHERE publish_at IS NOT NULL;
+CREATE INDEX idx_posts_unpublish_at ON posts (unpublish_at) WHERE unpublish_at IS NOT NULL;
@@ -0,0 +1,14 @@
+ALTER TABLE posts ADD COLUMN slug TEXT NOT NULL DEFAULT '';
+
+-- Posts created before slugs existed are addressed by their ID until their
+-- title changes
+UPDATE posts SET slug = id;
+
+CREATE TABLE post_slugs (
+    slug    TEXT PRIMARY KEY,
+    post_id TEXT NOT NULL REFERENCES posts (id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_post_slugs_post_id ON post_slugs (post_id);
+
+INSERT INTO post_slugs (slug, post_id) SELECT slug, id FROM posts;
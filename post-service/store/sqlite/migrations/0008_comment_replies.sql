@@ -0,0 +1,5 @@
+ALTER TABLE comments ADD COLUMN parent_id TEXT REFERENCES comments (id) ON DELETE CASCADE;
+ALTER TABLE comments ADD COLUMN depth INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE comments ADD COLUMN deleted INTEGER NOT NULL DEFAULT 0;
+
+CREATE INDEX idx_comments_parent_id ON comments (parent_id);
@@ -0,0 +1,6 @@
+ALTER TABLE comments ADD COLUMN state TEXT NOT NULL DEFAULT 'approved';
+
+CREATE INDEX idx_comments_state ON comments (state, created_at, id);
+CREATE INDEX idx_comments_author_id ON comments (author_id, state);
+
+ALTER TABLE posts ADD COLUMN comment_moderation TEXT NOT NULL DEFAULT '';
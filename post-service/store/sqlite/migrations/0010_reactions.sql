@@ -0,0 +1,15 @@
+CREATE TABLE post_reactions (
+    post_id    TEXT NOT NULL REFERENCES posts (id) ON DELETE CASCADE,
+    user_id    TEXT NOT NULL,
+    reaction   TEXT NOT NULL,
+    created_at INTEGER NOT NULL,
+    PRIMARY KEY (post_id, user_id)
+);
+
+CREATE TABLE comment_reactions (
+    comment_id TEXT NOT NULL REFERENCES comments (id) ON DELETE CASCADE,
+    user_id    TEXT NOT NULL,
+    reaction   TEXT NOT NULL,
+    created_at INTEGER NOT NULL,
+    PRIMARY KEY (comment_id, user_id)
+);
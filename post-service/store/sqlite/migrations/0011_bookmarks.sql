@@ -0,0 +1,20 @@
+CREATE TABLE reading_lists (
+    id         TEXT PRIMARY KEY,
+    user_id    TEXT NOT NULL,
+    name       TEXT NOT NULL,
+    created_at INTEGER NOT NULL,
+    updated_at INTEGER NOT NULL
+);
+
+CREATE INDEX idx_reading_lists_user_id ON reading_lists (user_id, created_at, id);
+
+CREATE TABLE bookmarks (
+    user_id    TEXT NOT NULL,
+    post_id    TEXT NOT NULL REFERENCES posts (id) ON DELETE CASCADE,
+    list_id    TEXT REFERENCES reading_lists (id) ON DELETE SET NULL,
+    created_at INTEGER NOT NULL,
+    PRIMARY KEY (user_id, post_id)
+);
+
+CREATE INDEX idx_bookmarks_user_id ON bookmarks (user_id, created_at, post_id);
+CREATE INDEX idx_bookmarks_list_id ON bookmarks (list_id);
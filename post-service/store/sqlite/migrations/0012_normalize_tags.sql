@@ -0,0 +1,14 @@
+-- Tags are normalized when posts are saved. Existing tags are normalized as
+-- far as SQLite can, lower() only folds ASCII letters and runs of spaces are
+-- not collapsed. Remaining variants can be merged by an admin.
+UPDATE post_tags SET tag = replace(lower(trim(tag, ' ' || char(9) || char(10) || char(13))), ' ', '-');
+
+DELETE FROM post_tags WHERE tag = '';
+
+DELETE FROM post_tags
+WHERE EXISTS (
+    SELECT 1 FROM post_tags t
+    WHERE t.post_id = post_tags.post_id AND t.tag = post_tags.tag AND t.position < post_tags.position
+);
+
+UPDATE posts_fts SET tags = COALESCE((SELECT group_concat(tag, ' ') FROM post_tags WHERE post_id = posts_fts.post_id), '');
@@ -0,0 +1,34 @@
+CREATE TABLE series (
+    id          TEXT PRIMARY KEY,
+    author_id   TEXT NOT NULL,
+    title       TEXT NOT NULL,
+    description TEXT NOT NULL,
+    created_at  INTEGER NOT NULL,
+    updated_at  INTEGER NOT NULL
+);
+
+CREATE INDEX idx_series_created_at ON series (created_at, id);
+CREATE INDEX idx_series_author_id ON series (author_id, created_at, id);
+
+CREATE TABLE series_posts (
+    post_id   TEXT PRIMARY KEY REFERENCES posts (id) ON DELETE CASCADE,
+    series_id TEXT NOT NULL REFERENCES series (id) ON DELETE CASCADE,
+    position  INTEGER NOT NULL
+);
+
+CREATE INDEX idx_series_posts_series_id ON series_posts (series_id, position);
+
+CREATE TABLE categories (
+    id          TEXT PRIMARY KEY,
+    parent_id   TEXT REFERENCES categories (id),
+    name        TEXT NOT NULL,
+    description TEXT NOT NULL,
+    created_at  INTEGER NOT NULL,
+    updated_at  INTEGER NOT NULL
+);
+
+CREATE INDEX idx_categories_parent_id ON categories (parent_id);
+
+ALTER TABLE posts ADD COLUMN category_id TEXT REFERENCES categories (id) ON DELETE SET NULL;
+
+CREATE INDEX idx_posts_category_id ON posts (category_id);
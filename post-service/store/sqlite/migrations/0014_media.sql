@@ -0,0 +1,25 @@
+CREATE TABLE media (
+    id           TEXT PRIMARY KEY,
+    owner_id     TEXT NOT NULL,
+    content_type TEXT NOT NULL,
+    size         INTEGER NOT NULL,
+    alt_text     TEXT NOT NULL,
+    width        INTEGER NOT NULL,
+    height       INTEGER NOT NULL,
+    variants     TEXT NOT NULL,
+    created_at   INTEGER NOT NULL,
+    updated_at   INTEGER NOT NULL
+);
+
+CREATE INDEX idx_media_owner_id ON media (owner_id, created_at, id);
+
+CREATE TABLE post_media (
+    post_id  TEXT NOT NULL REFERENCES posts (id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    media_id TEXT NOT NULL REFERENCES media (id) ON DELETE CASCADE,
+    PRIMARY KEY (post_id, position)
+);
+
+CREATE INDEX idx_post_media_media_id ON post_media (media_id);
+
+ALTER TABLE posts ADD COLUMN cover_media_id TEXT REFERENCES media (id) ON DELETE SET NULL;
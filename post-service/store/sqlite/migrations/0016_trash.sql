@@ -0,0 +1,5 @@
+ALTER TABLE posts ADD COLUMN deleted_at INTEGER;
+ALTER TABLE comments ADD COLUMN deleted_at INTEGER;
+
+CREATE INDEX idx_posts_deleted_at ON posts (deleted_at);
+CREATE INDEX idx_comments_deleted_at ON comments (deleted_at);
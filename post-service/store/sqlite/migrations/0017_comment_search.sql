@@ -0,0 +1,23 @@
+CREATE VIRTUAL TABLE comments_fts USING fts5 (
+    comment_id UNINDEXED,
+    content,
+    tokenize = 'porter unicode61'
+);
+
+-- Comments are also removed by cascading deletions of their post, so the
+-- index is kept up to date by triggers instead of the store
+CREATE TRIGGER comments_fts_insert AFTER INSERT ON comments BEGIN
+    INSERT INTO comments_fts (comment_id, content) VALUES (new.id, new.content);
+END;
+
+CREATE TRIGGER comments_fts_update AFTER UPDATE OF content ON comments BEGIN
+    DELETE FROM comments_fts WHERE comment_id = old.id;
+    INSERT INTO comments_fts (comment_id, content) VALUES (new.id, new.content);
+END;
+
+CREATE TRIGGER comments_fts_delete AFTER DELETE ON comments BEGIN
+    DELETE FROM comments_fts WHERE comment_id = old.id;
+END;
+
+INSERT INTO comments_fts (comment_id, content)
+SELECT id, content FROM comments;
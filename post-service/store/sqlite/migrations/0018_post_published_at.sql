@@ -0,0 +1,7 @@
+ALTER TABLE posts ADD COLUMN published_at INTEGER;
+
+-- The time posts were published was not recorded before, their creation is
+-- the closest guess
+UPDATE posts SET published_at = created_at WHERE published = 1;
+
+CREATE INDEX posts_published_at ON posts (published_at);
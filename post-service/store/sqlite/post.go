@@ -0,0 +1,359 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/chrishrb/blog-microservice/post-service/slug"
+	"github.com/chrishrb/blog-microservice/post-service/store"
+	"github.com/google/uuid"
+)
+
+// selectPost reads the posts outside of the trash and selectTrashedPost the
+// posts in it, see store.TrashStore.
+var (
+	selectPost        = fmt.Sprintf(selectPostFrom, `(SELECT * FROM posts WHERE deleted_at IS NULL)`)
+	selectTrashedPost = fmt.Sprintf(selectPostFrom, `(SELECT * FROM posts WHERE deleted_at IS NOT NULL)`)
+)
+
+const selectPostFrom = `SELECT p.id, p.author_id, p.title, p.slug, p.content, p.content_format, p.content_html, p.published, p.publish_at, p.unpublish_at,
+	p.comment_moderation, p.category_id, sp.series_id, sp.position, p.cover_media_id, p.version, p.published_at, p.deleted_at, p.created_at, p.updated_at,
+	(SELECT json_group_array(tag) FROM (SELECT tag FROM post_tags WHERE post_id = p.id ORDER BY position)),
+	(SELECT json_group_array(media_id) FROM (SELECT media_id FROM post_media WHERE post_id = p.id ORDER BY position))
+	FROM %s p LEFT JOIN series_posts sp ON sp.post_id = p.id`
+
+func (s *Store) SetPost(ctx context.Context, post *store.Post) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	// The post is only updated once the transaction is committed
+	stored := *post
+	if err := s.setPost(ctx, tx, &stored); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	*post = stored
+	return nil
+}
+
+// setPost stores the post in the transaction and assigns its slug, version
+// and timestamps, see SetPost.
+func (s *Store) setPost(ctx context.Context, tx *sql.Tx, post *store.Post) error {
+	// A post that was deleted or trashed since its version was read is not
+	// recreated
+	if post.Version != 0 {
+		var exists bool
+		err := tx.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM posts WHERE id = ? AND deleted_at IS NULL)`, post.ID).Scan(&exists)
+		if err != nil {
+			return fmt.Errorf("checking version of post %s: %w", post.ID, err)
+		}
+		if !exists {
+			return store.ErrVersionConflict
+		}
+	}
+
+	postSlug, err := uniqueSlug(ctx, tx, post)
+	if err != nil {
+		return err
+	}
+
+	// Set timestamps, the creation time of an existing post is kept. Only
+	// the current version of the post can be replaced, if another version
+	// is stored no row is returned.
+	now := toUnix(s.clock.Now())
+	var createdAt, version int64
+	var publishedAt sql.NullInt64
+	err = tx.QueryRowContext(ctx, `INSERT INTO posts
+			(id, author_id, title, slug, content, content_format, content_html, published, publish_at, unpublish_at,
+			comment_moderation, category_id, cover_media_id, version, published_at, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, 1, CASE WHEN ? THEN ? END, ?, ?)
+		ON CONFLICT (id) DO UPDATE SET
+			author_id = excluded.author_id,
+			title = excluded.title,
+			slug = excluded.slug,
+			content = excluded.content,
+			content_format = excluded.content_format,
+			content_html = excluded.content_html,
+			published = excluded.published,
+			publish_at = excluded.publish_at,
+			unpublish_at = excluded.unpublish_at,
+			comment_moderation = excluded.comment_moderation,
+			category_id = excluded.category_id,
+			cover_media_id = excluded.cover_media_id,
+			version = posts.version + 1,
+			published_at = CASE WHEN excluded.published AND NOT posts.published THEN excluded.updated_at
+				ELSE posts.published_at END,
+			updated_at = excluded.updated_at
+		WHERE ? = 0 OR posts.version = ?
+		RETURNING created_at, version, published_at`,
+		post.ID, post.AuthorID, post.Title, postSlug, post.Content, post.ContentFormat, post.ContentHTML, post.Published,
+		toNullUnix(post.PublishAt), toNullUnix(post.UnpublishAt), post.CommentModeration, post.CategoryID, post.CoverMediaID,
+		post.Published, now, now, now, post.Version, post.Version,
+	).Scan(&createdAt, &version, &publishedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return store.ErrVersionConflict
+	}
+	if err != nil {
+		return fmt.Errorf("storing post %s: %w", post.ID, err)
+	}
+	_, err = tx.ExecContext(ctx, `INSERT INTO post_slugs (slug, post_id) VALUES (?, ?) ON CONFLICT (slug) DO NOTHING`,
+		postSlug, post.ID)
+	if err != nil {
+		return fmt.Errorf("storing slug of post %s: %w", post.ID, err)
+	}
+
+	if err := storeTags(ctx, tx, post); err != nil {
+		return err
+	}
+	if err := storeMedia(ctx, tx, post); err != nil {
+		return err
+	}
+	if err := indexPost(ctx, tx, post); err != nil {
+		return err
+	}
+
+	post.Slug = postSlug
+	post.Version = version
+	post.PublishedAt = fromNullUnix(publishedAt)
+	post.CreatedAt = fromUnix(createdAt)
+	post.UpdatedAt = fromUnix(now)
+	return nil
+}
+
+// storeTags replaces the tags of the post.
+func storeTags(ctx context.Context, tx *sql.Tx, post *store.Post) error {
+	if _, err := tx.ExecContext(ctx, `DELETE FROM post_tags WHERE post_id = ?`, post.ID); err != nil {
+		return fmt.Errorf("removing tags of post %s: %w", post.ID, err)
+	}
+	for i, tag := range post.Tags {
+		_, err := tx.ExecContext(ctx, `INSERT INTO post_tags (post_id, position, tag) VALUES (?, ?, ?)`, post.ID, i, tag)
+		if err != nil {
+			return fmt.Errorf("storing tags of post %s: %w", post.ID, err)
+		}
+	}
+	return nil
+}
+
+// storeMedia replaces the media embedded in the post.
+func storeMedia(ctx context.Context, tx *sql.Tx, post *store.Post) error {
+	if _, err := tx.ExecContext(ctx, `DELETE FROM post_media WHERE post_id = ?`, post.ID); err != nil {
+		return fmt.Errorf("removing media of post %s: %w", post.ID, err)
+	}
+	for i, mediaID := range post.MediaIDs {
+		_, err := tx.ExecContext(ctx, `INSERT INTO post_media (post_id, position, media_id) VALUES (?, ?, ?)`,
+			post.ID, i, mediaID)
+		if err != nil {
+			return fmt.Errorf("storing media of post %s: %w", post.ID, err)
+		}
+	}
+	return nil
+}
+
+// indexPost replaces the post in the search index.
+func indexPost(ctx context.Context, tx *sql.Tx, post *store.Post) error {
+	if _, err := tx.ExecContext(ctx, `DELETE FROM posts_fts WHERE post_id = ?`, post.ID); err != nil {
+		return fmt.Errorf("removing post %s from search index: %w", post.ID, err)
+	}
+	_, err := tx.ExecContext(ctx, `INSERT INTO posts_fts (post_id, title, tags, content) VALUES (?, ?, ?, ?)`,
+		post.ID, post.Title, strings.Join(post.Tags, " "), post.Content)
+	if err != nil {
+		return fmt.Errorf("indexing post %s: %w", post.ID, err)
+	}
+	return nil
+}
+
+// uniqueSlug returns the first candidate slug of the post that is not used
+// by another post.
+func uniqueSlug(ctx context.Context, tx *sql.Tx, post *store.Post) (string, error) {
+	base := post.Slug
+	if base == "" {
+		base = slug.Make(post.Title)
+	}
+	for n := 1; ; n++ {
+		candidate := slug.WithSuffix(base, n)
+		var owner uuid.UUID
+		err := tx.QueryRowContext(ctx, `SELECT post_id FROM post_slugs WHERE slug = ?`, candidate).Scan(&owner)
+		if errors.Is(err, sql.ErrNoRows) || (err == nil && owner == post.ID) {
+			return candidate, nil
+		}
+		if err != nil {
+			return "", fmt.Errorf("looking up slug %q: %w", candidate, err)
+		}
+	}
+}
+
+func (s *Store) LookupPost(ctx context.Context, ID uuid.UUID) (*store.Post, error) {
+	row := s.db.QueryRowContext(ctx, selectPost+` WHERE p.id = ?`, ID)
+	post, err := scanPost(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("looking up post %s: %w", ID, err)
+	}
+	return post, nil
+}
+
+func (s *Store) LookupPostBySlug(ctx context.Context, slug string) (*store.Post, error) {
+	row := s.db.QueryRowContext(ctx, selectPost+` JOIN post_slugs ps ON ps.post_id = p.id WHERE ps.slug = ?`, slug)
+	post, err := scanPost(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("looking up post by slug %q: %w", slug, err)
+	}
+	return post, nil
+}
+
+func (s *Store) ListPosts(ctx context.Context, query store.PostQuery) ([]*store.Post, error) {
+	var where []string
+	var args []any
+	if query.Tag != "" {
+		where = append(where, `EXISTS (SELECT 1 FROM post_tags t WHERE t.post_id = p.id AND t.tag = ?)`)
+		args = append(args, query.Tag)
+	}
+	if query.AuthorID != nil {
+		where = append(where, `p.author_id = ?`)
+		args = append(args, *query.AuthorID)
+	}
+	if query.Published != nil {
+		where = append(where, `p.published = ?`)
+		args = append(args, *query.Published)
+	}
+	if query.CategoryIDs != nil {
+		placeholders := strings.TrimPrefix(strings.Repeat(", ?", len(query.CategoryIDs)), ", ")
+		where = append(where, fmt.Sprintf(`p.category_id IN (%s)`, placeholders))
+		for _, ID := range query.CategoryIDs {
+			args = append(args, ID)
+		}
+	}
+	if query.Reader != nil {
+		where = append(where, `(p.published = 1 OR p.author_id = ?)`)
+		args = append(args, *query.Reader)
+	}
+	if query.CreatedFrom != nil {
+		where = append(where, `p.created_at >= ?`)
+		args = append(args, toUnix(*query.CreatedFrom))
+	}
+	if query.CreatedTo != nil {
+		where = append(where, `p.created_at < ?`)
+		args = append(args, toUnix(*query.CreatedTo))
+	}
+
+	q, args := pageQuery(selectPost, where, args, postOrder(query), query.Page)
+	rows, err := s.db.QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, fmt.Errorf("listing posts: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var posts []*store.Post
+	for rows.Next() {
+		post, err := scanPost(rows)
+		if err != nil {
+			return nil, fmt.Errorf("listing posts: %w", err)
+		}
+		posts = append(posts, post)
+	}
+	return posts, rows.Err()
+}
+
+func (s *Store) DeletePost(ctx context.Context, ID uuid.UUID) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if err := removeSeriesPost(ctx, tx, ID); err != nil {
+		return err
+	}
+	// Comments, their reactions and the other dependent rows are removed by
+	// the foreign keys
+	if _, err := tx.ExecContext(ctx, `DELETE FROM posts WHERE id = ?`, ID); err != nil {
+		return fmt.Errorf("deleting post %s: %w", ID, err)
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM posts_fts WHERE post_id = ?`, ID); err != nil {
+		return fmt.Errorf("removing post %s from search index: %w", ID, err)
+	}
+	return tx.Commit()
+}
+
+// postOrder returns the ordering of a listing of posts, which is sorted by
+// the sort key of the query first and by creation time and ID afterwards.
+func postOrder(query store.PostQuery) listing {
+	order := byCreation("p")
+	order.descending = query.Descending
+
+	switch query.Sort {
+	case store.PostSortUpdatedAt:
+		order.columns = append([]string{"p.updated_at"}, order.columns...)
+		order.keys = func(c store.Cursor) []any {
+			return []any{toUnix(c.UpdatedAt), toUnix(c.CreatedAt), c.ID}
+		}
+	case store.PostSortPublishedAt:
+		// Posts that were never published come first
+		order.columns = append([]string{"COALESCE(p.published_at, 0)"}, order.columns...)
+		order.keys = func(c store.Cursor) []any {
+			publishedAt := int64(0)
+			if !c.PublishedAt.IsZero() {
+				publishedAt = toUnix(c.PublishedAt)
+			}
+			return []any{publishedAt, toUnix(c.CreatedAt), c.ID}
+		}
+	case store.PostSortTitle:
+		order.columns = append([]string{"p.title"}, order.columns...)
+		order.keys = func(c store.Cursor) []any {
+			return []any{c.Title, toUnix(c.CreatedAt), c.ID}
+		}
+	}
+	return order
+}
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanPost(row scanner) (*store.Post, error) {
+	var post store.Post
+	var publishAt, unpublishAt, publishedAt, deletedAt, seriesPosition sql.NullInt64
+	var createdAt, updatedAt int64
+	var tags, mediaIDs string
+	err := row.Scan(&post.ID, &post.AuthorID, &post.Title, &post.Slug, &post.Content, &post.ContentFormat,
+		&post.ContentHTML, &post.Published, &publishAt, &unpublishAt, &post.CommentModeration, &post.CategoryID,
+		&post.SeriesID, &seriesPosition, &post.CoverMediaID, &post.Version, &publishedAt, &deletedAt, &createdAt, &updatedAt,
+		&tags, &mediaIDs)
+	if err != nil {
+		return nil, err
+	}
+	post.SeriesPosition = int(seriesPosition.Int64)
+	post.PublishAt = fromNullUnix(publishAt)
+	post.UnpublishAt = fromNullUnix(unpublishAt)
+	post.PublishedAt = fromNullUnix(publishedAt)
+	post.DeletedAt = fromNullUnix(deletedAt)
+	if err := json.Unmarshal([]byte(tags), &post.Tags); err != nil {
+		return nil, fmt.Errorf("decoding tags: %w", err)
+	}
+	if len(post.Tags) == 0 {
+		post.Tags = nil
+	}
+	if err := json.Unmarshal([]byte(mediaIDs), &post.MediaIDs); err != nil {
+		return nil, fmt.Errorf("decoding media: %w", err)
+	}
+	if len(post.MediaIDs) == 0 {
+		post.MediaIDs = nil
+	}
+	post.CreatedAt = fromUnix(createdAt)
+	post.UpdatedAt = fromUnix(updatedAt)
+	return &post, nil
+}
@@ -0,0 +1,113 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/chrishrb/blog-microservice/post-service/store"
+	"github.com/google/uuid"
+)
+
+// reactionTables maps the targets of reactions to the table of the targets,
+// the table of their reactions and the column referencing the target.
+var reactionTables = map[store.ReactionTarget]struct {
+	targets, reactions, column string
+}{
+	store.ReactionTargetPost:    {"posts", "post_reactions", "post_id"},
+	store.ReactionTargetComment: {"comments", "comment_reactions", "comment_id"},
+}
+
+func (s *Store) SetReaction(ctx context.Context, reaction *store.Reaction) error {
+	table, ok := reactionTables[reaction.Target]
+	if !ok {
+		return fmt.Errorf("unknown reaction target: %s", reaction.Target)
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	// The creation time is only changed if the kind of the reaction changes
+	_, err = tx.ExecContext(ctx, fmt.Sprintf(`INSERT INTO %[1]s (%[2]s, user_id, reaction, created_at)
+		SELECT ?, ?, ?, ? WHERE EXISTS (SELECT 1 FROM %[3]s WHERE id = ?)
+		ON CONFLICT (%[2]s, user_id) DO UPDATE SET
+			reaction = excluded.reaction,
+			created_at = excluded.created_at
+		WHERE reaction != excluded.reaction`, table.reactions, table.column, table.targets),
+		reaction.TargetID, reaction.UserID, reaction.Kind, toUnix(s.clock.Now()), reaction.TargetID)
+	if err != nil {
+		return fmt.Errorf("storing reaction to %s %s: %w", reaction.Target, reaction.TargetID, err)
+	}
+
+	var createdAt int64
+	err = tx.QueryRowContext(ctx, fmt.Sprintf(`SELECT created_at FROM %s WHERE %s = ? AND user_id = ?`,
+		table.reactions, table.column), reaction.TargetID, reaction.UserID).Scan(&createdAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("storing reaction to %s %s: %w", reaction.Target, reaction.TargetID, err)
+	}
+	reaction.CreatedAt = fromUnix(createdAt)
+	return tx.Commit()
+}
+
+func (s *Store) DeleteReaction(ctx context.Context, target store.ReactionTarget, targetID, userID uuid.UUID) error {
+	table, ok := reactionTables[target]
+	if !ok {
+		return fmt.Errorf("unknown reaction target: %s", target)
+	}
+
+	_, err := s.db.ExecContext(ctx, fmt.Sprintf(`DELETE FROM %s WHERE %s = ? AND user_id = ?`, table.reactions, table.column),
+		targetID, userID)
+	if err != nil {
+		return fmt.Errorf("deleting reaction to %s %s: %w", target, targetID, err)
+	}
+	return nil
+}
+
+func (s *Store) SummarizeReactions(ctx context.Context, target store.ReactionTarget, targetIDs []uuid.UUID, reader uuid.UUID) (map[uuid.UUID]*store.ReactionSummary, error) {
+	table, ok := reactionTables[target]
+	if !ok {
+		return nil, fmt.Errorf("unknown reaction target: %s", target)
+	}
+
+	summaries := make(map[uuid.UUID]*store.ReactionSummary, len(targetIDs))
+	if len(targetIDs) == 0 {
+		return summaries, nil
+	}
+	args := []any{reader}
+	for _, ID := range targetIDs {
+		summaries[ID] = &store.ReactionSummary{Counts: make(map[string]int)}
+		args = append(args, ID)
+	}
+
+	placeholders := strings.Repeat(", ?", len(targetIDs))[2:]
+	rows, err := s.db.QueryContext(ctx, fmt.Sprintf(`SELECT %[2]s, reaction, COUNT(*), MAX(user_id = ?)
+		FROM %[1]s WHERE %[2]s IN (%[3]s) GROUP BY %[2]s, reaction`, table.reactions, table.column, placeholders),
+		args...)
+	if err != nil {
+		return nil, fmt.Errorf("summarizing reactions: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		var ID uuid.UUID
+		var kind string
+		var count int
+		var mine bool
+		if err := rows.Scan(&ID, &kind, &count, &mine); err != nil {
+			return nil, fmt.Errorf("summarizing reactions: %w", err)
+		}
+		summaries[ID].Counts[kind] = count
+		if mine && reader != uuid.Nil {
+			summaries[ID].Mine = kind
+		}
+	}
+	return summaries, rows.Err()
+}
@@ -0,0 +1,120 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/chrishrb/blog-microservice/post-service/store"
+	"github.com/google/uuid"
+)
+
+const selectPostRevision = `SELECT post_id, number, editor_id, title, content, tags, restored_from, created_at FROM post_revisions`
+
+func (s *Store) SetPostWithRevision(ctx context.Context, post *store.Post, revision *store.PostRevision) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	// Neither is updated before the transaction is committed
+	storedPost, storedRevision := *post, *revision
+	if err := s.setPost(ctx, tx, &storedPost); err != nil {
+		return err
+	}
+	storedRevision.PostID = post.ID
+	if err := s.addPostRevision(ctx, tx, &storedRevision); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	*post, *revision = storedPost, storedRevision
+	return nil
+}
+
+// addPostRevision stores the revision in the transaction and assigns its
+// number and creation time, see SetPostWithRevision.
+func (s *Store) addPostRevision(ctx context.Context, tx *sql.Tx, revision *store.PostRevision) error {
+	tags, err := json.Marshal(revision.Tags)
+	if err != nil {
+		return fmt.Errorf("encoding tags: %w", err)
+	}
+
+	// The next number is assigned in the same statement, so that concurrent
+	// saves of a post cannot get the same number. Nothing is stored if the
+	// post does not exist.
+	now := toUnix(s.clock.Now())
+	err = tx.QueryRowContext(ctx, `INSERT INTO post_revisions
+			(post_id, number, editor_id, title, content, tags, restored_from, created_at)
+		SELECT ?, COALESCE((SELECT MAX(number) FROM post_revisions WHERE post_id = ?), 0) + 1, ?, ?, ?, ?, ?, ?
+		WHERE EXISTS (SELECT 1 FROM posts WHERE id = ? AND deleted_at IS NULL)
+		RETURNING number`,
+		revision.PostID, revision.PostID, revision.EditorID, revision.Title, revision.Content, string(tags),
+		revision.RestoredFrom, now, revision.PostID,
+	).Scan(&revision.Number)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("storing revision of post %s: %w", revision.PostID, err)
+	}
+
+	revision.CreatedAt = fromUnix(now)
+	return nil
+}
+
+func (s *Store) LookupPostRevision(ctx context.Context, postID uuid.UUID, number int) (*store.PostRevision, error) {
+	row := s.db.QueryRowContext(ctx, selectPostRevision+` WHERE post_id = ? AND number = ?`, postID, number)
+	revision, err := scanPostRevision(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("looking up revision %d of post %s: %w", number, postID, err)
+	}
+	return revision, nil
+}
+
+func (s *Store) ListPostRevisions(ctx context.Context, postID uuid.UUID, after, limit int) ([]*store.PostRevision, error) {
+	rows, err := s.db.QueryContext(ctx, selectPostRevision+` WHERE post_id = ? AND number > ? ORDER BY number LIMIT ?`,
+		postID, after, max(limit, 0))
+	if err != nil {
+		return nil, fmt.Errorf("listing revisions: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	revisions := []*store.PostRevision{}
+	for rows.Next() {
+		revision, err := scanPostRevision(rows)
+		if err != nil {
+			return nil, fmt.Errorf("listing revisions: %w", err)
+		}
+		revisions = append(revisions, revision)
+	}
+	return revisions, rows.Err()
+}
+
+func scanPostRevision(row scanner) (*store.PostRevision, error) {
+	var revision store.PostRevision
+	var tags string
+	var restoredFrom sql.NullInt64
+	var createdAt int64
+	err := row.Scan(&revision.PostID, &revision.Number, &revision.EditorID, &revision.Title, &revision.Content,
+		&tags, &restoredFrom, &createdAt)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(tags), &revision.Tags); err != nil {
+		return nil, fmt.Errorf("decoding tags: %w", err)
+	}
+	if restoredFrom.Valid {
+		number := int(restoredFrom.Int64)
+		revision.RestoredFrom = &number
+	}
+	revision.CreatedAt = fromUnix(createdAt)
+	return &revision, nil
+}
@@ -0,0 +1,60 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/chrishrb/blog-microservice/post-service/store"
+)
+
+func (s *Store) ApplyPostSchedules(ctx context.Context, now time.Time) ([]*store.Post, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	rows, err := tx.QueryContext(ctx, selectPost+` WHERE p.publish_at <= ? OR p.unpublish_at <= ?`,
+		toUnix(now), toUnix(now))
+	if err != nil {
+		return nil, fmt.Errorf("listing scheduled posts: %w", err)
+	}
+	var changed []*store.Post
+	for rows.Next() {
+		post, err := scanPost(rows)
+		if err != nil {
+			_ = rows.Close()
+			return nil, fmt.Errorf("listing scheduled posts: %w", err)
+		}
+		if post.ApplySchedule(now) {
+			changed = append(changed, post)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		_ = rows.Close()
+		return nil, fmt.Errorf("listing scheduled posts: %w", err)
+	}
+	_ = rows.Close()
+
+	updatedAt := toUnix(now)
+	for _, post := range changed {
+		if post.Published {
+			post.PublishedAt = fromNullUnix(sql.NullInt64{Int64: updatedAt, Valid: true})
+		}
+		_, err := tx.ExecContext(ctx, `UPDATE posts SET published = ?, publish_at = ?, unpublish_at = ?,
+			published_at = ?, version = version + 1, updated_at = ? WHERE id = ?`,
+			post.Published, toNullUnix(post.PublishAt), toNullUnix(post.UnpublishAt), toNullUnix(post.PublishedAt),
+			updatedAt, post.ID)
+		if err != nil {
+			return nil, fmt.Errorf("applying schedule of post %s: %w", post.ID, err)
+		}
+		post.Version++
+		post.UpdatedAt = fromUnix(updatedAt)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return changed, nil
+}
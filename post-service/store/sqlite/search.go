@@ -0,0 +1,159 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"html"
+	"strings"
+
+	"github.com/chrishrb/blog-microservice/post-service/search"
+	"github.com/chrishrb/blog-microservice/post-service/store"
+	"github.com/google/uuid"
+)
+
+// Markers used by the FTS5 snippet function, they are replaced after the
+// snippet has been HTML escaped.
+const (
+	snippetStart = "\x01"
+	snippetEnd   = "\x02"
+)
+
+// searchPosts ranks the matches with BM25, the weights of the columns
+// (post_id, title, tags, content) boost matches in the title and tags.
+// Trashed posts and drafts are filtered by joining the posts, drafts only if
+// the reader may not see all posts.
+const searchPosts = `SELECT f.post_id, -bm25(posts_fts, 0.0, 3.0, 2.0, 1.0) AS score,
+	snippet(posts_fts, -1, char(1), char(2), '…', 24)
+	FROM posts_fts f JOIN posts p ON p.id = f.post_id AND p.deleted_at IS NULL
+	WHERE posts_fts MATCH ? AND (? OR p.published = 1 OR p.author_id = ?)
+	ORDER BY score DESC, f.post_id LIMIT ?`
+
+// searchComments ranks the matching comments with BM25. Trashed comments,
+// tombstones and comments of trashed posts are left out, comments of drafts
+// and comments that are not approved only if the reader may not see them.
+var searchComments = `SELECT f.comment_id, -bm25(comments_fts, 0.0, 1.0) AS score,
+	snippet(comments_fts, -1, char(1), char(2), '…', 24)
+	FROM comments_fts f
+	JOIN comments c ON c.id = f.comment_id AND c.deleted_at IS NULL AND c.deleted = 0
+	JOIN posts p ON p.id = c.post_id AND p.deleted_at IS NULL
+	WHERE comments_fts MATCH ? AND (? OR p.published = 1 OR p.author_id = ?) AND (? OR ` + visibleComment("c") + `)
+	ORDER BY score DESC, f.comment_id LIMIT ?`
+
+func (s *Store) SearchPosts(ctx context.Context, query store.PostSearchQuery) ([]*store.PostSearchResult, error) {
+	match := matchExpression(query.Text)
+	if match == "" {
+		return []*store.PostSearchResult{}, nil
+	}
+
+	allPosts, reader := query.Reader == nil, uuid.Nil
+	if query.Reader != nil {
+		reader = *query.Reader
+	}
+	rows, err := s.db.QueryContext(ctx, searchPosts, match, allPosts, reader, query.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("searching posts: %w", err)
+	}
+
+	hits, err := scanHits(rows)
+	if err != nil {
+		return nil, fmt.Errorf("searching posts: %w", err)
+	}
+
+	results := []*store.PostSearchResult{}
+	for _, h := range hits {
+		post, err := s.LookupPost(ctx, h.ID)
+		if err != nil {
+			return nil, err
+		}
+		if post == nil {
+			continue
+		}
+		results = append(results, &store.PostSearchResult{
+			Post:    post,
+			Score:   h.Score,
+			Snippet: h.Snippet,
+		})
+	}
+	return results, nil
+}
+
+func (s *Store) SearchComments(ctx context.Context, query store.CommentSearchQuery) ([]*store.CommentSearchResult, error) {
+	match := matchExpression(query.Text)
+	if match == "" {
+		return []*store.CommentSearchResult{}, nil
+	}
+
+	allPosts, postReader := query.PostReader == nil, uuid.Nil
+	if query.PostReader != nil {
+		postReader = *query.PostReader
+	}
+	allComments, reader := query.Reader == nil, uuid.Nil
+	if query.Reader != nil {
+		reader = *query.Reader
+	}
+	rows, err := s.db.QueryContext(ctx, searchComments, match, allPosts, postReader, allComments, reader, query.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("searching comments: %w", err)
+	}
+	hits, err := scanHits(rows)
+	if err != nil {
+		return nil, fmt.Errorf("searching comments: %w", err)
+	}
+
+	results := []*store.CommentSearchResult{}
+	for _, h := range hits {
+		comment, err := s.lookupComment(ctx, h.ID)
+		if err != nil {
+			return nil, err
+		}
+		if comment == nil {
+			continue
+		}
+		results = append(results, &store.CommentSearchResult{
+			Comment: comment,
+			Score:   h.Score,
+			Snippet: h.Snippet,
+		})
+	}
+	return results, nil
+}
+
+// hit is a row of a full-text search, see scanHits.
+type hit struct {
+	ID      uuid.UUID
+	Score   float64
+	Snippet string
+}
+
+// scanHits reads and closes the rows of a full-text search, which contain
+// the ID, score and snippet of each match. The snippets are HTML escaped with
+// the matching words wrapped in <mark> elements.
+func scanHits(rows *sql.Rows) ([]hit, error) {
+	defer func() { _ = rows.Close() }()
+
+	var hits []hit
+	for rows.Next() {
+		var h hit
+		if err := rows.Scan(&h.ID, &h.Score, &h.Snippet); err != nil {
+			return nil, err
+		}
+		h.Snippet = html.EscapeString(h.Snippet)
+		h.Snippet = strings.ReplaceAll(h.Snippet, snippetStart, "<mark>")
+		h.Snippet = strings.ReplaceAll(h.Snippet, snippetEnd, "</mark>")
+		hits = append(hits, h)
+	}
+	return hits, rows.Err()
+}
+
+// matchExpression turns a user query into an FTS5 query, which matches
+// documents containing all words of the query. The query is split into words
+// like the in-memory index does, and each word is quoted so that FTS5
+// operators in the query are treated as plain text.
+func matchExpression(query string) string {
+	var words []string
+	for _, token := range search.Tokenize(query) {
+		words = append(words, `"`+query[token.Start:token.End]+`"`)
+	}
+	return strings.Join(words, " ")
+}
@@ -0,0 +1,221 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/chrishrb/blog-microservice/post-service/store"
+	"github.com/google/uuid"
+)
+
+const selectSeries = `SELECT id, author_id, title, description, created_at, updated_at FROM series`
+
+func (s *Store) SetSeries(ctx context.Context, series *store.Series) error {
+	// Set timestamps, the creation time of an existing series is kept
+	now := toUnix(s.clock.Now())
+	var createdAt int64
+	err := s.db.QueryRowContext(ctx, `INSERT INTO series (id, author_id, title, description, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+		ON CONFLICT (id) DO UPDATE SET
+			author_id = excluded.author_id,
+			title = excluded.title,
+			description = excluded.description,
+			updated_at = excluded.updated_at
+		RETURNING created_at`,
+		series.ID, series.AuthorID, series.Title, series.Description, now, now,
+	).Scan(&createdAt)
+	if err != nil {
+		return fmt.Errorf("storing series %s: %w", series.ID, err)
+	}
+
+	series.CreatedAt = fromUnix(createdAt)
+	series.UpdatedAt = fromUnix(now)
+	return nil
+}
+
+func (s *Store) LookupSeries(ctx context.Context, ID uuid.UUID) (*store.Series, error) {
+	row := s.db.QueryRowContext(ctx, selectSeries+` WHERE id = ?`, ID)
+	series, err := scanSeries(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("looking up series %s: %w", ID, err)
+	}
+	return series, nil
+}
+
+func (s *Store) ListSeries(ctx context.Context, authorID *uuid.UUID, page store.Page) ([]*store.Series, error) {
+	var where []string
+	var args []any
+	if authorID != nil {
+		where = append(where, `author_id = ?`)
+		args = append(args, *authorID)
+	}
+
+	q, args := pageQuery(selectSeries, where, args, byCreation(""), page)
+	rows, err := s.db.QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, fmt.Errorf("listing series: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	list := []*store.Series{}
+	for rows.Next() {
+		series, err := scanSeries(rows)
+		if err != nil {
+			return nil, fmt.Errorf("listing series: %w", err)
+		}
+		list = append(list, series)
+	}
+	return list, rows.Err()
+}
+
+func (s *Store) DeleteSeries(ctx context.Context, ID uuid.UUID) error {
+	_, err := s.db.ExecContext(ctx, `DELETE FROM series WHERE id = ?`, ID)
+	if err != nil {
+		return fmt.Errorf("deleting series %s: %w", ID, err)
+	}
+	return nil
+}
+
+func (s *Store) AddSeriesPost(ctx context.Context, seriesID, postID uuid.UUID, position int) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var exists bool
+	err = tx.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM series WHERE id = ?) AND EXISTS (SELECT 1 FROM posts WHERE id = ? AND deleted_at IS NULL)`,
+		seriesID, postID).Scan(&exists)
+	if err != nil {
+		return fmt.Errorf("adding post %s to series %s: %w", postID, seriesID, err)
+	}
+	if !exists {
+		return nil
+	}
+	if err := removeSeriesPost(ctx, tx, postID); err != nil {
+		return err
+	}
+
+	var count int
+	err = tx.QueryRowContext(ctx, `SELECT COUNT(*) FROM series_posts WHERE series_id = ?`, seriesID).Scan(&count)
+	if err != nil {
+		return fmt.Errorf("adding post %s to series %s: %w", postID, seriesID, err)
+	}
+	if position < 1 || position > count {
+		position = count + 1
+	}
+	_, err = tx.ExecContext(ctx, `UPDATE series_posts SET position = position + 1 WHERE series_id = ? AND position >= ?`,
+		seriesID, position)
+	if err != nil {
+		return fmt.Errorf("adding post %s to series %s: %w", postID, seriesID, err)
+	}
+	_, err = tx.ExecContext(ctx, `INSERT INTO series_posts (post_id, series_id, position) VALUES (?, ?, ?)`,
+		postID, seriesID, position)
+	if err != nil {
+		return fmt.Errorf("adding post %s to series %s: %w", postID, seriesID, err)
+	}
+	return tx.Commit()
+}
+
+func (s *Store) RemoveSeriesPost(ctx context.Context, postID uuid.UUID) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if err := removeSeriesPost(ctx, tx, postID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (s *Store) ListSeriesPosts(ctx context.Context, seriesID uuid.UUID) ([]*store.Post, error) {
+	rows, err := s.db.QueryContext(ctx, selectPost+` WHERE sp.series_id = ? ORDER BY sp.position`, seriesID)
+	if err != nil {
+		return nil, fmt.Errorf("listing posts of series %s: %w", seriesID, err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	posts := []*store.Post{}
+	for rows.Next() {
+		post, err := scanPost(rows)
+		if err != nil {
+			return nil, fmt.Errorf("listing posts of series %s: %w", seriesID, err)
+		}
+		posts = append(posts, post)
+	}
+	return posts, rows.Err()
+}
+
+func (s *Store) ReorderSeries(ctx context.Context, seriesID uuid.UUID, postIDs []uuid.UUID) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	rows, err := tx.QueryContext(ctx, `SELECT post_id FROM series_posts WHERE series_id = ? ORDER BY position`, seriesID)
+	if err != nil {
+		return fmt.Errorf("reordering series %s: %w", seriesID, err)
+	}
+	var current []uuid.UUID
+	for rows.Next() {
+		var ID uuid.UUID
+		if err := rows.Scan(&ID); err != nil {
+			_ = rows.Close()
+			return fmt.Errorf("reordering series %s: %w", seriesID, err)
+		}
+		current = append(current, ID)
+	}
+	_ = rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("reordering series %s: %w", seriesID, err)
+	}
+
+	for i, ID := range store.SeriesOrder(current, postIDs) {
+		_, err := tx.ExecContext(ctx, `UPDATE series_posts SET position = ? WHERE post_id = ?`, i+1, ID)
+		if err != nil {
+			return fmt.Errorf("reordering series %s: %w", seriesID, err)
+		}
+	}
+	return tx.Commit()
+}
+
+// removeSeriesPost removes the post from its series and closes the gap it
+// leaves.
+func removeSeriesPost(ctx context.Context, tx *sql.Tx, postID uuid.UUID) error {
+	var seriesID uuid.UUID
+	var position int
+	err := tx.QueryRowContext(ctx, `DELETE FROM series_posts WHERE post_id = ? RETURNING series_id, position`,
+		postID).Scan(&seriesID, &position)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("removing post %s from its series: %w", postID, err)
+	}
+	_, err = tx.ExecContext(ctx, `UPDATE series_posts SET position = position - 1 WHERE series_id = ? AND position > ?`,
+		seriesID, position)
+	if err != nil {
+		return fmt.Errorf("removing post %s from its series: %w", postID, err)
+	}
+	return nil
+}
+
+func scanSeries(row scanner) (*store.Series, error) {
+	var series store.Series
+	var createdAt, updatedAt int64
+	err := row.Scan(&series.ID, &series.AuthorID, &series.Title, &series.Description, &createdAt, &updatedAt)
+	if err != nil {
+		return nil, err
+	}
+	series.CreatedAt = fromUnix(createdAt)
+	series.UpdatedAt = fromUnix(updatedAt)
+	return &series, nil
+}
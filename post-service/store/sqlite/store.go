@@ -0,0 +1,127 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"fmt"
+	"io/fs"
+	"strings"
+	"time"
+
+	"k8s.io/utils/clock"
+
+	"github.com/chrishrb/blog-microservice/internal/sqlitedb"
+	"github.com/chrishrb/blog-microservice/post-service/store"
+)
+
+//go:embed migrations/*.sql
+var migrations embed.FS
+
+// Store is a SQLite implementation of the store.Engine interface. Data is
+// persisted to a single database file, so it survives restarts but cannot be
+// shared between >1 instances.
+type Store struct {
+	db    *sql.DB
+	clock clock.PassiveClock
+}
+
+// NewStore opens the database at the given path and applies all pending
+// schema migrations.
+func NewStore(ctx context.Context, path string, clock clock.PassiveClock) (*Store, error) {
+	db, err := sqlitedb.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	migrationsFS, err := fs.Sub(migrations, "migrations")
+	if err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+	if err := sqlitedb.Migrate(ctx, db, migrationsFS); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("migrating database: %w", err)
+	}
+
+	return &Store{
+		db:    db,
+		clock: clock,
+	}, nil
+}
+
+// Close closes the underlying database.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+func toUnix(t time.Time) int64 {
+	return t.UnixNano()
+}
+
+func fromUnix(n int64) time.Time {
+	return time.Unix(0, n).UTC()
+}
+
+func toNullUnix(t *time.Time) sql.NullInt64 {
+	if t == nil {
+		return sql.NullInt64{}
+	}
+	return sql.NullInt64{Int64: toUnix(*t), Valid: true}
+}
+
+func fromNullUnix(n sql.NullInt64) *time.Time {
+	if !n.Valid {
+		return nil
+	}
+	t := fromUnix(n.Int64)
+	return &t
+}
+
+// listing describes the ordering of a listing query. The listing is ordered
+// by the columns and keys returns the values of these columns at a cursor.
+type listing struct {
+	columns    []string
+	keys       func(store.Cursor) []any
+	descending bool
+}
+
+// byCreation orders a listing by creation time and ID. Columns are qualified
+// with the table alias if it is not empty.
+func byCreation(alias string) listing {
+	return listing{
+		columns: []string{qualify(alias, "created_at"), qualify(alias, "id")},
+		keys: func(c store.Cursor) []any {
+			return []any{toUnix(c.CreatedAt), c.ID}
+		},
+	}
+}
+
+// pageQuery completes a listing query with the conditions in where, the
+// ordering of the listing and the limits selected by page.
+func pageQuery(query string, where []string, args []any, order listing, page store.Page) (string, []any) {
+	direction, comparison := "ASC", ">"
+	if order.descending {
+		direction, comparison = "DESC", "<"
+	}
+	placeholders := strings.Repeat(", ?", len(order.columns))[2:]
+
+	offset := max(page.Offset, 0)
+	if page.After != nil {
+		where = append(where, fmt.Sprintf("(%s) %s (%s)", strings.Join(order.columns, ", "), comparison, placeholders))
+		args = append(args, order.keys(*page.After)...)
+		offset = 0
+	}
+	if len(where) > 0 {
+		query += " WHERE " + strings.Join(where, " AND ")
+	}
+	query += fmt.Sprintf(" ORDER BY %s %s LIMIT ? OFFSET ?", strings.Join(order.columns, " "+direction+", "), direction)
+	return query, append(args, max(page.Limit, 0), offset)
+}
+
+func qualify(alias, column string) string {
+	if alias == "" {
+		return column
+	}
+	return alias + "." + column
+}
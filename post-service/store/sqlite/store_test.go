@@ -0,0 +1,76 @@
+package sqlite_test
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/chrishrb/blog-microservice/post-service/store"
+	"github.com/chrishrb/blog-microservice/post-service/store/sqlite"
+	"github.com/chrishrb/blog-microservice/post-service/store/storetest"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/utils/clock"
+	clock_testing "k8s.io/utils/clock/testing"
+)
+
+func newStore(t *testing.T, clock clock.PassiveClock) *sqlite.Store {
+	engine, err := sqlite.NewStore(t.Context(), filepath.Join(t.TempDir(), "post-service.db"), clock)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = engine.Close()
+	})
+	return engine
+}
+
+func TestStore(t *testing.T) {
+	storetest.Run(t, func(t *testing.T, clock clock.PassiveClock) store.Engine {
+		return newStore(t, clock)
+	})
+}
+
+func TestStorePersistsAcrossRestarts(t *testing.T) {
+	fakeClock := clock_testing.NewFakeClock(time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC))
+	path := filepath.Join(t.TempDir(), "post-service.db")
+
+	engine, err := sqlite.NewStore(t.Context(), path, fakeClock)
+	require.NoError(t, err)
+
+	postID := uuid.New()
+	commentID := uuid.New()
+	err = engine.SetPost(t.Context(), &store.Post{
+		ID:       postID,
+		AuthorID: uuid.New(),
+		Title:    "Some Title",
+		Content:  "Some Content",
+		Tags:     []string{"tag1"},
+	})
+	require.NoError(t, err)
+	err = engine.SetComment(t.Context(), &store.Comment{
+		ID:       commentID,
+		AuthorID: uuid.New(),
+		PostID:   postID,
+		Content:  "Some Comment",
+	})
+	require.NoError(t, err)
+	require.NoError(t, engine.Close())
+
+	// Reopening runs the migrations again, which must not touch existing data
+	engine, err = sqlite.NewStore(t.Context(), path, fakeClock)
+	require.NoError(t, err)
+	defer func() {
+		_ = engine.Close()
+	}()
+
+	post, err := engine.LookupPost(t.Context(), postID)
+	require.NoError(t, err)
+	require.NotNil(t, post)
+	assert.Equal(t, "Some Title", post.Title)
+	assert.Equal(t, []string{"tag1"}, post.Tags)
+
+	comment, err := engine.LookupComment(t.Context(), postID, commentID)
+	require.NoError(t, err)
+	require.NotNil(t, comment)
+	assert.Equal(t, "Some Comment", comment.Content)
+}
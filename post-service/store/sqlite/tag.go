@@ -0,0 +1,88 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/chrishrb/blog-microservice/post-service/store"
+)
+
+func (s *Store) ListTags(ctx context.Context, query store.TagQuery) ([]*store.TagCount, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT t.tag, COUNT(DISTINCT t.post_id) AS count
+		FROM post_tags t JOIN posts p ON p.id = t.post_id AND p.deleted_at IS NULL
+		WHERE p.published = 1 AND substr(t.tag, 1, length(?)) = ?
+		GROUP BY t.tag
+		ORDER BY count DESC, t.tag
+		LIMIT ?`, query.Prefix, query.Prefix, max(query.Limit, 0))
+	if err != nil {
+		return nil, fmt.Errorf("listing tags: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	tags := []*store.TagCount{}
+	for rows.Next() {
+		var tag store.TagCount
+		if err := rows.Scan(&tag.Tag, &tag.Count); err != nil {
+			return nil, fmt.Errorf("listing tags: %w", err)
+		}
+		tags = append(tags, &tag)
+	}
+	return tags, rows.Err()
+}
+
+func (s *Store) MergeTags(ctx context.Context, sources []string, target string) (int, error) {
+	if len(sources) == 0 {
+		return 0, nil
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	args := make([]any, len(sources))
+	for i, source := range sources {
+		args[i] = source
+	}
+	placeholders := strings.Repeat(", ?", len(sources))[2:]
+	rows, err := tx.QueryContext(ctx, selectPost+
+		fmt.Sprintf(` WHERE p.id IN (SELECT post_id FROM post_tags WHERE tag IN (%s))`, placeholders), args...)
+	if err != nil {
+		return 0, fmt.Errorf("merging tags: %w", err)
+	}
+	var posts []*store.Post
+	for rows.Next() {
+		post, err := scanPost(rows)
+		if err != nil {
+			_ = rows.Close()
+			return 0, fmt.Errorf("merging tags: %w", err)
+		}
+		posts = append(posts, post)
+	}
+	_ = rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("merging tags: %w", err)
+	}
+
+	changed := 0
+	for _, post := range posts {
+		tags, ok := store.ReplaceTags(post.Tags, sources, target)
+		if !ok {
+			continue
+		}
+		post.Tags = tags
+		if err := storeTags(ctx, tx, post); err != nil {
+			return 0, err
+		}
+		if err := indexPost(ctx, tx, post); err != nil {
+			return 0, err
+		}
+		if _, err := tx.ExecContext(ctx, `UPDATE posts SET version = version + 1 WHERE id = ?`, post.ID); err != nil {
+			return 0, fmt.Errorf("merging tags: %w", err)
+		}
+		changed++
+	}
+	return changed, tx.Commit()
+}
@@ -0,0 +1,219 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/chrishrb/blog-microservice/post-service/store"
+	"github.com/google/uuid"
+)
+
+func (s *Store) TrashPost(ctx context.Context, ID uuid.UUID) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	res, err := tx.ExecContext(ctx, `UPDATE posts SET deleted_at = ? WHERE id = ? AND deleted_at IS NULL`,
+		toUnix(s.clock.Now()), ID)
+	if err != nil {
+		return fmt.Errorf("trashing post %s: %w", ID, err)
+	}
+	trashed, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("trashing post %s: %w", ID, err)
+	}
+	if trashed == 0 {
+		return nil
+	}
+
+	if err := removeSeriesPost(ctx, tx, ID); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM posts_fts WHERE post_id = ?`, ID); err != nil {
+		return fmt.Errorf("removing post %s from search index: %w", ID, err)
+	}
+	return tx.Commit()
+}
+
+func (s *Store) LookupTrashedPost(ctx context.Context, ID uuid.UUID) (*store.Post, error) {
+	row := s.db.QueryRowContext(ctx, selectTrashedPost+` WHERE p.id = ?`, ID)
+	post, err := scanPost(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("looking up trashed post %s: %w", ID, err)
+	}
+	return post, nil
+}
+
+func (s *Store) ListTrashedPosts(ctx context.Context, authorID *uuid.UUID, page store.Page) ([]*store.Post, error) {
+	var where []string
+	var args []any
+	if authorID != nil {
+		where = append(where, `p.author_id = ?`)
+		args = append(args, *authorID)
+	}
+
+	query, args := pageQuery(selectTrashedPost, where, args, byCreation("p"), page)
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("listing trashed posts: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	posts := []*store.Post{}
+	for rows.Next() {
+		post, err := scanPost(rows)
+		if err != nil {
+			return nil, fmt.Errorf("listing trashed posts: %w", err)
+		}
+		posts = append(posts, post)
+	}
+	return posts, rows.Err()
+}
+
+func (s *Store) RestorePost(ctx context.Context, ID uuid.UUID) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	row := tx.QueryRowContext(ctx, selectTrashedPost+` WHERE p.id = ?`, ID)
+	post, err := scanPost(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("restoring post %s: %w", ID, err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE posts SET deleted_at = NULL WHERE id = ?`, ID); err != nil {
+		return fmt.Errorf("restoring post %s: %w", ID, err)
+	}
+	if err := indexPost(ctx, tx, post); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (s *Store) TrashComment(ctx context.Context, postID, ID uuid.UUID) error {
+	_, err := s.db.ExecContext(ctx, `UPDATE comments SET deleted_at = ?
+		WHERE post_id = ? AND id = ? AND deleted_at IS NULL
+		AND EXISTS (SELECT 1 FROM posts WHERE id = ? AND deleted_at IS NULL)`,
+		toUnix(s.clock.Now()), postID, ID, postID)
+	if err != nil {
+		return fmt.Errorf("trashing comment %s: %w", ID, err)
+	}
+	return nil
+}
+
+func (s *Store) LookupTrashedComment(ctx context.Context, postID, ID uuid.UUID) (*store.Comment, error) {
+	row := s.db.QueryRowContext(ctx, selectTrashedComment+` AND c.post_id = ? AND c.id = ?`, postID, ID)
+	comment, err := scanComment(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("looking up trashed comment %s: %w", ID, err)
+	}
+	return comment, nil
+}
+
+func (s *Store) ListTrashedComments(ctx context.Context, authorID *uuid.UUID, page store.Page) ([]*store.Comment, error) {
+	// selectTrashedComment has a WHERE clause already, which pageQuery
+	// extends by wrapping it
+	query := `SELECT * FROM (` + selectTrashedComment + `) c`
+	var where []string
+	var args []any
+	if authorID != nil {
+		where = append(where, `c.author_id = ?`)
+		args = append(args, *authorID)
+	}
+	query, args = pageQuery(query, where, args, byCreation("c"), page)
+	return s.queryComments(ctx, query, args...)
+}
+
+func (s *Store) RestoreComment(ctx context.Context, postID, ID uuid.UUID) error {
+	_, err := s.db.ExecContext(ctx, `UPDATE comments SET deleted_at = NULL
+		WHERE post_id = ? AND id = ?
+		AND EXISTS (SELECT 1 FROM posts WHERE id = ? AND deleted_at IS NULL)`,
+		postID, ID, postID)
+	if err != nil {
+		return fmt.Errorf("restoring comment %s: %w", ID, err)
+	}
+	return nil
+}
+
+func (s *Store) PurgeTrash(ctx context.Context, before time.Time) (int, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	// Collect the due posts and comments before deleting them. Comments of
+	// purged posts are removed by the foreign keys.
+	posts, err := dueTrash(ctx, tx, `SELECT id, NULL FROM posts WHERE deleted_at < ?`, before)
+	if err != nil {
+		return 0, fmt.Errorf("purging trashed posts: %w", err)
+	}
+	for _, key := range posts {
+		if err := removeSeriesPost(ctx, tx, key.ID); err != nil {
+			return 0, err
+		}
+		if _, err := tx.ExecContext(ctx, `DELETE FROM posts WHERE id = ?`, key.ID); err != nil {
+			return 0, fmt.Errorf("purging post %s: %w", key.ID, err)
+		}
+		if _, err := tx.ExecContext(ctx, `DELETE FROM posts_fts WHERE post_id = ?`, key.ID); err != nil {
+			return 0, fmt.Errorf("removing post %s from search index: %w", key.ID, err)
+		}
+	}
+
+	comments, err := dueTrash(ctx, tx, `SELECT id, post_id FROM comments WHERE deleted_at < ?`, before)
+	if err != nil {
+		return 0, fmt.Errorf("purging trashed comments: %w", err)
+	}
+	for _, key := range comments {
+		if err := s.deleteComment(ctx, tx, *key.postID, key.ID); err != nil {
+			return 0, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return len(posts) + len(comments), nil
+}
+
+// trashKey identifies a trashed post, or a trashed comment of a post.
+type trashKey struct {
+	ID     uuid.UUID
+	postID *uuid.UUID
+}
+
+// dueTrash returns the keys selected by the query for the items that were
+// trashed before the time.
+func dueTrash(ctx context.Context, tx *sql.Tx, query string, before time.Time) ([]trashKey, error) {
+	rows, err := tx.QueryContext(ctx, query, toUnix(before))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var keys []trashKey
+	for rows.Next() {
+		var key trashKey
+		if err := rows.Scan(&key.ID, &key.postID); err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return keys, rows.Err()
+}
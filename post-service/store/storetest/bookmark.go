@@ -0,0 +1,226 @@
+package storetest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/chrishrb/blog-microservice/post-service/store"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	clock_testing "k8s.io/utils/clock/testing"
+)
+
+// bookmarkedPostIDs returns the IDs of the posts of the bookmarks.
+func bookmarkedPostIDs(bookmarks []*store.Bookmark) []uuid.UUID {
+	IDs := make([]uuid.UUID, len(bookmarks))
+	for i, bookmark := range bookmarks {
+		IDs[i] = bookmark.PostID
+	}
+	return IDs
+}
+
+func testSetBookmark(t *testing.T, newEngine NewEngine) {
+	fakeClock := clock_testing.NewFakeClock(time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC))
+	engine := newEngine(t, fakeClock)
+
+	userID := uuid.New()
+	post := &store.Post{ID: uuid.New(), AuthorID: uuid.New(), Title: "Some Title", Published: true}
+	require.NoError(t, engine.SetPost(t.Context(), post))
+	list := &store.ReadingList{ID: uuid.New(), UserID: userID, Name: "Later"}
+	require.NoError(t, engine.SetReadingList(t.Context(), list))
+
+	bookmark := &store.Bookmark{UserID: userID, PostID: post.ID}
+	require.NoError(t, engine.SetBookmark(t.Context(), bookmark))
+	assert.Equal(t, fakeClock.Now(), bookmark.CreatedAt)
+
+	// Moving the bookmark to a list keeps its creation time
+	fakeClock.Step(time.Minute)
+	moved := &store.Bookmark{UserID: userID, PostID: post.ID, ListID: &list.ID}
+	require.NoError(t, engine.SetBookmark(t.Context(), moved))
+	assert.Equal(t, bookmark.CreatedAt, moved.CreatedAt)
+
+	bookmarks, err := engine.ListBookmarks(t.Context(), store.BookmarkQuery{UserID: userID, Page: store.Page{Limit: 10}})
+	require.NoError(t, err)
+	require.Len(t, bookmarks, 1)
+	assert.Equal(t, userID, bookmarks[0].UserID)
+	assert.Equal(t, post.ID, bookmarks[0].PostID)
+	assert.Equal(t, &list.ID, bookmarks[0].ListID)
+	assert.Equal(t, bookmark.CreatedAt, bookmarks[0].CreatedAt)
+	require.NotNil(t, bookmarks[0].Post)
+	assert.Equal(t, post.Title, bookmarks[0].Post.Title)
+
+	// Bookmarks of other users are not listed
+	bookmarks, err = engine.ListBookmarks(t.Context(), store.BookmarkQuery{UserID: uuid.New(), Page: store.Page{Limit: 10}})
+	require.NoError(t, err)
+	assert.Empty(t, bookmarks)
+}
+
+func testSetBookmark_MissingPost(t *testing.T, newEngine NewEngine) {
+	fakeClock := clock_testing.NewFakeClock(time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC))
+	engine := newEngine(t, fakeClock)
+
+	userID := uuid.New()
+	require.NoError(t, engine.SetBookmark(t.Context(), &store.Bookmark{UserID: userID, PostID: uuid.New()}))
+
+	bookmarks, err := engine.ListBookmarks(t.Context(), store.BookmarkQuery{UserID: userID, Page: store.Page{Limit: 10}})
+	require.NoError(t, err)
+	assert.Empty(t, bookmarks)
+}
+
+func testDeleteBookmark(t *testing.T, newEngine NewEngine) {
+	fakeClock := clock_testing.NewFakeClock(time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC))
+	engine := newEngine(t, fakeClock)
+
+	userID := uuid.New()
+	post := &store.Post{ID: uuid.New(), AuthorID: uuid.New(), Title: "Some Title", Published: true}
+	require.NoError(t, engine.SetPost(t.Context(), post))
+	require.NoError(t, engine.SetBookmark(t.Context(), &store.Bookmark{UserID: userID, PostID: post.ID}))
+
+	require.NoError(t, engine.DeleteBookmark(t.Context(), userID, post.ID))
+	// Deleting a missing bookmark is not an error
+	require.NoError(t, engine.DeleteBookmark(t.Context(), userID, post.ID))
+
+	bookmarks, err := engine.ListBookmarks(t.Context(), store.BookmarkQuery{UserID: userID, Page: store.Page{Limit: 10}})
+	require.NoError(t, err)
+	assert.Empty(t, bookmarks)
+}
+
+func testListBookmarks(t *testing.T, newEngine NewEngine) {
+	fakeClock := clock_testing.NewFakeClock(time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC))
+	engine := newEngine(t, fakeClock)
+
+	userID := uuid.New()
+	list := &store.ReadingList{ID: uuid.New(), UserID: userID, Name: "Later"}
+	require.NoError(t, engine.SetReadingList(t.Context(), list))
+
+	posts := make([]*store.Post, 4)
+	for i := range posts {
+		posts[i] = &store.Post{ID: uuid.New(), AuthorID: uuid.New(), Title: "Some Title", Published: true}
+		require.NoError(t, engine.SetPost(t.Context(), posts[i]))
+		bookmark := &store.Bookmark{UserID: userID, PostID: posts[i].ID}
+		if i%2 == 1 {
+			bookmark.ListID = &list.ID
+		}
+		require.NoError(t, engine.SetBookmark(t.Context(), bookmark))
+		fakeClock.Step(time.Minute)
+	}
+
+	t.Run("most recent first", func(t *testing.T) {
+		bookmarks, err := engine.ListBookmarks(t.Context(), store.BookmarkQuery{UserID: userID, Page: store.Page{Limit: 10}})
+		require.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{posts[3].ID, posts[2].ID, posts[1].ID, posts[0].ID}, bookmarkedPostIDs(bookmarks))
+	})
+
+	t.Run("reading list", func(t *testing.T) {
+		bookmarks, err := engine.ListBookmarks(t.Context(), store.BookmarkQuery{UserID: userID, ListID: &list.ID, Page: store.Page{Limit: 10}})
+		require.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{posts[3].ID, posts[1].ID}, bookmarkedPostIDs(bookmarks))
+	})
+
+	t.Run("pagination", func(t *testing.T) {
+		page, err := engine.ListBookmarks(t.Context(), store.BookmarkQuery{UserID: userID, Page: store.Page{Limit: 2}})
+		require.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{posts[3].ID, posts[2].ID}, bookmarkedPostIDs(page))
+
+		after := page[1].Cursor()
+		page, err = engine.ListBookmarks(t.Context(), store.BookmarkQuery{UserID: userID, Page: store.Page{After: &after, Limit: 2}})
+		require.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{posts[1].ID, posts[0].ID}, bookmarkedPostIDs(page))
+
+		page, err = engine.ListBookmarks(t.Context(), store.BookmarkQuery{UserID: userID, Page: store.Page{Offset: 3, Limit: 2}})
+		require.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{posts[0].ID}, bookmarkedPostIDs(page))
+	})
+
+	t.Run("unpublished and deleted posts", func(t *testing.T) {
+		posts[0].Published = false
+		require.NoError(t, engine.SetPost(t.Context(), posts[0]))
+		require.NoError(t, engine.DeletePost(t.Context(), posts[1].ID))
+
+		bookmarks, err := engine.ListBookmarks(t.Context(), store.BookmarkQuery{UserID: userID, Page: store.Page{Limit: 10}})
+		require.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{posts[3].ID, posts[2].ID}, bookmarkedPostIDs(bookmarks))
+
+		// Bookmarks of unpublished posts are listed again once the post is
+		// published again
+		posts[0].Published = true
+		require.NoError(t, engine.SetPost(t.Context(), posts[0]))
+
+		bookmarks, err = engine.ListBookmarks(t.Context(), store.BookmarkQuery{UserID: userID, Page: store.Page{Limit: 10}})
+		require.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{posts[3].ID, posts[2].ID, posts[0].ID}, bookmarkedPostIDs(bookmarks))
+	})
+}
+
+func testSetReadingList(t *testing.T, newEngine NewEngine) {
+	fakeClock := clock_testing.NewFakeClock(time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC))
+	engine := newEngine(t, fakeClock)
+
+	list := &store.ReadingList{ID: uuid.New(), UserID: uuid.New(), Name: "Later"}
+	require.NoError(t, engine.SetReadingList(t.Context(), list))
+	assert.Equal(t, fakeClock.Now(), list.CreatedAt)
+	assert.Equal(t, fakeClock.Now(), list.UpdatedAt)
+
+	// Renaming keeps the creation time
+	fakeClock.Step(time.Minute)
+	list.Name = "Weekend"
+	require.NoError(t, engine.SetReadingList(t.Context(), list))
+
+	found, err := engine.LookupReadingList(t.Context(), list.ID)
+	require.NoError(t, err)
+	assert.Equal(t, list, found)
+	assert.Equal(t, "Weekend", found.Name)
+	assert.True(t, found.UpdatedAt.After(found.CreatedAt))
+
+	found, err = engine.LookupReadingList(t.Context(), uuid.New())
+	require.NoError(t, err)
+	assert.Nil(t, found)
+}
+
+func testListReadingLists(t *testing.T, newEngine NewEngine) {
+	fakeClock := clock_testing.NewFakeClock(time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC))
+	engine := newEngine(t, fakeClock)
+
+	userID := uuid.New()
+	lists := make([]*store.ReadingList, 3)
+	for i := range lists {
+		lists[i] = &store.ReadingList{ID: uuid.New(), UserID: userID, Name: "Some List"}
+		require.NoError(t, engine.SetReadingList(t.Context(), lists[i]))
+		fakeClock.Step(time.Minute)
+	}
+	require.NoError(t, engine.SetReadingList(t.Context(), &store.ReadingList{ID: uuid.New(), UserID: uuid.New(), Name: "Other"}))
+
+	found, err := engine.ListReadingLists(t.Context(), userID, store.Page{Limit: 2})
+	require.NoError(t, err)
+	assert.Equal(t, lists[:2], found)
+
+	after := found[1].Cursor()
+	found, err = engine.ListReadingLists(t.Context(), userID, store.Page{After: &after, Limit: 2})
+	require.NoError(t, err)
+	assert.Equal(t, lists[2:], found)
+}
+
+func testDeleteReadingList(t *testing.T, newEngine NewEngine) {
+	fakeClock := clock_testing.NewFakeClock(time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC))
+	engine := newEngine(t, fakeClock)
+
+	userID := uuid.New()
+	post := &store.Post{ID: uuid.New(), AuthorID: uuid.New(), Title: "Some Title", Published: true}
+	require.NoError(t, engine.SetPost(t.Context(), post))
+	list := &store.ReadingList{ID: uuid.New(), UserID: userID, Name: "Later"}
+	require.NoError(t, engine.SetReadingList(t.Context(), list))
+	require.NoError(t, engine.SetBookmark(t.Context(), &store.Bookmark{UserID: userID, PostID: post.ID, ListID: &list.ID}))
+
+	require.NoError(t, engine.DeleteReadingList(t.Context(), list.ID))
+
+	found, err := engine.LookupReadingList(t.Context(), list.ID)
+	require.NoError(t, err)
+	assert.Nil(t, found)
+
+	// The bookmarks of the list are kept
+	bookmarks, err := engine.ListBookmarks(t.Context(), store.BookmarkQuery{UserID: userID, Page: store.Page{Limit: 10}})
+	require.NoError(t, err)
+	require.Len(t, bookmarks, 1)
+	assert.Nil(t, bookmarks[0].ListID)
+}
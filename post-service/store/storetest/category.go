@@ -0,0 +1,92 @@
+package storetest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/chrishrb/blog-microservice/post-service/store"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	clock_testing "k8s.io/utils/clock/testing"
+)
+
+func testSetCategory(t *testing.T, newEngine NewEngine) {
+	fakeClock := clock_testing.NewFakeClock(time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC))
+	engine := newEngine(t, fakeClock)
+
+	parent := &store.Category{ID: uuid.New(), Name: "Programming"}
+	require.NoError(t, engine.SetCategory(t.Context(), parent))
+	child := &store.Category{ID: uuid.New(), ParentID: &parent.ID, Name: "Go", Description: "All about Go"}
+	require.NoError(t, engine.SetCategory(t.Context(), child))
+	createdAt := child.CreatedAt
+
+	fakeClock.Step(time.Minute)
+	child.Name = "Golang"
+	require.NoError(t, engine.SetCategory(t.Context(), child))
+
+	got, err := engine.LookupCategory(t.Context(), child.ID)
+	require.NoError(t, err)
+	assert.Equal(t, &store.Category{
+		ID:          child.ID,
+		ParentID:    &parent.ID,
+		Name:        "Golang",
+		Description: "All about Go",
+		CreatedAt:   createdAt,
+		UpdatedAt:   createdAt.Add(time.Minute),
+	}, got)
+
+	got, err = engine.LookupCategory(t.Context(), uuid.New())
+	require.NoError(t, err)
+	assert.Nil(t, got)
+
+	categories, err := engine.ListCategories(t.Context())
+	require.NoError(t, err)
+	require.Len(t, categories, 2)
+	assert.Equal(t, "Golang", categories[0].Name)
+	assert.Equal(t, "Programming", categories[1].Name)
+}
+
+func testDeleteCategory(t *testing.T, newEngine NewEngine) {
+	fakeClock := clock_testing.NewFakeClock(time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC))
+	engine := newEngine(t, fakeClock)
+
+	root := &store.Category{ID: uuid.New(), Name: "Programming"}
+	middle := &store.Category{ID: uuid.New(), ParentID: &root.ID, Name: "Languages"}
+	leaf := &store.Category{ID: uuid.New(), ParentID: &middle.ID, Name: "Go"}
+	for _, category := range []*store.Category{root, middle, leaf} {
+		require.NoError(t, engine.SetCategory(t.Context(), category))
+	}
+	post := &store.Post{ID: uuid.New(), Title: "Post", Published: true, CategoryID: &middle.ID}
+	require.NoError(t, engine.SetPost(t.Context(), post))
+
+	posts, err := engine.ListPosts(t.Context(), store.PostQuery{CategoryIDs: []uuid.UUID{middle.ID}, Page: store.Page{Limit: 10}})
+	require.NoError(t, err)
+	assert.Len(t, posts, 1)
+
+	require.NoError(t, engine.DeleteCategory(t.Context(), middle.ID))
+
+	got, err := engine.LookupCategory(t.Context(), middle.ID)
+	require.NoError(t, err)
+	assert.Nil(t, got)
+
+	// Subcategories move to the parent
+	got, err = engine.LookupCategory(t.Context(), leaf.ID)
+	require.NoError(t, err)
+	require.NotNil(t, got.ParentID)
+	assert.Equal(t, root.ID, *got.ParentID)
+
+	// Posts are no longer categorized
+	dbPost, err := engine.LookupPost(t.Context(), post.ID)
+	require.NoError(t, err)
+	assert.Nil(t, dbPost.CategoryID)
+	posts, err = engine.ListPosts(t.Context(), store.PostQuery{CategoryIDs: []uuid.UUID{middle.ID}, Page: store.Page{Limit: 10}})
+	require.NoError(t, err)
+	assert.Empty(t, posts)
+
+	// Deleting a top-level category makes its subcategories top-level
+	require.NoError(t, engine.DeleteCategory(t.Context(), root.ID))
+	got, err = engine.LookupCategory(t.Context(), leaf.ID)
+	require.NoError(t, err)
+	assert.Nil(t, got.ParentID)
+}
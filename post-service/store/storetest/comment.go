@@ -0,0 +1,551 @@
+package storetest
+
+import (
+	"bytes"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/chrishrb/blog-microservice/internal/testutil"
+	"github.com/chrishrb/blog-microservice/post-service/store"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	clock_testing "k8s.io/utils/clock/testing"
+)
+
+func testSetComment(t *testing.T, newEngine NewEngine) {
+	fakeClock := clock_testing.NewFakeClock(time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC))
+	engine := newEngine(t, fakeClock)
+
+	ID := uuid.New()
+	postID := uuid.New()
+	authorID := uuid.New()
+
+	err := engine.SetPost(t.Context(), &store.Post{
+		ID:        postID,
+		AuthorID:  authorID,
+		Title:     "Some Title",
+		Content:   "Some Content",
+		Tags:      []string{"tag1", "tag2"},
+		Published: true,
+	})
+	require.NoError(t, err)
+
+	err = engine.SetComment(t.Context(), &store.Comment{
+		ID:          ID,
+		AuthorID:    authorID,
+		PostID:      postID,
+		Content:     "Some Comment",
+		ContentHTML: "<p>Some Comment</p>",
+	})
+	assert.NoError(t, err)
+
+	comment, err := engine.LookupComment(t.Context(), postID, ID)
+	assert.NoError(t, err)
+	assert.Equal(t, ID, comment.ID)
+	assert.Equal(t, authorID, comment.AuthorID)
+	assert.Equal(t, postID, comment.PostID)
+	assert.Equal(t, "Some Comment", comment.Content)
+	assert.Equal(t, "<p>Some Comment</p>", comment.ContentHTML)
+	assert.Equal(t, fakeClock.Now(), comment.CreatedAt)
+	assert.Equal(t, fakeClock.Now(), comment.UpdatedAt)
+}
+
+func testLookupComment(t *testing.T, newEngine NewEngine) {
+	fakeClock := clock_testing.NewFakeClock(time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC))
+	engine := newEngine(t, fakeClock)
+
+	ID := uuid.New()
+	postID := uuid.New()
+	authorID := uuid.New()
+
+	err := engine.SetPost(t.Context(), &store.Post{
+		ID:       postID,
+		AuthorID: authorID,
+		Title:    "Some Title",
+		Content:  "Some Content",
+	})
+	require.NoError(t, err)
+
+	err = engine.SetComment(t.Context(), &store.Comment{
+		ID:       ID,
+		AuthorID: authorID,
+		PostID:   postID,
+		Content:  "Some Comment",
+	})
+	require.NoError(t, err)
+
+	comment, err := engine.LookupComment(t.Context(), postID, ID)
+	assert.NoError(t, err)
+	assert.NotNil(t, comment)
+	assert.Equal(t, ID, comment.ID)
+	assert.Equal(t, authorID, comment.AuthorID)
+	assert.Equal(t, postID, comment.PostID)
+	assert.Equal(t, "Some Comment", comment.Content)
+	assert.Equal(t, fakeClock.Now(), comment.CreatedAt)
+	assert.Equal(t, fakeClock.Now(), comment.UpdatedAt)
+
+	comment, err = engine.LookupComment(t.Context(), postID, uuid.New())
+	assert.NoError(t, err)
+	assert.Nil(t, comment)
+}
+
+func testListCommentsByPostID(t *testing.T, newEngine NewEngine) {
+	fakeClock := clock_testing.NewFakeClock(time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC))
+	engine := newEngine(t, fakeClock)
+
+	postID := uuid.New()
+	authorID := uuid.New()
+
+	err := engine.SetPost(t.Context(), &store.Post{
+		ID:       postID,
+		AuthorID: authorID,
+		Title:    "Some Title",
+		Content:  "Some Content",
+	})
+	require.NoError(t, err)
+
+	ID1 := uuid.New()
+	err = engine.SetComment(t.Context(), &store.Comment{
+		ID:       ID1,
+		AuthorID: authorID,
+		PostID:   postID,
+		Content:  "First Comment",
+	})
+	require.NoError(t, err)
+
+	ID2 := uuid.New()
+	err = engine.SetComment(t.Context(), &store.Comment{
+		ID:       ID2,
+		AuthorID: authorID,
+		PostID:   postID,
+		Content:  "Second Comment",
+	})
+	require.NoError(t, err)
+
+	comments, err := engine.ListCommentsByPostID(t.Context(), postID, nil, store.Page{Offset: 0, Limit: 100})
+	assert.NoError(t, err)
+	assert.Len(t, comments, 2)
+
+	// Test pagination with limit
+	limitedDatapoints, err := engine.ListCommentsByPostID(t.Context(), postID, nil, store.Page{Offset: 0, Limit: 1})
+	assert.NoError(t, err)
+	assert.Len(t, limitedDatapoints, 1)
+
+	// Test pagination with offset
+	offsetDatapoints, err := engine.ListCommentsByPostID(t.Context(), postID, nil, store.Page{Offset: 1, Limit: 1})
+	assert.NoError(t, err)
+	assert.Len(t, offsetDatapoints, 1)
+
+	// Test nonexistent post
+	comments, err = engine.ListCommentsByPostID(t.Context(), uuid.New(), nil, store.Page{Offset: 0, Limit: 100})
+	assert.NoError(t, err)
+	assert.Nil(t, comments)
+
+}
+
+func testListCommentsByPostID_Cursor(t *testing.T, newEngine NewEngine) {
+	fakeClock := clock_testing.NewFakeClock(time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC))
+	engine := newEngine(t, fakeClock)
+
+	postID := uuid.New()
+	err := engine.SetPost(t.Context(), &store.Post{
+		ID:       postID,
+		AuthorID: uuid.New(),
+		Title:    "Some Title",
+		Content:  "Some Content",
+	})
+	require.NoError(t, err)
+
+	var IDs []uuid.UUID
+	for range 5 {
+		ID := uuid.New()
+		err := engine.SetComment(t.Context(), &store.Comment{
+			ID:       ID,
+			AuthorID: uuid.New(),
+			PostID:   postID,
+			Content:  "Some Comment",
+		})
+		require.NoError(t, err)
+		IDs = append(IDs, ID)
+	}
+
+	// Items with the same creation time are ordered by ID
+	slices.SortFunc(IDs, func(a, b uuid.UUID) int {
+		return bytes.Compare(a[:], b[:])
+	})
+
+	// Walk through all pages using the cursor of the last item
+	var got []uuid.UUID
+	page := store.Page{Limit: 2}
+	for {
+		comments, err := engine.ListCommentsByPostID(t.Context(), postID, nil, page)
+		require.NoError(t, err)
+		if len(comments) == 0 {
+			break
+		}
+		for _, c := range comments {
+			got = append(got, c.ID)
+		}
+		cursor := comments[len(comments)-1].Cursor()
+		page.After = &cursor
+	}
+	assert.Equal(t, IDs, got)
+
+	// Offsets past the end return an empty page
+	comments, err := engine.ListCommentsByPostID(t.Context(), postID, nil, store.Page{Offset: 10, Limit: 2})
+	require.NoError(t, err)
+	assert.Empty(t, comments)
+}
+
+func testDeleteComment(t *testing.T, newEngine NewEngine) {
+	fakeClock := clock_testing.NewFakeClock(time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC))
+	engine := newEngine(t, fakeClock)
+
+	ID := uuid.New()
+	postID := uuid.New()
+	authorID := uuid.New()
+
+	err := engine.SetPost(t.Context(), &store.Post{
+		ID:       postID,
+		AuthorID: authorID,
+		Title:    "Some Title",
+		Content:  "Some Content",
+	})
+	require.NoError(t, err)
+
+	err = engine.SetComment(t.Context(), &store.Comment{
+		ID:       ID,
+		AuthorID: authorID,
+		PostID:   postID,
+		Content:  "Some Comment",
+	})
+	require.NoError(t, err)
+
+	comment, err := engine.LookupComment(t.Context(), postID, ID)
+	assert.NoError(t, err)
+	assert.NotNil(t, comment)
+
+	err = engine.DeleteComment(t.Context(), postID, ID)
+	assert.NoError(t, err)
+
+	comment, err = engine.LookupComment(t.Context(), postID, ID)
+	assert.NoError(t, err)
+	assert.Nil(t, comment)
+
+	_, err = engine.LookupComment(t.Context(), postID, uuid.New())
+	assert.NoError(t, err)
+}
+
+// addComment stores a comment on the post, replying to parent if it is set.
+func addComment(t *testing.T, engine store.Engine, clock *clock_testing.FakeClock, postID uuid.UUID, parent *store.Comment) *store.Comment {
+	t.Helper()
+	clock.Step(time.Second)
+	comment := &store.Comment{
+		ID:       uuid.New(),
+		AuthorID: uuid.New(),
+		PostID:   postID,
+		Content:  "Some Comment",
+	}
+	if parent != nil {
+		comment.ParentID = &parent.ID
+		comment.Depth = parent.Depth + 1
+	}
+	require.NoError(t, engine.SetComment(t.Context(), comment))
+	return comment
+}
+
+func testSetComment_Reply(t *testing.T, newEngine NewEngine) {
+	fakeClock := clock_testing.NewFakeClock(time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC))
+	engine := newEngine(t, fakeClock)
+
+	postID := uuid.New()
+	require.NoError(t, engine.SetPost(t.Context(), &store.Post{ID: postID, AuthorID: uuid.New(), Title: "Some Title"}))
+	parent := addComment(t, engine, fakeClock, postID, nil)
+	reply := addComment(t, engine, fakeClock, postID, parent)
+	addComment(t, engine, fakeClock, postID, reply)
+
+	got, err := engine.LookupComment(t.Context(), postID, parent.ID)
+	require.NoError(t, err)
+	assert.Nil(t, got.ParentID)
+	assert.Equal(t, 0, got.Depth)
+	assert.Equal(t, 1, got.ReplyCount)
+
+	got, err = engine.LookupComment(t.Context(), postID, reply.ID)
+	require.NoError(t, err)
+	assert.Equal(t, &parent.ID, got.ParentID)
+	assert.Equal(t, 1, got.Depth)
+	assert.Equal(t, 1, got.ReplyCount)
+}
+
+func testListCommentThreads(t *testing.T, newEngine NewEngine) {
+	fakeClock := clock_testing.NewFakeClock(time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC))
+	engine := newEngine(t, fakeClock)
+
+	postID := uuid.New()
+	require.NoError(t, engine.SetPost(t.Context(), &store.Post{ID: postID, AuthorID: uuid.New(), Title: "Some Title"}))
+	first := addComment(t, engine, fakeClock, postID, nil)
+	second := addComment(t, engine, fakeClock, postID, nil)
+	firstReply := addComment(t, engine, fakeClock, postID, first)
+	secondReply := addComment(t, engine, fakeClock, postID, second)
+	nestedReply := addComment(t, engine, fakeClock, postID, firstReply)
+
+	ids := func(comments []*store.Comment) []uuid.UUID {
+		var res []uuid.UUID
+		for _, c := range comments {
+			res = append(res, c.ID)
+		}
+		return res
+	}
+
+	// The page applies to top-level comments, replies are always included
+	comments, err := engine.ListCommentThreads(t.Context(), postID, nil, store.Page{Limit: 1})
+	require.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{first.ID, firstReply.ID, nestedReply.ID}, ids(comments))
+	assert.Equal(t, 1, comments[0].ReplyCount)
+
+	comments, err = engine.ListCommentThreads(t.Context(), postID, nil, store.Page{After: testutil.Ptr(first.Cursor()), Limit: 1})
+	require.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{second.ID, secondReply.ID}, ids(comments))
+
+	comments, err = engine.ListCommentThreads(t.Context(), postID, nil, store.Page{Offset: 2, Limit: 1})
+	require.NoError(t, err)
+	assert.Empty(t, comments)
+
+	comments, err = engine.ListCommentThreads(t.Context(), uuid.New(), nil, store.Page{Limit: 10})
+	require.NoError(t, err)
+	assert.Empty(t, comments)
+}
+
+func testDeleteComment_Tombstone(t *testing.T, newEngine NewEngine) {
+	fakeClock := clock_testing.NewFakeClock(time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC))
+	engine := newEngine(t, fakeClock)
+
+	postID := uuid.New()
+	require.NoError(t, engine.SetPost(t.Context(), &store.Post{ID: postID, AuthorID: uuid.New(), Title: "Some Title"}))
+	root := addComment(t, engine, fakeClock, postID, nil)
+	parent := addComment(t, engine, fakeClock, postID, root)
+	reply := addComment(t, engine, fakeClock, postID, parent)
+
+	// Comments with replies are kept as tombstones
+	require.NoError(t, engine.DeleteComment(t.Context(), postID, root.ID))
+	require.NoError(t, engine.DeleteComment(t.Context(), postID, parent.ID))
+	got, err := engine.LookupComment(t.Context(), postID, parent.ID)
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.True(t, got.Deleted)
+	assert.Empty(t, got.Content)
+	assert.Equal(t, 1, got.ReplyCount)
+
+	// Deleting the last reply removes the tombstones above it
+	require.NoError(t, engine.DeleteComment(t.Context(), postID, reply.ID))
+	for _, ID := range []uuid.UUID{root.ID, parent.ID, reply.ID} {
+		got, err := engine.LookupComment(t.Context(), postID, ID)
+		require.NoError(t, err)
+		assert.Nil(t, got)
+	}
+}
+
+// addModeratedComment stores a comment of the author on the post in the
+// moderation state, replying to parent if it is set.
+func addModeratedComment(t *testing.T, engine store.Engine, clock *clock_testing.FakeClock, postID, authorID uuid.UUID, parent *store.Comment, state store.ModerationState) *store.Comment {
+	t.Helper()
+	clock.Step(time.Second)
+	comment := &store.Comment{
+		ID:       uuid.New(),
+		AuthorID: authorID,
+		PostID:   postID,
+		Content:  "Some Comment",
+		State:    state,
+	}
+	if parent != nil {
+		comment.ParentID = &parent.ID
+		comment.Depth = parent.Depth + 1
+	}
+	require.NoError(t, engine.SetComment(t.Context(), comment))
+	return comment
+}
+
+func testSetComment_State(t *testing.T, newEngine NewEngine) {
+	fakeClock := clock_testing.NewFakeClock(time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC))
+	engine := newEngine(t, fakeClock)
+
+	postID := uuid.New()
+	require.NoError(t, engine.SetPost(t.Context(), &store.Post{ID: postID, AuthorID: uuid.New(), Title: "Some Title"}))
+
+	// Comments without a state are approved
+	comment := addComment(t, engine, fakeClock, postID, nil)
+	assert.Equal(t, store.ModerationStateApproved, comment.State)
+
+	comment.State = store.ModerationStateSpam
+	require.NoError(t, engine.SetComment(t.Context(), comment))
+	got, err := engine.LookupComment(t.Context(), postID, comment.ID)
+	require.NoError(t, err)
+	assert.Equal(t, store.ModerationStateSpam, got.State)
+}
+
+func testListComments_Reader(t *testing.T, newEngine NewEngine) {
+	fakeClock := clock_testing.NewFakeClock(time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC))
+	engine := newEngine(t, fakeClock)
+
+	postID := uuid.New()
+	authorID := uuid.New()
+	require.NoError(t, engine.SetPost(t.Context(), &store.Post{ID: postID, AuthorID: uuid.New(), Title: "Some Title"}))
+	approved := addModeratedComment(t, engine, fakeClock, postID, uuid.New(), nil, store.ModerationStateApproved)
+	pending := addModeratedComment(t, engine, fakeClock, postID, authorID, nil, store.ModerationStatePending)
+	rejected := addModeratedComment(t, engine, fakeClock, postID, authorID, nil, store.ModerationStateRejected)
+	pendingReply := addModeratedComment(t, engine, fakeClock, postID, authorID, approved, store.ModerationStatePending)
+	hiddenReply := addModeratedComment(t, engine, fakeClock, postID, uuid.New(), pending, store.ModerationStateApproved)
+
+	ids := func(comments []*store.Comment) []uuid.UUID {
+		var res []uuid.UUID
+		for _, c := range comments {
+			res = append(res, c.ID)
+		}
+		return res
+	}
+
+	tests := []struct {
+		name    string
+		reader  *uuid.UUID
+		list    []uuid.UUID
+		threads []uuid.UUID
+	}{
+		{
+			name:    "anonymous",
+			reader:  &uuid.Nil,
+			list:    []uuid.UUID{approved.ID, hiddenReply.ID},
+			threads: []uuid.UUID{approved.ID},
+		},
+		{
+			name:    "author",
+			reader:  &authorID,
+			list:    []uuid.UUID{approved.ID, pending.ID, pendingReply.ID, hiddenReply.ID},
+			threads: []uuid.UUID{approved.ID, pending.ID, pendingReply.ID, hiddenReply.ID},
+		},
+		{
+			name:    "moderator",
+			list:    []uuid.UUID{approved.ID, pending.ID, rejected.ID, pendingReply.ID, hiddenReply.ID},
+			threads: []uuid.UUID{approved.ID, pending.ID, rejected.ID, pendingReply.ID, hiddenReply.ID},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			comments, err := engine.ListCommentsByPostID(t.Context(), postID, tt.reader, store.Page{Limit: 10})
+			require.NoError(t, err)
+			assert.Equal(t, tt.list, ids(comments))
+
+			comments, err = engine.ListCommentThreads(t.Context(), postID, tt.reader, store.Page{Limit: 10})
+			require.NoError(t, err)
+			assert.Equal(t, tt.threads, ids(comments))
+		})
+	}
+
+	// Only approved replies are counted
+	got, err := engine.LookupComment(t.Context(), postID, approved.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 0, got.ReplyCount)
+}
+
+func testListCommentsByState(t *testing.T, newEngine NewEngine) {
+	fakeClock := clock_testing.NewFakeClock(time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC))
+	engine := newEngine(t, fakeClock)
+
+	postID := uuid.New()
+	otherPostID := uuid.New()
+	require.NoError(t, engine.SetPost(t.Context(), &store.Post{ID: postID, AuthorID: uuid.New(), Title: "Some Title"}))
+	require.NoError(t, engine.SetPost(t.Context(), &store.Post{ID: otherPostID, AuthorID: uuid.New(), Title: "Other Title"}))
+	first := addModeratedComment(t, engine, fakeClock, postID, uuid.New(), nil, store.ModerationStatePending)
+	addModeratedComment(t, engine, fakeClock, postID, uuid.New(), nil, store.ModerationStateApproved)
+	second := addModeratedComment(t, engine, fakeClock, otherPostID, uuid.New(), nil, store.ModerationStatePending)
+
+	comments, err := engine.ListCommentsByState(t.Context(), store.ModerationStatePending, store.Page{Limit: 1})
+	require.NoError(t, err)
+	require.Len(t, comments, 1)
+	assert.Equal(t, first.ID, comments[0].ID)
+
+	comments, err = engine.ListCommentsByState(t.Context(), store.ModerationStatePending,
+		store.Page{After: testutil.Ptr(first.Cursor()), Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, comments, 1)
+	assert.Equal(t, second.ID, comments[0].ID)
+	assert.Equal(t, otherPostID, comments[0].PostID)
+
+	comments, err = engine.ListCommentsByState(t.Context(), store.ModerationStateSpam, store.Page{Limit: 10})
+	require.NoError(t, err)
+	assert.Empty(t, comments)
+}
+
+func testCountApprovedComments(t *testing.T, newEngine NewEngine) {
+	fakeClock := clock_testing.NewFakeClock(time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC))
+	engine := newEngine(t, fakeClock)
+
+	postID := uuid.New()
+	otherPostID := uuid.New()
+	authorID := uuid.New()
+	require.NoError(t, engine.SetPost(t.Context(), &store.Post{ID: postID, AuthorID: uuid.New(), Title: "Some Title"}))
+	require.NoError(t, engine.SetPost(t.Context(), &store.Post{ID: otherPostID, AuthorID: uuid.New(), Title: "Other Title"}))
+	addModeratedComment(t, engine, fakeClock, postID, authorID, nil, store.ModerationStateApproved)
+	addModeratedComment(t, engine, fakeClock, otherPostID, authorID, nil, store.ModerationStateApproved)
+	addModeratedComment(t, engine, fakeClock, postID, authorID, nil, store.ModerationStatePending)
+	addModeratedComment(t, engine, fakeClock, postID, uuid.New(), nil, store.ModerationStateApproved)
+
+	count, err := engine.CountApprovedComments(t.Context(), authorID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	count, err = engine.CountApprovedComments(t.Context(), uuid.New())
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+}
+
+func testSetComment_PostNotFound(t *testing.T, newEngine NewEngine) {
+	fakeClock := clock_testing.NewFakeClock(time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC))
+	engine := newEngine(t, fakeClock)
+
+	postID := uuid.New()
+	comment := &store.Comment{ID: uuid.New(), AuthorID: uuid.New(), PostID: postID, Content: "Some Comment"}
+	err := engine.SetComment(t.Context(), comment)
+	assert.ErrorIs(t, err, store.ErrPostNotFound)
+
+	got, err := engine.LookupComment(t.Context(), postID, comment.ID)
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
+
+func testDeletePost_Comments(t *testing.T, newEngine NewEngine) {
+	fakeClock := clock_testing.NewFakeClock(time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC))
+	engine := newEngine(t, fakeClock)
+
+	postID := uuid.New()
+	otherPostID := uuid.New()
+	require.NoError(t, engine.SetPost(t.Context(), &store.Post{ID: postID, AuthorID: uuid.New(), Title: "Some Title"}))
+	require.NoError(t, engine.SetPost(t.Context(), &store.Post{ID: otherPostID, AuthorID: uuid.New(), Title: "Other Title"}))
+	parent := addModeratedComment(t, engine, fakeClock, postID, uuid.New(), nil, store.ModerationStateApproved)
+	reply := addModeratedComment(t, engine, fakeClock, postID, uuid.New(), parent, store.ModerationStatePending)
+	other := addModeratedComment(t, engine, fakeClock, otherPostID, uuid.New(), nil, store.ModerationStatePending)
+	require.NoError(t, engine.SetReaction(t.Context(), &store.Reaction{
+		Target: store.ReactionTargetComment, TargetID: parent.ID, UserID: uuid.New(), Kind: "like",
+	}))
+
+	require.NoError(t, engine.DeletePost(t.Context(), postID))
+
+	// The comments of the post are gone together with their reactions
+	for _, comment := range []*store.Comment{parent, reply} {
+		got, err := engine.LookupComment(t.Context(), postID, comment.ID)
+		require.NoError(t, err)
+		assert.Nil(t, got)
+	}
+	summaries, err := engine.SummarizeReactions(t.Context(), store.ReactionTargetComment, []uuid.UUID{parent.ID}, uuid.Nil)
+	require.NoError(t, err)
+	assert.Empty(t, summaries[parent.ID].Counts)
+	pending, err := engine.ListCommentsByState(t.Context(), store.ModerationStatePending, store.Page{Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	assert.Equal(t, other.ID, pending[0].ID)
+
+	// Comments cannot be added to the deleted post
+	err = engine.SetComment(t.Context(), &store.Comment{ID: uuid.New(), AuthorID: uuid.New(), PostID: postID, Content: "Late"})
+	assert.ErrorIs(t, err, store.ErrPostNotFound)
+}
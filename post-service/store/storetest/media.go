@@ -0,0 +1,120 @@
+package storetest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/chrishrb/blog-microservice/post-service/store"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	clock_testing "k8s.io/utils/clock/testing"
+)
+
+func testSetMedia(t *testing.T, newEngine NewEngine) {
+	fakeClock := clock_testing.NewFakeClock(time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC))
+	engine := newEngine(t, fakeClock)
+
+	ownerID := uuid.New()
+	media := &store.Media{
+		ID:          uuid.New(),
+		OwnerID:     ownerID,
+		ContentType: "image/png",
+		Size:        1234,
+		Width:       640,
+		Height:      480,
+		Variants:    []string{"thumbnail"},
+	}
+	require.NoError(t, engine.SetMedia(t.Context(), media))
+	createdAt := media.CreatedAt
+
+	fakeClock.Step(time.Minute)
+	media.AltText = "A cat"
+	require.NoError(t, engine.SetMedia(t.Context(), media))
+
+	got, err := engine.LookupMedia(t.Context(), media.ID)
+	require.NoError(t, err)
+	assert.Equal(t, &store.Media{
+		ID:          media.ID,
+		OwnerID:     ownerID,
+		ContentType: "image/png",
+		Size:        1234,
+		AltText:     "A cat",
+		Width:       640,
+		Height:      480,
+		Variants:    []string{"thumbnail"},
+		CreatedAt:   createdAt,
+		UpdatedAt:   createdAt.Add(time.Minute),
+	}, got)
+
+	got, err = engine.LookupMedia(t.Context(), uuid.New())
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
+
+func testListMedia(t *testing.T, newEngine NewEngine) {
+	fakeClock := clock_testing.NewFakeClock(time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC))
+	engine := newEngine(t, fakeClock)
+
+	ownerID := uuid.New()
+	var IDs []uuid.UUID
+	for range 3 {
+		media := &store.Media{ID: uuid.New(), OwnerID: ownerID, ContentType: "image/png"}
+		require.NoError(t, engine.SetMedia(t.Context(), media))
+		IDs = append(IDs, media.ID)
+		fakeClock.Step(time.Second)
+	}
+	other := &store.Media{ID: uuid.New(), OwnerID: uuid.New(), ContentType: "image/png"}
+	require.NoError(t, engine.SetMedia(t.Context(), other))
+
+	first, err := engine.ListMedia(t.Context(), ownerID, store.Page{Limit: 2})
+	require.NoError(t, err)
+	require.Len(t, first, 2)
+	assert.Equal(t, IDs[0], first[0].ID)
+	assert.Equal(t, IDs[1], first[1].ID)
+
+	cursor := first[1].Cursor()
+	second, err := engine.ListMedia(t.Context(), ownerID, store.Page{After: &cursor, Limit: 2})
+	require.NoError(t, err)
+	require.Len(t, second, 1)
+	assert.Equal(t, IDs[2], second[0].ID)
+}
+
+func testDeleteMedia(t *testing.T, newEngine NewEngine) {
+	fakeClock := clock_testing.NewFakeClock(time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC))
+	engine := newEngine(t, fakeClock)
+
+	cover := &store.Media{ID: uuid.New(), ContentType: "image/jpeg"}
+	embedded := &store.Media{ID: uuid.New(), ContentType: "image/png"}
+	for _, media := range []*store.Media{cover, embedded} {
+		require.NoError(t, engine.SetMedia(t.Context(), media))
+	}
+	post := &store.Post{
+		ID:           uuid.New(),
+		Title:        "Post",
+		MediaIDs:     []uuid.UUID{embedded.ID, cover.ID},
+		CoverMediaID: &cover.ID,
+	}
+	require.NoError(t, engine.SetPost(t.Context(), post))
+
+	dbPost, err := engine.LookupPost(t.Context(), post.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{embedded.ID, cover.ID}, dbPost.MediaIDs)
+	require.NotNil(t, dbPost.CoverMediaID)
+	assert.Equal(t, cover.ID, *dbPost.CoverMediaID)
+
+	require.NoError(t, engine.DeleteMedia(t.Context(), cover.ID))
+
+	got, err := engine.LookupMedia(t.Context(), cover.ID)
+	require.NoError(t, err)
+	assert.Nil(t, got)
+
+	// Posts no longer reference the media
+	dbPost, err = engine.LookupPost(t.Context(), post.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{embedded.ID}, dbPost.MediaIDs)
+	assert.Nil(t, dbPost.CoverMediaID)
+
+	// Deleting missing media is not an error
+	require.NoError(t, engine.DeleteMedia(t.Context(), uuid.New()))
+}
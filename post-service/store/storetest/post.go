@@ -0,0 +1,518 @@
+package storetest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/chrishrb/blog-microservice/internal/testutil"
+	"github.com/chrishrb/blog-microservice/post-service/store"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	clock_testing "k8s.io/utils/clock/testing"
+)
+
+func testSetPost(t *testing.T, newEngine NewEngine) {
+	fakeClock := clock_testing.NewFakeClock(time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC))
+	engine := newEngine(t, fakeClock)
+
+	ID := uuid.New()
+	authorID := uuid.New()
+
+	err := engine.SetPost(t.Context(), &store.Post{
+		ID:            ID,
+		AuthorID:      authorID,
+		Title:         "Some Title",
+		Content:       "Some Content",
+		ContentFormat: store.ContentFormatMarkdown,
+		ContentHTML:   "<p>Some Content</p>",
+		Tags:          []string{"tag1", "tag2"},
+		Published:     true,
+	})
+	require.NoError(t, err)
+
+	post, err := engine.LookupPost(t.Context(), ID)
+	require.NoError(t, err)
+	assert.Equal(t, ID, post.ID)
+	assert.Equal(t, authorID, post.AuthorID)
+	assert.Equal(t, "Some Title", post.Title)
+	assert.Equal(t, "some-title", post.Slug)
+	assert.Equal(t, "Some Content", post.Content)
+	assert.Equal(t, store.ContentFormatMarkdown, post.ContentFormat)
+	assert.Equal(t, "<p>Some Content</p>", post.ContentHTML)
+	assert.Equal(t, []string{"tag1", "tag2"}, post.Tags)
+	assert.True(t, post.Published)
+	assert.Equal(t, testutil.Ptr(fakeClock.Now()), post.PublishedAt)
+	assert.Equal(t, fakeClock.Now(), post.CreatedAt)
+	assert.Equal(t, fakeClock.Now(), post.UpdatedAt)
+}
+
+func testSetPost_PublishedAt(t *testing.T, newEngine NewEngine) {
+	fakeClock := clock_testing.NewFakeClock(time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC))
+	engine := newEngine(t, fakeClock)
+
+	// Drafts were never published
+	post := &store.Post{ID: uuid.New(), AuthorID: uuid.New(), Title: "Draft"}
+	require.NoError(t, engine.SetPost(t.Context(), post))
+	assert.Nil(t, post.PublishedAt)
+
+	// Publishing the post sets the time
+	fakeClock.Step(time.Hour)
+	publishedAt := fakeClock.Now()
+	post.Published = true
+	require.NoError(t, engine.SetPost(t.Context(), post))
+	assert.Equal(t, &publishedAt, post.PublishedAt)
+
+	// Further updates and values set by the caller keep it
+	fakeClock.Step(time.Hour)
+	post.PublishedAt = testutil.Ptr(fakeClock.Now().Add(time.Hour))
+	require.NoError(t, engine.SetPost(t.Context(), post))
+	assert.Equal(t, &publishedAt, post.PublishedAt)
+
+	// Unpublishing keeps the time of the last publication and publishing
+	// again replaces it
+	post.Published = false
+	require.NoError(t, engine.SetPost(t.Context(), post))
+	assert.Equal(t, &publishedAt, post.PublishedAt)
+
+	fakeClock.Step(time.Hour)
+	post.Published = true
+	require.NoError(t, engine.SetPost(t.Context(), post))
+	assert.Equal(t, testutil.Ptr(fakeClock.Now()), post.PublishedAt)
+
+	stored, err := engine.LookupPost(t.Context(), post.ID)
+	require.NoError(t, err)
+	assert.Equal(t, post.PublishedAt, stored.PublishedAt)
+}
+
+func testSetPost_Slug(t *testing.T, newEngine NewEngine) {
+	fakeClock := clock_testing.NewFakeClock(time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC))
+	engine := newEngine(t, fakeClock)
+
+	first := &store.Post{ID: uuid.New(), AuthorID: uuid.New(), Title: "Hello, World!"}
+	require.NoError(t, engine.SetPost(t.Context(), first))
+	assert.Equal(t, "hello-world", first.Slug)
+
+	// Another post with the same title gets a suffix
+	second := &store.Post{ID: uuid.New(), AuthorID: uuid.New(), Title: "Hello World"}
+	require.NoError(t, engine.SetPost(t.Context(), second))
+	assert.Equal(t, "hello-world-2", second.Slug)
+
+	// Updates keep the slug
+	second.Content = "Updated"
+	require.NoError(t, engine.SetPost(t.Context(), second))
+	assert.Equal(t, "hello-world-2", second.Slug)
+
+	// The former slug of a renamed post stays reserved
+	first.Title = "Goodbye"
+	first.Slug = "goodbye"
+	require.NoError(t, engine.SetPost(t.Context(), first))
+	assert.Equal(t, "goodbye", first.Slug)
+
+	third := &store.Post{ID: uuid.New(), AuthorID: uuid.New(), Title: "Hello World"}
+	require.NoError(t, engine.SetPost(t.Context(), third))
+	assert.Equal(t, "hello-world-3", third.Slug)
+
+	// A post may return to one of its former slugs
+	first.Slug = "hello-world"
+	require.NoError(t, engine.SetPost(t.Context(), first))
+	assert.Equal(t, "hello-world", first.Slug)
+}
+
+func testLookupPostBySlug(t *testing.T, newEngine NewEngine) {
+	fakeClock := clock_testing.NewFakeClock(time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC))
+	engine := newEngine(t, fakeClock)
+
+	post := &store.Post{ID: uuid.New(), AuthorID: uuid.New(), Title: "Hello World"}
+	require.NoError(t, engine.SetPost(t.Context(), post))
+	post.Title = "Goodbye"
+	post.Slug = "goodbye"
+	require.NoError(t, engine.SetPost(t.Context(), post))
+
+	for _, s := range []string{"goodbye", "hello-world"} {
+		got, err := engine.LookupPostBySlug(t.Context(), s)
+		require.NoError(t, err)
+		require.NotNil(t, got, s)
+		assert.Equal(t, post.ID, got.ID)
+		assert.Equal(t, "goodbye", got.Slug)
+	}
+
+	got, err := engine.LookupPostBySlug(t.Context(), "missing")
+	require.NoError(t, err)
+	assert.Nil(t, got)
+
+	// Deleting the post releases all of its slugs
+	require.NoError(t, engine.DeletePost(t.Context(), post.ID))
+	got, err = engine.LookupPostBySlug(t.Context(), "hello-world")
+	require.NoError(t, err)
+	assert.Nil(t, got)
+
+	other := &store.Post{ID: uuid.New(), AuthorID: uuid.New(), Title: "Hello World"}
+	require.NoError(t, engine.SetPost(t.Context(), other))
+	assert.Equal(t, "hello-world", other.Slug)
+}
+
+func testLookupPost(t *testing.T, newEngine NewEngine) {
+	fakeClock := clock_testing.NewFakeClock(time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC))
+	engine := newEngine(t, fakeClock)
+
+	ID := uuid.New()
+	authorID := uuid.New()
+
+	post := &store.Post{
+		ID:        ID,
+		AuthorID:  authorID,
+		Title:     "Some Title",
+		Content:   "Some Content",
+		Tags:      []string{"tag1", "tag2"},
+		Published: true,
+	}
+	err := engine.SetPost(t.Context(), post)
+	require.NoError(t, err)
+
+	result, err := engine.LookupPost(t.Context(), ID)
+	require.NoError(t, err)
+	assert.Equal(t, post.ID, result.ID)
+	assert.Equal(t, post.AuthorID, result.AuthorID)
+	assert.Equal(t, post.Title, result.Title)
+	assert.Equal(t, post.Content, result.Content)
+	assert.Equal(t, post.Tags, result.Tags)
+	assert.Equal(t, post.Published, result.Published)
+	assert.Equal(t, fakeClock.Now(), result.CreatedAt)
+	assert.Equal(t, fakeClock.Now(), result.UpdatedAt)
+
+	result, err = engine.LookupPost(t.Context(), uuid.New())
+	assert.NoError(t, err)
+	assert.Nil(t, result)
+}
+
+func testListPosts(t *testing.T, newEngine NewEngine) {
+	fakeClock := clock_testing.NewFakeClock(time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC))
+	engine := newEngine(t, fakeClock)
+
+	ID1 := uuid.New()
+	authorID1 := uuid.New()
+	err := engine.SetPost(t.Context(), &store.Post{
+		ID:        ID1,
+		AuthorID:  authorID1,
+		Title:     "Title 1",
+		Content:   "Content 1",
+		Tags:      []string{"tag1"},
+		Published: true,
+	})
+	require.NoError(t, err)
+
+	ID2 := uuid.New()
+	authorID2 := uuid.New()
+	err = engine.SetPost(t.Context(), &store.Post{
+		ID:        ID2,
+		AuthorID:  authorID2,
+		Title:     "Title 2",
+		Content:   "Content 2",
+		Tags:      []string{"tag2"},
+		Published: false,
+	})
+	require.NoError(t, err)
+
+	posts, err := engine.ListPosts(t.Context(), store.PostQuery{Page: store.Page{Offset: 0, Limit: 100}})
+	require.NoError(t, err)
+	assert.Len(t, posts, 2)
+
+	ids := map[uuid.UUID]bool{
+		posts[0].ID: true,
+		posts[1].ID: true,
+	}
+	assert.True(t, ids[ID1])
+	assert.True(t, ids[ID2])
+
+	// Test pagination with limit
+	limitedDatapoints, err := engine.ListPosts(t.Context(), store.PostQuery{Page: store.Page{Offset: 0, Limit: 1}})
+	assert.NoError(t, err)
+	assert.Len(t, limitedDatapoints, 1)
+
+	// Test pagination with offset
+	offsetDatapoints, err := engine.ListPosts(t.Context(), store.PostQuery{Page: store.Page{Offset: 1, Limit: 1}})
+	assert.NoError(t, err)
+	assert.Len(t, offsetDatapoints, 1)
+}
+
+func testListPosts_Cursor(t *testing.T, newEngine NewEngine) {
+	fakeClock := clock_testing.NewFakeClock(time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC))
+	engine := newEngine(t, fakeClock)
+
+	var IDs []uuid.UUID
+	for range 5 {
+		ID := uuid.New()
+		err := engine.SetPost(t.Context(), &store.Post{
+			ID:       ID,
+			AuthorID: uuid.New(),
+			Title:    "Some Title",
+			Content:  "Some Content",
+		})
+		require.NoError(t, err)
+		IDs = append(IDs, ID)
+		fakeClock.Step(time.Second)
+	}
+
+	// Updating a post does not change its position
+	post, err := engine.LookupPost(t.Context(), IDs[0])
+	require.NoError(t, err)
+	post.Title = "Updated Title"
+	require.NoError(t, engine.SetPost(t.Context(), post))
+
+	// Walk through all pages using the cursor of the last item
+	var got []uuid.UUID
+	page := store.Page{Limit: 2}
+	for {
+		posts, err := engine.ListPosts(t.Context(), store.PostQuery{Page: page})
+		require.NoError(t, err)
+		if len(posts) == 0 {
+			break
+		}
+		for _, p := range posts {
+			got = append(got, p.ID)
+		}
+		cursor := posts[len(posts)-1].Cursor()
+		page.After = &cursor
+	}
+	assert.Equal(t, IDs, got)
+
+	// Offsets past the end return an empty page
+	posts, err := engine.ListPosts(t.Context(), store.PostQuery{Page: store.Page{Offset: 10, Limit: 2}})
+	require.NoError(t, err)
+	assert.Empty(t, posts)
+}
+
+func testListPosts_Filter(t *testing.T, newEngine NewEngine) {
+	fakeClock := clock_testing.NewFakeClock(time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC))
+	engine := newEngine(t, fakeClock)
+
+	authorID := uuid.New()
+	posts := []*store.Post{
+		{ID: uuid.New(), AuthorID: authorID, Title: "Post 1", Tags: []string{"go", "sql"}, Published: true},
+		{ID: uuid.New(), AuthorID: authorID, Title: "Post 2", Tags: []string{"go"}, Published: false},
+		{ID: uuid.New(), AuthorID: uuid.New(), Title: "Post 3", Tags: []string{"sql"}, Published: true},
+	}
+	for _, post := range posts {
+		require.NoError(t, engine.SetPost(t.Context(), post))
+		fakeClock.Step(time.Hour)
+	}
+
+	from := time.Date(2023, 1, 1, 13, 0, 0, 0, time.UTC)
+	to := time.Date(2023, 1, 1, 14, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name  string
+		query store.PostQuery
+		want  []uuid.UUID
+	}{
+		{"no filter", store.PostQuery{}, []uuid.UUID{posts[0].ID, posts[1].ID, posts[2].ID}},
+		{"tag", store.PostQuery{Tag: "go"}, []uuid.UUID{posts[0].ID, posts[1].ID}},
+		{"unknown tag", store.PostQuery{Tag: "rust"}, nil},
+		{"author", store.PostQuery{AuthorID: &authorID}, []uuid.UUID{posts[0].ID, posts[1].ID}},
+		{"published", store.PostQuery{Published: testutil.Ptr(true)}, []uuid.UUID{posts[0].ID, posts[2].ID}},
+		{"unpublished", store.PostQuery{Published: testutil.Ptr(false)}, []uuid.UUID{posts[1].ID}},
+		{"created from", store.PostQuery{CreatedFrom: &from}, []uuid.UUID{posts[1].ID, posts[2].ID}},
+		{"created to", store.PostQuery{CreatedTo: &to}, []uuid.UUID{posts[0].ID, posts[1].ID}},
+		{"created range", store.PostQuery{CreatedFrom: &from, CreatedTo: &to}, []uuid.UUID{posts[1].ID}},
+		{"combined", store.PostQuery{Tag: "sql", Published: testutil.Ptr(true), AuthorID: &authorID}, []uuid.UUID{posts[0].ID}},
+		{"reader is author", store.PostQuery{Reader: &authorID}, []uuid.UUID{posts[0].ID, posts[1].ID, posts[2].ID}},
+		{"reader is other user", store.PostQuery{Reader: testutil.Ptr(uuid.New())}, []uuid.UUID{posts[0].ID, posts[2].ID}},
+		{"anonymous reader", store.PostQuery{Reader: &uuid.Nil}, []uuid.UUID{posts[0].ID, posts[2].ID}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.query.Page = store.Page{Limit: 100}
+			result, err := engine.ListPosts(t.Context(), tt.query)
+			require.NoError(t, err)
+
+			var got []uuid.UUID
+			for _, p := range result {
+				got = append(got, p.ID)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func testListPosts_Sort(t *testing.T, newEngine NewEngine) {
+	fakeClock := clock_testing.NewFakeClock(time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC))
+	engine := newEngine(t, fakeClock)
+
+	posts := []*store.Post{
+		{ID: uuid.New(), AuthorID: uuid.New(), Title: "Banana"},
+		{ID: uuid.New(), AuthorID: uuid.New(), Title: "Apple"},
+		{ID: uuid.New(), AuthorID: uuid.New(), Title: "Cherry", Published: true},
+	}
+	for _, post := range posts {
+		require.NoError(t, engine.SetPost(t.Context(), post))
+		fakeClock.Step(time.Hour)
+	}
+
+	// Publish the first post, so it is the most recently updated and
+	// published one
+	posts[0].Published = true
+	require.NoError(t, engine.SetPost(t.Context(), posts[0]))
+
+	tests := []struct {
+		name  string
+		query store.PostQuery
+		want  []uuid.UUID
+	}{
+		{"created at", store.PostQuery{}, []uuid.UUID{posts[0].ID, posts[1].ID, posts[2].ID}},
+		{"created at descending", store.PostQuery{Descending: true}, []uuid.UUID{posts[2].ID, posts[1].ID, posts[0].ID}},
+		{"updated at", store.PostQuery{Sort: store.PostSortUpdatedAt}, []uuid.UUID{posts[1].ID, posts[2].ID, posts[0].ID}},
+		{"published at", store.PostQuery{Sort: store.PostSortPublishedAt}, []uuid.UUID{posts[1].ID, posts[2].ID, posts[0].ID}},
+		{"published at descending", store.PostQuery{Sort: store.PostSortPublishedAt, Descending: true}, []uuid.UUID{posts[0].ID, posts[2].ID, posts[1].ID}},
+		{"title", store.PostQuery{Sort: store.PostSortTitle}, []uuid.UUID{posts[1].ID, posts[0].ID, posts[2].ID}},
+		{"title descending", store.PostQuery{Sort: store.PostSortTitle, Descending: true}, []uuid.UUID{posts[2].ID, posts[0].ID, posts[1].ID}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Walk through all pages using the cursor of the last item
+			var got []uuid.UUID
+			tt.query.Page = store.Page{Limit: 1}
+			for {
+				result, err := engine.ListPosts(t.Context(), tt.query)
+				require.NoError(t, err)
+				if len(result) == 0 {
+					break
+				}
+				got = append(got, result[0].ID)
+				cursor := tt.query.Cursor(result[0])
+				tt.query.Page.After = &cursor
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func testDeletePost(t *testing.T, newEngine NewEngine) {
+	fakeClock := clock_testing.NewFakeClock(time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC))
+	engine := newEngine(t, fakeClock)
+
+	ID := uuid.New()
+	authorID := uuid.New()
+
+	post := &store.Post{
+		ID:       ID,
+		AuthorID: authorID,
+		Title:    "Some Title",
+		Content:  "Some Content",
+	}
+	err := engine.SetPost(t.Context(), post)
+	require.NoError(t, err)
+
+	_, err = engine.LookupPost(t.Context(), ID)
+	require.NoError(t, err)
+
+	err = engine.DeletePost(t.Context(), ID)
+	require.NoError(t, err)
+
+	result, err := engine.LookupPost(t.Context(), ID)
+	assert.NoError(t, err)
+	assert.Nil(t, result)
+
+	err = engine.DeletePost(t.Context(), uuid.New())
+	assert.NoError(t, err)
+	assert.Nil(t, result)
+}
+
+func testSetPost_Update(t *testing.T, newEngine NewEngine) {
+	createdAt := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	fakeClock := clock_testing.NewFakeClock(createdAt)
+	engine := newEngine(t, fakeClock)
+
+	ID := uuid.New()
+	post := &store.Post{
+		ID:       ID,
+		AuthorID: uuid.New(),
+		Title:    "Some Title",
+		Content:  "Some Content",
+		Tags:     []string{"tag1", "tag2"},
+	}
+	err := engine.SetPost(t.Context(), post)
+	require.NoError(t, err)
+
+	fakeClock.Step(time.Hour)
+	post.Title = "Updated Title"
+	post.Tags = []string{"tag3"}
+	post.Published = true
+	err = engine.SetPost(t.Context(), post)
+	require.NoError(t, err)
+
+	result, err := engine.LookupPost(t.Context(), ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Updated Title", result.Title)
+	assert.Equal(t, []string{"tag3"}, result.Tags)
+	assert.True(t, result.Published)
+	assert.Equal(t, createdAt, result.CreatedAt)
+	assert.Equal(t, fakeClock.Now(), result.UpdatedAt)
+}
+
+func testSetPost_Version(t *testing.T, newEngine NewEngine) {
+	fakeClock := clock_testing.NewFakeClock(time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC))
+	engine := newEngine(t, fakeClock)
+
+	post := &store.Post{ID: uuid.New(), AuthorID: uuid.New(), Title: "Post"}
+	require.NoError(t, engine.SetPost(t.Context(), post))
+	assert.Equal(t, int64(1), post.Version)
+
+	// Two editors read the same version
+	first, err := engine.LookupPost(t.Context(), post.ID)
+	require.NoError(t, err)
+	second, err := engine.LookupPost(t.Context(), post.ID)
+	require.NoError(t, err)
+
+	first.Title = "First"
+	require.NoError(t, engine.SetPost(t.Context(), first))
+	assert.Equal(t, int64(2), first.Version)
+
+	// The second editor is rejected and the first change is kept
+	second.Title = "Second"
+	assert.ErrorIs(t, engine.SetPost(t.Context(), second), store.ErrVersionConflict)
+	got, err := engine.LookupPost(t.Context(), post.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "First", got.Title)
+	assert.Equal(t, int64(2), got.Version)
+
+	// Version 0 stores the post unconditionally
+	second.Version = 0
+	require.NoError(t, engine.SetPost(t.Context(), second))
+	assert.Equal(t, int64(3), second.Version)
+
+	// Deleted posts are not recreated by stale versions
+	require.NoError(t, engine.DeletePost(t.Context(), post.ID))
+	assert.ErrorIs(t, engine.SetPost(t.Context(), second), store.ErrVersionConflict)
+	got, err = engine.LookupPost(t.Context(), post.ID)
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
+
+func testPostVersion_ScheduleAndTags(t *testing.T, newEngine NewEngine) {
+	fakeClock := clock_testing.NewFakeClock(time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC))
+	engine := newEngine(t, fakeClock)
+
+	post := &store.Post{
+		ID:        uuid.New(),
+		AuthorID:  uuid.New(),
+		Title:     "Post",
+		Tags:      []string{"golang"},
+		PublishAt: testutil.Ptr(fakeClock.Now().Add(time.Hour)),
+	}
+	require.NoError(t, engine.SetPost(t.Context(), post))
+
+	fakeClock.Step(time.Hour)
+	changed, err := engine.ApplyPostSchedules(t.Context(), fakeClock.Now())
+	require.NoError(t, err)
+	require.Len(t, changed, 1)
+	assert.Equal(t, int64(2), changed[0].Version)
+
+	_, err = engine.MergeTags(t.Context(), []string{"golang"}, "go")
+	require.NoError(t, err)
+	got, err := engine.LookupPost(t.Context(), post.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), got.Version)
+
+	// Editors that read the post before are rejected
+	assert.ErrorIs(t, engine.SetPost(t.Context(), post), store.ErrVersionConflict)
+}
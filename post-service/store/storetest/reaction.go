@@ -0,0 +1,163 @@
+package storetest
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/chrishrb/blog-microservice/post-service/store"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	clock_testing "k8s.io/utils/clock/testing"
+)
+
+func testSetReaction(t *testing.T, newEngine NewEngine) {
+	fakeClock := clock_testing.NewFakeClock(time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC))
+	engine := newEngine(t, fakeClock)
+
+	postID := uuid.New()
+	userID := uuid.New()
+	require.NoError(t, engine.SetPost(t.Context(), &store.Post{ID: postID, AuthorID: uuid.New(), Title: "Some Title"}))
+
+	reaction := &store.Reaction{Target: store.ReactionTargetPost, TargetID: postID, UserID: userID, Kind: store.ReactionLike}
+	require.NoError(t, engine.SetReaction(t.Context(), reaction))
+	assert.Equal(t, fakeClock.Now(), reaction.CreatedAt)
+
+	// Setting the same reaction again has no effect
+	fakeClock.Step(time.Minute)
+	again := &store.Reaction{Target: store.ReactionTargetPost, TargetID: postID, UserID: userID, Kind: store.ReactionLike}
+	require.NoError(t, engine.SetReaction(t.Context(), again))
+	assert.Equal(t, reaction.CreatedAt, again.CreatedAt)
+
+	summaries, err := engine.SummarizeReactions(t.Context(), store.ReactionTargetPost, []uuid.UUID{postID}, userID)
+	require.NoError(t, err)
+	assert.Equal(t, &store.ReactionSummary{Counts: map[string]int{store.ReactionLike: 1}, Mine: store.ReactionLike}, summaries[postID])
+
+	// Another reaction replaces the previous one
+	other := &store.Reaction{Target: store.ReactionTargetPost, TargetID: postID, UserID: userID, Kind: "🎉"}
+	require.NoError(t, engine.SetReaction(t.Context(), other))
+	assert.Equal(t, fakeClock.Now(), other.CreatedAt)
+
+	summaries, err = engine.SummarizeReactions(t.Context(), store.ReactionTargetPost, []uuid.UUID{postID}, userID)
+	require.NoError(t, err)
+	assert.Equal(t, &store.ReactionSummary{Counts: map[string]int{"🎉": 1}, Mine: "🎉"}, summaries[postID])
+}
+
+func testSetReaction_MissingTarget(t *testing.T, newEngine NewEngine) {
+	fakeClock := clock_testing.NewFakeClock(time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC))
+	engine := newEngine(t, fakeClock)
+
+	for _, target := range []store.ReactionTarget{store.ReactionTargetPost, store.ReactionTargetComment} {
+		targetID := uuid.New()
+		reaction := &store.Reaction{Target: target, TargetID: targetID, UserID: uuid.New(), Kind: store.ReactionLike}
+		require.NoError(t, engine.SetReaction(t.Context(), reaction))
+
+		summaries, err := engine.SummarizeReactions(t.Context(), target, []uuid.UUID{targetID}, uuid.Nil)
+		require.NoError(t, err)
+		assert.Empty(t, summaries[targetID].Counts)
+	}
+}
+
+func testDeleteReaction(t *testing.T, newEngine NewEngine) {
+	fakeClock := clock_testing.NewFakeClock(time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC))
+	engine := newEngine(t, fakeClock)
+
+	postID := uuid.New()
+	commentID := uuid.New()
+	userID := uuid.New()
+	require.NoError(t, engine.SetPost(t.Context(), &store.Post{ID: postID, AuthorID: uuid.New(), Title: "Some Title"}))
+	require.NoError(t, engine.SetComment(t.Context(), &store.Comment{ID: commentID, AuthorID: uuid.New(), PostID: postID, Content: "Some Comment"}))
+	require.NoError(t, engine.SetReaction(t.Context(),
+		&store.Reaction{Target: store.ReactionTargetPost, TargetID: postID, UserID: userID, Kind: store.ReactionLike}))
+	require.NoError(t, engine.SetReaction(t.Context(),
+		&store.Reaction{Target: store.ReactionTargetComment, TargetID: commentID, UserID: userID, Kind: store.ReactionLike}))
+
+	// Removing a reaction twice has no effect
+	for range 2 {
+		require.NoError(t, engine.DeleteReaction(t.Context(), store.ReactionTargetPost, postID, userID))
+	}
+
+	summaries, err := engine.SummarizeReactions(t.Context(), store.ReactionTargetPost, []uuid.UUID{postID}, userID)
+	require.NoError(t, err)
+	assert.Equal(t, &store.ReactionSummary{Counts: map[string]int{}}, summaries[postID])
+
+	// Reactions to comments are kept
+	summaries, err = engine.SummarizeReactions(t.Context(), store.ReactionTargetComment, []uuid.UUID{commentID}, userID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, summaries[commentID].Counts[store.ReactionLike])
+
+	// Reactions are removed together with their target
+	require.NoError(t, engine.DeleteComment(t.Context(), postID, commentID))
+	require.NoError(t, engine.SetComment(t.Context(), &store.Comment{ID: commentID, AuthorID: uuid.New(), PostID: postID, Content: "Some Comment"}))
+	summaries, err = engine.SummarizeReactions(t.Context(), store.ReactionTargetComment, []uuid.UUID{commentID}, userID)
+	require.NoError(t, err)
+	assert.Empty(t, summaries[commentID].Counts)
+}
+
+func testSummarizeReactions(t *testing.T, newEngine NewEngine) {
+	fakeClock := clock_testing.NewFakeClock(time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC))
+	engine := newEngine(t, fakeClock)
+
+	first := uuid.New()
+	second := uuid.New()
+	reader := uuid.New()
+	require.NoError(t, engine.SetPost(t.Context(), &store.Post{ID: first, AuthorID: uuid.New(), Title: "First"}))
+	require.NoError(t, engine.SetPost(t.Context(), &store.Post{ID: second, AuthorID: uuid.New(), Title: "Second"}))
+	for _, reaction := range []*store.Reaction{
+		{Target: store.ReactionTargetPost, TargetID: first, UserID: reader, Kind: "❤️"},
+		{Target: store.ReactionTargetPost, TargetID: first, UserID: uuid.New(), Kind: "❤️"},
+		{Target: store.ReactionTargetPost, TargetID: first, UserID: uuid.New(), Kind: store.ReactionLike},
+		{Target: store.ReactionTargetPost, TargetID: second, UserID: uuid.New(), Kind: store.ReactionLike},
+	} {
+		require.NoError(t, engine.SetReaction(t.Context(), reaction))
+	}
+	// Comments are separate targets
+	require.NoError(t, engine.SetComment(t.Context(), &store.Comment{ID: second, AuthorID: uuid.New(), PostID: first, Content: "Some Comment"}))
+	require.NoError(t, engine.SetReaction(t.Context(),
+		&store.Reaction{Target: store.ReactionTargetComment, TargetID: second, UserID: reader, Kind: "🎉"}))
+
+	summaries, err := engine.SummarizeReactions(t.Context(), store.ReactionTargetPost, []uuid.UUID{first, second}, reader)
+	require.NoError(t, err)
+	assert.Equal(t, map[uuid.UUID]*store.ReactionSummary{
+		first:  {Counts: map[string]int{"❤️": 2, store.ReactionLike: 1}, Mine: "❤️"},
+		second: {Counts: map[string]int{store.ReactionLike: 1}},
+	}, summaries)
+
+	// Anonymous readers have no reaction
+	summaries, err = engine.SummarizeReactions(t.Context(), store.ReactionTargetPost, []uuid.UUID{first}, uuid.Nil)
+	require.NoError(t, err)
+	assert.Empty(t, summaries[first].Mine)
+
+	summaries, err = engine.SummarizeReactions(t.Context(), store.ReactionTargetPost, nil, reader)
+	require.NoError(t, err)
+	assert.Empty(t, summaries)
+}
+
+func testSetReaction_Concurrent(t *testing.T, newEngine NewEngine) {
+	fakeClock := clock_testing.NewFakeClock(time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC))
+	engine := newEngine(t, fakeClock)
+
+	postID := uuid.New()
+	require.NoError(t, engine.SetPost(t.Context(), &store.Post{ID: postID, AuthorID: uuid.New(), Title: "Some Title"}))
+
+	// Every user likes the post, changes their mind and likes it again
+	const users = 20
+	var wg sync.WaitGroup
+	for range users {
+		userID := uuid.New()
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for _, kind := range []string{store.ReactionLike, "🎉", store.ReactionLike, store.ReactionLike} {
+				reaction := &store.Reaction{Target: store.ReactionTargetPost, TargetID: postID, UserID: userID, Kind: kind}
+				assert.NoError(t, engine.SetReaction(t.Context(), reaction))
+			}
+		}()
+	}
+	wg.Wait()
+
+	summaries, err := engine.SummarizeReactions(t.Context(), store.ReactionTargetPost, []uuid.UUID{postID}, uuid.Nil)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{store.ReactionLike: users}, summaries[postID].Counts)
+}
@@ -0,0 +1,121 @@
+package storetest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/chrishrb/blog-microservice/post-service/store"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	clock_testing "k8s.io/utils/clock/testing"
+)
+
+func testLookupPostRevision(t *testing.T, newEngine NewEngine) {
+	fakeClock := clock_testing.NewFakeClock(time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC))
+	engine := newEngine(t, fakeClock)
+
+	post := &store.Post{ID: uuid.New(), AuthorID: uuid.New(), Title: "Title", Content: "Content", Tags: []string{"go"}}
+	editorID := uuid.New()
+	first := &store.PostRevision{EditorID: post.AuthorID, Title: "Title", Content: "Content", Tags: []string{"go"}}
+	require.NoError(t, engine.SetPostWithRevision(t.Context(), post, first))
+	assert.Equal(t, 1, first.Number)
+	assert.Equal(t, fakeClock.Now(), first.CreatedAt)
+
+	fakeClock.Step(time.Minute)
+	restoredFrom := 1
+	post.Title = "New title"
+	post.Tags = nil
+	second := &store.PostRevision{EditorID: editorID, Title: "New title", Content: "Content", RestoredFrom: &restoredFrom}
+	require.NoError(t, engine.SetPostWithRevision(t.Context(), post, second))
+	assert.Equal(t, 2, second.Number)
+
+	// Revisions are immutable snapshots
+	first.Title = "Changed"
+	got, err := engine.LookupPostRevision(t.Context(), post.ID, 1)
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Equal(t, post.ID, got.PostID)
+	assert.Equal(t, "Title", got.Title)
+	assert.Equal(t, []string{"go"}, got.Tags)
+	assert.Equal(t, post.AuthorID, got.EditorID)
+	assert.Nil(t, got.RestoredFrom)
+	assert.Equal(t, time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC), got.CreatedAt)
+
+	got, err = engine.LookupPostRevision(t.Context(), post.ID, 2)
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Equal(t, "New title", got.Title)
+	assert.Equal(t, editorID, got.EditorID)
+	assert.Equal(t, &restoredFrom, got.RestoredFrom)
+	assert.Empty(t, got.Tags)
+
+	// Unknown revision
+	got, err = engine.LookupPostRevision(t.Context(), post.ID, 3)
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
+
+func testSetPostWithRevision(t *testing.T, newEngine NewEngine) {
+	fakeClock := clock_testing.NewFakeClock(time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC))
+	engine := newEngine(t, fakeClock)
+
+	post := &store.Post{ID: uuid.New(), AuthorID: uuid.New(), Title: "Title", Content: "Content"}
+	first := &store.PostRevision{EditorID: post.AuthorID, Title: "Title", Content: "Content"}
+	require.NoError(t, engine.SetPostWithRevision(t.Context(), post, first))
+	assert.Equal(t, int64(1), post.Version)
+	assert.Equal(t, "title", post.Slug)
+	assert.Equal(t, post.ID, first.PostID)
+	assert.Equal(t, 1, first.Number)
+	assert.Equal(t, fakeClock.Now(), first.CreatedAt)
+
+	// Neither the post nor the revision is stored on a version conflict
+	stale := *post
+	post.Title = "New title"
+	second := &store.PostRevision{EditorID: post.AuthorID, Title: "New title", Content: "Content"}
+	require.NoError(t, engine.SetPostWithRevision(t.Context(), post, second))
+	assert.Equal(t, 2, second.Number)
+
+	stale.Title = "Stale title"
+	err := engine.SetPostWithRevision(t.Context(), &stale, &store.PostRevision{EditorID: post.AuthorID, Title: "Stale title"})
+	assert.ErrorIs(t, err, store.ErrVersionConflict)
+
+	got, err := engine.LookupPost(t.Context(), post.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "New title", got.Title)
+	revisions, err := engine.ListPostRevisions(t.Context(), post.ID, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, revisions, 2)
+	assert.Equal(t, "New title", revisions[1].Title)
+}
+
+func testListPostRevisions(t *testing.T, newEngine NewEngine) {
+	fakeClock := clock_testing.NewFakeClock(time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC))
+	engine := newEngine(t, fakeClock)
+
+	post := &store.Post{ID: uuid.New(), AuthorID: uuid.New(), Title: "Title"}
+	for range 3 {
+		require.NoError(t, engine.SetPostWithRevision(t.Context(), post, &store.PostRevision{Title: "Title"}))
+	}
+
+	revisions, err := engine.ListPostRevisions(t.Context(), post.ID, 0, 2)
+	require.NoError(t, err)
+	require.Len(t, revisions, 2)
+	assert.Equal(t, 1, revisions[0].Number)
+	assert.Equal(t, 2, revisions[1].Number)
+
+	revisions, err = engine.ListPostRevisions(t.Context(), post.ID, 2, 2)
+	require.NoError(t, err)
+	require.Len(t, revisions, 1)
+	assert.Equal(t, 3, revisions[0].Number)
+
+	revisions, err = engine.ListPostRevisions(t.Context(), post.ID, 3, 2)
+	require.NoError(t, err)
+	assert.Empty(t, revisions)
+
+	// Revisions are deleted together with the post
+	require.NoError(t, engine.DeletePost(t.Context(), post.ID))
+	revisions, err = engine.ListPostRevisions(t.Context(), post.ID, 0, 10)
+	require.NoError(t, err)
+	assert.Empty(t, revisions)
+}
@@ -0,0 +1,73 @@
+package storetest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/chrishrb/blog-microservice/internal/testutil"
+	"github.com/chrishrb/blog-microservice/post-service/store"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	clock_testing "k8s.io/utils/clock/testing"
+)
+
+func testApplyPostSchedules(t *testing.T, newEngine NewEngine) {
+	fakeClock := clock_testing.NewFakeClock(time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC))
+	engine := newEngine(t, fakeClock)
+
+	now := fakeClock.Now()
+	toPublish := &store.Post{ID: uuid.New(), AuthorID: uuid.New(), Title: "To publish", PublishAt: testutil.Ptr(now.Add(time.Hour))}
+	toUnpublish := &store.Post{ID: uuid.New(), AuthorID: uuid.New(), Title: "To unpublish", Published: true, UnpublishAt: testutil.Ptr(now.Add(2 * time.Hour))}
+	unscheduled := &store.Post{ID: uuid.New(), AuthorID: uuid.New(), Title: "Unscheduled"}
+	for _, post := range []*store.Post{toPublish, toUnpublish, unscheduled} {
+		require.NoError(t, engine.SetPost(t.Context(), post))
+	}
+
+	// Nothing is due yet
+	changed, err := engine.ApplyPostSchedules(t.Context(), now)
+	require.NoError(t, err)
+	assert.Empty(t, changed)
+
+	// The first post is published
+	changed, err = engine.ApplyPostSchedules(t.Context(), now.Add(time.Hour))
+	require.NoError(t, err)
+	require.Len(t, changed, 1)
+	assert.Equal(t, toPublish.ID, changed[0].ID)
+	assert.True(t, changed[0].Published)
+	assert.Nil(t, changed[0].PublishAt)
+	assert.Equal(t, testutil.Ptr(now.Add(time.Hour)), changed[0].PublishedAt)
+	assert.Equal(t, now.Add(time.Hour), changed[0].UpdatedAt)
+
+	post, err := engine.LookupPost(t.Context(), toPublish.ID)
+	require.NoError(t, err)
+	assert.True(t, post.Published)
+	assert.Nil(t, post.PublishAt)
+	assert.Equal(t, testutil.Ptr(now.Add(time.Hour)), post.PublishedAt)
+	assert.Equal(t, now.Add(time.Hour), post.UpdatedAt)
+	assert.Equal(t, changed[0].Version, post.Version)
+
+	// Applying a schedule again has no effect
+	changed, err = engine.ApplyPostSchedules(t.Context(), now.Add(time.Hour))
+	require.NoError(t, err)
+	assert.Empty(t, changed)
+
+	// The second post is unpublished, which also applies overdue schedules
+	changed, err = engine.ApplyPostSchedules(t.Context(), now.Add(24*time.Hour))
+	require.NoError(t, err)
+	require.Len(t, changed, 1)
+	assert.Equal(t, toUnpublish.ID, changed[0].ID)
+
+	post, err = engine.LookupPost(t.Context(), toUnpublish.ID)
+	require.NoError(t, err)
+	assert.False(t, post.Published)
+	assert.Nil(t, post.UnpublishAt)
+	assert.Equal(t, testutil.Ptr(now), post.PublishedAt, "the time of the last publication is kept")
+	assert.Equal(t, now.Add(24*time.Hour), post.UpdatedAt)
+
+	// Posts without a due schedule keep their update time
+	post, err = engine.LookupPost(t.Context(), unscheduled.ID)
+	require.NoError(t, err)
+	assert.False(t, post.Published)
+	assert.Equal(t, now, post.UpdatedAt)
+}
@@ -0,0 +1,240 @@
+package storetest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/chrishrb/blog-microservice/internal/testutil"
+	"github.com/chrishrb/blog-microservice/post-service/store"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	clock_testing "k8s.io/utils/clock/testing"
+)
+
+func testSearchPosts(t *testing.T, newEngine NewEngine) {
+	fakeClock := clock_testing.NewFakeClock(time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC))
+	engine := newEngine(t, fakeClock)
+
+	inTitle := &store.Post{
+		ID:       uuid.New(),
+		AuthorID: uuid.New(),
+		Title:    "Running a marathon",
+		Content:  "Some thoughts about training.",
+	}
+	inContent := &store.Post{
+		ID:       uuid.New(),
+		AuthorID: uuid.New(),
+		Title:    "My weekend",
+		Content:  "I went for a run and then I was <running> again.",
+	}
+	other := &store.Post{
+		ID:       uuid.New(),
+		AuthorID: uuid.New(),
+		Title:    "Cooking",
+		Content:  "How to cook pasta.",
+		Tags:     []string{"food"},
+	}
+	for _, post := range []*store.Post{inTitle, inContent, other} {
+		require.NoError(t, engine.SetPost(t.Context(), post))
+	}
+
+	// Matches in the title rank higher
+	results, err := engine.SearchPosts(t.Context(), store.PostSearchQuery{Text: "runs", Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.Equal(t, inTitle.ID, results[0].Post.ID)
+	assert.Equal(t, "Running a marathon", results[0].Post.Title)
+	assert.Equal(t, inContent.ID, results[1].Post.ID)
+	assert.Greater(t, results[0].Score, results[1].Score)
+	assert.Contains(t, results[1].Snippet, "&lt;<mark>running</mark>&gt;")
+
+	// Tags are searchable
+	results, err = engine.SearchPosts(t.Context(), store.PostSearchQuery{Text: "food", Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, other.ID, results[0].Post.ID)
+
+	// All words have to match
+	results, err = engine.SearchPosts(t.Context(), store.PostSearchQuery{Text: "running pasta", Limit: 10})
+	require.NoError(t, err)
+	assert.Empty(t, results)
+
+	// Query operators are treated as text
+	results, err = engine.SearchPosts(t.Context(), store.PostSearchQuery{Text: "pasta\" OR (", Limit: 10})
+	require.NoError(t, err)
+	assert.Len(t, results, 1)
+
+	// Updates are reflected in the index
+	other.Title = "Cooking for runners"
+	require.NoError(t, engine.SetPost(t.Context(), other))
+	results, err = engine.SearchPosts(t.Context(), store.PostSearchQuery{Text: "runner", Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, other.ID, results[0].Post.ID)
+
+	// Deleted posts are removed from the index
+	require.NoError(t, engine.DeletePost(t.Context(), inTitle.ID))
+	results, err = engine.SearchPosts(t.Context(), store.PostSearchQuery{Text: "marathon", Limit: 10})
+	require.NoError(t, err)
+	assert.Empty(t, results)
+
+	// Empty query
+	results, err = engine.SearchPosts(t.Context(), store.PostSearchQuery{Text: "", Limit: 10})
+	require.NoError(t, err)
+	assert.Empty(t, results)
+}
+
+func testSearchPosts_Reader(t *testing.T, newEngine NewEngine) {
+	fakeClock := clock_testing.NewFakeClock(time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC))
+	engine := newEngine(t, fakeClock)
+
+	authorID := uuid.New()
+	published := &store.Post{ID: uuid.New(), AuthorID: uuid.New(), Title: "Running", Published: true}
+	draft := &store.Post{ID: uuid.New(), AuthorID: authorID, Title: "Running again"}
+	for _, post := range []*store.Post{published, draft} {
+		require.NoError(t, engine.SetPost(t.Context(), post))
+	}
+
+	tests := []struct {
+		name   string
+		reader *uuid.UUID
+		want   int
+	}{
+		{"all posts", nil, 2},
+		{"author", &authorID, 2},
+		{"other user", testutil.Ptr(uuid.New()), 1},
+		{"anonymous", &uuid.Nil, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := engine.SearchPosts(t.Context(), store.PostSearchQuery{Text: "running", Reader: tt.reader, Limit: 10})
+			require.NoError(t, err)
+			assert.Len(t, results, tt.want)
+			for _, result := range results {
+				assert.True(t, result.Post.Published || result.Post.AuthorID == authorID)
+			}
+		})
+	}
+}
+
+func testSearchComments(t *testing.T, newEngine NewEngine) {
+	fakeClock := clock_testing.NewFakeClock(time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC))
+	engine := newEngine(t, fakeClock)
+
+	post := &store.Post{ID: uuid.New(), AuthorID: uuid.New(), Title: "Running", Published: true}
+	require.NoError(t, engine.SetPost(t.Context(), post))
+
+	matching := &store.Comment{
+		ID:       uuid.New(),
+		AuthorID: uuid.New(),
+		PostID:   post.ID,
+		Content:  "I was <running> all day.",
+		State:    store.ModerationStateApproved,
+	}
+	other := &store.Comment{
+		ID:       uuid.New(),
+		AuthorID: uuid.New(),
+		PostID:   post.ID,
+		Content:  "I prefer cooking.",
+		State:    store.ModerationStateApproved,
+	}
+	for _, comment := range []*store.Comment{matching, other} {
+		require.NoError(t, engine.SetComment(t.Context(), comment))
+	}
+
+	results, err := engine.SearchComments(t.Context(), store.CommentSearchQuery{Text: "runs", Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, matching.ID, results[0].Comment.ID)
+	assert.Equal(t, post.ID, results[0].Comment.PostID)
+	assert.Greater(t, results[0].Score, 0.0)
+	assert.Contains(t, results[0].Snippet, "&lt;<mark>running</mark>&gt;")
+
+	// Updates are reflected in the index
+	other.Content = "Cooking after running."
+	require.NoError(t, engine.SetComment(t.Context(), other))
+	results, err = engine.SearchComments(t.Context(), store.CommentSearchQuery{Text: "cooking", Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, other.ID, results[0].Comment.ID)
+
+	// Trashed comments are left out until they are restored
+	require.NoError(t, engine.TrashComment(t.Context(), post.ID, other.ID))
+	results, err = engine.SearchComments(t.Context(), store.CommentSearchQuery{Text: "cooking", Limit: 10})
+	require.NoError(t, err)
+	assert.Empty(t, results)
+	require.NoError(t, engine.RestoreComment(t.Context(), post.ID, other.ID))
+	results, err = engine.SearchComments(t.Context(), store.CommentSearchQuery{Text: "cooking", Limit: 10})
+	require.NoError(t, err)
+	assert.Len(t, results, 1)
+
+	// Tombstones are left out
+	require.NoError(t, engine.DeleteComment(t.Context(), post.ID, other.ID))
+	results, err = engine.SearchComments(t.Context(), store.CommentSearchQuery{Text: "cooking", Limit: 10})
+	require.NoError(t, err)
+	assert.Empty(t, results)
+
+	// Comments of trashed posts are left out
+	require.NoError(t, engine.TrashPost(t.Context(), post.ID))
+	results, err = engine.SearchComments(t.Context(), store.CommentSearchQuery{Text: "running", Limit: 10})
+	require.NoError(t, err)
+	assert.Empty(t, results)
+	require.NoError(t, engine.RestorePost(t.Context(), post.ID))
+	results, err = engine.SearchComments(t.Context(), store.CommentSearchQuery{Text: "running", Limit: 10})
+	require.NoError(t, err)
+	assert.Len(t, results, 1)
+
+	// Comments of deleted posts are removed from the index
+	require.NoError(t, engine.DeletePost(t.Context(), post.ID))
+	results, err = engine.SearchComments(t.Context(), store.CommentSearchQuery{Text: "running", Limit: 10})
+	require.NoError(t, err)
+	assert.Empty(t, results)
+}
+
+func testSearchComments_Reader(t *testing.T, newEngine NewEngine) {
+	fakeClock := clock_testing.NewFakeClock(time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC))
+	engine := newEngine(t, fakeClock)
+
+	postAuthorID, commentAuthorID := uuid.New(), uuid.New()
+	published := &store.Post{ID: uuid.New(), AuthorID: uuid.New(), Title: "Published", Published: true}
+	draft := &store.Post{ID: uuid.New(), AuthorID: postAuthorID, Title: "Draft"}
+	for _, post := range []*store.Post{published, draft} {
+		require.NoError(t, engine.SetPost(t.Context(), post))
+	}
+	comments := []*store.Comment{
+		{ID: uuid.New(), AuthorID: uuid.New(), PostID: published.ID, Content: "Running", State: store.ModerationStateApproved},
+		{ID: uuid.New(), AuthorID: commentAuthorID, PostID: published.ID, Content: "Running", State: store.ModerationStatePending},
+		{ID: uuid.New(), AuthorID: uuid.New(), PostID: published.ID, Content: "Running", State: store.ModerationStateSpam},
+		{ID: uuid.New(), AuthorID: uuid.New(), PostID: draft.ID, Content: "Running", State: store.ModerationStateApproved},
+	}
+	for _, comment := range comments {
+		require.NoError(t, engine.SetComment(t.Context(), comment))
+	}
+
+	tests := []struct {
+		name       string
+		postReader *uuid.UUID
+		reader     *uuid.UUID
+		want       int
+	}{
+		{"all comments", nil, nil, 4},
+		{"all comments of visible posts", &uuid.Nil, nil, 3},
+		{"post author", &postAuthorID, &postAuthorID, 2},
+		{"comment author", &commentAuthorID, &commentAuthorID, 2},
+		{"other user", testutil.Ptr(uuid.New()), testutil.Ptr(uuid.New()), 1},
+		{"anonymous", &uuid.Nil, &uuid.Nil, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := engine.SearchComments(t.Context(), store.CommentSearchQuery{
+				Text:       "running",
+				PostReader: tt.postReader,
+				Reader:     tt.reader,
+				Limit:      10,
+			})
+			require.NoError(t, err)
+			assert.Len(t, results, tt.want)
+		})
+	}
+}
@@ -0,0 +1,173 @@
+package storetest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/chrishrb/blog-microservice/post-service/store"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	clock_testing "k8s.io/utils/clock/testing"
+)
+
+func testSetSeries(t *testing.T, newEngine NewEngine) {
+	fakeClock := clock_testing.NewFakeClock(time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC))
+	engine := newEngine(t, fakeClock)
+
+	series := &store.Series{ID: uuid.New(), AuthorID: uuid.New(), Title: "Tutorial", Description: "Step by step"}
+	require.NoError(t, engine.SetSeries(t.Context(), series))
+	createdAt := series.CreatedAt
+
+	fakeClock.Step(time.Minute)
+	series.Title = "Go Tutorial"
+	require.NoError(t, engine.SetSeries(t.Context(), series))
+
+	got, err := engine.LookupSeries(t.Context(), series.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Go Tutorial", got.Title)
+	assert.Equal(t, createdAt, got.CreatedAt)
+	assert.Equal(t, createdAt.Add(time.Minute), got.UpdatedAt)
+
+	got, err = engine.LookupSeries(t.Context(), uuid.New())
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
+
+func testListSeries(t *testing.T, newEngine NewEngine) {
+	fakeClock := clock_testing.NewFakeClock(time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC))
+	engine := newEngine(t, fakeClock)
+
+	author := uuid.New()
+	first := &store.Series{ID: uuid.New(), AuthorID: author, Title: "First"}
+	second := &store.Series{ID: uuid.New(), AuthorID: uuid.New(), Title: "Second"}
+	third := &store.Series{ID: uuid.New(), AuthorID: author, Title: "Third"}
+	for _, series := range []*store.Series{first, second, third} {
+		require.NoError(t, engine.SetSeries(t.Context(), series))
+		fakeClock.Step(time.Minute)
+	}
+
+	list, err := engine.ListSeries(t.Context(), nil, store.Page{Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, list, 3)
+	assert.Equal(t, []uuid.UUID{first.ID, second.ID, third.ID}, []uuid.UUID{list[0].ID, list[1].ID, list[2].ID})
+
+	list, err = engine.ListSeries(t.Context(), &author, store.Page{Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	assert.Equal(t, first.ID, list[0].ID)
+	assert.Equal(t, third.ID, list[1].ID)
+
+	cursor := first.Cursor()
+	list, err = engine.ListSeries(t.Context(), nil, store.Page{Limit: 1, After: &cursor})
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	assert.Equal(t, second.ID, list[0].ID)
+}
+
+func seriesPostIDs(t *testing.T, engine store.Engine, seriesID uuid.UUID) []uuid.UUID {
+	t.Helper()
+	posts, err := engine.ListSeriesPosts(t.Context(), seriesID)
+	require.NoError(t, err)
+	IDs := []uuid.UUID{}
+	for i, post := range posts {
+		require.NotNil(t, post.SeriesID)
+		assert.Equal(t, seriesID, *post.SeriesID)
+		assert.Equal(t, i+1, post.SeriesPosition)
+		IDs = append(IDs, post.ID)
+	}
+	return IDs
+}
+
+func testSeriesPosts(t *testing.T, newEngine NewEngine) {
+	fakeClock := clock_testing.NewFakeClock(time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC))
+	engine := newEngine(t, fakeClock)
+
+	series := &store.Series{ID: uuid.New(), AuthorID: uuid.New(), Title: "Tutorial"}
+	other := &store.Series{ID: uuid.New(), AuthorID: uuid.New(), Title: "Other"}
+	require.NoError(t, engine.SetSeries(t.Context(), series))
+	require.NoError(t, engine.SetSeries(t.Context(), other))
+	var posts []*store.Post
+	for range 4 {
+		post := &store.Post{ID: uuid.New(), Title: "Post", Published: true}
+		require.NoError(t, engine.SetPost(t.Context(), post))
+		posts = append(posts, post)
+	}
+	a, b, c, d := posts[0].ID, posts[1].ID, posts[2].ID, posts[3].ID
+
+	// Out of range positions append
+	require.NoError(t, engine.AddSeriesPost(t.Context(), series.ID, a, 0))
+	require.NoError(t, engine.AddSeriesPost(t.Context(), series.ID, b, 10))
+	assert.Equal(t, []uuid.UUID{a, b}, seriesPostIDs(t, engine, series.ID))
+
+	// Inserting moves the following posts back
+	require.NoError(t, engine.AddSeriesPost(t.Context(), series.ID, c, 1))
+	assert.Equal(t, []uuid.UUID{c, a, b}, seriesPostIDs(t, engine, series.ID))
+
+	// Adding a post of the series moves it
+	require.NoError(t, engine.AddSeriesPost(t.Context(), series.ID, c, 3))
+	assert.Equal(t, []uuid.UUID{a, b, c}, seriesPostIDs(t, engine, series.ID))
+
+	// Updating a post keeps its position
+	posts[1].Title = "Updated"
+	require.NoError(t, engine.SetPost(t.Context(), posts[1]))
+	post, err := engine.LookupPost(t.Context(), b)
+	require.NoError(t, err)
+	require.NotNil(t, post.SeriesID)
+	assert.Equal(t, series.ID, *post.SeriesID)
+	assert.Equal(t, 2, post.SeriesPosition)
+
+	// A post is part of one series only
+	require.NoError(t, engine.AddSeriesPost(t.Context(), other.ID, a, 1))
+	assert.Equal(t, []uuid.UUID{b, c}, seriesPostIDs(t, engine, series.ID))
+	assert.Equal(t, []uuid.UUID{a}, seriesPostIDs(t, engine, other.ID))
+
+	// Missing posts and series are ignored
+	require.NoError(t, engine.AddSeriesPost(t.Context(), series.ID, uuid.New(), 1))
+	require.NoError(t, engine.AddSeriesPost(t.Context(), uuid.New(), d, 1))
+	assert.Equal(t, []uuid.UUID{b, c}, seriesPostIDs(t, engine, series.ID))
+	post, err = engine.LookupPost(t.Context(), d)
+	require.NoError(t, err)
+	assert.Nil(t, post.SeriesID)
+
+	// Removing and deleting posts closes the gaps
+	require.NoError(t, engine.AddSeriesPost(t.Context(), series.ID, d, 1))
+	assert.Equal(t, []uuid.UUID{d, b, c}, seriesPostIDs(t, engine, series.ID))
+	require.NoError(t, engine.RemoveSeriesPost(t.Context(), d))
+	assert.Equal(t, []uuid.UUID{b, c}, seriesPostIDs(t, engine, series.ID))
+	require.NoError(t, engine.DeletePost(t.Context(), b))
+	assert.Equal(t, []uuid.UUID{c}, seriesPostIDs(t, engine, series.ID))
+	require.NoError(t, engine.RemoveSeriesPost(t.Context(), d))
+
+	// Deleting the series keeps its posts
+	require.NoError(t, engine.DeleteSeries(t.Context(), other.ID))
+	assert.Equal(t, []uuid.UUID{}, seriesPostIDs(t, engine, other.ID))
+	post, err = engine.LookupPost(t.Context(), a)
+	require.NoError(t, err)
+	require.NotNil(t, post)
+	assert.Nil(t, post.SeriesID)
+	assert.Zero(t, post.SeriesPosition)
+}
+
+func testReorderSeries(t *testing.T, newEngine NewEngine) {
+	fakeClock := clock_testing.NewFakeClock(time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC))
+	engine := newEngine(t, fakeClock)
+
+	series := &store.Series{ID: uuid.New(), AuthorID: uuid.New(), Title: "Tutorial"}
+	require.NoError(t, engine.SetSeries(t.Context(), series))
+	var IDs []uuid.UUID
+	for range 3 {
+		post := &store.Post{ID: uuid.New(), Title: "Post", Published: true}
+		require.NoError(t, engine.SetPost(t.Context(), post))
+		require.NoError(t, engine.AddSeriesPost(t.Context(), series.ID, post.ID, 0))
+		IDs = append(IDs, post.ID)
+	}
+	a, b, c := IDs[0], IDs[1], IDs[2]
+
+	require.NoError(t, engine.ReorderSeries(t.Context(), series.ID, []uuid.UUID{c, a, b}))
+	assert.Equal(t, []uuid.UUID{c, a, b}, seriesPostIDs(t, engine, series.ID))
+
+	// Posts that are not given follow, unknown posts are ignored
+	require.NoError(t, engine.ReorderSeries(t.Context(), series.ID, []uuid.UUID{b, uuid.New()}))
+	assert.Equal(t, []uuid.UUID{b, c, a}, seriesPostIDs(t, engine, series.ID))
+}
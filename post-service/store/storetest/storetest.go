@@ -0,0 +1,90 @@
+// Package storetest holds the tests every storage engine of the posts has to
+// pass, so that the engines behave the same.
+package storetest
+
+import (
+	"testing"
+
+	"github.com/chrishrb/blog-microservice/post-service/store"
+	"k8s.io/utils/clock"
+)
+
+// NewEngine creates an empty engine for a test that uses the clock for its
+// timestamps.
+type NewEngine func(t *testing.T, clock clock.PassiveClock) store.Engine
+
+// Run runs the tests against engines created by newEngine. Every test creates
+// its own engine.
+func Run(t *testing.T, newEngine NewEngine) {
+	tests := []struct {
+		name string
+		test func(t *testing.T, newEngine NewEngine)
+	}{
+		{"SetPost", testSetPost},
+		{"SetPost_PublishedAt", testSetPost_PublishedAt},
+		{"SetPost_Slug", testSetPost_Slug},
+		{"LookupPostBySlug", testLookupPostBySlug},
+		{"LookupPost", testLookupPost},
+		{"ListPosts", testListPosts},
+		{"ListPosts_Cursor", testListPosts_Cursor},
+		{"ListPosts_Filter", testListPosts_Filter},
+		{"ListPosts_Sort", testListPosts_Sort},
+		{"DeletePost", testDeletePost},
+		{"SetPost_Update", testSetPost_Update},
+		{"SetPost_Version", testSetPost_Version},
+		{"PostVersion_ScheduleAndTags", testPostVersion_ScheduleAndTags},
+		{"ApplyPostSchedules", testApplyPostSchedules},
+		{"LookupPostRevision", testLookupPostRevision},
+		{"SetPostWithRevision", testSetPostWithRevision},
+		{"ListPostRevisions", testListPostRevisions},
+		{"SearchPosts", testSearchPosts},
+		{"SearchPosts_Reader", testSearchPosts_Reader},
+		{"SearchComments", testSearchComments},
+		{"SearchComments_Reader", testSearchComments_Reader},
+		{"SetComment", testSetComment},
+		{"LookupComment", testLookupComment},
+		{"ListCommentsByPostID", testListCommentsByPostID},
+		{"ListCommentsByPostID_Cursor", testListCommentsByPostID_Cursor},
+		{"DeleteComment", testDeleteComment},
+		{"SetComment_Reply", testSetComment_Reply},
+		{"ListCommentThreads", testListCommentThreads},
+		{"DeleteComment_Tombstone", testDeleteComment_Tombstone},
+		{"SetComment_State", testSetComment_State},
+		{"ListComments_Reader", testListComments_Reader},
+		{"ListCommentsByState", testListCommentsByState},
+		{"CountApprovedComments", testCountApprovedComments},
+		{"SetComment_PostNotFound", testSetComment_PostNotFound},
+		{"DeletePost_Comments", testDeletePost_Comments},
+		{"SetReaction", testSetReaction},
+		{"SetReaction_MissingTarget", testSetReaction_MissingTarget},
+		{"DeleteReaction", testDeleteReaction},
+		{"SummarizeReactions", testSummarizeReactions},
+		{"SetReaction_Concurrent", testSetReaction_Concurrent},
+		{"SetBookmark", testSetBookmark},
+		{"SetBookmark_MissingPost", testSetBookmark_MissingPost},
+		{"DeleteBookmark", testDeleteBookmark},
+		{"ListBookmarks", testListBookmarks},
+		{"SetReadingList", testSetReadingList},
+		{"ListReadingLists", testListReadingLists},
+		{"DeleteReadingList", testDeleteReadingList},
+		{"ListTags", testListTags},
+		{"MergeTags", testMergeTags},
+		{"SetSeries", testSetSeries},
+		{"ListSeries", testListSeries},
+		{"SeriesPosts", testSeriesPosts},
+		{"ReorderSeries", testReorderSeries},
+		{"SetCategory", testSetCategory},
+		{"DeleteCategory", testDeleteCategory},
+		{"SetMedia", testSetMedia},
+		{"ListMedia", testListMedia},
+		{"DeleteMedia", testDeleteMedia},
+		{"TrashPost", testTrashPost},
+		{"TrashComment", testTrashComment},
+		{"PurgeTrash", testPurgeTrash},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.test(t, newEngine)
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package storetest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/chrishrb/blog-microservice/post-service/store"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	clock_testing "k8s.io/utils/clock/testing"
+)
+
+func testListTags(t *testing.T, newEngine NewEngine) {
+	fakeClock := clock_testing.NewFakeClock(time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC))
+	engine := newEngine(t, fakeClock)
+
+	for _, post := range []*store.Post{
+		{ID: uuid.New(), Title: "Post 1", Tags: []string{"go", "web"}, Published: true},
+		{ID: uuid.New(), Title: "Post 2", Tags: []string{"go", "golang"}, Published: true},
+		{ID: uuid.New(), Title: "Post 3", Tags: []string{"web", "go"}, Published: true},
+		{ID: uuid.New(), Title: "Draft", Tags: []string{"golang", "drafts"}},
+	} {
+		require.NoError(t, engine.SetPost(t.Context(), post))
+	}
+
+	tests := []struct {
+		name  string
+		query store.TagQuery
+		want  []*store.TagCount
+	}{
+		{
+			name:  "all",
+			query: store.TagQuery{Limit: 10},
+			want:  []*store.TagCount{{Tag: "go", Count: 3}, {Tag: "web", Count: 2}, {Tag: "golang", Count: 1}},
+		},
+		{
+			name:  "prefix",
+			query: store.TagQuery{Prefix: "go", Limit: 10},
+			want:  []*store.TagCount{{Tag: "go", Count: 3}, {Tag: "golang", Count: 1}},
+		},
+		{
+			name:  "limit",
+			query: store.TagQuery{Limit: 1},
+			want:  []*store.TagCount{{Tag: "go", Count: 3}},
+		},
+		{
+			name:  "no match",
+			query: store.TagQuery{Prefix: "rust", Limit: 10},
+			want:  []*store.TagCount{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tags, err := engine.ListTags(t.Context(), tt.query)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, tags)
+		})
+	}
+}
+
+func testMergeTags(t *testing.T, newEngine NewEngine) {
+	fakeClock := clock_testing.NewFakeClock(time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC))
+	engine := newEngine(t, fakeClock)
+
+	first := &store.Post{ID: uuid.New(), Title: "Post 1", Tags: []string{"web", "golang"}, Published: true}
+	second := &store.Post{ID: uuid.New(), Title: "Post 2", Tags: []string{"go", "go-lang", "golang"}, Published: true}
+	third := &store.Post{ID: uuid.New(), Title: "Post 3", Tags: []string{"rust"}, Published: true}
+	for _, post := range []*store.Post{first, second, third} {
+		require.NoError(t, engine.SetPost(t.Context(), post))
+	}
+	updatedAt := first.UpdatedAt
+
+	fakeClock.Step(time.Minute)
+	changed, err := engine.MergeTags(t.Context(), []string{"golang", "go-lang"}, "go")
+	require.NoError(t, err)
+	assert.Equal(t, 2, changed)
+
+	tests := []struct {
+		ID   uuid.UUID
+		want []string
+	}{
+		{first.ID, []string{"web", "go"}},
+		{second.ID, []string{"go"}},
+		{third.ID, []string{"rust"}},
+	}
+	for _, tt := range tests {
+		post, err := engine.LookupPost(t.Context(), tt.ID)
+		require.NoError(t, err)
+		assert.Equal(t, tt.want, post.Tags)
+	}
+
+	post, err := engine.LookupPost(t.Context(), first.ID)
+	require.NoError(t, err)
+	assert.Equal(t, updatedAt, post.UpdatedAt)
+
+	// Merged tags are found by the tag filter and the search
+	posts, err := engine.ListPosts(t.Context(), store.PostQuery{Tag: "go", Page: store.Page{Limit: 10}})
+	require.NoError(t, err)
+	assert.Len(t, posts, 2)
+	posts, err = engine.ListPosts(t.Context(), store.PostQuery{Tag: "golang", Page: store.Page{Limit: 10}})
+	require.NoError(t, err)
+	assert.Empty(t, posts)
+
+	results, err := engine.SearchPosts(t.Context(), store.PostSearchQuery{Text: "golang", Limit: 10})
+	require.NoError(t, err)
+	assert.Empty(t, results)
+
+	// Renaming is a merge of a single tag
+	changed, err = engine.MergeTags(t.Context(), []string{"rust"}, "rustlang")
+	require.NoError(t, err)
+	assert.Equal(t, 1, changed)
+	post, err = engine.LookupPost(t.Context(), third.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"rustlang"}, post.Tags)
+
+	// Merging tags nobody uses changes nothing
+	changed, err = engine.MergeTags(t.Context(), []string{"missing"}, "go")
+	require.NoError(t, err)
+	assert.Zero(t, changed)
+}
@@ -0,0 +1,199 @@
+package storetest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/chrishrb/blog-microservice/internal/testutil"
+	"github.com/chrishrb/blog-microservice/post-service/store"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	clock_testing "k8s.io/utils/clock/testing"
+)
+
+func testTrashPost(t *testing.T, newEngine NewEngine) {
+	fakeClock := clock_testing.NewFakeClock(time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC))
+	engine := newEngine(t, fakeClock)
+
+	authorID := uuid.New()
+	postID := uuid.New()
+	seriesID := uuid.New()
+	require.NoError(t, engine.SetPost(t.Context(), &store.Post{
+		ID: postID, AuthorID: authorID, Title: "Some Title", Content: "Running fast", Published: true,
+	}))
+	require.NoError(t, engine.SetSeries(t.Context(), &store.Series{ID: seriesID, AuthorID: authorID, Title: "Some Series"}))
+	require.NoError(t, engine.AddSeriesPost(t.Context(), seriesID, postID, 0))
+	comment := addModeratedComment(t, engine, fakeClock, postID, uuid.New(), nil, store.ModerationStateApproved)
+
+	fakeClock.Step(time.Minute)
+	require.NoError(t, engine.TrashPost(t.Context(), postID))
+
+	// The post and its comments are hidden from all reads
+	post, err := engine.LookupPost(t.Context(), postID)
+	require.NoError(t, err)
+	assert.Nil(t, post)
+	posts, err := engine.ListPosts(t.Context(), store.PostQuery{Page: store.Page{Limit: 10}})
+	require.NoError(t, err)
+	assert.Empty(t, posts)
+	results, err := engine.SearchPosts(t.Context(), store.PostSearchQuery{Text: "running", Limit: 10})
+	require.NoError(t, err)
+	assert.Empty(t, results)
+	got, err := engine.LookupComment(t.Context(), postID, comment.ID)
+	require.NoError(t, err)
+	assert.Nil(t, got)
+	count, err := engine.CountApprovedComments(t.Context(), comment.AuthorID)
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+	seriesPosts, err := engine.ListSeriesPosts(t.Context(), seriesID)
+	require.NoError(t, err)
+	assert.Empty(t, seriesPosts)
+	err = engine.SetComment(t.Context(), &store.Comment{ID: uuid.New(), AuthorID: uuid.New(), PostID: postID, Content: "Late"})
+	assert.ErrorIs(t, err, store.ErrPostNotFound)
+
+	// The post is in the trash
+	post, err = engine.LookupTrashedPost(t.Context(), postID)
+	require.NoError(t, err)
+	require.NotNil(t, post)
+	require.NotNil(t, post.DeletedAt)
+	assert.Equal(t, fakeClock.Now(), *post.DeletedAt)
+	posts, err = engine.ListTrashedPosts(t.Context(), &authorID, store.Page{Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, posts, 1)
+	assert.Equal(t, postID, posts[0].ID)
+	posts, err = engine.ListTrashedPosts(t.Context(), testutil.Ptr(uuid.New()), store.Page{Limit: 10})
+	require.NoError(t, err)
+	assert.Empty(t, posts)
+
+	// Restoring brings back the post and its comments, but not its place in
+	// the series
+	require.NoError(t, engine.RestorePost(t.Context(), postID))
+	post, err = engine.LookupPost(t.Context(), postID)
+	require.NoError(t, err)
+	require.NotNil(t, post)
+	assert.Nil(t, post.DeletedAt)
+	assert.Nil(t, post.SeriesID)
+	results, err = engine.SearchPosts(t.Context(), store.PostSearchQuery{Text: "running", Limit: 10})
+	require.NoError(t, err)
+	assert.Len(t, results, 1)
+	got, err = engine.LookupComment(t.Context(), postID, comment.ID)
+	require.NoError(t, err)
+	assert.NotNil(t, got)
+	post, err = engine.LookupTrashedPost(t.Context(), postID)
+	require.NoError(t, err)
+	assert.Nil(t, post)
+}
+
+func testTrashComment(t *testing.T, newEngine NewEngine) {
+	fakeClock := clock_testing.NewFakeClock(time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC))
+	engine := newEngine(t, fakeClock)
+
+	postID := uuid.New()
+	authorID := uuid.New()
+	require.NoError(t, engine.SetPost(t.Context(), &store.Post{ID: postID, AuthorID: uuid.New(), Title: "Some Title"}))
+	parent := addModeratedComment(t, engine, fakeClock, postID, authorID, nil, store.ModerationStateApproved)
+	reply := addModeratedComment(t, engine, fakeClock, postID, authorID, parent, store.ModerationStateApproved)
+
+	fakeClock.Step(time.Minute)
+	require.NoError(t, engine.TrashComment(t.Context(), postID, reply.ID))
+	require.NoError(t, engine.TrashComment(t.Context(), postID, parent.ID))
+
+	// The reply is hidden and the parent it leaves behind reads as a
+	// tombstone, as it still has a reply in the trash
+	got, err := engine.LookupComment(t.Context(), postID, reply.ID)
+	require.NoError(t, err)
+	assert.Nil(t, got)
+	got, err = engine.LookupComment(t.Context(), postID, parent.ID)
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.True(t, got.Deleted)
+	assert.Empty(t, got.Content)
+	assert.Equal(t, 0, got.ReplyCount)
+	comments, err := engine.ListCommentThreads(t.Context(), postID, nil, store.Page{Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, comments, 1)
+	assert.Equal(t, parent.ID, comments[0].ID)
+	count, err := engine.CountApprovedComments(t.Context(), authorID)
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+
+	// Both are in the trash with their content
+	got, err = engine.LookupTrashedComment(t.Context(), postID, parent.ID)
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.False(t, got.Deleted)
+	assert.Equal(t, "Some Comment", got.Content)
+	require.NotNil(t, got.DeletedAt)
+	assert.Equal(t, fakeClock.Now(), *got.DeletedAt)
+	comments, err = engine.ListTrashedComments(t.Context(), &authorID, store.Page{Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, comments, 2)
+	assert.Equal(t, parent.ID, comments[0].ID)
+	assert.Equal(t, reply.ID, comments[1].ID)
+	comments, err = engine.ListTrashedComments(t.Context(), testutil.Ptr(uuid.New()), store.Page{Limit: 10})
+	require.NoError(t, err)
+	assert.Empty(t, comments)
+
+	// Restoring brings back the comment with its content
+	require.NoError(t, engine.RestoreComment(t.Context(), postID, reply.ID))
+	got, err = engine.LookupComment(t.Context(), postID, reply.ID)
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Equal(t, "Some Comment", got.Content)
+	assert.Nil(t, got.DeletedAt)
+	got, err = engine.LookupComment(t.Context(), postID, parent.ID)
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Equal(t, 1, got.ReplyCount)
+	got, err = engine.LookupTrashedComment(t.Context(), postID, reply.ID)
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
+
+func testPurgeTrash(t *testing.T, newEngine NewEngine) {
+	fakeClock := clock_testing.NewFakeClock(time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC))
+	engine := newEngine(t, fakeClock)
+
+	duePostID := uuid.New()
+	postID := uuid.New()
+	require.NoError(t, engine.SetPost(t.Context(), &store.Post{ID: duePostID, AuthorID: uuid.New(), Title: "Due Title"}))
+	require.NoError(t, engine.SetPost(t.Context(), &store.Post{ID: postID, AuthorID: uuid.New(), Title: "Some Title"}))
+	addModeratedComment(t, engine, fakeClock, duePostID, uuid.New(), nil, store.ModerationStateApproved)
+	parent := addModeratedComment(t, engine, fakeClock, postID, uuid.New(), nil, store.ModerationStateApproved)
+	reply := addModeratedComment(t, engine, fakeClock, postID, uuid.New(), parent, store.ModerationStateApproved)
+	other := addModeratedComment(t, engine, fakeClock, postID, uuid.New(), nil, store.ModerationStateApproved)
+
+	require.NoError(t, engine.TrashPost(t.Context(), duePostID))
+	require.NoError(t, engine.TrashComment(t.Context(), postID, parent.ID))
+	fakeClock.Step(time.Hour)
+	before := fakeClock.Now()
+	require.NoError(t, engine.TrashComment(t.Context(), postID, other.ID))
+
+	purged, err := engine.PurgeTrash(t.Context(), before)
+	require.NoError(t, err)
+	assert.Equal(t, 2, purged)
+
+	// The due post is gone for good, the due comment with a reply is a
+	// tombstone that is no longer in the trash
+	post, err := engine.LookupTrashedPost(t.Context(), duePostID)
+	require.NoError(t, err)
+	assert.Nil(t, post)
+	got, err := engine.LookupComment(t.Context(), postID, parent.ID)
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.True(t, got.Deleted)
+	assert.Nil(t, got.DeletedAt)
+	got, err = engine.LookupComment(t.Context(), postID, reply.ID)
+	require.NoError(t, err)
+	assert.NotNil(t, got)
+
+	// The comment trashed later is kept
+	comments, err := engine.ListTrashedComments(t.Context(), nil, store.Page{Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, comments, 1)
+	assert.Equal(t, other.ID, comments[0].ID)
+
+	purged, err = engine.PurgeTrash(t.Context(), before)
+	require.NoError(t, err)
+	assert.Equal(t, 0, purged)
+}
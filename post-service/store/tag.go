@@ -0,0 +1,49 @@
+package store
+
+import (
+	"context"
+	"slices"
+)
+
+// TagCount is a tag together with the number of published posts carrying it.
+type TagCount struct {
+	Tag   string
+	Count int
+}
+
+// TagQuery selects the tags of the catalog. Tags are sorted by their number
+// of posts, most used first, and ties are broken by the tag.
+type TagQuery struct {
+	// Prefix restricts the catalog to tags starting with it.
+	Prefix string
+	Limit  int
+}
+
+type TagStore interface {
+	// ListTags returns the tags of published posts with their number of
+	// published posts.
+	ListTags(ctx context.Context, query TagQuery) ([]*TagCount, error)
+	// MergeTags replaces the source tags by the target tag across all posts
+	// in a single transaction. A post carries the target at most once, at
+	// the first position of the target or a source tag. Renaming a tag is a
+	// merge of a single source. The update times of the posts are kept. It
+	// returns the number of changed posts.
+	MergeTags(ctx context.Context, sources []string, target string) (int, error)
+}
+
+// ReplaceTags returns the tags with the source tags replaced by the target,
+// see MergeTags. It reports whether any source tag was replaced.
+func ReplaceTags(tags, sources []string, target string) ([]string, bool) {
+	replaced := false
+	var result []string
+	for _, t := range tags {
+		if slices.Contains(sources, t) {
+			t = target
+			replaced = true
+		}
+		if !slices.Contains(result, t) {
+			result = append(result, t)
+		}
+	}
+	return result, replaced
+}
@@ -0,0 +1,45 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TrashStore keeps deleted posts and comments for a while, so that they can
+// be restored, before they are purged for good.
+//
+// Trashed posts are left out of all other reads together with their
+// comments, as if they were deleted. A trashed comment is read as a tombstone
+// while it has replies and left out otherwise, see DeleteComment.
+type TrashStore interface {
+	// TrashPost moves the post to the trash and removes it from its series.
+	// Posts that do not exist or are trashed already are ignored.
+	TrashPost(ctx context.Context, ID uuid.UUID) error
+	// LookupTrashedPost returns the post if it is in the trash.
+	LookupTrashedPost(ctx context.Context, ID uuid.UUID) (*Post, error)
+	// ListTrashedPosts returns a page of the trashed posts ordered by creation
+	// time. If authorID is set only the posts of the author are listed.
+	ListTrashedPosts(ctx context.Context, authorID *uuid.UUID, page Page) ([]*Post, error)
+	// RestorePost moves the post out of the trash.
+	RestorePost(ctx context.Context, ID uuid.UUID) error
+
+	// TrashComment moves the comment to the trash. Comments that do not exist
+	// or are trashed already are ignored.
+	TrashComment(ctx context.Context, postID, ID uuid.UUID) error
+	// LookupTrashedComment returns the comment with its content if it is in
+	// the trash and its post is not.
+	LookupTrashedComment(ctx context.Context, postID, ID uuid.UUID) (*Comment, error)
+	// ListTrashedComments returns a page of the trashed comments of posts
+	// that are not trashed, ordered by creation time. If authorID is set only
+	// the comments of the author are listed.
+	ListTrashedComments(ctx context.Context, authorID *uuid.UUID, page Page) ([]*Comment, error)
+	// RestoreComment moves the comment out of the trash.
+	RestoreComment(ctx context.Context, postID, ID uuid.UUID) error
+
+	// PurgeTrash deletes the posts and comments that were trashed before the
+	// time, see DeletePost and DeleteComment, and returns how many posts and
+	// comments were purged.
+	PurgeTrash(ctx context.Context, before time.Time) (int, error)
+}
@@ -0,0 +1,33 @@
+// Package tag normalizes the tags of posts, so that tags which only differ in
+// case or whitespace are the same tag.
+package tag
+
+import (
+	"strings"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Normalize returns the canonical form of a tag. Tags are lower case and in
+// Unicode normal form C, leading and trailing whitespace is removed and every
+// other run of whitespace becomes a single hyphen. The canonical form of a
+// tag without any other characters is empty.
+func Normalize(tag string) string {
+	return strings.Join(strings.Fields(strings.ToLower(norm.NFC.String(tag))), "-")
+}
+
+// NormalizeAll returns the canonical forms of the tags in their original
+// order. Empty tags and duplicates are dropped.
+func NormalizeAll(tags []string) []string {
+	var normalized []string
+	seen := make(map[string]bool, len(tags))
+	for _, t := range tags {
+		t = Normalize(t)
+		if t == "" || seen[t] {
+			continue
+		}
+		seen[t] = true
+		normalized = append(normalized, t)
+	}
+	return normalized
+}
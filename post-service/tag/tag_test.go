@@ -0,0 +1,36 @@
+package tag_test
+
+import (
+	"testing"
+
+	"github.com/chrishrb/blog-microservice/post-service/tag"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		name string
+		tag  string
+		want string
+	}{
+		{"lower case", "Go", "go"},
+		{"surrounding whitespace", "  golang \t", "golang"},
+		{"inner whitespace", "web   Development", "web-development"},
+		{"hyphens are kept", "e-mail", "e-mail"},
+		{"unicode", "ÜBERSICHT", "übersicht"},
+		{"composed form", "cafe\u0301", "caf\u00e9"},
+		{"only whitespace", " \n ", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tag.Normalize(tt.tag))
+		})
+	}
+}
+
+func TestNormalizeAll(t *testing.T) {
+	got := tag.NormalizeAll([]string{"Go", "golang", "go ", " ", "Web Development", "GOLANG"})
+	assert.Equal(t, []string{"go", "golang", "web-development"}, got)
+
+	assert.Nil(t, tag.NormalizeAll(nil))
+}
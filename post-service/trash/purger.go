@@ -0,0 +1,65 @@
+// Package trash purges posts and comments that were kept in the trash for
+// longer than the retention period.
+package trash
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/chrishrb/blog-microservice/post-service/store"
+	"k8s.io/utils/clock"
+)
+
+// Purger periodically deletes the posts and comments that were trashed more
+// than the retention period ago. The trash is kept in the store, so items
+// that became due while the service was down are purged as soon as it runs
+// again.
+type Purger struct {
+	engine    store.TrashStore
+	clock     clock.Clock
+	interval  time.Duration
+	retention time.Duration
+}
+
+func New(engine store.TrashStore, clock clock.Clock, interval, retention time.Duration) *Purger {
+	return &Purger{
+		engine:    engine,
+		clock:     clock,
+		interval:  interval,
+		retention: retention,
+	}
+}
+
+// Run purges the due items immediately and then every interval until the
+// context is cancelled.
+func (p *Purger) Run(ctx context.Context) {
+	timer := p.clock.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C():
+		}
+
+		if _, err := p.PurgeDue(ctx); err != nil {
+			slog.Error("purging trash", "err", err)
+		}
+		timer.Reset(p.interval)
+	}
+}
+
+// PurgeDue deletes the items that were trashed more than the retention
+// period ago and returns how many were purged.
+func (p *Purger) PurgeDue(ctx context.Context) (int, error) {
+	purged, err := p.engine.PurgeTrash(ctx, p.clock.Now().Add(-p.retention))
+	if err != nil {
+		return 0, err
+	}
+	if purged > 0 {
+		slog.Info("purged trash", slog.Int("count", purged))
+	}
+	return purged, nil
+}
@@ -0,0 +1,80 @@
+package trash_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/chrishrb/blog-microservice/post-service/store"
+	"github.com/chrishrb/blog-microservice/post-service/store/inmemory"
+	"github.com/chrishrb/blog-microservice/post-service/trash"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	clock_testing "k8s.io/utils/clock/testing"
+)
+
+func TestPurger_Run(t *testing.T) {
+	fakeClock := clock_testing.NewFakeClock(time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC))
+	engine := inmemory.NewStore(fakeClock)
+
+	postID := uuid.New()
+	require.NoError(t, engine.SetPost(t.Context(), &store.Post{ID: postID, AuthorID: uuid.New(), Title: "Trashed"}))
+	require.NoError(t, engine.TrashPost(t.Context(), postID))
+
+	ctx, cancel := context.WithCancel(t.Context())
+	done := make(chan struct{})
+	go func() {
+		trash.New(engine, fakeClock, time.Minute, 90*time.Second).Run(ctx)
+		close(done)
+	}()
+	defer func() {
+		cancel()
+		<-done
+	}()
+
+	isPurged := func() bool {
+		p, err := engine.LookupTrashedPost(t.Context(), postID)
+		require.NoError(t, err)
+		return p == nil
+	}
+
+	// Not yet due at the first tick
+	require.Eventually(t, fakeClock.HasWaiters, time.Second, time.Millisecond)
+	fakeClock.Step(time.Minute)
+	require.Eventually(t, fakeClock.HasWaiters, time.Second, time.Millisecond)
+	assert.False(t, isPurged())
+
+	// Purged at the second tick
+	fakeClock.Step(time.Minute)
+	assert.Eventually(t, isPurged, time.Second, time.Millisecond)
+}
+
+func TestPurger_PurgeDue(t *testing.T) {
+	fakeClock := clock_testing.NewFakeClock(time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC))
+	engine := inmemory.NewStore(fakeClock)
+
+	postID := uuid.New()
+	commentID := uuid.New()
+	require.NoError(t, engine.SetPost(t.Context(), &store.Post{ID: postID, AuthorID: uuid.New(), Title: "Some Title"}))
+	require.NoError(t, engine.SetComment(t.Context(), &store.Comment{
+		ID: commentID, PostID: postID, AuthorID: uuid.New(), Content: "Some Comment",
+	}))
+	require.NoError(t, engine.TrashComment(t.Context(), postID, commentID))
+
+	p := trash.New(engine, fakeClock, time.Minute, 24*time.Hour)
+
+	// Nothing is due within the retention period
+	purged, err := p.PurgeDue(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, 0, purged)
+
+	fakeClock.Step(24*time.Hour + time.Second)
+	purged, err = p.PurgeDue(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, 1, purged)
+
+	comment, err := engine.LookupTrashedComment(t.Context(), postID, commentID)
+	require.NoError(t, err)
+	assert.Nil(t, comment)
+}
@@ -26,8 +26,9 @@ const (
 
 // Defines values for UserRole.
 const (
-	UserRoleAdmin UserRole = "admin"
-	UserRoleUser  UserRole = "user"
+	UserRoleAdmin     UserRole = "admin"
+	UserRoleModerator UserRole = "moderator"
+	UserRoleUser      UserRole = "user"
 )
 
 // Defines values for UserStatus.
@@ -39,8 +40,9 @@ const (
 
 // Defines values for UserUpdateRole.
 const (
-	UserUpdateRoleAdmin UserUpdateRole = "admin"
-	UserUpdateRoleUser  UserUpdateRole = "user"
+	UserUpdateRoleAdmin     UserUpdateRole = "admin"
+	UserUpdateRoleModerator UserUpdateRole = "moderator"
+	UserUpdateRoleUser      UserUpdateRole = "user"
 )
 
 // Defines values for UserUpdateStatus.
@@ -118,6 +120,9 @@ type User struct {
 
 	// Status User's account status
 	Status UserStatus `json:"status"`
+
+	// Version Version of the user, incremented on every change
+	Version int64 `json:"version"`
 }
 
 // UserRole User's role in the system
@@ -141,6 +146,14 @@ type UserCreate struct {
 	Password string `json:"password"`
 }
 
+// UserList defines model for UserList.
+type UserList struct {
+	Items []User `json:"items"`
+
+	// NextCursor Cursor of the next page, missing on the last page
+	NextCursor *string `json:"nextCursor,omitempty"`
+}
+
 // UserUpdate defines model for UserUpdate.
 type UserUpdate struct {
 	// Email User's email address
@@ -183,6 +196,9 @@ type UserUpdateCurrent struct {
 	Password *string `json:"password,omitempty"`
 }
 
+// IfMatch defines model for IfMatch.
+type IfMatch = string
+
 // BadRequest defines model for BadRequest.
 type BadRequest = Error
 
@@ -192,6 +208,12 @@ type Forbidden = Error
 // NotFound defines model for NotFound.
 type NotFound = Error
 
+// PreconditionFailed defines model for PreconditionFailed.
+type PreconditionFailed = Error
+
+// PreconditionRequired defines model for PreconditionRequired.
+type PreconditionRequired = Error
+
 // ServerError defines model for ServerError.
 type ServerError = Error
 
@@ -200,10 +222,26 @@ type Unauthorized = Error
 
 // ListUsersParams defines parameters for ListUsers.
 type ListUsersParams struct {
+	// Cursor Opaque cursor returned as nextCursor by the previous page. If set, offset is ignored.
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Offset Number of items to skip, kept for compatibility. Prefer cursor.
 	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
 	Limit  *int `form:"limit,omitempty" json:"limit,omitempty"`
 }
 
+// UpdateCurrentUserParams defines parameters for UpdateCurrentUser.
+type UpdateCurrentUserParams struct {
+	// IfMatch ETag of the version of the user the change is based on
+	IfMatch *IfMatch `json:"If-Match,omitempty"`
+}
+
+// UpdateUserParams defines parameters for UpdateUser.
+type UpdateUserParams struct {
+	// IfMatch ETag of the version of the user the change is based on
+	IfMatch *IfMatch `json:"If-Match,omitempty"`
+}
+
 // LoginUserJSONRequestBody defines body for LoginUser for application/json ContentType.
 type LoginUserJSONRequestBody = LoginRequest
 
@@ -256,7 +294,7 @@ type ServerInterface interface {
 	GetCurrentUser(w http.ResponseWriter, r *http.Request)
 	// Update current user
 	// (PUT /users/me)
-	UpdateCurrentUser(w http.ResponseWriter, r *http.Request)
+	UpdateCurrentUser(w http.ResponseWriter, r *http.Request, params UpdateCurrentUserParams)
 	// Delete user
 	// (DELETE /users/{userId})
 	DeleteUser(w http.ResponseWriter, r *http.Request, userId openapi_types.UUID)
@@ -265,7 +303,7 @@ type ServerInterface interface {
 	LookupUser(w http.ResponseWriter, r *http.Request, userId openapi_types.UUID)
 	// Update user
 	// (PUT /users/{userId})
-	UpdateUser(w http.ResponseWriter, r *http.Request, userId openapi_types.UUID)
+	UpdateUser(w http.ResponseWriter, r *http.Request, userId openapi_types.UUID, params UpdateUserParams)
 }
 
 // Unimplemented server implementation that returns http.StatusNotImplemented for each endpoint.
@@ -328,7 +366,7 @@ func (_ Unimplemented) GetCurrentUser(w http.ResponseWriter, r *http.Request) {
 
 // Update current user
 // (PUT /users/me)
-func (_ Unimplemented) UpdateCurrentUser(w http.ResponseWriter, r *http.Request) {
+func (_ Unimplemented) UpdateCurrentUser(w http.ResponseWriter, r *http.Request, params UpdateCurrentUserParams) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
@@ -346,7 +384,7 @@ func (_ Unimplemented) LookupUser(w http.ResponseWriter, r *http.Request, userId
 
 // Update user
 // (PUT /users/{userId})
-func (_ Unimplemented) UpdateUser(w http.ResponseWriter, r *http.Request, userId openapi_types.UUID) {
+func (_ Unimplemented) UpdateUser(w http.ResponseWriter, r *http.Request, userId openapi_types.UUID, params UpdateUserParams) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
@@ -485,6 +523,14 @@ func (siw *ServerInterfaceWrapper) ListUsers(w http.ResponseWriter, r *http.Requ
 	// Parameter object where we will unmarshal all parameters from the context
 	var params ListUsersParams
 
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
 	// ------------- Optional query parameter "offset" -------------
 
 	err = runtime.BindQueryParameter("form", true, false, "offset", r.URL.Query(), &params.Offset)
@@ -549,14 +595,40 @@ func (siw *ServerInterfaceWrapper) GetCurrentUser(w http.ResponseWriter, r *http
 // UpdateCurrentUser operation middleware
 func (siw *ServerInterfaceWrapper) UpdateCurrentUser(w http.ResponseWriter, r *http.Request) {
 
+	var err error
+
 	ctx := r.Context()
 
 	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
 
 	r = r.WithContext(ctx)
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params UpdateCurrentUserParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch IfMatch
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-Match", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-Match", Err: err})
+			return
+		}
+
+		params.IfMatch = &IfMatch
+
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.UpdateCurrentUser(w, r)
+		siw.Handler.UpdateCurrentUser(w, r, params)
 	}))
 
 	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
@@ -648,8 +720,32 @@ func (siw *ServerInterfaceWrapper) UpdateUser(w http.ResponseWriter, r *http.Req
 
 	r = r.WithContext(ctx)
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params UpdateUserParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch IfMatch
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-Match", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-Match", Err: err})
+			return
+		}
+
+		params.IfMatch = &IfMatch
+
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.UpdateUser(w, r, userId)
+		siw.Handler.UpdateUser(w, r, userId, params)
 	}))
 
 	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
@@ -818,39 +914,45 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/+xaX4/buBH/KgRboC2gxN4kfaifmksuBx8OwWI323sI/MCVRjbvJFLhn924gb97MSRl",
-	"UTJlb7K2k/TuadcSOTOc+c1f6hPNZd1IAcJoOvtEFehGCg3uxw+suIIPFrTBX7kUBoT7lzVNxXNmuBST",
-	"37QU+EznK6gZ/vdXBSWd0b9MOtIT/1ZPflRKKrrZbDJagM4Vb5AInSEvogKzTUbfSHXLiwLE6Tl3rDYZ",
-	"fSvNG2lFcXq2V6ClVTkQIQ0pHc9NRq9B3YHym04uwlwYUIJVRDuuBPzCjN4IZs1KKv5fOIMmetzwddiB",
-	"BF9as7oKmMTfjZINKMM9QFmeg9bv5O8eJ32yP//6jvgFxLgVGTXrBuiMaqO4WOJB4WPDFeh5YrujStwC",
-	"d1pieA2EC6Ihl6LQHTkuDCzBKU5BqUCv9kgUVoyJ5Gh8sFyh4t/3DjigHgu/2NKRt79B7jxoi6G+yqB9",
-	"vKMLbZixes+rV7JwNiilqpnxB3/+LKGHwSGi3VsuKYl/kUsuooAzELxmvNrV6Y0G9TdN3FvCikKBRtNs",
-	"ZfTbEqZvmNb3UhWjJLcLImrRs/2Ga9luN6QOfBleXoEG80qKkiMb7p2qf/rcv70cFTpsJ2YFRMD9Z0qf",
-	"UQH348TfRgSJkUSD+SKtxEyynTMdVNHnYuPdCvrAILJ0CmJ5Lq0weBQFg8OMACZp35TEV5GTjgp87DjR",
-	"o5eSCjF9ep8qudLmLathlKZbQQQuSeznKW8U/IMFwgsQhpccFCmlcla0eKZILGt5EtoVOyAULhiVSclq",
-	"fCe+xJyA4ui1NlBjXBa2RqME+VhR89goqYibJN6iNCzrCLPc8DsUtgFRIK2M3jIhoEhwGQDFaai1X2eu",
-	"SEnhxHsjNQr4SgEz8O1j6nHWP0+WGDHE3uSB/G+a4g9gg+/fA/cY75VVKpTWg4zvX1weAmBY1+Xnv7cI",
-	"I/crECRfMbHkYrld8I+HlgR/ME/+/Kpp4M1Di+16LSIOcqu4WV9jjxPabGAKFHY6+OvW/XrTcv/513c0",
-	"S3U2oWXyvckKWAGKWI2GRlfwNInrpFww9//MAvnuLCtjGt+UcVHKttljuYniCNW2aaQy/4aPrG4qeJpL",
-	"9DKn6Rl9eTkn134B3ent8CXma/REUjPBllAjWrkgt5VckprnSmL7yfPIfw036PLOMuQ6vH15OacZvQOl",
-	"PemLp9OnU+QoGxCs4XRGn7tHaGuzcqqdoJomFTYWzsOkL8YGMlqzwuIiZwY0YV5WJgqiwFgl9LCLRC91",
-	"ap8XdOa7lhsfaMIA4wdZrI/WNve6ok0fc0ZZcA+ioc2z6fRovHvdd6Jzd7IRbZ1+SluhNV54/imyWzkn",
-	"0WTJbbk4x7DjjlW8ILkCV0qySiPvfz5E3HgqEzsxnb1fZFTbumZq3eLVoy2jhi01hoUIXijJAglscSmt",
-	"GQdmENnBEp26DfUaNPrAZBSQ0potInvQeLHL5Be5XEJBpDWRIat1ZJf9uulPb75UoTsqRMU8RIdtdH7i",
-	"G7lRXV6DKLpqzbd9hAttlM1xCbq38/w2te3oNQC215GeyOeTXe+DfD9h4Mv+mX3e1ijjl3rri9N7649O",
-	"yt5o9KieGs4zAMQXIG7yyXnhZhx5zoi6xdaWYZervV3Szuw2RzOThilWgwGFQh6wdEsRc59LiV3ONtuJ",
-	"YgynLLLasNRZnAHpvRHYkeC+G9O+TcC36UkqP3OGItjv+MhHtcRF7UHEh+nSPoi7BSFNxeVSD+b9idYQ",
-	"6L3Z9imwlprMfWMFlb90CJqC4jjoPVsSH0WcN/zwMuYg7u5A8XIdR9glJND3H1zGXeku4L5aY4nHDBSh",
-	"kA89fAdERzawG0GjI7l+6bceCrthWZru8YLvocDXF+NY4Pk6oS+hymOj0pu4h5FDoMS1ehSGV2AUhzuH",
-	"w4YtuXAYrLg2RJbE792p1bl2lbrexZgDzgcLat0hR5alr1I6jRdQMlsZOptmtOaC17Z2/+/eyaVJVrzm",
-	"IxSfIUn20ZO8mMYMLhIMFo+Mm9xArQ+ByLU13USNKcXWyc40Vjs28s40xXFanBfT54c39T4reCxy+0Oi",
-	"95RV1RN3tJmii00P1z+BIayqtoBrEe1htthkIzncz/NDEB26RR+1fukJZx7R/cKDEvTFUTknv1FAfbR5",
-	"5SiB9V9n+LAChb7nZtUOaysFrFgT+Mi1Of70w1ssAlACfdswOvFz3AORNJp6VGs382wHdUXbUHHhJ7W4",
-	"dQjUn8CE+Xp6HDI9OW4Ce+9PRw5Dj5y0YKDII/HSscIm7ONvLh5tnd4FyImjSf+y5cxV/96gYp1k31up",
-	"383rnPiHgNS5/Sf8My82HlUVmMR1zmv3vBvFj6Qhv+zBg06nbc/yq9UBoZ7ev2P7DeJJC4d7xQ0Miwev",
-	"0vFwcLDw1Q3kWLl7y92uMUZwReavE2Nq+bttvlZcvjlBPP6u4YCVQaqUbO3oLJjID/sa4/nr9oOrAKhE",
-	"M+zDwd5u+MAHPijz3jTFhK94sP9/cFo6Sz76MxH9X0fTkBvHcuJe2p6UEyXlWsOb8fAJN82oVRWd+Wz7",
-	"JFysT+4uKJILEiRpRXfzIIpGcmF056Ve5k225+qcS0EUVA5CCQqDgcpmsflfAAAA//9vH0zfezAAAA==",
+	"H4sIAAAAAAAC/+xb33Mbue3/Vzj8fmeundlYsuN2Wj01cZIb3aSpxz96Dxk/ULtYiZddckNybase/e8d",
+	"kNyf4kq2Iylu7p7iFUkABD4AAZB5oLHMCylAGE0nD7RgiuVgQNmvafpPZuIF/pmAjhUvDJeCTuj7KzYn",
+	"MiVmAeQWlOZSVJ+lBmX/iBdMzIFwTWZMQ0KkoBHluHoBLAFFIypYDnRCp+krxyeiOl5AzpChWRY4po3i",
+	"Yk5Xq1VEFehCCg1WtrcsuYCvJWiDX7EUBoT9kxVFxmOGko5+0yjuQ4vs/ytI6YT+36jZ98iN6tF7paRy",
+	"rLrbfcsSojyzVUQ/SDXjSQJi/5wbVquIfpLmgyxFsn+2F6BlqWIgQhqSWp6riJ4riKVIOE76wHgGB5Dk",
+	"qoLUHdMeUgnRXMTQAR8XpEZRT1JECVeHkrWSgjiQI/xzrrXFcEQvQd2CcgT2Ls5UGFCCZURbrgTcxIhe",
+	"C1aahVT8P4fQSocbDvsVSPBNaRYX3qvxu1CyAGW4c3EWx6D1lfziPK1L9pdfr4ibQIydEfVDRkThvuAK",
+	"9DSw3FIldoLdLTE8B4SRtsjRDTkuDMzBKk5BqkAvNkjkZwyJZGlUcPzc2WCPelv4m5qOnP0GsY1BNYa6",
+	"KoPq5zVdaMNMqTcMncnE2iCVKmfGbfz1SUAPvU20VtdcQhJ/lHMuWiG7J3jOeLau02sN6idN7ChhSaJA",
+	"o2lqGd2ygOkLpvWdVMkgyXpCi1rrt82Gq9jWC0IbPveDF6DBnEmRcmTDpVjffexGzweF9sttzBNw90Tp",
+	"Iyrgbpj4pxZBYiTRYJ6llTaTaG1PW1X0VGxguO0Ao0pBWBzLUhjcioLeZgYAE7RvSOKLlpMOCrzrONGh",
+	"F5IKMb1/n0q50uaTzdkGaNoZxKZ1gfU85I2Cfy2B8ASE4SkHRVKp6kSyLVZZ8iC0M7ZFqIxtkEnJbHgl",
+	"DuKZgOLopTaQ04iCKHM0ipcvlwkoZiT+zZKctw0Uir5BRhVi/bSGCYsNv0XBCxAJ0orojAkBSZCLz4bW",
+	"2fx7PUePCBexghyEsck5gVtQS59itfXOhfnr6fZjwBqngk6DlJZ9vLJrZTTyDkH6TAEz8PKB/W0QPMxR",
+	"NWCSjScY8v/IQxGOG8i7f2zKDZEOXdUMmFJs6U6le3NWKi3V+t7d7xVicSYp2ByiKp1GyOKIVS2ObNWE",
+	"E3Von9dF8jvA2g8U7lYbDXlWKuVrml6q5QbOtzmdn9ckRn+qsETuFiBcoEQcVhP+/Nhc7HcWvZ6ervb8",
+	"tm+xdQ9GxEFcKm6WlxhyfIcImAKFJSZ+zezXh4r7L79e0ShUUvpa1RWFvogvbcBBt3A0iQ1sUPWrUBRH",
+	"vtnLwpjCVcNcpLKqsllsWjGF6rIopDL/gHuWFxkcxTJvumJvzqfk0k2ga0U1DmKiZFsjORNsbk9y9N5Z",
+	"Juck57GSWPfzuOXLhpsMvGXIpR99cz5tHcUTenw0PhojR1mAYAWnE/ra/oS2Ngur2hGqaZRhRYefhXRn",
+	"RE/G0ixAGGwngCbMycpEQhSYUgndL9/RS63apwmduHLx2gUd33t7K5PlzvoVnXJ01cWcUSX0+40n4/HO",
+	"eHfaHoGWiZWN6NLqJy0ztMbpeDxEtpZz1GqK2iXHh+gy3bKMJyRWYHN4lmnk/ZfHiNtuh7WdmE4+30RU",
+	"l3nO1LLCq0NbRA2bawwLLXjZHBIJ1LiUpRkGphfZwtJ2qn2o16DRB0aDgJSlqRHZgcbpOpOPco6tSlma",
+	"liGzZcsum3XTbZs9V6FrKkTFPEaHVXR+5SroQV1egkiaDNXV24QLbVQZ4xR0b1K2jrY1vXrAdloBe/L5",
+	"YLvhUb4fMPB5d8/u3NYo43O99XT/3vreStnp6u/UU/1+eoB4BuJGD9YLV8PIs0bUFbZqhs1Z7ewSdma7",
+	"uNWsat97fd5i6YqivcvCI7E5s6uhLpw2XWvdHADpnd7jjuC+HtNeJuCr40kq1+yHxNtv98hHtbST2q2I",
+	"9229TRC3E/wx1U6XOjDvthL7QO9cKuwDa6GW6AtLqKxslaYg2Q16D3aIDyLOGb5/C7YVd7egeLpsR9g5",
+	"mGDTEDuymLoLuMuWJLbtuMQn8r6Gb4BoyXp2A2i0JJdv3NJtYddPC9PdXfDdFvi6YuwGPDvFgVNqxyrb",
+	"YIBz9aDhL8AoDrfW8gWbc2GtnnFtsB3n1q5lx1ybaz+y0ar/Khg2/GPX3nOlICSEadJ0A8lsaRFVKLjl",
+	"ssTTfQ5HZJoSDSYiMk1tlqkJnwupIDmqAPG1BLVsEOGYbHxWEq1dhpX5DGzf0fYL7X3YF15E5AsUxtbc",
+	"aCxm+Ixn3CyPyLmCFJTf0JAkTuSOJAmkrMwMnYwjmnPBc2yBjUN99ocgyYznfIDiCZJk947k8bjN4DjA",
+	"4GaPkbluIYfK3DaiiPKo+37R+XT8evuizoucb/XjbpPqM2VZ9spqY6Lozarj5T+DISzLaver/Ns53c0q",
+	"Gsgh3B2KD+L9INH1YTd1jz2X1p3OoxKE451yDj5OQX1U51oHd5F/pmZFwfduj7xVM5LMwNaBGNPq50C2",
+	"Z1xiaxqPy57jrr11ey7o/36A5zz2MRY3i6pTnSlgyZLAPddm960fB5cWegPQr0+0UQ6PONRaLZ9sSVhz",
+	"PvrU5idNsGdbFUx9L/kZjL9cCPeCxnsHrWfvnHkgbH5/+B6+x4UhMm7pJhwlywA43J3RN0Ojc/Xk0dFL",
+	"hkJ7a6aMqhe3++oLrF+QHbhS2xiILcB+iED8rOzjRUTv0+OT7bIHXgLj0pO/PW1p/TR3B/1tC51t7t+c",
+	"FA/4zzRZOTBlYALXn+/s783V1UDa5KY9+mLAmsCxTHZzNfD0vNW33javqJ+b7zXRvVPcQD/ZdSodDuJb",
+	"y1ZdQIxNA2c5V05yRabvAtc68ktZfK+j/PpHO8L/p7GIsTBUd1Ugmr4LonFjv2P6rm2dcOfKxaKNrast",
+	"zyBXN1syGyZcjEfbPzqTeeEpzB+5y8vJXfbu9X+kR4c8gH06NZRGbaTtSFlRQgGx//jI//ckGtFSZXTi",
+	"ErRX/u3S6PbYBhIvQZBW6/kTiKSQXBjdxFYn83qft9sXJwoy674BCt2ZdHWz+u8AN9ROCzY5AAA=",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
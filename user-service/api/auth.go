@@ -41,12 +41,7 @@ func (s *Server) LoginUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	claims := make([]string, 0)
-	if user.Role == store.RoleAdmin {
-		claims = append(claims, "all-users:read", "all-users:write")
-	}
-
-	accessToken, accessTokenExpiresIn, err := s.jwsSigner.CreateAccessToken(user.ID, claims)
+	accessToken, accessTokenExpiresIn, err := s.jwsSigner.CreateAccessToken(user.ID, permissionsForRole(user.Role))
 	if err != nil {
 		_ = render.Render(w, r, api_utils.ErrInternalError(err))
 		return
@@ -129,12 +124,7 @@ func (s *Server) RefreshToken(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	claims := make([]string, 0)
-	if user.Role == store.RoleAdmin {
-		claims = append(claims, "all-users:read", "all-users:write")
-	}
-
-	accessToken, accessTokenExpiresIn, err := s.jwsSigner.CreateAccessToken(user.ID, claims)
+	accessToken, accessTokenExpiresIn, err := s.jwsSigner.CreateAccessToken(user.ID, permissionsForRole(user.Role))
 	if err != nil {
 		_ = render.Render(w, r, api_utils.ErrInternalError(err))
 		return
@@ -275,3 +265,22 @@ func (s *Server) VerifyAccount(w http.ResponseWriter, r *http.Request, token str
 	}
 	w.WriteHeader(http.StatusNoContent)
 }
+
+// permissionsForRole returns the permissions that are granted to users with
+// the given role.
+func permissionsForRole(role string) []string {
+	switch role {
+	case store.RoleAdmin:
+		return []string{
+			auth.PermissionAllUsersRead,
+			auth.PermissionAllUsersWrite,
+			auth.PermissionAllPostsRead,
+			auth.PermissionAllPostsWrite,
+			auth.PermissionModerate,
+		}
+	case store.RoleModerator:
+		return []string{auth.PermissionModerate}
+	default:
+		return []string{}
+	}
+}
@@ -18,6 +18,10 @@ func (c User) Render(w http.ResponseWriter, r *http.Request) error {
 	return nil
 }
 
+func (c UserList) Render(w http.ResponseWriter, r *http.Request) error {
+	return nil
+}
+
 func (c LoginRequest) Bind(r *http.Request) error {
 	return nil
 }
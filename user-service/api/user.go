@@ -52,6 +52,10 @@ func (s *Server) CreateUser(w http.ResponseWriter, r *http.Request) {
 		Role:         store.RoleUser,
 	}
 	err = s.engine.SetUser(r.Context(), user)
+	if errors.Is(err, store.ErrDuplicateEmail) {
+		_ = render.Render(w, r, api_utils.ErrConflict)
+		return
+	}
 	if err != nil {
 		_ = render.Render(w, r, api_utils.ErrInternalError(err))
 		return
@@ -63,7 +67,19 @@ func (s *Server) CreateUser(w http.ResponseWriter, r *http.Request) {
 		_ = render.Render(w, r, api_utils.ErrInternalError(err))
 		return
 	}
+	err = s.sendUserEvent(r.Context(), transport.UserCreatedTopic, transport.UserCreatedEvent{
+		UserID:    user.ID,
+		Email:     user.Email,
+		FirstName: user.FirstName,
+		LastName:  user.LastName,
+		Version:   user.Version,
+	})
+	if err != nil {
+		_ = render.Render(w, r, api_utils.ErrInternalError(err))
+		return
+	}
 
+	w.Header().Set("ETag", api_utils.ETag(user.Version))
 	render.Status(r, http.StatusCreated)
 	_ = render.Render(w, r, &User{
 		Id:        ID,
@@ -72,31 +88,41 @@ func (s *Server) CreateUser(w http.ResponseWriter, r *http.Request) {
 		LastName:  user.LastName,
 		Role:      UserRole(user.Role),
 		Status:    UserStatus(user.Status),
+		Version:   user.Version,
 	})
 }
 
 func (s *Server) ListUsers(w http.ResponseWriter, r *http.Request, params ListUsersParams) {
-	offset, limit := api_utils.GetPaginationWithDefaults(params.Offset, params.Limit)
+	page, err := api_utils.GetPage[store.Cursor](params.Cursor, params.Offset, params.Limit)
+	if err != nil {
+		_ = render.Render(w, r, api_utils.ErrInvalidRequest(err))
+		return
+	}
 
-	users, err := s.engine.ListUsers(r.Context(), offset, limit)
+	users, err := s.engine.ListUsers(r.Context(), page)
 	if err != nil {
 		_ = render.Render(w, r, api_utils.ErrInternalError(err))
 		return
 	}
+	users, nextCursor := api_utils.NextPage(users, page, (*store.User).Cursor)
 
-	res := make([]render.Renderer, len(users))
+	res := &UserList{
+		Items:      make([]User, len(users)),
+		NextCursor: nextCursor,
+	}
 	for i, user := range users {
-		res[i] = &User{
+		res.Items[i] = User{
 			Id:        user.ID,
 			Email:     openapi_types.Email(user.Email),
 			FirstName: user.FirstName,
 			LastName:  user.LastName,
 			Role:      UserRole(user.Role),
 			Status:    UserStatus(user.Status),
+			Version:   user.Version,
 		}
 	}
 
-	_ = render.RenderList(w, r, res)
+	_ = render.Render(w, r, res)
 }
 
 func (s *Server) DeleteUser(w http.ResponseWriter, r *http.Request, ID openapi_types.UUID) {
@@ -117,6 +143,11 @@ func (s *Server) DeleteUser(w http.ResponseWriter, r *http.Request, ID openapi_t
 		_ = render.Render(w, r, api_utils.ErrInternalError(err))
 		return
 	}
+	err = s.sendUserEvent(r.Context(), transport.UserDeletedTopic, transport.UserDeletedEvent{UserID: ID})
+	if err != nil {
+		_ = render.Render(w, r, api_utils.ErrInternalError(err))
+		return
+	}
 	w.WriteHeader(http.StatusNoContent)
 }
 
@@ -131,6 +162,7 @@ func (s *Server) LookupUser(w http.ResponseWriter, r *http.Request, ID openapi_t
 		return
 	}
 
+	w.Header().Set("ETag", api_utils.ETag(user.Version))
 	_ = render.Render(w, r, &User{
 		Id:        user.ID,
 		Email:     openapi_types.Email(user.Email),
@@ -138,10 +170,11 @@ func (s *Server) LookupUser(w http.ResponseWriter, r *http.Request, ID openapi_t
 		LastName:  user.LastName,
 		Role:      UserRole(user.Role),
 		Status:    UserStatus(user.Status),
+		Version:   user.Version,
 	})
 }
 
-func (s *Server) UpdateUser(w http.ResponseWriter, r *http.Request, ID openapi_types.UUID) {
+func (s *Server) UpdateUser(w http.ResponseWriter, r *http.Request, ID openapi_types.UUID, params UpdateUserParams) {
 	// Check if the user exists
 	user, err := s.engine.LookupUser(r.Context(), ID)
 	if err != nil {
@@ -153,6 +186,11 @@ func (s *Server) UpdateUser(w http.ResponseWriter, r *http.Request, ID openapi_t
 		return
 	}
 
+	if errResponse := api_utils.CheckIfMatch(r, user.Version); errResponse != nil {
+		_ = render.Render(w, r, errResponse)
+		return
+	}
+
 	// Afterwards update the user
 	req := new(UserUpdate)
 	if err := render.Bind(r, req); err != nil {
@@ -177,11 +215,25 @@ func (s *Server) UpdateUser(w http.ResponseWriter, r *http.Request, ID openapi_t
 	}
 
 	err = s.engine.SetUser(r.Context(), user)
+	if errors.Is(err, store.ErrDuplicateEmail) {
+		_ = render.Render(w, r, api_utils.ErrConflict)
+		return
+	}
+	if errors.Is(err, store.ErrVersionConflict) {
+		_ = render.Render(w, r, api_utils.ErrPreconditionFailed)
+		return
+	}
+	if err != nil {
+		_ = render.Render(w, r, api_utils.ErrInternalError(err))
+		return
+	}
+	err = s.sendUserUpdatedEvent(r.Context(), user)
 	if err != nil {
 		_ = render.Render(w, r, api_utils.ErrInternalError(err))
 		return
 	}
 
+	w.Header().Set("ETag", api_utils.ETag(user.Version))
 	_ = render.Render(w, r, &User{
 		Id:        ID,
 		Email:     openapi_types.Email(user.Email),
@@ -189,6 +241,7 @@ func (s *Server) UpdateUser(w http.ResponseWriter, r *http.Request, ID openapi_t
 		LastName:  user.LastName,
 		Role:      UserRole(user.Role),
 		Status:    UserStatus(user.Status),
+		Version:   user.Version,
 	})
 }
 
@@ -205,6 +258,7 @@ func (s *Server) GetCurrentUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	w.Header().Set("ETag", api_utils.ETag(user.Version))
 	_ = render.Render(w, r, &User{
 		Id:        user.ID,
 		Email:     openapi_types.Email(user.Email),
@@ -212,10 +266,11 @@ func (s *Server) GetCurrentUser(w http.ResponseWriter, r *http.Request) {
 		LastName:  user.LastName,
 		Role:      UserRole(user.Role),
 		Status:    UserStatus(user.Status),
+		Version:   user.Version,
 	})
 }
 
-func (s *Server) UpdateCurrentUser(w http.ResponseWriter, r *http.Request) {
+func (s *Server) UpdateCurrentUser(w http.ResponseWriter, r *http.Request, params UpdateCurrentUserParams) {
 	userID, err := auth.GetUserIDFromContext(r.Context())
 	if err != nil {
 		_ = render.Render(w, r, api_utils.ErrUnauthorized)
@@ -228,6 +283,11 @@ func (s *Server) UpdateCurrentUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if errResponse := api_utils.CheckIfMatch(r, user.Version); errResponse != nil {
+		_ = render.Render(w, r, errResponse)
+		return
+	}
+
 	// Afterwards update the user
 	req := new(UserUpdateCurrent)
 	if err := render.Bind(r, req); err != nil {
@@ -260,11 +320,25 @@ func (s *Server) UpdateCurrentUser(w http.ResponseWriter, r *http.Request) {
 	}
 
 	err = s.engine.SetUser(r.Context(), user)
+	if errors.Is(err, store.ErrDuplicateEmail) {
+		_ = render.Render(w, r, api_utils.ErrConflict)
+		return
+	}
+	if errors.Is(err, store.ErrVersionConflict) {
+		_ = render.Render(w, r, api_utils.ErrPreconditionFailed)
+		return
+	}
+	if err != nil {
+		_ = render.Render(w, r, api_utils.ErrInternalError(err))
+		return
+	}
+	err = s.sendUserUpdatedEvent(r.Context(), user)
 	if err != nil {
 		_ = render.Render(w, r, api_utils.ErrInternalError(err))
 		return
 	}
 
+	w.Header().Set("ETag", api_utils.ETag(user.Version))
 	_ = render.Render(w, r, &User{
 		Id:        user.ID,
 		Email:     openapi_types.Email(user.Email),
@@ -272,6 +346,7 @@ func (s *Server) UpdateCurrentUser(w http.ResponseWriter, r *http.Request) {
 		LastName:  user.LastName,
 		Role:      UserRole(user.Role),
 		Status:    UserStatus(user.Status),
+		Version:   user.Version,
 	})
 }
 
@@ -295,3 +370,26 @@ func (s *Server) sendVerifyAccountEvent(ctx context.Context, userID uuid.UUID, e
 		Data: data,
 	})
 }
+
+func (s *Server) sendUserUpdatedEvent(ctx context.Context, user *store.User) error {
+	return s.sendUserEvent(ctx, transport.UserUpdatedTopic, transport.UserUpdatedEvent{
+		UserID:    user.ID,
+		Email:     user.Email,
+		FirstName: user.FirstName,
+		LastName:  user.LastName,
+		Version:   user.Version,
+	})
+}
+
+// sendUserEvent publishes a domain event of a user, which other services use
+// to keep their projection of the users up to date.
+func (s *Server) sendUserEvent(ctx context.Context, topic string, event any) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	return s.producer.Produce(ctx, topic, &transport.Message{
+		ID:   uuid.New().String(),
+		Data: data,
+	})
+}
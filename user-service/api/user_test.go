@@ -8,6 +8,7 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	"github.com/chrishrb/blog-microservice/internal/api_utils"
 	"github.com/chrishrb/blog-microservice/internal/testutil"
 	"github.com/chrishrb/blog-microservice/internal/transport"
 	"github.com/chrishrb/blog-microservice/user-service/api"
@@ -66,7 +67,7 @@ func TestCreateUser(t *testing.T) {
 
 	// Verify event was produced
 	require.NotNil(t, producer.ProducedMessages)
-	assert.Len(t, producer.ProducedMessages, 1)
+	assert.Len(t, producer.ProducedMessages, 2)
 	assert.NotEmpty(t, producer.ProducedMessages[0].Message.ID)
 	assert.Equal(t, transport.VerifyAccountTopic, producer.ProducedMessages[0].Topic)
 	assert.NotEmpty(t, producer.ProducedMessages[0].Message.Data)
@@ -80,6 +81,17 @@ func TestCreateUser(t *testing.T) {
 	assert.NotEmpty(t, resetEvent.Token)
 	assert.Equal(t, "John", resetEvent.FirstName)
 	assert.Equal(t, "Doe", resetEvent.LastName)
+
+	// Verify the user was published for other services
+	assert.Equal(t, transport.UserCreatedTopic, producer.ProducedMessages[1].Topic)
+	var createdEvent transport.UserCreatedEvent
+	err = json.Unmarshal(producer.ProducedMessages[1].Message.Data, &createdEvent)
+	require.NoError(t, err)
+	assert.Equal(t, res.Id, createdEvent.UserID)
+	assert.Equal(t, "test@example.com", createdEvent.Email)
+	assert.Equal(t, "John", createdEvent.FirstName)
+	assert.Equal(t, "Doe", createdEvent.LastName)
+	assert.Equal(t, res.Version, createdEvent.Version)
 }
 
 func TestListUsers(t *testing.T) {
@@ -120,10 +132,12 @@ func TestListUsers(t *testing.T) {
 	r.ServeHTTP(rr, req)
 
 	assert.Equal(t, http.StatusOK, rr.Result().StatusCode)
-	var resList []api.User
-	err = json.NewDecoder(rr.Body).Decode(&resList)
+	var res api.UserList
+	err = json.NewDecoder(rr.Body).Decode(&res)
 
 	require.NoError(t, err)
+	assert.Nil(t, res.NextCursor)
+	resList := res.Items
 	require.Equal(t, 2, len(resList))
 
 	// Check that our created users exist in the response
@@ -152,7 +166,7 @@ func TestListUsers(t *testing.T) {
 }
 
 func TestDeleteUser(t *testing.T) {
-	server, r, engine, _, _, _ := setupServer(t)
+	server, r, engine, _, _, producer := setupServer(t)
 	defer server.Close()
 
 	userID := uuid.New()
@@ -177,6 +191,14 @@ func TestDeleteUser(t *testing.T) {
 	r.ServeHTTP(rr, req)
 
 	assert.Equal(t, http.StatusNoContent, rr.Result().StatusCode)
+
+	// Verify event was produced
+	require.Len(t, producer.ProducedMessages, 1)
+	assert.Equal(t, transport.UserDeletedTopic, producer.ProducedMessages[0].Topic)
+	var event transport.UserDeletedEvent
+	err = json.Unmarshal(producer.ProducedMessages[0].Message.Data, &event)
+	require.NoError(t, err)
+	assert.Equal(t, userID, event.UserID)
 }
 
 func TestLookupUser(t *testing.T) {
@@ -236,7 +258,7 @@ func TestLookupUser_NotFound(t *testing.T) {
 }
 
 func TestUpdateUser(t *testing.T) {
-	server, r, engine, _, _, _ := setupServer(t)
+	server, r, engine, _, _, producer := setupServer(t)
 	defer server.Close()
 
 	userID := uuid.New()
@@ -267,6 +289,7 @@ func TestUpdateUser(t *testing.T) {
 		bytes.NewBuffer(jsonData),
 	)
 	req.Header.Set("content-type", "application/json")
+	req.Header.Set("If-Match", api_utils.ETag(1))
 	rr := httptest.NewRecorder()
 	r.ServeHTTP(rr, req)
 
@@ -291,6 +314,69 @@ func TestUpdateUser(t *testing.T) {
 	assert.Equal(t, "Doe", dbUser.LastName)
 	assert.Equal(t, store.RoleAdmin, dbUser.Role)
 	assert.Equal(t, store.StatusActive, dbUser.Status)
+
+	// Verify event was produced
+	require.Len(t, producer.ProducedMessages, 1)
+	assert.Equal(t, transport.UserUpdatedTopic, producer.ProducedMessages[0].Topic)
+	var event transport.UserUpdatedEvent
+	err = json.Unmarshal(producer.ProducedMessages[0].Message.Data, &event)
+	require.NoError(t, err)
+	assert.Equal(t, userID, event.UserID)
+	assert.Equal(t, "updated@example.com", event.Email)
+	assert.Equal(t, "Updated", event.FirstName)
+	assert.Equal(t, "Doe", event.LastName)
+	assert.Equal(t, res.Version, event.Version)
+}
+
+func TestUpdateUser_DuplicateEmail(t *testing.T) {
+	server, r, engine, _, _, _ := setupServer(t)
+	defer server.Close()
+
+	userID := uuid.New()
+	err := engine.SetUser(t.Context(), &store.User{
+		ID:           userID,
+		Email:        "john@example.com",
+		FirstName:    "John",
+		LastName:     "Doe",
+		PasswordHash: "hashedpassword",
+		Status:       store.StatusActive,
+		Role:         store.RoleUser,
+	})
+	require.NoError(t, err)
+	err = engine.SetUser(t.Context(), &store.User{
+		ID:           uuid.New(),
+		Email:        "jane@example.com",
+		FirstName:    "Jane",
+		LastName:     "Doe",
+		PasswordHash: "hashedpassword",
+		Status:       store.StatusActive,
+		Role:         store.RoleUser,
+	})
+	require.NoError(t, err)
+
+	// Update the user with the email address of another user
+	newEmail := openapi_types.Email("jane@example.com")
+	d := api.UserUpdate{
+		Email: &newEmail,
+	}
+	jsonData, err := json.Marshal(d)
+	require.NoError(t, err)
+	req := httptest.NewRequest(
+		http.MethodPut,
+		fmt.Sprintf("/users/%s", userID),
+		bytes.NewBuffer(jsonData),
+	)
+	req.Header.Set("content-type", "application/json")
+	req.Header.Set("If-Match", api_utils.ETag(1))
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusConflict, rr.Result().StatusCode)
+
+	// Check the database
+	dbUser, err := engine.LookupUser(req.Context(), userID)
+	require.NoError(t, err)
+	assert.Equal(t, "john@example.com", dbUser.Email)
 }
 
 func TestUpdateUser_NotFound(t *testing.T) {
@@ -318,6 +404,64 @@ func TestUpdateUser_NotFound(t *testing.T) {
 	assert.Equal(t, http.StatusNotFound, rr.Result().StatusCode)
 }
 
+func TestUpdateUser_Precondition(t *testing.T) {
+	server, r, engine, _, _, _ := setupServer(t)
+	defer server.Close()
+
+	userID := uuid.New()
+	err := engine.SetUser(t.Context(), &store.User{
+		ID:           userID,
+		Email:        "john@example.com",
+		FirstName:    "John",
+		LastName:     "Doe",
+		PasswordHash: "hashedpassword",
+		Status:       store.StatusActive,
+		Role:         store.RoleUser,
+	})
+	require.NoError(t, err)
+
+	// Reads return the current version as ETag
+	req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/users/%s", userID), nil)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusOK, rr.Result().StatusCode)
+	etag := rr.Header().Get("ETag")
+	assert.Equal(t, `"1"`, etag)
+
+	update := func(firstName, ifMatch string) *httptest.ResponseRecorder {
+		jsonData, err := json.Marshal(api.UserUpdate{FirstName: testutil.Ptr(firstName)})
+		require.NoError(t, err)
+		req := httptest.NewRequest(http.MethodPut, fmt.Sprintf("/users/%s", userID), bytes.NewBuffer(jsonData))
+		req.Header.Set("content-type", "application/json")
+		if ifMatch != "" {
+			req.Header.Set("If-Match", ifMatch)
+		}
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		return rr
+	}
+
+	// Updates without a precondition are rejected
+	rr = update("Missing", "")
+	assert.Equal(t, http.StatusPreconditionRequired, rr.Result().StatusCode)
+
+	rr = update("First", etag)
+	require.Equal(t, http.StatusOK, rr.Result().StatusCode)
+	assert.Equal(t, `"2"`, rr.Header().Get("ETag"))
+	var res api.User
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&res))
+	assert.Equal(t, int64(2), res.Version)
+
+	// A second update based on the same version lost the race
+	rr = update("Second", etag)
+	assert.Equal(t, http.StatusPreconditionFailed, rr.Result().StatusCode)
+
+	dbUser, err := engine.LookupUser(t.Context(), userID)
+	require.NoError(t, err)
+	assert.Equal(t, "First", dbUser.FirstName)
+	assert.Equal(t, int64(2), dbUser.Version)
+}
+
 func TestGetCurrentUser(t *testing.T) {
 	server, r, engine, _, _, _ := setupServer(t)
 	defer server.Close()
@@ -359,7 +503,7 @@ func TestGetCurrentUser(t *testing.T) {
 }
 
 func TestUpdateCurrentUser(t *testing.T) {
-	server, r, engine, _, _, _ := setupServer(t)
+	server, r, engine, _, _, producer := setupServer(t)
 	defer server.Close()
 
 	userID := uuid.New()
@@ -396,6 +540,7 @@ func TestUpdateCurrentUser(t *testing.T) {
 		bytes.NewBuffer(jsonData),
 	)
 	req.Header.Set("content-type", "application/json")
+	req.Header.Set("If-Match", api_utils.ETag(1))
 	req = userIDContext(req, userID)
 	rr := httptest.NewRecorder()
 	r.ServeHTTP(rr, req)
@@ -419,6 +564,14 @@ func TestUpdateCurrentUser(t *testing.T) {
 	assert.True(t, service.VerifyPassword("newPassword", dbUser.PasswordHash))
 	assert.Equal(t, store.RoleUser, dbUser.Role)
 	assert.Equal(t, store.StatusActive, dbUser.Status)
+
+	// Verify event was produced
+	require.Len(t, producer.ProducedMessages, 1)
+	assert.Equal(t, transport.UserUpdatedTopic, producer.ProducedMessages[0].Topic)
+	var event transport.UserUpdatedEvent
+	err = json.Unmarshal(producer.ProducedMessages[0].Message.Data, &event)
+	require.NoError(t, err)
+	assert.Equal(t, "updated@example.com", event.Email)
 }
 
 func TestUpdateCurrentUser_IncorrectPassword(t *testing.T) {
@@ -455,6 +608,7 @@ func TestUpdateCurrentUser_IncorrectPassword(t *testing.T) {
 		bytes.NewBuffer(jsonData),
 	)
 	req.Header.Set("content-type", "application/json")
+	req.Header.Set("If-Match", api_utils.ETag(1))
 	req = userIDContext(req, userID)
 	rr := httptest.NewRecorder()
 	r.ServeHTTP(rr, req)
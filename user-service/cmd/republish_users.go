@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/chrishrb/blog-microservice/user-service/config"
+	"github.com/chrishrb/blog-microservice/user-service/service"
+	"github.com/spf13/cobra"
+)
+
+var (
+	republishBatchSize int
+)
+
+// republishUsersCmd represents the republish-users command
+var republishUsersCmd = &cobra.Command{
+	Use:   "republish-users",
+	Short: "Publish the current state of all users",
+	Long: `Publish a user-updated event with the current state of every user.
+
+Services that keep a projection of the users, like the notification-service,
+only learn about users from their events. Run this command once after such a
+service is deployed to backfill the users that existed before.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg := config.DefaultConfig
+		if configFile != "" {
+			err := cfg.LoadFromFile(configFile)
+			if err != nil {
+				return err
+			}
+		}
+
+		settings, err := config.Configure(context.Background(), &cfg)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			err := settings.TracerProvider.Shutdown(context.Background())
+			if err != nil {
+				slog.Warn("shutting down tracer provider", "error", err)
+			}
+		}()
+
+		count, err := service.RepublishUsers(cmd.Context(), settings.Storage, settings.MsgProducer, republishBatchSize)
+		if err != nil {
+			return err
+		}
+		slog.Info("republished users", slog.Int("count", count))
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(republishUsersCmd)
+
+	republishUsersCmd.Flags().StringVarP(&configFile, "config-file", "c", "/config/config.yaml",
+		"The config file to use")
+	republishUsersCmd.Flags().IntVar(&republishBatchSize, "batch-size", 100,
+		"The number of users to read at once")
+}
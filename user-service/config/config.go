@@ -14,6 +14,7 @@ import (
 	"github.com/chrishrb/blog-microservice/internal/transport/kafka"
 	"github.com/chrishrb/blog-microservice/user-service/store"
 	"github.com/chrishrb/blog-microservice/user-service/store/inmemory"
+	"github.com/chrishrb/blog-microservice/user-service/store/sqlite"
 	"github.com/subnova/slog-exporter/slogtrace"
 	"go.opentelemetry.io/contrib/detectors/gcp"
 	"go.opentelemetry.io/otel"
@@ -100,10 +101,15 @@ func Configure(ctx context.Context, cfg *BaseConfig) (c *Config, err error) {
 	return
 }
 
-func getStorage(_ context.Context, cfg *StorageConfig) (engine store.Engine, err error) {
+func getStorage(ctx context.Context, cfg *StorageConfig) (engine store.Engine, err error) {
 	switch cfg.Type {
 	case "in_memory":
 		engine = inmemory.NewStore(clock.RealClock{})
+	case "sqlite":
+		engine, err = sqlite.NewStore(ctx, cfg.SqliteStorage.Path, clock.RealClock{})
+		if err != nil {
+			return nil, fmt.Errorf("create sqlite storage: %w", err)
+		}
 	default:
 		return nil, fmt.Errorf("unknown storage type: %s", cfg.Type)
 	}
@@ -1,6 +1,7 @@
 package config_test
 
 import (
+	"path/filepath"
 	"testing"
 
 	"github.com/chrishrb/blog-microservice/user-service/config"
@@ -38,3 +39,23 @@ func TestConfigureInMemoryStorage(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, settings.Storage)
 }
+
+func TestConfigureSqliteStorage(t *testing.T) {
+	cfg := clone.Clone(&config.DefaultConfig)
+	cfg.Storage.Type = "sqlite"
+	cfg.Storage.SqliteStorage = &config.SqliteStorageConfig{
+		Path: filepath.Join(t.TempDir(), "user-service.db"),
+	}
+
+	settings, err := config.Configure(t.Context(), cfg)
+	require.NoError(t, err)
+	require.NotNil(t, settings.Storage)
+}
+
+func TestConfigureSqliteStorageRequiresPath(t *testing.T) {
+	cfg := clone.Clone(&config.DefaultConfig)
+	cfg.Storage.Type = "sqlite"
+
+	_, err := config.Configure(t.Context(), cfg)
+	assert.Error(t, err)
+}
@@ -2,7 +2,12 @@ package config
 
 type InMemoryStorageConfig struct{}
 
+type SqliteStorageConfig struct {
+	Path string `mapstructure:"path" json:"path" validate:"required"`
+}
+
 type StorageConfig struct {
-	Type            string                 `mapstructure:"type" json:"type" validate:"required,oneof=in_memory"`
+	Type            string                 `mapstructure:"type" json:"type" validate:"required,oneof=in_memory sqlite"`
 	InMemoryStorage *InMemoryStorageConfig `mapstructure:"in_memory,omitempty" json:"in_memory,omitempty"`
+	SqliteStorage   *SqliteStorageConfig   `mapstructure:"sqlite,omitempty" json:"sqlite,omitempty" validate:"required_if=Type sqlite"`
 }
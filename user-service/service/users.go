@@ -0,0 +1,57 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/chrishrb/blog-microservice/internal/transport"
+	"github.com/chrishrb/blog-microservice/user-service/store"
+	"github.com/google/uuid"
+)
+
+// RepublishUsers publishes a UserUpdatedEvent with the current state of every
+// user, reading batchSize users at a time. Services that keep a projection of
+// the users use it to backfill the users that were created before they
+// consumed the events. The events carry the current version of the users, so
+// they can be published again at any time. It returns the number of users
+// that were published.
+func RepublishUsers(ctx context.Context, engine store.UserStore, producer transport.Producer, batchSize int) (int, error) {
+	if batchSize < 1 {
+		return 0, fmt.Errorf("batch size must be positive: %d", batchSize)
+	}
+
+	count := 0
+	page := store.Page{Limit: batchSize}
+	for {
+		users, err := engine.ListUsers(ctx, page)
+		if err != nil {
+			return count, fmt.Errorf("listing users: %w", err)
+		}
+		for _, user := range users {
+			data, err := json.Marshal(transport.UserUpdatedEvent{
+				UserID:    user.ID,
+				Email:     user.Email,
+				FirstName: user.FirstName,
+				LastName:  user.LastName,
+				Version:   user.Version,
+			})
+			if err != nil {
+				return count, err
+			}
+			err = producer.Produce(ctx, transport.UserUpdatedTopic, &transport.Message{
+				ID:   uuid.New().String(),
+				Data: data,
+			})
+			if err != nil {
+				return count, fmt.Errorf("publishing user %s: %w", user.ID, err)
+			}
+			count++
+		}
+		if len(users) < batchSize {
+			return count, nil
+		}
+		cursor := users[len(users)-1].Cursor()
+		page = store.Page{After: &cursor, Limit: batchSize}
+	}
+}
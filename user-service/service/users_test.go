@@ -0,0 +1,77 @@
+package service_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/chrishrb/blog-microservice/internal/transport"
+	"github.com/chrishrb/blog-microservice/user-service/service"
+	"github.com/chrishrb/blog-microservice/user-service/store"
+	"github.com/chrishrb/blog-microservice/user-service/store/inmemory"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	clock_testing "k8s.io/utils/clock/testing"
+)
+
+// fakeProducer records the produced messages and fails with err if set.
+type fakeProducer struct {
+	topics []string
+	events []transport.UserUpdatedEvent
+	err    error
+}
+
+func (p *fakeProducer) Produce(ctx context.Context, topic string, message *transport.Message) error {
+	if p.err != nil {
+		return p.err
+	}
+	var event transport.UserUpdatedEvent
+	if err := json.Unmarshal(message.Data, &event); err != nil {
+		return err
+	}
+	p.topics = append(p.topics, topic)
+	p.events = append(p.events, event)
+	return nil
+}
+
+func TestRepublishUsers(t *testing.T) {
+	fakeClock := clock_testing.NewFakeClock(time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC))
+	engine := inmemory.NewStore(fakeClock)
+
+	var ids []uuid.UUID
+	for range 5 {
+		user := &store.User{ID: uuid.New(), Email: uuid.NewString() + "@example.com", FirstName: "John", LastName: "Doe"}
+		require.NoError(t, engine.SetUser(t.Context(), user))
+		ids = append(ids, user.ID)
+		fakeClock.Step(time.Second)
+	}
+
+	// Users are read in several batches
+	producer := &fakeProducer{}
+	count, err := service.RepublishUsers(t.Context(), engine, producer, 2)
+	require.NoError(t, err)
+	assert.Equal(t, 5, count)
+	require.Len(t, producer.events, 5)
+	for i, event := range producer.events {
+		assert.Equal(t, transport.UserUpdatedTopic, producer.topics[i])
+		assert.Equal(t, ids[i], event.UserID)
+		assert.Equal(t, "John", event.FirstName)
+		assert.Equal(t, int64(1), event.Version)
+	}
+}
+
+func TestRepublishUsers_Errors(t *testing.T) {
+	fakeClock := clock_testing.NewFakeClock(time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC))
+	engine := inmemory.NewStore(fakeClock)
+	require.NoError(t, engine.SetUser(t.Context(), &store.User{ID: uuid.New(), Email: "john@example.com"}))
+
+	_, err := service.RepublishUsers(t.Context(), engine, &fakeProducer{}, 0)
+	assert.Error(t, err)
+
+	count, err := service.RepublishUsers(t.Context(), engine, &fakeProducer{err: errors.New("broker down")}, 10)
+	assert.Error(t, err)
+	assert.Equal(t, 0, count)
+}
@@ -3,6 +3,7 @@ package inmemory
 import (
 	"context"
 
+	"github.com/chrishrb/blog-microservice/internal/api_utils"
 	"github.com/chrishrb/blog-microservice/user-service/store"
 	"github.com/google/uuid"
 )
@@ -11,13 +12,36 @@ func (s *Store) SetUser(ctx context.Context, user *store.User) error {
 	s.Lock()
 	defer s.Unlock()
 
-	// Set timestamps
+	// The email address has to be unique across all users
+	for _, u := range s.users {
+		if u.ID != user.ID && u.Email == user.Email {
+			return store.ErrDuplicateEmail
+		}
+	}
+
+	// Only the current version of the user can be replaced
+	var version int64
+	if existing, ok := s.users[user.ID]; ok {
+		version = existing.Version
+	}
+	if user.Version != 0 && user.Version != version {
+		return store.ErrVersionConflict
+	}
+
+	// Set timestamps, the creation time of an existing user is kept
 	now := s.clock.Now()
-	user.CreatedAt = now
+	createdAt := now
+	if existing, ok := s.users[user.ID]; ok {
+		createdAt = existing.CreatedAt
+	}
+	user.Version = version + 1
+	user.CreatedAt = createdAt
 	user.UpdatedAt = now
 
-	// Store the user
-	s.users[user.ID] = user
+	// Store a copy of the user so that callers cannot modify the stored
+	// state without calling SetUser
+	u := *user
+	s.users[user.ID] = &u
 	return nil
 }
 
@@ -29,7 +53,8 @@ func (s *Store) LookupUser(ctx context.Context, ID uuid.UUID) (*store.User, erro
 	if !ok {
 		return nil, nil
 	}
-	return user, nil
+	u := *user
+	return &u, nil
 }
 
 func (s *Store) LookupUserByEmail(ctx context.Context, email string) (*store.User, error) {
@@ -38,24 +63,25 @@ func (s *Store) LookupUserByEmail(ctx context.Context, email string) (*store.Use
 
 	for _, user := range s.users {
 		if user.Email == email {
-			return user, nil
+			u := *user
+			return &u, nil
 		}
 	}
 
 	return nil, nil
 }
 
-func (s *Store) ListUsers(ctx context.Context, offset, limit int) ([]*store.User, error) {
+func (s *Store) ListUsers(ctx context.Context, page store.Page) ([]*store.User, error) {
 	s.Lock()
 	defer s.Unlock()
 
 	var users []*store.User
 	for _, user := range s.users {
-		users = append(users, user)
+		u := *user
+		users = append(users, &u)
 	}
 
-	end := min(offset+limit, len(users))
-	return users[offset:end], nil
+	return api_utils.Paginate(users, (*store.User).Cursor, api_utils.CompareCursor, page), nil
 }
 
 func (s *Store) DeleteUser(ctx context.Context, ID uuid.UUID) error {
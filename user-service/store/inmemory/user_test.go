@@ -121,23 +121,62 @@ func TestListUsers(t *testing.T) {
 		require.NoError(t, err)
 	}
 
-	users, err := engine.ListUsers(t.Context(), 0, 3)
+	users, err := engine.ListUsers(t.Context(), store.Page{Offset: 0, Limit: 3})
 	require.NoError(t, err)
 	assert.Len(t, users, 3)
 
-	users, err = engine.ListUsers(t.Context(), 3, 4)
+	users, err = engine.ListUsers(t.Context(), store.Page{Offset: 3, Limit: 4})
 	require.NoError(t, err)
 	assert.Len(t, users, 2)
 
-	users, err = engine.ListUsers(t.Context(), 0, 10)
+	users, err = engine.ListUsers(t.Context(), store.Page{Offset: 0, Limit: 10})
 	require.NoError(t, err)
 	assert.Len(t, users, 5)
 
-	users, err = engine.ListUsers(t.Context(), 5, 10)
+	users, err = engine.ListUsers(t.Context(), store.Page{Offset: 5, Limit: 10})
 	require.NoError(t, err)
 	assert.Len(t, users, 0)
 }
 
+func TestListUsers_Cursor(t *testing.T) {
+	fakeClock := clock_testing.NewFakeClock(time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC))
+	engine := inmemory.NewStore(fakeClock)
+
+	var IDs []uuid.UUID
+	for i := range 5 {
+		ID := uuid.New()
+		err := engine.SetUser(t.Context(), &store.User{
+			ID:           ID,
+			Email:        fmt.Sprintf("user%d@example.com", i),
+			FirstName:    "Test",
+			LastName:     fmt.Sprintf("User %d", i),
+			PasswordHash: "hashedPassword",
+			Status:       store.StatusActive,
+			Role:         store.RoleUser,
+		})
+		require.NoError(t, err)
+		IDs = append(IDs, ID)
+		fakeClock.Step(time.Second)
+	}
+
+	// Walk through all pages using the cursor of the last item
+	var got []uuid.UUID
+	page := store.Page{Limit: 2}
+	for {
+		users, err := engine.ListUsers(t.Context(), page)
+		require.NoError(t, err)
+		if len(users) == 0 {
+			break
+		}
+		for _, u := range users {
+			got = append(got, u.ID)
+		}
+		cursor := users[len(users)-1].Cursor()
+		page.After = &cursor
+	}
+	assert.Equal(t, IDs, got)
+}
+
 func TestDeleteUser(t *testing.T) {
 	fakeClock := clock_testing.NewFakeClock(time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC))
 	engine := inmemory.NewStore(fakeClock)
@@ -171,3 +210,72 @@ func TestDeleteUser(t *testing.T) {
 	err = engine.DeleteUser(t.Context(), nonExistentID)
 	assert.NoError(t, err)
 }
+
+func TestSetUser_DuplicateEmail(t *testing.T) {
+	fakeClock := clock_testing.NewFakeClock(time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC))
+	engine := inmemory.NewStore(fakeClock)
+
+	user := &store.User{
+		ID:           uuid.New(),
+		Email:        "test@example.com",
+		FirstName:    "Test",
+		LastName:     "User",
+		PasswordHash: "hashedPassword",
+		Status:       store.StatusActive,
+		Role:         store.RoleUser,
+	}
+	err := engine.SetUser(t.Context(), user)
+	require.NoError(t, err)
+
+	// Updating the same user keeps the email address
+	user.FirstName = "Updated"
+	err = engine.SetUser(t.Context(), user)
+	require.NoError(t, err)
+
+	err = engine.SetUser(t.Context(), &store.User{
+		ID:           uuid.New(),
+		Email:        "test@example.com",
+		FirstName:    "Another",
+		LastName:     "User",
+		PasswordHash: "hashedPassword",
+		Status:       store.StatusActive,
+		Role:         store.RoleUser,
+	})
+	assert.ErrorIs(t, err, store.ErrDuplicateEmail)
+}
+
+func TestSetUser_Version(t *testing.T) {
+	fakeClock := clock_testing.NewFakeClock(time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC))
+	engine := inmemory.NewStore(fakeClock)
+
+	user := &store.User{ID: uuid.New(), Email: "test@example.com", Status: store.StatusActive, Role: store.RoleUser}
+	require.NoError(t, engine.SetUser(t.Context(), user))
+	assert.Equal(t, int64(1), user.Version)
+
+	// Two editors read the same version
+	first, err := engine.LookupUser(t.Context(), user.ID)
+	require.NoError(t, err)
+	second, err := engine.LookupUser(t.Context(), user.ID)
+	require.NoError(t, err)
+
+	first.FirstName = "First"
+	require.NoError(t, engine.SetUser(t.Context(), first))
+	assert.Equal(t, int64(2), first.Version)
+
+	// The second editor is rejected and the first change is kept
+	second.FirstName = "Second"
+	assert.ErrorIs(t, engine.SetUser(t.Context(), second), store.ErrVersionConflict)
+	got, err := engine.LookupUser(t.Context(), user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "First", got.FirstName)
+	assert.Equal(t, int64(2), got.Version)
+
+	// Version 0 stores the user unconditionally
+	second.Version = 0
+	require.NoError(t, engine.SetUser(t.Context(), second))
+	assert.Equal(t, int64(3), second.Version)
+
+	// Deleted users are not recreated by stale versions
+	require.NoError(t, engine.DeleteUser(t.Context(), user.ID))
+	assert.ErrorIs(t, engine.SetUser(t.Context(), second), store.ErrVersionConflict)
+}
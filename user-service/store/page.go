@@ -0,0 +1,14 @@
+package store
+
+import (
+	"github.com/chrishrb/blog-microservice/internal/api_utils"
+)
+
+// Cursor marks the position of an item in a listing. Listings are ordered by
+// creation time and ID, so the cursor is stable even if items are added or
+// removed between two requests.
+type Cursor = api_utils.Cursor
+
+// Page selects a part of a listing. If After is set, the page starts with the
+// first item after the cursor and Offset is ignored.
+type Page = api_utils.Page[Cursor]
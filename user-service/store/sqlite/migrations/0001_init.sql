@@ -0,0 +1,25 @@
+CREATE TABLE users (
+    id            TEXT PRIMARY KEY,
+    email         TEXT NOT NULL,
+    first_name    TEXT NOT NULL,
+    last_name     TEXT NOT NULL,
+    password_hash TEXT NOT NULL,
+    status        TEXT NOT NULL,
+    role          TEXT NOT NULL,
+    created_at    INTEGER NOT NULL,
+    updated_at    INTEGER NOT NULL
+);
+
+CREATE UNIQUE INDEX idx_users_email ON users (email);
+CREATE INDEX idx_users_created_at ON users (created_at, id);
+
+CREATE TABLE tokens (
+    token      TEXT PRIMARY KEY,
+    user_id    TEXT NOT NULL,
+    ttl        INTEGER NOT NULL,
+    revoked    INTEGER NOT NULL DEFAULT 0,
+    created_at INTEGER NOT NULL,
+    updated_at INTEGER NOT NULL
+);
+
+CREATE INDEX idx_tokens_user_id ON tokens (user_id);
@@ -0,0 +1,92 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"fmt"
+	"io/fs"
+	"strings"
+	"time"
+
+	"k8s.io/utils/clock"
+
+	"github.com/chrishrb/blog-microservice/internal/sqlitedb"
+	"github.com/chrishrb/blog-microservice/user-service/store"
+)
+
+//go:embed migrations/*.sql
+var migrations embed.FS
+
+// Store is a SQLite implementation of the store.Engine interface. Data is
+// persisted to a single database file, so it survives restarts but cannot be
+// shared between >1 instances.
+type Store struct {
+	db    *sql.DB
+	clock clock.PassiveClock
+}
+
+// NewStore opens the database at the given path and applies all pending
+// schema migrations.
+func NewStore(ctx context.Context, path string, clock clock.PassiveClock) (*Store, error) {
+	db, err := sqlitedb.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	migrationsFS, err := fs.Sub(migrations, "migrations")
+	if err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+	if err := sqlitedb.Migrate(ctx, db, migrationsFS); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("migrating database: %w", err)
+	}
+
+	return &Store{
+		db:    db,
+		clock: clock,
+	}, nil
+}
+
+// Close closes the underlying database.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func toUnix(t time.Time) int64 {
+	return t.UnixNano()
+}
+
+func fromUnix(n int64) time.Time {
+	return time.Unix(0, n).UTC()
+}
+
+// pageQuery completes a listing query with the conditions in where, the
+// ordering by creation time and ID and the limits selected by page. Columns
+// are qualified with the table alias if it is not empty.
+func pageQuery(query, alias string, where []string, args []any, page store.Page) (string, []any) {
+	column := func(name string) string {
+		if alias == "" {
+			return name
+		}
+		return alias + "." + name
+	}
+
+	offset := max(page.Offset, 0)
+	if page.After != nil {
+		where = append(where, fmt.Sprintf("(%s, %s) > (?, ?)", column("created_at"), column("id")))
+		args = append(args, toUnix(page.After.CreatedAt), page.After.ID)
+		offset = 0
+	}
+	if len(where) > 0 {
+		query += " WHERE " + strings.Join(where, " AND ")
+	}
+	query += fmt.Sprintf(" ORDER BY %s, %s LIMIT ? OFFSET ?", column("created_at"), column("id"))
+	return query, append(args, max(page.Limit, 0), offset)
+}
@@ -0,0 +1,69 @@
+package sqlite_test
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/chrishrb/blog-microservice/user-service/store"
+	"github.com/chrishrb/blog-microservice/user-service/store/sqlite"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/utils/clock"
+	clock_testing "k8s.io/utils/clock/testing"
+)
+
+func newStore(t *testing.T, clock clock.PassiveClock) *sqlite.Store {
+	engine, err := sqlite.NewStore(t.Context(), filepath.Join(t.TempDir(), "user-service.db"), clock)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = engine.Close()
+	})
+	return engine
+}
+
+func TestStorePersistsAcrossRestarts(t *testing.T) {
+	fakeClock := clock_testing.NewFakeClock(time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC))
+	path := filepath.Join(t.TempDir(), "user-service.db")
+
+	engine, err := sqlite.NewStore(t.Context(), path, fakeClock)
+	require.NoError(t, err)
+
+	userID := uuid.New()
+	err = engine.SetUser(t.Context(), &store.User{
+		ID:           userID,
+		Email:        "test@example.com",
+		FirstName:    "Test",
+		LastName:     "User",
+		PasswordHash: "hashedPassword",
+		Status:       store.StatusActive,
+		Role:         store.RoleUser,
+	})
+	require.NoError(t, err)
+	err = engine.SetToken(t.Context(), &store.Token{
+		UserID: userID,
+		Token:  "some-refresh-token",
+		TTL:    5 * time.Minute,
+	})
+	require.NoError(t, err)
+	err = engine.SetTokenRevoked(t.Context(), userID)
+	require.NoError(t, err)
+	require.NoError(t, engine.Close())
+
+	// Reopening runs the migrations again, which must not touch existing data
+	engine, err = sqlite.NewStore(t.Context(), path, fakeClock)
+	require.NoError(t, err)
+	defer func() {
+		_ = engine.Close()
+	}()
+
+	user, err := engine.LookupUserByEmail(t.Context(), "test@example.com")
+	require.NoError(t, err)
+	require.NotNil(t, user)
+	assert.Equal(t, userID, user.ID)
+
+	isRevoked, err := engine.IsTokenRevoked(t.Context(), "some-refresh-token")
+	require.NoError(t, err)
+	assert.True(t, isRevoked)
+}
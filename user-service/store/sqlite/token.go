@@ -0,0 +1,100 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/chrishrb/blog-microservice/user-service/store"
+	"github.com/google/uuid"
+)
+
+const selectToken = `SELECT token, user_id, ttl, revoked, created_at, updated_at FROM tokens`
+
+func (s *Store) SetToken(ctx context.Context, token *store.Token) error {
+	// Set timestamps
+	now := toUnix(s.clock.Now())
+	_, err := s.db.ExecContext(ctx, `INSERT INTO tokens (token, user_id, ttl, revoked, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+		ON CONFLICT (token) DO UPDATE SET
+			user_id = excluded.user_id,
+			ttl = excluded.ttl,
+			revoked = excluded.revoked,
+			created_at = excluded.created_at,
+			updated_at = excluded.updated_at`,
+		token.Token, token.UserID, int64(token.TTL), token.Revoked, now, now,
+	)
+	if err != nil {
+		return fmt.Errorf("storing token: %w", err)
+	}
+
+	token.CreatedAt = fromUnix(now)
+	token.UpdatedAt = fromUnix(now)
+	return nil
+}
+
+func (s *Store) GetToken(ctx context.Context, token string) (*store.Token, error) {
+	row := s.db.QueryRowContext(ctx, selectToken+` WHERE token = ?`, token)
+	t, err := scanToken(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("getting token: %w", err)
+	}
+	return t, nil
+}
+
+func (s *Store) IsTokenRevoked(ctx context.Context, token string) (bool, error) {
+	var revoked bool
+	err := s.db.QueryRowContext(ctx, `SELECT revoked FROM tokens WHERE token = ?`, token).Scan(&revoked)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("checking token: %w", err)
+	}
+	return revoked, nil
+}
+
+func (s *Store) SetTokenRevoked(ctx context.Context, userID uuid.UUID) error {
+	_, err := s.db.ExecContext(ctx, `UPDATE tokens SET revoked = 1, updated_at = ? WHERE user_id = ?`,
+		toUnix(s.clock.Now()), userID)
+	if err != nil {
+		return fmt.Errorf("revoking tokens of user %s: %w", userID, err)
+	}
+	return nil
+}
+
+func (s *Store) ListTokens(ctx context.Context, userID uuid.UUID) ([]*store.Token, error) {
+	rows, err := s.db.QueryContext(ctx, selectToken+` WHERE user_id = ? ORDER BY created_at, token`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("listing tokens: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	result := make([]*store.Token, 0)
+	for rows.Next() {
+		t, err := scanToken(rows)
+		if err != nil {
+			return nil, fmt.Errorf("listing tokens: %w", err)
+		}
+		result = append(result, t)
+	}
+	return result, rows.Err()
+}
+
+func scanToken(row scanner) (*store.Token, error) {
+	var token store.Token
+	var ttl, createdAt, updatedAt int64
+	err := row.Scan(&token.Token, &token.UserID, &ttl, &token.Revoked, &createdAt, &updatedAt)
+	if err != nil {
+		return nil, err
+	}
+	token.TTL = time.Duration(ttl)
+	token.CreatedAt = fromUnix(createdAt)
+	token.UpdatedAt = fromUnix(updatedAt)
+	return &token, nil
+}
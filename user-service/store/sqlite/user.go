@@ -0,0 +1,142 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/chrishrb/blog-microservice/user-service/store"
+	"github.com/google/uuid"
+)
+
+const selectUser = `SELECT id, email, first_name, last_name, password_hash, status, role, version, created_at, updated_at
+	FROM users`
+
+func (s *Store) SetUser(ctx context.Context, user *store.User) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	// The email address has to be unique across all users
+	var taken bool
+	err = tx.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM users WHERE email = ? AND id != ?)`, user.Email, user.ID).Scan(&taken)
+	if err != nil {
+		return fmt.Errorf("checking email of user %s: %w", user.ID, err)
+	}
+	if taken {
+		return store.ErrDuplicateEmail
+	}
+
+	// A user that was deleted since its version was read is not recreated
+	if user.Version != 0 {
+		var exists bool
+		err = tx.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM users WHERE id = ?)`, user.ID).Scan(&exists)
+		if err != nil {
+			return fmt.Errorf("checking version of user %s: %w", user.ID, err)
+		}
+		if !exists {
+			return store.ErrVersionConflict
+		}
+	}
+
+	// Set timestamps, the creation time of an existing user is kept. Only
+	// the current version of the user can be replaced, if another version
+	// is stored no row is returned.
+	now := toUnix(s.clock.Now())
+	var createdAt, version int64
+	err = tx.QueryRowContext(ctx, `INSERT INTO users
+			(id, email, first_name, last_name, password_hash, status, role, version, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, 1, ?, ?)
+		ON CONFLICT (id) DO UPDATE SET
+			email = excluded.email,
+			first_name = excluded.first_name,
+			last_name = excluded.last_name,
+			password_hash = excluded.password_hash,
+			status = excluded.status,
+			role = excluded.role,
+			version = users.version + 1,
+			updated_at = excluded.updated_at
+		WHERE ? = 0 OR users.version = ?
+		RETURNING created_at, version`,
+		user.ID, user.Email, user.FirstName, user.LastName, user.PasswordHash, user.Status, user.Role, now, now,
+		user.Version, user.Version,
+	).Scan(&createdAt, &version)
+	if errors.Is(err, sql.ErrNoRows) {
+		return store.ErrVersionConflict
+	}
+	if err != nil {
+		return fmt.Errorf("storing user %s: %w", user.ID, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	user.Version = version
+	user.CreatedAt = fromUnix(createdAt)
+	user.UpdatedAt = fromUnix(now)
+	return nil
+}
+
+func (s *Store) LookupUser(ctx context.Context, ID uuid.UUID) (*store.User, error) {
+	return s.lookupUser(ctx, `WHERE id = ?`, ID)
+}
+
+func (s *Store) LookupUserByEmail(ctx context.Context, email string) (*store.User, error) {
+	return s.lookupUser(ctx, `WHERE email = ?`, email)
+}
+
+func (s *Store) ListUsers(ctx context.Context, page store.Page) ([]*store.User, error) {
+	query, args := pageQuery(selectUser, "", nil, nil, page)
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("listing users: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	users := []*store.User{}
+	for rows.Next() {
+		user, err := scanUser(rows)
+		if err != nil {
+			return nil, fmt.Errorf("listing users: %w", err)
+		}
+		users = append(users, user)
+	}
+	return users, rows.Err()
+}
+
+func (s *Store) DeleteUser(ctx context.Context, ID uuid.UUID) error {
+	_, err := s.db.ExecContext(ctx, `DELETE FROM users WHERE id = ?`, ID)
+	if err != nil {
+		return fmt.Errorf("deleting user %s: %w", ID, err)
+	}
+	return nil
+}
+
+func (s *Store) lookupUser(ctx context.Context, where string, args ...any) (*store.User, error) {
+	row := s.db.QueryRowContext(ctx, selectUser+` `+where, args...)
+	user, err := scanUser(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("looking up user: %w", err)
+	}
+	return user, nil
+}
+
+func scanUser(row scanner) (*store.User, error) {
+	var user store.User
+	var createdAt, updatedAt int64
+	err := row.Scan(&user.ID, &user.Email, &user.FirstName, &user.LastName, &user.PasswordHash,
+		&user.Status, &user.Role, &user.Version, &createdAt, &updatedAt)
+	if err != nil {
+		return nil, err
+	}
+	user.CreatedAt = fromUnix(createdAt)
+	user.UpdatedAt = fromUnix(updatedAt)
+	return &user, nil
+}
@@ -2,6 +2,7 @@ package store
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/google/uuid"
@@ -14,10 +15,19 @@ const (
 )
 
 const (
-	RoleUser  = "user"
-	RoleAdmin = "admin"
+	RoleUser      = "user"
+	RoleModerator = "moderator"
+	RoleAdmin     = "admin"
 )
 
+// ErrDuplicateEmail is returned when a user is stored with an email address
+// that already belongs to another user.
+var ErrDuplicateEmail = errors.New("email address already in use")
+
+// ErrVersionConflict is returned by SetUser if the user was changed since the
+// version that is stored.
+var ErrVersionConflict = errors.New("version conflict")
+
 type User struct {
 	ID           uuid.UUID
 	Email        string
@@ -26,14 +36,26 @@ type User struct {
 	PasswordHash string
 	Status       string
 	Role         string
-	CreatedAt    time.Time
-	UpdatedAt    time.Time
+	// Version is incremented whenever the user is stored. It is assigned by
+	// SetUser.
+	Version   int64
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Cursor returns the position of the user in a listing.
+func (u *User) Cursor() Cursor {
+	return Cursor{CreatedAt: u.CreatedAt, ID: u.ID}
 }
 
 type UserStore interface {
+	// SetUser stores the user. If user.Version is not 0, the user is only
+	// stored if it is the version that is currently stored, otherwise
+	// ErrVersionConflict is returned. The check and the update are atomic. A
+	// version of 0 stores the user unconditionally.
 	SetUser(ctx context.Context, user *User) error
 	LookupUser(ctx context.Context, ID uuid.UUID) (*User, error)
 	LookupUserByEmail(ctx context.Context, email string) (*User, error)
-	ListUsers(ctx context.Context, offset, limit int) ([]*User, error)
+	ListUsers(ctx context.Context, page Page) ([]*User, error)
 	DeleteUser(ctx context.Context, ID uuid.UUID) error
 }